@@ -2,6 +2,8 @@ package address
 
 import (
 	"fmt"
+	"net"
+	"regexp"
 	"strings"
 
 	"github.com/pivotal-cf/pcfdev-cli/network"
@@ -19,6 +21,14 @@ var allowedSubnets = []string{
 	"192.168.99.1",
 }
 
+var privateNetworks = []string{
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+}
+
+var domainRegex = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z]([a-z0-9-]*[a-z0-9])?$`)
+
 var AllowedAddresses = map[string]string{
 	"192.168.11.11": "local.pcfdev.io",
 	"192.168.22.11": "local2.pcfdev.io",
@@ -31,12 +41,20 @@ var AllowedAddresses = map[string]string{
 	"192.168.99.11": "local9.pcfdev.io",
 }
 
+// LocalDomain is the domain of a VM that is reached on 127.0.0.1. It has no
+// public wildcard DNS record and is resolved by cf dev dns or cf dev hosts.
+const LocalDomain = "local.pcfdev.test"
+
+// DomainForIP returns the domain of a VM at ip. An IP without a public
+// wildcard DNS record gets its own subdomain of pcfdev.test, such as
+// local-10-20-30-40.pcfdev.test, so that VMs on different IPs do not share a
+// domain.
 func DomainForIP(ip string) string {
 	domain, ok := AllowedAddresses[ip]
 	if ok {
 		return domain
 	} else {
-		return fmt.Sprintf("%s.xip.io", ip)
+		return "local-" + strings.Replace(ip, ".", "-", -1) + ".pcfdev.test"
 	}
 }
 
@@ -79,3 +97,43 @@ func IsDomainAllowed(domain string) bool {
 	}
 	return false
 }
+
+// VerifyIP checks that ip is a private (RFC 1918) address that the VM can
+// use. The .1 address of its /24 is taken by the host-only interface.
+func VerifyIP(ip string) error {
+	if !network.IsIPV4(ip) {
+		return fmt.Errorf("%s is not a supported IP address", ip)
+	}
+	if !IsPrivateIP(ip) {
+		return fmt.Errorf("%s is not a private IP address, use one in 10.0.0.0/8, 172.16.0.0/12 or 192.168.0.0/16", ip)
+	}
+	switch strings.Split(ip, ".")[3] {
+	case "0", "1", "255":
+		return fmt.Errorf("%s cannot be used by PCF Dev, the .0, .1 and .255 addresses of its subnet are reserved", ip)
+	}
+	return nil
+}
+
+func IsPrivateIP(ip string) bool {
+	parsedIP := net.ParseIP(ip)
+	for _, cidr := range privateNetworks {
+		_, privateNetwork, _ := net.ParseCIDR(cidr)
+		if privateNetwork.Contains(parsedIP) {
+			return true
+		}
+	}
+	return false
+}
+
+// InSameSubnet returns whether two IPv4 addresses are in the same /24.
+func InSameSubnet(ip string, otherIP string) bool {
+	if !network.IsIPV4(ip) || !network.IsIPV4(otherIP) {
+		return false
+	}
+	return ip[:strings.LastIndex(ip, ".")] == otherIP[:strings.LastIndex(otherIP, ".")]
+}
+
+// IsValidDomain returns whether domain can be used as the wildcard domain of PCF Dev.
+func IsValidDomain(domain string) bool {
+	return len(domain) <= 253 && domainRegex.MatchString(domain)
+}
@@ -11,7 +11,8 @@ var _ = Describe("Address", func() {
 		It("should convert a passed in ip to the correct domain", func() {
 			Expect(address.DomainForIP("192.168.11.11")).To(Equal("local.pcfdev.io"))
 			Expect(address.DomainForIP("192.168.22.11")).To(Equal("local2.pcfdev.io"))
-			Expect(address.DomainForIP("192.168.89.11")).To(Equal("192.168.89.11.xip.io"))
+			Expect(address.DomainForIP("192.168.89.11")).To(Equal("local-192-168-89-11.pcfdev.test"))
+			Expect(address.DomainForIP("10.20.30.40")).To(Equal("local-10-20-30-40.pcfdev.test"))
 		})
 	})
 
@@ -67,4 +68,53 @@ var _ = Describe("Address", func() {
 			})
 		})
 	})
+
+	Describe("#VerifyIP", func() {
+		It("should accept private IPs", func() {
+			Expect(address.VerifyIP("192.168.11.11")).To(Succeed())
+			Expect(address.VerifyIP("172.20.5.11")).To(Succeed())
+			Expect(address.VerifyIP("10.0.7.200")).To(Succeed())
+		})
+
+		Context("when the ip is not a valid IPv4 address", func() {
+			It("should return an error", func() {
+				Expect(address.VerifyIP("some-bad-ip")).To(MatchError("some-bad-ip is not a supported IP address"))
+			})
+		})
+
+		Context("when the ip is not private", func() {
+			It("should return an error", func() {
+				Expect(address.VerifyIP("172.32.0.11")).To(MatchError("172.32.0.11 is not a private IP address, use one in 10.0.0.0/8, 172.16.0.0/12 or 192.168.0.0/16"))
+			})
+		})
+
+		Context("when the ip is reserved in its subnet", func() {
+			It("should return an error", func() {
+				Expect(address.VerifyIP("10.0.7.1")).To(MatchError("10.0.7.1 cannot be used by PCF Dev, the .0, .1 and .255 addresses of its subnet are reserved"))
+				Expect(address.VerifyIP("10.0.7.255")).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("#InSameSubnet", func() {
+		It("should compare the /24 of both IPs", func() {
+			Expect(address.InSameSubnet("192.168.50.11", "192.168.50.1")).To(BeTrue())
+			Expect(address.InSameSubnet("192.168.50.11", "192.168.5.11")).To(BeFalse())
+			Expect(address.InSameSubnet("192.168.50.11", "some-bad-ip")).To(BeFalse())
+		})
+	})
+
+	Describe("#IsValidDomain", func() {
+		It("should accept multi-label domains", func() {
+			Expect(address.IsValidDomain("local.pcfdev.io")).To(BeTrue())
+			Expect(address.IsValidDomain("pcfdev.corp-1.example.com")).To(BeTrue())
+		})
+
+		It("should reject anything else", func() {
+			Expect(address.IsValidDomain("some-bad-domain")).To(BeFalse())
+			Expect(address.IsValidDomain("-bad.example.com")).To(BeFalse())
+			Expect(address.IsValidDomain("bad_domain.example.com")).To(BeFalse())
+			Expect(address.IsValidDomain("")).To(BeFalse())
+		})
+	})
 })
@@ -23,11 +23,14 @@ type Picker struct {
 }
 
 func (p *Picker) SelectAvailableInterface(reusableInterfaces []*network.Interface, config *cfg.VMConfig) (*cfg.NetworkConfig, error) {
-	if config.IP != "" || config.Domain != "" {
+	if config.IP != "" || IsDomainAllowed(config.Domain) {
 		var subnetIP, ip, domain string
 		var err error
 
 		if config.IP != "" {
+			if err := VerifyIP(config.IP); err != nil {
+				return nil, err
+			}
 			subnetIP, err = SubnetForIP(config.IP)
 			if err != nil {
 				return nil, err
@@ -47,8 +50,25 @@ func (p *Picker) SelectAvailableInterface(reusableInterfaces []*network.Interfac
 			domain = DomainForIP(ip)
 		}
 
+		allInterfaces, err := p.Network.Interfaces()
+		if err != nil {
+			return nil, err
+		}
+		if p.nonReusableInterfaceExists(subnetIP, reusableInterfaces, allInterfaces) {
+			return nil, fmt.Errorf("the subnet of %s is already used by a network on this host, choose another IP with -i", ip)
+		}
+
 		var networkInterface *network.Interface
 		if addrs := p.addrsInSet(subnetIP, reusableInterfaces); len(addrs) > 0 {
+			inUse, err := p.Driver.IsInterfaceInUse(addrs[0].Name)
+			if err != nil {
+				return nil, err
+			}
+
+			if inUse {
+				return nil, fmt.Errorf("the network interface for %s is already in use by another VM", ip)
+			}
+
 			networkInterface = addrs[0]
 		} else {
 			networkInterface = &network.Interface{
@@ -64,48 +84,91 @@ func (p *Picker) SelectAvailableInterface(reusableInterfaces []*network.Interfac
 		}, nil
 	}
 
+	networkConfig, err := p.selectAllowedSubnet(reusableInterfaces)
+	if err != nil {
+		return nil, err
+	}
+	if config.Domain != "" {
+		networkConfig.VMDomain = config.Domain
+	}
+	return networkConfig, nil
+}
+
+// fallbackRanges are searched for a free /24 once every allowed subnet is taken.
+var fallbackRanges = []struct {
+	first      int
+	secondFrom int
+	secondTo   int
+}{
+	{10, 0, 255},
+	{172, 16, 31},
+}
+
+func (p *Picker) selectAllowedSubnet(reusableInterfaces []*network.Interface) (*cfg.NetworkConfig, error) {
 	allInterfaces, err := p.Network.Interfaces()
 	if err != nil {
 		return nil, err
 	}
 
 	for _, subnetIP := range allowedSubnets {
-		if p.nonReusableInterfaceExists(subnetIP, reusableInterfaces, allInterfaces) {
-			continue
+		networkConfig, err := p.selectSubnet(subnetIP, reusableInterfaces, allInterfaces)
+		if err != nil || networkConfig != nil {
+			return networkConfig, err
 		}
+	}
 
-		matchingAddrs := p.addrsInSet(subnetIP, reusableInterfaces)
-		domain := DomainForIP(IPForSubnet(subnetIP))
-
-		switch len(matchingAddrs) {
-		case 0:
-			return &cfg.NetworkConfig{
-				VMIP:     IPForSubnet(subnetIP),
-				VMDomain: domain,
-				Interface: &network.Interface{
-					IP:     subnetIP,
-					Exists: false,
-				},
-			}, nil
-		case 1:
-			inUse, err := p.Driver.IsInterfaceInUse(matchingAddrs[0].Name)
-			if err != nil {
-				return nil, err
+	for _, fallbackRange := range fallbackRanges {
+		for second := fallbackRange.secondFrom; second <= fallbackRange.secondTo; second++ {
+			for third := 0; third <= 255; third++ {
+				subnetIP := fmt.Sprintf("%d.%d.%d.1", fallbackRange.first, second, third)
+				networkConfig, err := p.selectSubnet(subnetIP, reusableInterfaces, allInterfaces)
+				if err != nil || networkConfig != nil {
+					return networkConfig, err
+				}
 			}
+		}
+	}
 
-			if inUse {
-				continue
-			}
+	return nil, fmt.Errorf("all allowed network interfaces are currently taken, choose a private IP with -i")
+}
 
-			return &cfg.NetworkConfig{
-				VMIP:      IPForSubnet(subnetIP),
-				VMDomain:  domain,
-				Interface: matchingAddrs[0],
-			}, nil
-		}
+// selectSubnet returns the network config for the VM on subnetIP, or nil
+// when the subnet is taken.
+func (p *Picker) selectSubnet(subnetIP string, reusableInterfaces []*network.Interface, allInterfaces []*network.Interface) (*cfg.NetworkConfig, error) {
+	if p.nonReusableInterfaceExists(subnetIP, reusableInterfaces, allInterfaces) {
+		return nil, nil
 	}
 
-	return nil, fmt.Errorf("all allowed network interfaces are currently taken")
+	matchingAddrs := p.addrsInSet(subnetIP, reusableInterfaces)
+	domain := DomainForIP(IPForSubnet(subnetIP))
+
+	switch len(matchingAddrs) {
+	case 0:
+		return &cfg.NetworkConfig{
+			VMIP:     IPForSubnet(subnetIP),
+			VMDomain: domain,
+			Interface: &network.Interface{
+				IP:     subnetIP,
+				Exists: false,
+			},
+		}, nil
+	case 1:
+		inUse, err := p.Driver.IsInterfaceInUse(matchingAddrs[0].Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if inUse {
+			return nil, nil
+		}
+
+		return &cfg.NetworkConfig{
+			VMIP:      IPForSubnet(subnetIP),
+			VMDomain:  domain,
+			Interface: matchingAddrs[0],
+		}, nil
+	}
+	return nil, nil
 }
 
 func (p *Picker) addrsInSet(ip string, set []*network.Interface) (addrs []*network.Interface) {
@@ -128,7 +191,7 @@ func (p *Picker) nonReusableInterfaceExists(ip string, reusableInterfaces []*net
 			}
 		}
 
-		if !reusable && ip == iface.IP {
+		if !reusable && InSameSubnet(ip, iface.IP) {
 			return true
 		}
 	}
@@ -49,6 +49,8 @@ var _ = Describe("Picker", func() {
 					},
 				}
 
+				mockNetwork.EXPECT().Interfaces().Return([]*network.Interface{}, nil)
+
 				Expect(picker.SelectAvailableInterface(vboxInterfaces, &config.VMConfig{
 					IP: "192.168.11.11",
 				})).To(Equal(expectedNetworkConfig))
@@ -97,6 +99,9 @@ var _ = Describe("Picker", func() {
 					},
 				}
 
+				mockNetwork.EXPECT().Interfaces().Return([]*network.Interface{}, nil)
+				mockDriver.EXPECT().IsInterfaceInUse("some-net-iface").Return(false, nil)
+
 				Expect(picker.SelectAvailableInterface(vboxInterfaces, &config.VMConfig{
 					IP: "192.168.11.11",
 				})).To(Equal(expectedNetworkConfig))
@@ -115,6 +120,8 @@ var _ = Describe("Picker", func() {
 					},
 				}
 
+				mockNetwork.EXPECT().Interfaces().Return([]*network.Interface{}, nil)
+
 				Expect(picker.SelectAvailableInterface(vboxInterfaces, &config.VMConfig{
 					Domain: "local2.pcfdev.io",
 				})).To(Equal(expectedNetworkConfig))
@@ -140,6 +147,9 @@ var _ = Describe("Picker", func() {
 					},
 				}
 
+				mockNetwork.EXPECT().Interfaces().Return([]*network.Interface{}, nil)
+				mockDriver.EXPECT().IsInterfaceInUse("some-net-iface").Return(false, nil)
+
 				Expect(picker.SelectAvailableInterface(vboxInterfaces, &config.VMConfig{
 					Domain: "local2.pcfdev.io",
 				})).To(Equal(expectedNetworkConfig))
@@ -158,6 +168,8 @@ var _ = Describe("Picker", func() {
 					},
 				}
 
+				mockNetwork.EXPECT().Interfaces().Return([]*network.Interface{}, nil)
+
 				Expect(picker.SelectAvailableInterface(vboxInterfaces, &config.VMConfig{
 					Domain: "some-domain",
 					IP:     "192.168.99.99",
@@ -184,6 +196,9 @@ var _ = Describe("Picker", func() {
 					},
 				}
 
+				mockNetwork.EXPECT().Interfaces().Return([]*network.Interface{}, nil)
+				mockDriver.EXPECT().IsInterfaceInUse("some-net-iface").Return(false, nil)
+
 				Expect(picker.SelectAvailableInterface(vboxInterfaces, &config.VMConfig{
 					Domain: "some-domain",
 					IP:     "192.168.22.11",
@@ -191,14 +206,117 @@ var _ = Describe("Picker", func() {
 			})
 		})
 
+		Context("when there is a desired ip passed in and its interface is used by another VM", func() {
+			It("should return an error", func() {
+				vboxInterfaces := []*network.Interface{
+					&network.Interface{
+						Name:   "some-net-iface",
+						IP:     "192.168.11.1",
+						Exists: true,
+					},
+				}
+				mockNetwork.EXPECT().Interfaces().Return([]*network.Interface{}, nil)
+				mockDriver.EXPECT().IsInterfaceInUse("some-net-iface").Return(true, nil)
+
+				_, err := picker.SelectAvailableInterface(vboxInterfaces, &config.VMConfig{
+					IP: "192.168.11.11",
+				})
+				Expect(err).To(MatchError("the network interface for 192.168.11.11 is already in use by another VM"))
+			})
+		})
+
+		Context("when there is an error determining whether the interface of a desired ip is in use", func() {
+			It("should return an error", func() {
+				vboxInterfaces := []*network.Interface{
+					&network.Interface{
+						Name:   "some-net-iface",
+						IP:     "192.168.11.1",
+						Exists: true,
+					},
+				}
+				mockNetwork.EXPECT().Interfaces().Return([]*network.Interface{}, nil)
+				mockDriver.EXPECT().IsInterfaceInUse("some-net-iface").Return(false, errors.New("some-error"))
+
+				_, err := picker.SelectAvailableInterface(vboxInterfaces, &config.VMConfig{
+					IP: "192.168.11.11",
+				})
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+
 		Context("when there is a desired non-PCFDev domain passed in", func() {
-			It("should return an error - even though this code path should never be reached", func() {
+			It("should return the next available interface with that domain", func() {
 				vboxInterfaces := []*network.Interface{}
+				expectedNetworkConfig := &config.NetworkConfig{
+					VMIP:     "192.168.11.11",
+					VMDomain: "pcfdev.example.com",
+					Interface: &network.Interface{
+						IP:     "192.168.11.1",
+						Exists: false,
+					},
+				}
 
-				_, err := picker.SelectAvailableInterface(vboxInterfaces, &config.VMConfig{
-					Domain: "some-bad-domain",
+				mockNetwork.EXPECT().Interfaces().Return([]*network.Interface{}, nil)
+
+				Expect(picker.SelectAvailableInterface(vboxInterfaces, &config.VMConfig{
+					Domain: "pcfdev.example.com",
+				})).To(Equal(expectedNetworkConfig))
+			})
+		})
+
+		Context("when there is a desired ip passed in outside of the default subnets", func() {
+			It("should return an interface on the subnet of that IP with a local domain for that IP", func() {
+				vboxInterfaces := []*network.Interface{}
+				expectedNetworkConfig := &config.NetworkConfig{
+					VMIP:     "10.20.30.40",
+					VMDomain: "local-10-20-30-40.pcfdev.test",
+					Interface: &network.Interface{
+						IP:     "10.20.30.1",
+						Exists: false,
+					},
+				}
+
+				mockNetwork.EXPECT().Interfaces().Return([]*network.Interface{}, nil)
+
+				Expect(picker.SelectAvailableInterface(vboxInterfaces, &config.VMConfig{
+					IP: "10.20.30.40",
+				})).To(Equal(expectedNetworkConfig))
+			})
+		})
+
+		Context("when there is a desired public ip passed in", func() {
+			It("should return an error", func() {
+				_, err := picker.SelectAvailableInterface([]*network.Interface{}, &config.VMConfig{
+					IP: "8.8.8.8",
+				})
+				Expect(err).To(MatchError("8.8.8.8 is not a private IP address, use one in 10.0.0.0/8, 172.16.0.0/12 or 192.168.0.0/16"))
+			})
+		})
+
+		Context("when there is a desired ip passed in and a host network is on its subnet", func() {
+			It("should return an error", func() {
+				mockNetwork.EXPECT().Interfaces().Return([]*network.Interface{
+					&network.Interface{
+						IP:              "192.168.50.7",
+						HardwareAddress: "some-vpn-hardware-address",
+					},
+				}, nil)
+
+				_, err := picker.SelectAvailableInterface([]*network.Interface{}, &config.VMConfig{
+					IP: "192.168.50.11",
+				})
+				Expect(err).To(MatchError("the subnet of 192.168.50.11 is already used by a network on this host, choose another IP with -i"))
+			})
+		})
+
+		Context("when there is an error getting all interfaces for a desired ip", func() {
+			It("should return the error", func() {
+				mockNetwork.EXPECT().Interfaces().Return(nil, errors.New("some-error"))
+
+				_, err := picker.SelectAvailableInterface([]*network.Interface{}, &config.VMConfig{
+					IP: "192.168.50.11",
 				})
-				Expect(err).To(MatchError("some-bad-domain is not one of the allowed PCF Dev domains"))
+				Expect(err).To(MatchError("some-error"))
 			})
 		})
 
@@ -387,8 +505,10 @@ var _ = Describe("Picker", func() {
 		})
 
 		Context("when all allowed interfaces are taken", func() {
-			It("should return an error", func() {
-				allInterfaces := []*network.Interface{}
+			var allInterfaces []*network.Interface
+
+			BeforeEach(func() {
+				allInterfaces = []*network.Interface{}
 				for i := 1; i < 10; i++ {
 					allInterfaces = append(allInterfaces,
 						&network.Interface{
@@ -399,11 +519,37 @@ var _ = Describe("Picker", func() {
 						},
 					)
 				}
+			})
 
+			It("should return a new interface on a free subnet in 10.0.0.0/8 with the local domain", func() {
+				mockNetwork.EXPECT().Interfaces().Return(append(allInterfaces, &network.Interface{
+					Name:            "some-other-interface",
+					IP:              "10.0.0.2",
+					HardwareAddress: "some-other-hardware-address",
+					Exists:          true,
+				}), nil)
+
+				Expect(picker.SelectAvailableInterface([]*network.Interface{}, &config.VMConfig{})).To(Equal(&config.NetworkConfig{
+					VMIP:     "10.0.1.11",
+					VMDomain: "local-10-0-1-11.pcfdev.test",
+					Interface: &network.Interface{
+						IP:     "10.0.1.1",
+						Exists: false,
+					},
+				}))
+			})
+
+			It("should keep a desired domain", func() {
 				mockNetwork.EXPECT().Interfaces().Return(allInterfaces, nil)
 
-				_, err := picker.SelectAvailableInterface([]*network.Interface{}, &config.VMConfig{})
-				Expect(err).To(MatchError("all allowed network interfaces are currently taken"))
+				Expect(picker.SelectAvailableInterface([]*network.Interface{}, &config.VMConfig{Domain: "pcfdev.example.com"})).To(Equal(&config.NetworkConfig{
+					VMIP:     "10.0.0.11",
+					VMDomain: "pcfdev.example.com",
+					Interface: &network.Interface{
+						IP:     "10.0.0.1",
+						Exists: false,
+					},
+				}))
 			})
 		})
 
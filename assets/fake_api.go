@@ -1,15 +1,54 @@
 package main
 
 import (
+	"encoding/json"
 	"log"
 	"net/http"
+	"strings"
+	"sync"
 )
 
 func main() {
+	var mutex sync.Mutex
+	services := map[string]bool{
+		"rabbitmq":              true,
+		"redis":                 true,
+		"spring-cloud-services": false,
+	}
+
 	http.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`{"status":"Running"}`))
 	})
 
+	http.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		json.NewEncoder(w).Encode(map[string]map[string]bool{"services": services})
+	})
+
+	http.HandleFunc("/services/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/services/"), "/")
+		if len(parts) != 2 || (parts[1] != "enable" && parts[1] != "disable") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if _, ok := services[parts[0]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		services[parts[0]] = parts[1] == "enable"
+	})
+
 	if err := http.ListenAndServe("127.0.0.1:8090", nil); err != nil {
 		log.Fatal("ListenAndServe: ", err)
 	}
@@ -0,0 +1,182 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pivotal-cf/pcfdev-cli/digest"
+)
+
+const metadataExtension = ".json"
+
+type FS interface {
+	Digests(path string) (digests *digest.Digests, err error)
+	Exists(path string) (exists bool, err error)
+	Length(path string) (bytes int64, err error)
+	List(path string) (filenames []string, err error)
+	Read(path string) (contents []byte, err error)
+	Remove(path string) error
+	Write(path string, contents io.Reader, append bool) error
+}
+
+type Cache struct {
+	Dir string
+	FS  FS
+}
+
+type Entry struct {
+	Version      string    `json:"version"`
+	File         string    `json:"file"`
+	MD5          string    `json:"md5,omitempty"`
+	SHA256       string    `json:"sha256,omitempty"`
+	Source       string    `json:"source"`
+	DownloadedAt time.Time `json:"downloaded_at"`
+	Size         int64     `json:"-"`
+}
+
+func (e *Entry) Digests() *digest.Digests {
+	return &digest.Digests{MD5: e.MD5, SHA256: e.SHA256}
+}
+
+func (e *Entry) HasDigests() bool {
+	return e.MD5 != "" || e.SHA256 != ""
+}
+
+func (c *Cache) Save(entry *Entry) error {
+	if entry.DownloadedAt.IsZero() {
+		entry.DownloadedAt = time.Now().UTC()
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	return c.FS.Write(c.metadataPath(entry), bytes.NewReader(data), false)
+}
+
+func (c *Cache) Entries() ([]*Entry, error) {
+	exists, err := c.FS.Exists(c.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return []*Entry{}, nil
+	}
+
+	filenames, err := c.FS.List(c.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*Entry{}
+	for _, filename := range filenames {
+		if filepath.Ext(filename) != ".ova" {
+			continue
+		}
+
+		entry, err := c.Entry(filename)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Stable(newestFirst(entries))
+	return entries, nil
+}
+
+func (c *Cache) Verify(entry *Entry) (bool, error) {
+	if !entry.HasDigests() {
+		return false, &MissingDigestError{File: entry.File}
+	}
+
+	digests, err := c.FS.Digests(filepath.Join(c.Dir, entry.File))
+	if err != nil {
+		return false, err
+	}
+	return entry.Digests().Matches(digests), nil
+}
+
+func (c *Cache) Remove(entry *Entry) error {
+	if err := c.FS.Remove(filepath.Join(c.Dir, entry.File)); err != nil {
+		return err
+	}
+	return c.FS.Remove(c.metadataPath(entry))
+}
+
+// Prune removes the cached OVAs downloaded before olderThan ago and all but the
+// keep most recently downloaded ones. A negative keep does not limit the count.
+// The OVA named except is never removed.
+func (c *Cache) Prune(olderThan time.Duration, keep int, except string) ([]*Entry, error) {
+	entries, err := c.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	kept := 0
+	pruned := []*Entry{}
+	for _, entry := range entries {
+		if entry.File == except {
+			continue
+		}
+
+		tooOld := olderThan > 0 && entry.DownloadedAt.Before(cutoff)
+		tooMany := keep >= 0 && kept >= keep
+		if !tooOld && !tooMany {
+			kept++
+			continue
+		}
+
+		if err := c.Remove(entry); err != nil {
+			return nil, err
+		}
+		pruned = append(pruned, entry)
+	}
+	return pruned, nil
+}
+
+// Entry returns the cached OVA filename with its saved metadata, if any.
+func (c *Cache) Entry(filename string) (*Entry, error) {
+	entry := &Entry{File: filename}
+	metadataPath := c.metadataPath(entry)
+
+	exists, err := c.FS.Exists(metadataPath)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		data, err := c.FS.Read(metadataPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, entry); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %s", metadataPath, err)
+		}
+		entry.File = filename
+	} else {
+		entry.Version = strings.TrimPrefix(strings.TrimSuffix(filename, ".ova"), "pcfdev-v")
+	}
+
+	entry.Size, err = c.FS.Length(filepath.Join(c.Dir, filename))
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (c *Cache) metadataPath(entry *Entry) string {
+	return filepath.Join(c.Dir, entry.File+metadataExtension)
+}
+
+type newestFirst []*Entry
+
+func (n newestFirst) Len() int           { return len(n) }
+func (n newestFirst) Swap(i, j int)      { n[i], n[j] = n[j], n[i] }
+func (n newestFirst) Less(i, j int) bool { return n[i].DownloadedAt.After(n[j].DownloadedAt) }
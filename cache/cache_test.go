@@ -0,0 +1,224 @@
+package cache_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pivotal-cf/pcfdev-cli/cache"
+	"github.com/pivotal-cf/pcfdev-cli/fs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache", func() {
+	var (
+		dir      string
+		ovaCache *cache.Cache
+	)
+
+	writeOVA := func(filename string, contents string) {
+		Expect(ioutil.WriteFile(filepath.Join(dir, filename), []byte(contents), 0644)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "pcfdev-cache")
+		Expect(err).NotTo(HaveOccurred())
+
+		ovaCache = &cache.Cache{Dir: dir, FS: &fs.FS{}}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("#Save", func() {
+		It("should write the metadata next to the OVA", func() {
+			downloadedAt := time.Date(2016, 10, 5, 12, 0, 0, 0, time.UTC)
+			Expect(ovaCache.Save(&cache.Entry{
+				Version:      "0.22.0",
+				File:         "pcfdev-v0.22.0.ova",
+				MD5:          "some-md5",
+				SHA256:       "some-sha256",
+				Source:       "pivnet",
+				DownloadedAt: downloadedAt,
+			})).To(Succeed())
+
+			data, err := ioutil.ReadFile(filepath.Join(dir, "pcfdev-v0.22.0.ova.json"))
+			Expect(err).NotTo(HaveOccurred())
+			entry := &cache.Entry{}
+			Expect(json.Unmarshal(data, entry)).To(Succeed())
+			Expect(entry).To(Equal(&cache.Entry{
+				Version:      "0.22.0",
+				File:         "pcfdev-v0.22.0.ova",
+				MD5:          "some-md5",
+				SHA256:       "some-sha256",
+				Source:       "pivnet",
+				DownloadedAt: downloadedAt,
+			}))
+		})
+
+		Context("when the download time is not set", func() {
+			It("should record the current time", func() {
+				entry := &cache.Entry{File: "pcfdev-v0.22.0.ova"}
+				Expect(ovaCache.Save(entry)).To(Succeed())
+				Expect(entry.DownloadedAt).To(BeTemporally("~", time.Now(), time.Minute))
+			})
+		})
+	})
+
+	Describe("#Entries", func() {
+		It("should return the cached OVAs, most recently downloaded first", func() {
+			writeOVA("pcfdev-v0.21.0.ova", "some-old-ova")
+			writeOVA("pcfdev-v0.22.0.ova", "some-ova")
+			writeOVA("pcfdev-v0.23.0.ova.partial", "some-partial-ova")
+			Expect(ovaCache.Save(&cache.Entry{Version: "0.21.0", File: "pcfdev-v0.21.0.ova", Source: "pivnet", DownloadedAt: time.Date(2016, 9, 14, 0, 0, 0, 0, time.UTC)})).To(Succeed())
+			Expect(ovaCache.Save(&cache.Entry{Version: "0.22.0", File: "pcfdev-v0.22.0.ova", Source: "pivnet", DownloadedAt: time.Date(2016, 10, 5, 0, 0, 0, 0, time.UTC)})).To(Succeed())
+
+			entries, err := ovaCache.Entries()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+			Expect(entries[0].Version).To(Equal("0.22.0"))
+			Expect(entries[0].Size).To(Equal(int64(8)))
+			Expect(entries[1].Version).To(Equal("0.21.0"))
+			Expect(entries[1].Size).To(Equal(int64(12)))
+		})
+
+		Context("when an OVA has no metadata", func() {
+			It("should take the version from the file name and list it last", func() {
+				writeOVA("pcfdev-v0.20.0.ova", "some-ova")
+				writeOVA("pcfdev-v0.22.0.ova", "some-ova")
+				Expect(ovaCache.Save(&cache.Entry{Version: "0.22.0", File: "pcfdev-v0.22.0.ova"})).To(Succeed())
+
+				entries, err := ovaCache.Entries()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(HaveLen(2))
+				Expect(entries[1].Version).To(Equal("0.20.0"))
+				Expect(entries[1].File).To(Equal("pcfdev-v0.20.0.ova"))
+				Expect(entries[1].DownloadedAt.IsZero()).To(BeTrue())
+			})
+		})
+
+		Context("when the metadata is invalid", func() {
+			It("should return an error", func() {
+				writeOVA("pcfdev-v0.22.0.ova", "some-ova")
+				writeOVA("pcfdev-v0.22.0.ova.json", "some-bad-json")
+
+				_, err := ovaCache.Entries()
+				Expect(err).To(MatchError(ContainSubstring("failed to parse " + filepath.Join(dir, "pcfdev-v0.22.0.ova.json"))))
+			})
+		})
+
+		Context("when the cache directory does not exist", func() {
+			It("should return no entries", func() {
+				ovaCache.Dir = filepath.Join(dir, "some-missing-dir")
+				Expect(ovaCache.Entries()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("#Entry", func() {
+		It("should return the OVA with its recorded digests", func() {
+			writeOVA("pcfdev-v0.22.0.ova", "some-ova")
+			Expect(ovaCache.Save(&cache.Entry{Version: "0.22.0", File: "pcfdev-v0.22.0.ova", SHA256: "some-sha256"})).To(Succeed())
+
+			entry, err := ovaCache.Entry("pcfdev-v0.22.0.ova")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entry.SHA256).To(Equal("some-sha256"))
+			Expect(entry.Size).To(Equal(int64(8)))
+		})
+
+		Context("when the OVA has no metadata", func() {
+			It("should return the OVA without digests", func() {
+				writeOVA("pcfdev-v0.22.0.ova", "some-ova")
+
+				entry, err := ovaCache.Entry("pcfdev-v0.22.0.ova")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entry.Version).To(Equal("0.22.0"))
+				Expect(entry.HasDigests()).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("#Verify", func() {
+		BeforeEach(func() {
+			writeOVA("pcfdev-v0.22.0.ova", "some-ova-contents")
+		})
+
+		It("should return true when the OVA matches its recorded digest", func() {
+			Expect(ovaCache.Verify(&cache.Entry{
+				File:   "pcfdev-v0.22.0.ova",
+				MD5:    "1c509ad51dcbd17359be1f8c45ef9368",
+				SHA256: "686f754c09668569ee47ce757ff41665b45a860668a41c253a96a1c94f992bfa",
+			})).To(BeTrue())
+		})
+
+		It("should return false when the OVA does not match its recorded digest", func() {
+			Expect(ovaCache.Verify(&cache.Entry{
+				File:   "pcfdev-v0.22.0.ova",
+				SHA256: "some-other-sha256",
+			})).To(BeFalse())
+		})
+
+		Context("when the OVA has no recorded digest", func() {
+			It("should return an error", func() {
+				_, err := ovaCache.Verify(&cache.Entry{File: "pcfdev-v0.22.0.ova"})
+				Expect(err).To(MatchError("pcfdev-v0.22.0.ova has no recorded digest"))
+			})
+		})
+	})
+
+	Describe("#Prune", func() {
+		BeforeEach(func() {
+			for i, version := range []string{"0.19.0", "0.20.0", "0.21.0", "0.22.0"} {
+				file := "pcfdev-v" + version + ".ova"
+				writeOVA(file, "some-ova")
+				Expect(ovaCache.Save(&cache.Entry{
+					Version:      version,
+					File:         file,
+					DownloadedAt: time.Now().Add(time.Duration(i-3) * 10 * 24 * time.Hour),
+				})).To(Succeed())
+			}
+		})
+
+		versions := func(entries []*cache.Entry) []string {
+			result := []string{}
+			for _, entry := range entries {
+				result = append(result, entry.Version)
+			}
+			return result
+		}
+
+		It("should remove all but the given number of most recent OVAs", func() {
+			pruned, err := ovaCache.Prune(0, 2, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions(pruned)).To(Equal([]string{"0.20.0", "0.19.0"}))
+
+			entries, err := ovaCache.Entries()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions(entries)).To(Equal([]string{"0.22.0", "0.21.0"}))
+			_, err = os.Stat(filepath.Join(dir, "pcfdev-v0.19.0.ova.json"))
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("should remove OVAs downloaded before the given age", func() {
+			pruned, err := ovaCache.Prune(15*24*time.Hour, -1, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions(pruned)).To(Equal([]string{"0.20.0", "0.19.0"}))
+		})
+
+		It("should never remove the excepted OVA", func() {
+			pruned, err := ovaCache.Prune(0, 0, "pcfdev-v0.19.0.ova")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions(pruned)).To(Equal([]string{"0.22.0", "0.21.0", "0.20.0"}))
+
+			entries, err := ovaCache.Entries()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(versions(entries)).To(Equal([]string{"0.19.0"}))
+		})
+	})
+})
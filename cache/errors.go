@@ -0,0 +1,11 @@
+package cache
+
+import "fmt"
+
+type MissingDigestError struct {
+	File string
+}
+
+func (e *MissingDigestError) Error() string {
+	return fmt.Sprintf("%s has no recorded digest", e.File)
+}
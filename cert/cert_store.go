@@ -1,11 +1,23 @@
 package cert
 
 import (
+	"errors"
+	"fmt"
 	"io"
+	"os/exec"
 	"path/filepath"
 	"strings"
 )
 
+const (
+	StoreSystem = "system"
+	StoreNSS    = "nss"
+	StoreJava   = "java"
+)
+
+// Stores are the certificate stores that the PCF Dev CA can be trusted in.
+var Stores = []string{StoreSystem, StoreNSS, StoreJava}
+
 //go:generate mockgen -package mocks -destination mocks/fs.go github.com/pivotal-cf/pcfdev-cli/cert FS
 type FS interface {
 	Exists(path string) (exists bool, err error)
@@ -26,12 +38,23 @@ type CmdRunner interface {
 	Run(command string, args ...string) (output []byte, err error)
 }
 
+// CertStore trusts certificates in the stores named in Stores, and records
+// the stores it trusted them in at StoresPath so that Unstore only touches
+// those.
 type CertStore struct {
 	FS          FS
 	SystemStore SystemStore
+	NSSStore    SystemStore
+	JavaStore   SystemStore
+	StoresPath  string
 }
 
-func (c *CertStore) Store(cert string) error {
+// Store trusts cert in the given stores, or in the system store when none are given.
+func (c *CertStore) Store(cert string, stores []string) (err error) {
+	if len(stores) == 0 {
+		stores = []string{StoreSystem}
+	}
+
 	tempDir, err := c.FS.TempDir()
 	if err != nil {
 		return err
@@ -42,9 +65,116 @@ func (c *CertStore) Store(cert string) error {
 		return err
 	}
 
-	return c.SystemStore.Store(filepath.Join(tempDir, "cert"))
+	var stored []string
+	defer func() {
+		if len(stored) == 0 {
+			return
+		}
+		if recordErr := c.record(stored); err == nil {
+			err = recordErr
+		}
+	}()
+
+	for _, name := range stores {
+		store, err := c.store(name)
+		if err != nil {
+			return err
+		}
+		if err := store.Store(filepath.Join(tempDir, "cert")); err != nil {
+			return err
+		}
+		stored = append(stored, name)
+	}
+	return nil
 }
 
+// Unstore removes the certificates from the stores that they were trusted
+// in. Certificates trusted before the stores were recorded are only in the
+// system store.
 func (c *CertStore) Unstore() error {
-	return c.SystemStore.Unstore()
+	stores, err := c.recorded()
+	if err != nil {
+		return err
+	}
+	if stores == nil {
+		stores = []string{StoreSystem}
+	}
+
+	var errs []string
+	for _, name := range stores {
+		store, err := c.store(name)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if err := store.Unstore(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return c.FS.Remove(c.StoresPath)
+}
+
+// recorded returns the stores recorded at StoresPath, or nil when none are.
+func (c *CertStore) recorded() ([]string, error) {
+	exists, err := c.FS.Exists(c.StoresPath)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	contents, err := c.FS.Read(c.StoresPath)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(contents)), nil
+}
+
+func (c *CertStore) record(stores []string) error {
+	recorded, err := c.recorded()
+	if err != nil {
+		return err
+	}
+
+	var contents string
+	for _, name := range Stores {
+		if containsStore(recorded, name) || containsStore(stores, name) {
+			contents += name + "\n"
+		}
+	}
+	return c.FS.Write(c.StoresPath, strings.NewReader(contents), false)
+}
+
+func containsStore(stores []string, name string) bool {
+	for _, store := range stores {
+		if store == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CertStore) store(name string) (SystemStore, error) {
+	var store SystemStore
+	switch name {
+	case StoreSystem:
+		store = c.SystemStore
+	case StoreNSS:
+		store = c.NSSStore
+	case StoreJava:
+		store = c.JavaStore
+	}
+
+	if store == nil {
+		return nil, fmt.Errorf("unknown certificate store: %s", name)
+	}
+	return store, nil
+}
+
+// isNotInstalled returns whether err is from running a command that is not
+// on the PATH.
+func isNotInstalled(err error) bool {
+	return strings.Contains(err.Error(), exec.ErrNotFound.Error())
 }
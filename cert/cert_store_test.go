@@ -18,15 +18,22 @@ var _ = Describe("CertStore", func() {
 		mockCtrl        *gomock.Controller
 		mockFS          *mocks.MockFS
 		mockSystemStore *mocks.MockSystemStore
+		mockNSSStore    *mocks.MockSystemStore
+		mockJavaStore   *mocks.MockSystemStore
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockFS = mocks.NewMockFS(mockCtrl)
 		mockSystemStore = mocks.NewMockSystemStore(mockCtrl)
+		mockNSSStore = mocks.NewMockSystemStore(mockCtrl)
+		mockJavaStore = mocks.NewMockSystemStore(mockCtrl)
 		certStore = &cert.CertStore{
 			FS:          mockFS,
 			SystemStore: mockSystemStore,
+			NSSStore:    mockNSSStore,
+			JavaStore:   mockJavaStore,
+			StoresPath:  "some-stores-path",
 		}
 	})
 
@@ -40,17 +47,94 @@ var _ = Describe("CertStore", func() {
 				mockFS.EXPECT().TempDir().Return("some-temp-dir", nil),
 				mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "cert"), strings.NewReader("some-cert"), false),
 				mockSystemStore.EXPECT().Store(filepath.Join("some-temp-dir", "cert")),
+				mockFS.EXPECT().Exists("some-stores-path").Return(false, nil),
+				mockFS.EXPECT().Write("some-stores-path", strings.NewReader("system\n"), false),
 				mockFS.EXPECT().Remove("some-temp-dir"),
 			)
 
-			Expect(certStore.Store("some-cert")).To(Succeed())
+			Expect(certStore.Store("some-cert", nil)).To(Succeed())
+		})
+
+		Context("when stores are given", func() {
+			It("should add the certificate to each of them", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().TempDir().Return("some-temp-dir", nil),
+					mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "cert"), strings.NewReader("some-cert"), false),
+					mockNSSStore.EXPECT().Store(filepath.Join("some-temp-dir", "cert")),
+					mockJavaStore.EXPECT().Store(filepath.Join("some-temp-dir", "cert")),
+					mockFS.EXPECT().Exists("some-stores-path").Return(false, nil),
+					mockFS.EXPECT().Write("some-stores-path", strings.NewReader("nss\njava\n"), false),
+					mockFS.EXPECT().Remove("some-temp-dir"),
+				)
+
+				Expect(certStore.Store("some-cert", []string{"nss", "java"})).To(Succeed())
+			})
+		})
+
+		Context("when stores were recorded before", func() {
+			It("should add the stores to the record", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().TempDir().Return("some-temp-dir", nil),
+					mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "cert"), strings.NewReader("some-cert"), false),
+					mockNSSStore.EXPECT().Store(filepath.Join("some-temp-dir", "cert")),
+					mockFS.EXPECT().Exists("some-stores-path").Return(true, nil),
+					mockFS.EXPECT().Read("some-stores-path").Return([]byte("java\n"), nil),
+					mockFS.EXPECT().Write("some-stores-path", strings.NewReader("nss\njava\n"), false),
+					mockFS.EXPECT().Remove("some-temp-dir"),
+				)
+
+				Expect(certStore.Store("some-cert", []string{"nss"})).To(Succeed())
+			})
+		})
+
+		Context("when storing the cert fails after it was stored in another store", func() {
+			It("should record the store it was stored in and return the error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().TempDir().Return("some-temp-dir", nil),
+					mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "cert"), strings.NewReader("some-cert"), false),
+					mockNSSStore.EXPECT().Store(filepath.Join("some-temp-dir", "cert")),
+					mockJavaStore.EXPECT().Store(filepath.Join("some-temp-dir", "cert")).Return(errors.New("some-error")),
+					mockFS.EXPECT().Exists("some-stores-path").Return(false, nil),
+					mockFS.EXPECT().Write("some-stores-path", strings.NewReader("nss\n"), false),
+					mockFS.EXPECT().Remove("some-temp-dir"),
+				)
+
+				Expect(certStore.Store("some-cert", []string{"nss", "java"})).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when there is an error recording the stores", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().TempDir().Return("some-temp-dir", nil),
+					mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "cert"), strings.NewReader("some-cert"), false),
+					mockSystemStore.EXPECT().Store(filepath.Join("some-temp-dir", "cert")),
+					mockFS.EXPECT().Exists("some-stores-path").Return(false, nil),
+					mockFS.EXPECT().Write("some-stores-path", strings.NewReader("system\n"), false).Return(errors.New("some-error")),
+					mockFS.EXPECT().Remove("some-temp-dir"),
+				)
+
+				Expect(certStore.Store("some-cert", nil)).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when an unknown store is given", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().TempDir().Return("some-temp-dir", nil),
+					mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "cert"), strings.NewReader("some-cert"), false),
+					mockFS.EXPECT().Remove("some-temp-dir"),
+				)
+
+				Expect(certStore.Store("some-cert", []string{"some-store"})).To(MatchError("unknown certificate store: some-store"))
+			})
 		})
 
 		Context("when there is an error creating a temp dir", func() {
 			It("should return the error", func() {
 				mockFS.EXPECT().TempDir().Return("", errors.New("some-error"))
 
-				Expect(certStore.Store("some-cert")).To(MatchError("some-error"))
+				Expect(certStore.Store("some-cert", nil)).To(MatchError("some-error"))
 			})
 		})
 
@@ -62,7 +146,7 @@ var _ = Describe("CertStore", func() {
 					mockFS.EXPECT().Remove("some-temp-dir"),
 				)
 
-				Expect(certStore.Store("some-cert")).To(MatchError("some-error"))
+				Expect(certStore.Store("some-cert", nil)).To(MatchError("some-error"))
 			})
 		})
 
@@ -75,21 +159,56 @@ var _ = Describe("CertStore", func() {
 					mockFS.EXPECT().Remove("some-temp-dir"),
 				)
 
-				Expect(certStore.Store("some-cert")).To(MatchError("some-error"))
+				Expect(certStore.Store("some-cert", nil)).To(MatchError("some-error"))
 			})
 		})
 	})
 
 	Describe("#Unstore", func() {
-		It("should remove the certificates from the system certificate store", func() {
-			mockSystemStore.EXPECT().Unstore()
+		It("should remove the certificates from the recorded certificate stores", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Exists("some-stores-path").Return(true, nil),
+				mockFS.EXPECT().Read("some-stores-path").Return([]byte("nss\njava\n"), nil),
+				mockNSSStore.EXPECT().Unstore(),
+				mockJavaStore.EXPECT().Unstore(),
+				mockFS.EXPECT().Remove("some-stores-path"),
+			)
 
 			Expect(certStore.Unstore()).To(Succeed())
 		})
 
-		Context("when there is an issue removing the certificates from the system certificate store", func() {
+		Context("when no stores are recorded", func() {
+			It("should remove the certificates from the system certificate store", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists("some-stores-path").Return(false, nil),
+					mockSystemStore.EXPECT().Unstore(),
+					mockFS.EXPECT().Remove("some-stores-path"),
+				)
+
+				Expect(certStore.Unstore()).To(Succeed())
+			})
+		})
+
+		Context("when there is an issue removing the certificates from a certificate store", func() {
+			It("should keep going, keep the record and return the errors", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists("some-stores-path").Return(true, nil),
+					mockFS.EXPECT().Read("some-stores-path").Return([]byte("system\nnss\njava\n"), nil),
+					mockSystemStore.EXPECT().Unstore().Return(errors.New("some-error")),
+					mockNSSStore.EXPECT().Unstore(),
+					mockJavaStore.EXPECT().Unstore().Return(errors.New("some-other-error")),
+				)
+
+				Expect(certStore.Unstore()).To(MatchError("some-error\nsome-other-error"))
+			})
+		})
+
+		Context("when there is an error reading the recorded stores", func() {
 			It("should return the error", func() {
-				mockSystemStore.EXPECT().Unstore().Return(errors.New("some-error"))
+				gomock.InOrder(
+					mockFS.EXPECT().Exists("some-stores-path").Return(true, nil),
+					mockFS.EXPECT().Read("some-stores-path").Return(nil, errors.New("some-error")),
+				)
 
 				Expect(certStore.Unstore()).To(MatchError("some-error"))
 			})
@@ -0,0 +1,112 @@
+package cert
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	certAlias        = "pcfdev-root-ca"
+	cacertsStorepass = "changeit"
+)
+
+// JavaStore trusts certificates in the cacerts keystore of the JDK in
+// JavaHome, or of the java on the PATH when JavaHome is empty. The keystore
+// is always passed with -keystore, since -cacerts needs keytool from JDK 9.
+type JavaStore struct {
+	FS        FS
+	CmdRunner CmdRunner
+	JavaHome  string
+}
+
+func (j *JavaStore) Store(path string) error {
+	keytool, keystoreArgs, err := j.keystore()
+	if err != nil {
+		return err
+	}
+
+	if err := j.run(keytool, append([]string{"-delete", "-alias", certAlias, "-storepass", cacertsStorepass}, keystoreArgs...)...); err != nil && !isAliasNotFound(err) {
+		return err
+	}
+	return j.run(keytool, append([]string{"-importcert", "-noprompt", "-trustcacerts", "-alias", certAlias, "-file", path, "-storepass", cacertsStorepass}, keystoreArgs...)...)
+}
+
+// Unstore removes the certificate from the keystore. It is not an error
+// when the certificate was never stored or no java is installed.
+func (j *JavaStore) Unstore() error {
+	keytool, keystoreArgs, err := j.keystore()
+	if err != nil {
+		if isNotInstalled(err) {
+			return nil
+		}
+		return err
+	}
+
+	err = j.run(keytool, append([]string{"-delete", "-alias", certAlias, "-storepass", cacertsStorepass}, keystoreArgs...)...)
+	if err != nil && !isAliasNotFound(err) && !isNotInstalled(err) {
+		return err
+	}
+	return nil
+}
+
+// run runs keytool, and runs it again with sudo when the keystore belongs
+// to a system-wide JDK that the user cannot write to.
+func (j *JavaStore) run(keytool string, args ...string) error {
+	_, err := j.CmdRunner.Run(keytool, args...)
+	if err == nil || runtime.GOOS == "windows" || !isPermissionDenied(err) {
+		return err
+	}
+
+	_, err = j.CmdRunner.Run("sudo", append([]string{keytool}, args...)...)
+	return err
+}
+
+func (j *JavaStore) keystore() (keytool string, keystoreArgs []string, err error) {
+	javaHome := j.JavaHome
+	if javaHome == "" {
+		if javaHome, err = j.javaHome(); err != nil {
+			return "", nil, err
+		}
+	}
+
+	for _, path := range []string{
+		filepath.Join(javaHome, "lib", "security", "cacerts"),
+		filepath.Join(javaHome, "jre", "lib", "security", "cacerts"),
+	} {
+		exists, err := j.FS.Exists(path)
+		if err != nil {
+			return "", nil, err
+		}
+		if exists {
+			return filepath.Join(javaHome, "bin", "keytool"), []string{"-keystore", path}, nil
+		}
+	}
+	return "", nil, fmt.Errorf("failed to find the cacerts keystore in %s", javaHome)
+}
+
+// javaHome returns the java.home of the java on the PATH, which is the jre
+// directory of a JDK 8.
+func (j *JavaStore) javaHome() (string, error) {
+	output, err := j.CmdRunner.Run("java", "-XshowSettings:properties", "-version")
+	if err != nil {
+		return "", err
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		if fields := strings.SplitN(line, "=", 2); len(fields) == 2 && strings.TrimSpace(fields[0]) == "java.home" {
+			return strings.TrimSpace(fields[1]), nil
+		}
+	}
+	return "", errors.New("failed to find java.home in the java settings")
+}
+
+func isAliasNotFound(err error) bool {
+	return strings.Contains(err.Error(), "Alias <"+certAlias+"> does not exist")
+}
+
+func isPermissionDenied(err error) bool {
+	return strings.Contains(err.Error(), "Permission denied") || strings.Contains(err.Error(), "AccessDeniedException")
+}
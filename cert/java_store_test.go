@@ -0,0 +1,200 @@
+package cert_test
+
+import (
+	"errors"
+	"path/filepath"
+	"runtime"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/cert"
+	"github.com/pivotal-cf/pcfdev-cli/cert/mocks"
+)
+
+var _ = Describe("JavaStore", func() {
+	var (
+		javaStore     *cert.JavaStore
+		mockCtrl      *gomock.Controller
+		mockFS        *mocks.MockFS
+		mockCmdRunner *mocks.MockCmdRunner
+		keytool       string
+		cacerts       string
+	)
+
+	const javaSettings = "Property settings:\n    java.class.version = 52.0\n    java.home = some-java-home\n\nopenjdk version \"1.8.0_292\"\n"
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockFS = mocks.NewMockFS(mockCtrl)
+		mockCmdRunner = mocks.NewMockCmdRunner(mockCtrl)
+		javaStore = &cert.JavaStore{
+			FS:        mockFS,
+			CmdRunner: mockCmdRunner,
+		}
+		keytool = filepath.Join("some-java-home", "bin", "keytool")
+		cacerts = filepath.Join("some-java-home", "lib", "security", "cacerts")
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("#Store", func() {
+		It("should replace the certificate in the cacerts keystore of the java on the PATH", func() {
+			gomock.InOrder(
+				mockCmdRunner.EXPECT().Run("java", "-XshowSettings:properties", "-version").Return([]byte(javaSettings), nil),
+				mockFS.EXPECT().Exists(cacerts).Return(true, nil),
+				mockCmdRunner.EXPECT().Run(keytool, "-delete", "-alias", "pcfdev-root-ca", "-storepass", "changeit", "-keystore", cacerts).Return(nil, errors.New("keytool error: java.lang.Exception: Alias <pcfdev-root-ca> does not exist")),
+				mockCmdRunner.EXPECT().Run(keytool, "-importcert", "-noprompt", "-trustcacerts", "-alias", "pcfdev-root-ca", "-file", "some-cert-path", "-storepass", "changeit", "-keystore", cacerts),
+			)
+
+			Expect(javaStore.Store("some-cert-path")).To(Succeed())
+		})
+
+		Context("when java does not report its java.home", func() {
+			It("should return an error", func() {
+				mockCmdRunner.EXPECT().Run("java", "-XshowSettings:properties", "-version").Return([]byte("openjdk version \"1.8.0_292\"\n"), nil)
+
+				Expect(javaStore.Store("some-cert-path")).To(MatchError("failed to find java.home in the java settings"))
+			})
+		})
+
+		Context("when java is not installed", func() {
+			It("should return the error", func() {
+				mockCmdRunner.EXPECT().Run("java", "-XshowSettings:properties", "-version").Return(nil, errors.New("some-error"))
+
+				Expect(javaStore.Store("some-cert-path")).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when JAVA_HOME is set", func() {
+			BeforeEach(func() {
+				javaStore.JavaHome = "some-java-home"
+			})
+
+			It("should use the keytool and cacerts of that JDK", func() {
+				cacerts := filepath.Join("some-java-home", "jre", "lib", "security", "cacerts")
+
+				gomock.InOrder(
+					mockFS.EXPECT().Exists(filepath.Join("some-java-home", "lib", "security", "cacerts")).Return(false, nil),
+					mockFS.EXPECT().Exists(cacerts).Return(true, nil),
+					mockCmdRunner.EXPECT().Run(keytool, "-delete", "-alias", "pcfdev-root-ca", "-storepass", "changeit", "-keystore", cacerts),
+					mockCmdRunner.EXPECT().Run(keytool, "-importcert", "-noprompt", "-trustcacerts", "-alias", "pcfdev-root-ca", "-file", "some-cert-path", "-storepass", "changeit", "-keystore", cacerts),
+				)
+
+				Expect(javaStore.Store("some-cert-path")).To(Succeed())
+			})
+
+			Context("when the keystore is not writable by the user", func() {
+				It("should run keytool with sudo", func() {
+					if runtime.GOOS == "windows" {
+						Skip("sudo is not available on windows")
+					}
+
+					gomock.InOrder(
+						mockFS.EXPECT().Exists(cacerts).Return(true, nil),
+						mockCmdRunner.EXPECT().Run(keytool, "-delete", "-alias", "pcfdev-root-ca", "-storepass", "changeit", "-keystore", cacerts).Return(nil, errors.New("keytool error: java.io.FileNotFoundException: /usr/lib/jvm/java/lib/security/cacerts (Permission denied)")),
+						mockCmdRunner.EXPECT().Run("sudo", keytool, "-delete", "-alias", "pcfdev-root-ca", "-storepass", "changeit", "-keystore", cacerts),
+						mockCmdRunner.EXPECT().Run(keytool, "-importcert", "-noprompt", "-trustcacerts", "-alias", "pcfdev-root-ca", "-file", "some-cert-path", "-storepass", "changeit", "-keystore", cacerts).Return(nil, errors.New("keytool error: java.nio.file.AccessDeniedException: /usr/lib/jvm/java/lib/security/cacerts")),
+						mockCmdRunner.EXPECT().Run("sudo", keytool, "-importcert", "-noprompt", "-trustcacerts", "-alias", "pcfdev-root-ca", "-file", "some-cert-path", "-storepass", "changeit", "-keystore", cacerts),
+					)
+
+					Expect(javaStore.Store("some-cert-path")).To(Succeed())
+				})
+			})
+
+			Context("when removing an old certificate fails", func() {
+				It("should return the error", func() {
+					gomock.InOrder(
+						mockFS.EXPECT().Exists(cacerts).Return(true, nil),
+						mockCmdRunner.EXPECT().Run(keytool, "-delete", "-alias", "pcfdev-root-ca", "-storepass", "changeit", "-keystore", cacerts).Return(nil, errors.New("some-error")),
+					)
+
+					Expect(javaStore.Store("some-cert-path")).To(MatchError("some-error"))
+				})
+			})
+
+			Context("when importing the certificate fails", func() {
+				It("should return the error", func() {
+					gomock.InOrder(
+						mockFS.EXPECT().Exists(cacerts).Return(true, nil),
+						mockCmdRunner.EXPECT().Run(keytool, "-delete", "-alias", "pcfdev-root-ca", "-storepass", "changeit", "-keystore", cacerts),
+						mockCmdRunner.EXPECT().Run(keytool, "-importcert", "-noprompt", "-trustcacerts", "-alias", "pcfdev-root-ca", "-file", "some-cert-path", "-storepass", "changeit", "-keystore", cacerts).Return(nil, errors.New("some-error")),
+					)
+
+					Expect(javaStore.Store("some-cert-path")).To(MatchError("some-error"))
+				})
+			})
+
+			Context("when the JDK has no cacerts keystore", func() {
+				It("should return an error", func() {
+					mockFS.EXPECT().Exists(gomock.Any()).Return(false, nil).Times(2)
+
+					Expect(javaStore.Store("some-cert-path")).To(MatchError("failed to find the cacerts keystore in some-java-home"))
+				})
+			})
+		})
+	})
+
+	Describe("#Unstore", func() {
+		It("should remove the certificate from the cacerts keystore of the java on the PATH", func() {
+			gomock.InOrder(
+				mockCmdRunner.EXPECT().Run("java", "-XshowSettings:properties", "-version").Return([]byte(javaSettings), nil),
+				mockFS.EXPECT().Exists(cacerts).Return(true, nil),
+				mockCmdRunner.EXPECT().Run(keytool, "-delete", "-alias", "pcfdev-root-ca", "-storepass", "changeit", "-keystore", cacerts),
+			)
+
+			Expect(javaStore.Unstore()).To(Succeed())
+		})
+
+		Context("when java is not installed", func() {
+			It("should succeed", func() {
+				mockCmdRunner.EXPECT().Run("java", "-XshowSettings:properties", "-version").Return(nil, errors.New(`failed to execute 'java -XshowSettings:properties -version': exec: "java": executable file not found in $PATH: `))
+
+				Expect(javaStore.Unstore()).To(Succeed())
+			})
+		})
+
+		Context("when JAVA_HOME is set", func() {
+			BeforeEach(func() {
+				javaStore.JavaHome = "some-java-home"
+			})
+
+			Context("when the certificate is not in the keystore", func() {
+				It("should succeed", func() {
+					gomock.InOrder(
+						mockFS.EXPECT().Exists(cacerts).Return(true, nil),
+						mockCmdRunner.EXPECT().Run(keytool, "-delete", "-alias", "pcfdev-root-ca", "-storepass", "changeit", "-keystore", cacerts).Return(nil, errors.New("keytool error: java.lang.Exception: Alias <pcfdev-root-ca> does not exist")),
+					)
+
+					Expect(javaStore.Unstore()).To(Succeed())
+				})
+			})
+
+			Context("when removing the certificate fails", func() {
+				It("should return the error", func() {
+					if runtime.GOOS == "windows" {
+						Skip("sudo is not available on windows")
+					}
+
+					gomock.InOrder(
+						mockFS.EXPECT().Exists(cacerts).Return(true, nil),
+						mockCmdRunner.EXPECT().Run(keytool, "-delete", "-alias", "pcfdev-root-ca", "-storepass", "changeit", "-keystore", cacerts).Return(nil, errors.New("keytool error: java.io.FileNotFoundException: cacerts (Permission denied)")),
+						mockCmdRunner.EXPECT().Run("sudo", keytool, "-delete", "-alias", "pcfdev-root-ca", "-storepass", "changeit", "-keystore", cacerts).Return(nil, errors.New("some-error")),
+					)
+
+					Expect(javaStore.Unstore()).To(MatchError("some-error"))
+				})
+			})
+
+			Context("when the JDK has no cacerts keystore", func() {
+				It("should return an error", func() {
+					mockFS.EXPECT().Exists(gomock.Any()).Return(false, nil).Times(2)
+
+					Expect(javaStore.Unstore()).To(MatchError("failed to find the cacerts keystore in some-java-home"))
+				})
+			})
+		})
+	})
+})
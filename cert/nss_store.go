@@ -0,0 +1,98 @@
+package cert
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/pivotal-cf/pcfdev-cli/user"
+)
+
+const certNickname = "PCF Dev Root CA"
+
+// NSSStore trusts certificates in the NSS databases used by Chrome and
+// Firefox, with certutil from the NSS tools. Home defaults to the user's
+// home directory.
+type NSSStore struct {
+	CmdRunner CmdRunner
+	Home      string
+}
+
+func (n *NSSStore) Store(path string) error {
+	databases, err := n.databases()
+	if err != nil {
+		return err
+	}
+	if len(databases) == 0 {
+		return errors.New("failed to find an NSS database in ~/.pki/nssdb or a Firefox profile")
+	}
+
+	for _, database := range databases {
+		if _, err := n.CmdRunner.Run("certutil", "-D", "-d", database, "-n", certNickname); err != nil && !isNicknameNotFound(err) {
+			return err
+		}
+		if _, err := n.CmdRunner.Run("certutil", "-A", "-d", database, "-t", "C,,", "-n", certNickname, "-i", path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unstore removes the certificate from every database that has it. It is
+// not an error when certutil is not installed, since nothing was stored.
+func (n *NSSStore) Unstore() error {
+	databases, err := n.databases()
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	for _, database := range databases {
+		_, err := n.CmdRunner.Run("certutil", "-D", "-d", database, "-n", certNickname)
+		if err == nil || isNicknameNotFound(err) {
+			continue
+		}
+		if isNotInstalled(err) {
+			return nil
+		}
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+func (n *NSSStore) databases() ([]string, error) {
+	home := n.Home
+	if home == "" {
+		var err error
+		if home, err = user.GetHome(); err != nil {
+			return nil, err
+		}
+	}
+
+	databases := []string{}
+	for _, pattern := range []struct {
+		glob   string
+		prefix string
+	}{
+		{filepath.Join(home, ".pki", "nssdb", "cert9.db"), "sql:"},
+		{filepath.Join(home, ".mozilla", "firefox", "*", "cert9.db"), "sql:"},
+		{filepath.Join(home, ".mozilla", "firefox", "*", "cert8.db"), "dbm:"},
+	} {
+		matches, err := filepath.Glob(pattern.glob)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			databases = append(databases, pattern.prefix+filepath.Dir(match))
+		}
+	}
+	return databases, nil
+}
+
+func isNicknameNotFound(err error) bool {
+	return strings.Contains(err.Error(), "could not find certificate named")
+}
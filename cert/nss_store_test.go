@@ -0,0 +1,133 @@
+package cert_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/cert"
+	"github.com/pivotal-cf/pcfdev-cli/cert/mocks"
+)
+
+var _ = Describe("NSSStore", func() {
+	var (
+		nssStore      *cert.NSSStore
+		mockCtrl      *gomock.Controller
+		mockCmdRunner *mocks.MockCmdRunner
+		home          string
+	)
+
+	createDatabase := func(path ...string) string {
+		dir := filepath.Join(append([]string{home}, path[:len(path)-1]...)...)
+		Expect(os.MkdirAll(dir, 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(dir, path[len(path)-1]), []byte{}, 0644)).To(Succeed())
+		return dir
+	}
+
+	BeforeEach(func() {
+		var err error
+		home, err = ioutil.TempDir("", "pcfdev-nss")
+		Expect(err).NotTo(HaveOccurred())
+
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockCmdRunner = mocks.NewMockCmdRunner(mockCtrl)
+		nssStore = &cert.NSSStore{
+			CmdRunner: mockCmdRunner,
+			Home:      home,
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+		os.RemoveAll(home)
+	})
+
+	Describe("#Store", func() {
+		It("should add the certificate to the Chrome and Firefox databases", func() {
+			chrome := createDatabase(".pki", "nssdb", "cert9.db")
+			firefox := createDatabase(".mozilla", "firefox", "some-profile", "cert8.db")
+
+			gomock.InOrder(
+				mockCmdRunner.EXPECT().Run("certutil", "-D", "-d", "sql:"+chrome, "-n", "PCF Dev Root CA").Return(nil, errors.New(`certutil: could not find certificate named "PCF Dev Root CA": SEC_ERROR_BAD_DATABASE`)),
+				mockCmdRunner.EXPECT().Run("certutil", "-A", "-d", "sql:"+chrome, "-t", "C,,", "-n", "PCF Dev Root CA", "-i", "some-cert-path"),
+				mockCmdRunner.EXPECT().Run("certutil", "-D", "-d", "dbm:"+firefox, "-n", "PCF Dev Root CA"),
+				mockCmdRunner.EXPECT().Run("certutil", "-A", "-d", "dbm:"+firefox, "-t", "C,,", "-n", "PCF Dev Root CA", "-i", "some-cert-path"),
+			)
+
+			Expect(nssStore.Store("some-cert-path")).To(Succeed())
+		})
+
+		Context("when there are no databases", func() {
+			It("should return an error", func() {
+				Expect(nssStore.Store("some-cert-path")).To(MatchError("failed to find an NSS database in ~/.pki/nssdb or a Firefox profile"))
+			})
+		})
+
+		Context("when removing an old certificate fails", func() {
+			It("should return the error", func() {
+				createDatabase(".pki", "nssdb", "cert9.db")
+
+				mockCmdRunner.EXPECT().Run("certutil", "-D", "-d", gomock.Any(), "-n", "PCF Dev Root CA").Return(nil, errors.New("some-error"))
+
+				Expect(nssStore.Store("some-cert-path")).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when adding the certificate fails", func() {
+			It("should return the error", func() {
+				createDatabase(".pki", "nssdb", "cert9.db")
+
+				gomock.InOrder(
+					mockCmdRunner.EXPECT().Run("certutil", "-D", "-d", gomock.Any(), "-n", "PCF Dev Root CA"),
+					mockCmdRunner.EXPECT().Run("certutil", "-A", "-d", gomock.Any(), "-t", "C,,", "-n", "PCF Dev Root CA", "-i", "some-cert-path").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(nssStore.Store("some-cert-path")).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#Unstore", func() {
+		It("should remove the certificate from every database", func() {
+			chrome := createDatabase(".pki", "nssdb", "cert9.db")
+			firefox := createDatabase(".mozilla", "firefox", "some-profile", "cert8.db")
+
+			gomock.InOrder(
+				mockCmdRunner.EXPECT().Run("certutil", "-D", "-d", "sql:"+chrome, "-n", "PCF Dev Root CA"),
+				mockCmdRunner.EXPECT().Run("certutil", "-D", "-d", "dbm:"+firefox, "-n", "PCF Dev Root CA").Return(nil, errors.New(`certutil: could not find certificate named "PCF Dev Root CA": SEC_ERROR_BAD_DATABASE`)),
+			)
+
+			Expect(nssStore.Unstore()).To(Succeed())
+		})
+
+		Context("when removing the certificate fails", func() {
+			It("should return the error", func() {
+				createDatabase(".pki", "nssdb", "cert9.db")
+
+				mockCmdRunner.EXPECT().Run("certutil", "-D", "-d", gomock.Any(), "-n", "PCF Dev Root CA").Return(nil, errors.New("some-error"))
+
+				Expect(nssStore.Unstore()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when certutil is not installed", func() {
+			It("should succeed", func() {
+				createDatabase(".pki", "nssdb", "cert9.db")
+
+				mockCmdRunner.EXPECT().Run("certutil", "-D", "-d", gomock.Any(), "-n", "PCF Dev Root CA").Return(nil, errors.New(`failed to execute 'certutil': exec: "certutil": executable file not found in $PATH: `))
+
+				Expect(nssStore.Unstore()).To(Succeed())
+			})
+		})
+
+		Context("when there are no databases", func() {
+			It("should succeed", func() {
+				Expect(nssStore.Unstore()).To(Succeed())
+			})
+		})
+	})
+})
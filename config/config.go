@@ -2,9 +2,13 @@ package config
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/pivotal-cf/pcfdev-cli/user"
@@ -30,11 +34,55 @@ type Config struct {
 	SpringCloudMaxMemory     uint64
 	DefaultCPUs              func() (int, error)
 	ExpectedMD5              string
+	ExpectedSHA256           string
+	ReleaseVersion           string
+	OVAManifestPath          string
+	DownloadConcurrency      int
+	OVASource                string
+	OVASourceUsername        string
+	OVASourcePassword        string
+	OVASourceToken           string
+	PruneOVACache            bool
+	AcceptEULA               bool
+	ProvisionTimeout         time.Duration
 	InsecurePrivateKey       []byte
 	PrivateKeyPath           string
+	VMConfigPath             string
+	ReleasePath              string
+	Instance                 string
+	Provider                 string
+	UserConfigPath           string
+	TrustedStoresPath        string
+	UserConfig               *UserConfig
 	Version                  *Version
+
+	pluginRelease *pluginRelease
+}
+
+// pluginRelease is what SelectRelease changes, as it was before a saved
+// release was selected.
+type pluginRelease struct {
+	releaseVersion string
+	defaultVMName  string
+	ovaPath        string
+	partialOVAPath string
+	expectedMD5    string
+	expectedSHA256 string
 }
 
+const OVASourcePivNet = "pivnet"
+
+const DefaultProvisionTimeout = 60 * time.Minute
+
+const (
+	ProviderVirtualBox = "virtualbox"
+	ProviderQEMU       = "qemu"
+)
+
+const instanceSeparator = "--"
+
+var instanceRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
 type Version struct {
 	BuildVersion    string
 	BuildSHA        string
@@ -48,7 +96,7 @@ type System interface {
 	PhysicalCores() (int, error)
 }
 
-func New(defaultVMName string, expectedMD5 string, insecurePrivateKey []byte, system System, version *Version) (*Config, error) {
+func New(defaultVMName string, expectedMD5 string, expectedSHA256 string, insecurePrivateKey []byte, system System, version *Version) (*Config, error) {
 	pcfdevHome, err := getPCFDevHome()
 	if err != nil {
 		return nil, err
@@ -61,14 +109,46 @@ func New(defaultVMName string, expectedMD5 string, insecurePrivateKey []byte, sy
 	if err != nil {
 		return nil, err
 	}
+
+	downloadConcurrency, err := getDownloadConcurrency()
+	if err != nil {
+		return nil, err
+	}
+	acceptEULA, err := getAcceptEULA()
+	if err != nil {
+		return nil, err
+	}
+	provisionTimeout, err := getProvisionTimeout()
+	if err != nil {
+		return nil, err
+	}
+	userConfigPath := filepath.Join(pcfdevHome, "config.yml")
+	userConfig, err := loadUserConfig(userConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := getProvider(userConfig.Provider())
+	if err != nil {
+		return nil, err
+	}
+
 	minMemory := uint64(3072)
 	maxMemory := uint64(4096)
 	springCloudMinMemory := uint64(6144)
 	springCloudMaxMemory := uint64(8192)
 
-	return &Config{
+	conf := &Config{
 		DefaultVMName:            defaultVMName,
 		ExpectedMD5:              expectedMD5,
+		ExpectedSHA256:           expectedSHA256,
+		OVAManifestPath:          os.Getenv("PCFDEV_OVA_MANIFEST"),
+		DownloadConcurrency:      downloadConcurrency,
+		OVASource:                getOVASource(),
+		OVASourceUsername:        os.Getenv("PCFDEV_OVA_SOURCE_USERNAME"),
+		OVASourcePassword:        os.Getenv("PCFDEV_OVA_SOURCE_PASSWORD"),
+		OVASourceToken:           os.Getenv("PCFDEV_OVA_SOURCE_TOKEN"),
+		AcceptEULA:               acceptEULA,
+		ProvisionTimeout:         provisionTimeout,
 		PCFDevHome:               pcfdevHome,
 		OVADir:                   filepath.Join(pcfdevHome, "ova"),
 		VMDir:                    filepath.Join(pcfdevHome, "vms"),
@@ -87,9 +167,100 @@ func New(defaultVMName string, expectedMD5 string, insecurePrivateKey []byte, sy
 		SpringCloudMaxMemory:     springCloudMaxMemory,
 		DefaultCPUs:              system.PhysicalCores,
 		InsecurePrivateKey:       insecurePrivateKey,
-		PrivateKeyPath:           filepath.Join(pcfdevHome, "vms", "key.pem"),
+		Provider:                 provider,
+		UserConfigPath:           userConfigPath,
+		TrustedStoresPath:        filepath.Join(pcfdevHome, "trusted_stores"),
+		UserConfig:               userConfig,
 		Version:                  version,
-	}, nil
+	}
+	if err := conf.SetInstance(os.Getenv("PCFDEV_INSTANCE")); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+func (c *Config) SetInstance(instance string) error {
+	if instance != "" && !instanceRegex.MatchString(instance) {
+		return fmt.Errorf("%s is not a valid instance name, use lowercase letters, numbers and single dashes", instance)
+	}
+
+	c.Instance = instance
+	if instance == "" {
+		c.PrivateKeyPath = filepath.Join(c.VMDir, "key.pem")
+		c.VMConfigPath = filepath.Join(c.VMDir, "vm_config")
+		c.ReleasePath = filepath.Join(c.VMDir, "release")
+	} else {
+		c.PrivateKeyPath = filepath.Join(c.VMDir, "key-"+instance+".pem")
+		c.VMConfigPath = filepath.Join(c.VMDir, "vm_config-"+instance)
+		c.ReleasePath = filepath.Join(c.VMDir, "release-"+instance)
+	}
+	return c.selectSavedRelease()
+}
+
+// selectSavedRelease selects the release saved for the instance, or the
+// release of the plugin when none is saved.
+func (c *Config) selectSavedRelease() error {
+	if c.pluginRelease == nil {
+		c.pluginRelease = &pluginRelease{
+			releaseVersion: c.ReleaseVersion,
+			defaultVMName:  c.DefaultVMName,
+			ovaPath:        c.OVAPath,
+			partialOVAPath: c.PartialOVAPath,
+			expectedMD5:    c.ExpectedMD5,
+			expectedSHA256: c.ExpectedSHA256,
+		}
+	}
+
+	release, err := loadRelease(c.ReleasePath)
+	if err != nil {
+		return err
+	}
+	if release != nil {
+		c.SelectRelease(release.Version, release.MD5, release.SHA256)
+		return nil
+	}
+
+	c.ReleaseVersion = c.pluginRelease.releaseVersion
+	c.DefaultVMName = c.pluginRelease.defaultVMName
+	c.OVAPath = c.pluginRelease.ovaPath
+	c.PartialOVAPath = c.pluginRelease.partialOVAPath
+	c.ExpectedMD5 = c.pluginRelease.expectedMD5
+	c.ExpectedSHA256 = c.pluginRelease.expectedSHA256
+	return nil
+}
+
+func (c *Config) UsesPivNet() bool {
+	return c.OVASource == "" || c.OVASource == OVASourcePivNet
+}
+
+func (c *Config) SelectRelease(version string, expectedMD5 string, expectedSHA256 string) {
+	c.ReleaseVersion = strings.TrimPrefix(version, "v")
+	c.DefaultVMName = "pcfdev-v" + c.ReleaseVersion
+	c.OVAPath = filepath.Join(c.OVADir, c.DefaultVMName+".ova")
+	c.PartialOVAPath = filepath.Join(c.OVADir, c.DefaultVMName+".ova.partial")
+	c.ExpectedMD5 = expectedMD5
+	c.ExpectedSHA256 = expectedSHA256
+}
+
+func (c *Config) OVAVersion() string {
+	if c.ReleaseVersion != "" {
+		return c.ReleaseVersion
+	}
+	return c.Version.OVABuildVersion
+}
+
+func (c *Config) InstanceVMName(vmName string) string {
+	if c.Instance == "" {
+		return vmName
+	}
+	return vmName + instanceSeparator + c.Instance
+}
+
+func InstanceOfVMName(instanceVMName string) (vmName string, instance string) {
+	if index := strings.LastIndex(instanceVMName, instanceSeparator); index >= 0 {
+		return instanceVMName[:index], instanceVMName[index+len(instanceSeparator):]
+	}
+	return instanceVMName, ""
 }
 
 func getPCFDevHome() (string, error) {
@@ -105,6 +276,67 @@ func getPCFDevHome() (string, error) {
 	return filepath.Join(homeDir, ".pcfdev"), nil
 }
 
+func loadUserConfig(path string) (*UserConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewUserConfig(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %s", path, err)
+	}
+
+	userConfig, err := ParseUserConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", path, err)
+	}
+	return userConfig, nil
+}
+
+// getProvider prefers PCFDEV_PROVIDER over the provider saved in config.yml.
+func getProvider(configured string) (string, error) {
+	if provider := os.Getenv("PCFDEV_PROVIDER"); provider != "" {
+		name, ok := parseProvider(provider)
+		if !ok {
+			return "", fmt.Errorf("%s is not a supported PCFDEV_PROVIDER, options: %s, %s", provider, ProviderVirtualBox, ProviderQEMU)
+		}
+		return name, nil
+	}
+
+	name, _ := parseProvider(configured)
+	return name, nil
+}
+
+func parseProvider(provider string) (name string, ok bool) {
+	switch provider {
+	case "", ProviderVirtualBox:
+		return ProviderVirtualBox, true
+	case ProviderQEMU, "kvm":
+		return ProviderQEMU, true
+	default:
+		return "", false
+	}
+}
+
+func getOVASource() string {
+	if source := strings.TrimSpace(os.Getenv("PCFDEV_OVA_SOURCE")); source != "" {
+		return source
+	}
+	return OVASourcePivNet
+}
+
+func getDownloadConcurrency() (int, error) {
+	value := os.Getenv("PCFDEV_DOWNLOAD_CONCURRENCY")
+	if value == "" {
+		return 1, nil
+	}
+
+	concurrency, err := strconv.Atoi(value)
+	if err != nil || concurrency < 1 || concurrency > 16 {
+		return 0, fmt.Errorf("%s is not a valid PCFDEV_DOWNLOAD_CONCURRENCY, use a number from 1 to 16", value)
+	}
+	return concurrency, nil
+}
+
 func getHTTPProxy() string {
 	if proxy := os.Getenv("HTTP_PROXY"); proxy != "" {
 		return stripWhitespace(proxy)
@@ -144,3 +376,29 @@ func stripWhitespace(s string) string {
 		return r
 	}, s)
 }
+
+func getAcceptEULA() (bool, error) {
+	value := os.Getenv("PCFDEV_ACCEPT_EULA")
+	if value == "" {
+		return false, nil
+	}
+
+	accept, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s is not a valid PCFDEV_ACCEPT_EULA, use true or false", value)
+	}
+	return accept, nil
+}
+
+func getProvisionTimeout() (time.Duration, error) {
+	value := os.Getenv("PCFDEV_PROVISION_TIMEOUT")
+	if value == "" {
+		return DefaultProvisionTimeout, nil
+	}
+
+	minutes, err := strconv.Atoi(value)
+	if err != nil || minutes < 1 {
+		return 0, fmt.Errorf("%s is not a valid PCFDEV_PROVISION_TIMEOUT, use a number of minutes", value)
+	}
+	return time.Duration(minutes) * time.Minute, nil
+}
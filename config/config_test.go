@@ -2,9 +2,12 @@ package config_test
 
 import (
 	"errors"
+	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pivotal-cf/pcfdev-cli/config"
@@ -53,10 +56,16 @@ var _ = Describe("Config", func() {
 			mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
 			mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
 			expectedVersion := &config.Version{}
-			conf, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, expectedVersion)
+			conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, expectedVersion)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(conf.DefaultVMName).To(Equal("some-vm"))
 			Expect(conf.ExpectedMD5).To(Equal("some-md5"))
+			Expect(conf.ExpectedSHA256).To(Equal("some-sha256"))
+			Expect(conf.OVAManifestPath).To(BeEmpty())
+			Expect(conf.DownloadConcurrency).To(Equal(1))
+			Expect(conf.AcceptEULA).To(BeFalse())
+			Expect(conf.ProvisionTimeout).To(Equal(60 * time.Minute))
+			Expect(conf.OVASource).To(Equal("pivnet"))
 			Expect(conf.PCFDevHome).To(Equal("some-pcfdev-home"))
 			Expect(conf.OVADir).To(Equal(filepath.Join("some-pcfdev-home", "ova")))
 			Expect(conf.VMDir).To(Equal(filepath.Join("some-pcfdev-home", "vms")))
@@ -72,6 +81,307 @@ var _ = Describe("Config", func() {
 			Expect(conf.Version).To(BeIdenticalTo(expectedVersion))
 			Expect(conf.InsecurePrivateKey).To(Equal([]byte("some-insecure-private-key")))
 			Expect(conf.PrivateKeyPath).To(Equal(filepath.Join("some-pcfdev-home", "vms", "key.pem")))
+			Expect(conf.VMConfigPath).To(Equal(filepath.Join("some-pcfdev-home", "vms", "vm_config")))
+			Expect(conf.ReleasePath).To(Equal(filepath.Join("some-pcfdev-home", "vms", "release")))
+			Expect(conf.Instance).To(BeEmpty())
+			Expect(conf.Provider).To(Equal("virtualbox"))
+			Expect(conf.UserConfigPath).To(Equal(filepath.Join("some-pcfdev-home", "config.yml")))
+			Expect(conf.TrustedStoresPath).To(Equal(filepath.Join("some-pcfdev-home", "trusted_stores")))
+			Expect(conf.UserConfig).To(Equal(config.NewUserConfig()))
+		})
+
+		Context("when a config file exists in PCFDEV_HOME", func() {
+			var pcfdevHome string
+
+			BeforeEach(func() {
+				var err error
+				pcfdevHome, err = ioutil.TempDir("", "pcfdev-home")
+				Expect(err).NotTo(HaveOccurred())
+				os.Setenv("PCFDEV_HOME", pcfdevHome)
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(pcfdevHome)
+			})
+
+			It("should load the config file", func() {
+				Expect(ioutil.WriteFile(filepath.Join(pcfdevHome, "config.yml"), []byte("memory: 8192\nservices: all\n"), 0644)).To(Succeed())
+				mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
+				mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
+
+				conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conf.UserConfig.Memory()).To(Equal(uint64(8192)))
+				Expect(conf.UserConfig.Get("services")).To(Equal("all"))
+			})
+
+			It("should use the provider from the config file", func() {
+				Expect(ioutil.WriteFile(filepath.Join(pcfdevHome, "config.yml"), []byte("provider: kvm\n"), 0644)).To(Succeed())
+				mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
+				mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
+
+				conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conf.Provider).To(Equal("qemu"))
+			})
+
+			Context("when PCFDEV_PROVIDER is also set", func() {
+				var savedProvider string
+
+				BeforeEach(func() {
+					savedProvider = os.Getenv("PCFDEV_PROVIDER")
+					os.Setenv("PCFDEV_PROVIDER", "virtualbox")
+				})
+
+				AfterEach(func() {
+					os.Setenv("PCFDEV_PROVIDER", savedProvider)
+				})
+
+				It("should prefer the env var", func() {
+					Expect(ioutil.WriteFile(filepath.Join(pcfdevHome, "config.yml"), []byte("provider: qemu\n"), 0644)).To(Succeed())
+					mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
+					mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
+
+					conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(conf.Provider).To(Equal("virtualbox"))
+				})
+			})
+
+			Context("when the config file is invalid", func() {
+				It("should return an error", func() {
+					Expect(ioutil.WriteFile(filepath.Join(pcfdevHome, "config.yml"), []byte("some-bad-line\n"), 0644)).To(Succeed())
+					mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
+					mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
+
+					_, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+					Expect(err).To(MatchError(HavePrefix(fmt.Sprintf("failed to parse %s: yaml: ", filepath.Join(pcfdevHome, "config.yml")))))
+				})
+			})
+		})
+
+		Context("when PCFDEV_INSTANCE is set", func() {
+			var savedInstance string
+
+			BeforeEach(func() {
+				savedInstance = os.Getenv("PCFDEV_INSTANCE")
+			})
+
+			AfterEach(func() {
+				os.Setenv("PCFDEV_INSTANCE", savedInstance)
+			})
+
+			It("should namespace the instance files", func() {
+				os.Setenv("PCFDEV_INSTANCE", "some-instance")
+				mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
+				mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
+
+				conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conf.Instance).To(Equal("some-instance"))
+				Expect(conf.PrivateKeyPath).To(Equal(filepath.Join("some-pcfdev-home", "vms", "key-some-instance.pem")))
+				Expect(conf.VMConfigPath).To(Equal(filepath.Join("some-pcfdev-home", "vms", "vm_config-some-instance")))
+				Expect(conf.ReleasePath).To(Equal(filepath.Join("some-pcfdev-home", "vms", "release-some-instance")))
+				Expect(conf.OVAPath).To(Equal(filepath.Join("some-pcfdev-home", "ova", "some-vm.ova")))
+			})
+
+			Context("when the instance name is invalid", func() {
+				It("should return an error", func() {
+					os.Setenv("PCFDEV_INSTANCE", "Some--Bad_Instance")
+					mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
+					mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
+
+					_, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+					Expect(err).To(MatchError("Some--Bad_Instance is not a valid instance name, use lowercase letters, numbers and single dashes"))
+				})
+			})
+		})
+
+		Context("when PCFDEV_OVA_MANIFEST is set", func() {
+			var savedManifest string
+
+			BeforeEach(func() {
+				savedManifest = os.Getenv("PCFDEV_OVA_MANIFEST")
+				os.Setenv("PCFDEV_OVA_MANIFEST", "some-manifest-path")
+			})
+
+			AfterEach(func() {
+				os.Setenv("PCFDEV_OVA_MANIFEST", savedManifest)
+			})
+
+			It("should use the given manifest", func() {
+				mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
+				mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
+
+				conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conf.OVAManifestPath).To(Equal("some-manifest-path"))
+			})
+		})
+
+		Context("when PCFDEV_OVA_SOURCE is set", func() {
+			var savedEnv map[string]string
+
+			BeforeEach(func() {
+				savedEnv = map[string]string{}
+				for _, name := range []string{"PCFDEV_OVA_SOURCE", "PCFDEV_OVA_SOURCE_USERNAME", "PCFDEV_OVA_SOURCE_PASSWORD", "PCFDEV_OVA_SOURCE_TOKEN"} {
+					savedEnv[name] = os.Getenv(name)
+				}
+				os.Setenv("PCFDEV_OVA_SOURCE", "https://some-mirror/pcfdev/")
+				os.Setenv("PCFDEV_OVA_SOURCE_USERNAME", "some-username")
+				os.Setenv("PCFDEV_OVA_SOURCE_PASSWORD", "some-password")
+				os.Setenv("PCFDEV_OVA_SOURCE_TOKEN", "some-token")
+			})
+
+			AfterEach(func() {
+				for name, value := range savedEnv {
+					os.Setenv(name, value)
+				}
+			})
+
+			It("should use the given source and credentials", func() {
+				mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
+				mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
+
+				conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conf.OVASource).To(Equal("https://some-mirror/pcfdev/"))
+				Expect(conf.OVASourceUsername).To(Equal("some-username"))
+				Expect(conf.OVASourcePassword).To(Equal("some-password"))
+				Expect(conf.OVASourceToken).To(Equal("some-token"))
+			})
+		})
+
+		Context("when PCFDEV_DOWNLOAD_CONCURRENCY is set", func() {
+			var savedConcurrency string
+
+			BeforeEach(func() {
+				savedConcurrency = os.Getenv("PCFDEV_DOWNLOAD_CONCURRENCY")
+			})
+
+			AfterEach(func() {
+				os.Setenv("PCFDEV_DOWNLOAD_CONCURRENCY", savedConcurrency)
+			})
+
+			It("should use the given concurrency", func() {
+				os.Setenv("PCFDEV_DOWNLOAD_CONCURRENCY", "4")
+				mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
+				mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
+
+				conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conf.DownloadConcurrency).To(Equal(4))
+			})
+
+			Context("when the concurrency is not valid", func() {
+				It("should return an error", func() {
+					os.Setenv("PCFDEV_DOWNLOAD_CONCURRENCY", "0")
+					mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
+					mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
+
+					_, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+					Expect(err).To(MatchError("0 is not a valid PCFDEV_DOWNLOAD_CONCURRENCY, use a number from 1 to 16"))
+				})
+			})
+		})
+
+		Context("when PCFDEV_ACCEPT_EULA is set", func() {
+			var savedAcceptEULA string
+
+			BeforeEach(func() {
+				savedAcceptEULA = os.Getenv("PCFDEV_ACCEPT_EULA")
+			})
+
+			AfterEach(func() {
+				os.Setenv("PCFDEV_ACCEPT_EULA", savedAcceptEULA)
+			})
+
+			It("should accept the EULA", func() {
+				os.Setenv("PCFDEV_ACCEPT_EULA", "true")
+				mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
+				mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
+
+				conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conf.AcceptEULA).To(BeTrue())
+			})
+
+			Context("when the value is not valid", func() {
+				It("should return an error", func() {
+					os.Setenv("PCFDEV_ACCEPT_EULA", "some-value")
+					mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
+					mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
+
+					_, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+					Expect(err).To(MatchError("some-value is not a valid PCFDEV_ACCEPT_EULA, use true or false"))
+				})
+			})
+		})
+
+		Context("when PCFDEV_PROVISION_TIMEOUT is set", func() {
+			var savedProvisionTimeout string
+
+			BeforeEach(func() {
+				savedProvisionTimeout = os.Getenv("PCFDEV_PROVISION_TIMEOUT")
+			})
+
+			AfterEach(func() {
+				os.Setenv("PCFDEV_PROVISION_TIMEOUT", savedProvisionTimeout)
+			})
+
+			It("should use the given number of minutes", func() {
+				os.Setenv("PCFDEV_PROVISION_TIMEOUT", "90")
+				mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
+				mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
+
+				conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conf.ProvisionTimeout).To(Equal(90 * time.Minute))
+			})
+
+			Context("when the timeout is not valid", func() {
+				It("should return an error", func() {
+					os.Setenv("PCFDEV_PROVISION_TIMEOUT", "0")
+					mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
+					mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
+
+					_, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+					Expect(err).To(MatchError("0 is not a valid PCFDEV_PROVISION_TIMEOUT, use a number of minutes"))
+				})
+			})
+		})
+
+		Context("when PCFDEV_PROVIDER is set", func() {
+			var savedProvider string
+
+			BeforeEach(func() {
+				savedProvider = os.Getenv("PCFDEV_PROVIDER")
+			})
+
+			AfterEach(func() {
+				os.Setenv("PCFDEV_PROVIDER", savedProvider)
+			})
+
+			It("should use the given provider", func() {
+				os.Setenv("PCFDEV_PROVIDER", "qemu")
+				mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
+				mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
+
+				conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conf.Provider).To(Equal("qemu"))
+			})
+
+			Context("when the provider is not supported", func() {
+				It("should return an error", func() {
+					os.Setenv("PCFDEV_PROVIDER", "some-bad-provider")
+					mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
+					mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
+
+					_, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+					Expect(err).To(MatchError("some-bad-provider is not a supported PCFDEV_PROVIDER, options: virtualbox, qemu"))
+				})
+			})
 		})
 
 		Context("when caps proxy env vars are unset", func() {
@@ -104,7 +414,7 @@ var _ = Describe("Config", func() {
 			It("should use lower case env vars", func() {
 				mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
 				mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
-				conf, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+				conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(conf.HTTPProxy).To(Equal("some-other-http-proxy"))
 				Expect(conf.HTTPSProxy).To(Equal("some-other-https-proxy"))
@@ -141,7 +451,7 @@ var _ = Describe("Config", func() {
 				}
 				mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
 				mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
-				conf, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+				conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(conf.HTTPProxy).To(Equal("some-http-proxy"))
 				Expect(conf.HTTPSProxy).To(Equal("some-https-proxy"))
@@ -175,7 +485,7 @@ var _ = Describe("Config", func() {
 			It("should strip all whitespace", func() {
 				mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
 				mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
-				conf, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+				conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(conf.HTTPProxy).To(Equal("somehttpproxywithwhitespace"))
 				Expect(conf.HTTPSProxy).To(Equal("somehttpsproxywithwhitespace"))
@@ -196,7 +506,7 @@ var _ = Describe("Config", func() {
 				mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
 				os.Unsetenv("PCFDEV_HOME")
 
-				conf, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+				conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(conf.PCFDevHome).To(Equal(filepath.Join(expectedHome, ".pcfdev")))
 				Expect(conf.OVADir).To(Equal(filepath.Join(expectedHome, ".pcfdev", "ova")))
@@ -208,7 +518,7 @@ var _ = Describe("Config", func() {
 				mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
 				mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
 
-				conf, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+				conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(conf.TotalMemory).To(Equal(uint64(1000)))
 			})
@@ -217,7 +527,7 @@ var _ = Describe("Config", func() {
 				mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
 				mockSystem.EXPECT().TotalMemory().Return(uint64(1000), nil)
 
-				conf, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+				conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(conf.FreeMemory).To(Equal(uint64(2000)))
 			})
@@ -231,7 +541,7 @@ var _ = Describe("Config", func() {
 					It("should give the VM half the total memory", func() {
 						mockSystem.EXPECT().TotalMemory().Return(uint64(7000), nil)
 
-						conf, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+						conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
 						Expect(err).NotTo(HaveOccurred())
 						Expect(conf.DefaultMemory).To(Equal(uint64(3500)))
 					})
@@ -241,7 +551,7 @@ var _ = Describe("Config", func() {
 					It("should give the VM the minimum amount of memory", func() {
 						mockSystem.EXPECT().TotalMemory().Return(uint64(6000), nil)
 
-						conf, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+						conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
 						Expect(err).NotTo(HaveOccurred())
 						Expect(conf.DefaultMemory).To(Equal(uint64(3072)))
 					})
@@ -251,7 +561,7 @@ var _ = Describe("Config", func() {
 					It("should give the VM the maximum amount of memory", func() {
 						mockSystem.EXPECT().TotalMemory().Return(uint64(60000), nil)
 
-						conf, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+						conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
 						Expect(err).NotTo(HaveOccurred())
 						Expect(conf.DefaultMemory).To(Equal(uint64(4096)))
 					})
@@ -267,7 +577,7 @@ var _ = Describe("Config", func() {
 					It("should give the VM half the total memory", func() {
 						mockSystem.EXPECT().TotalMemory().Return(uint64(14000), nil)
 
-						conf, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+						conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
 						Expect(err).NotTo(HaveOccurred())
 						Expect(conf.SpringCloudDefaultMemory).To(Equal(uint64(7000)))
 					})
@@ -277,7 +587,7 @@ var _ = Describe("Config", func() {
 					It("should give the VM the minimum amount of memory", func() {
 						mockSystem.EXPECT().TotalMemory().Return(uint64(12000), nil)
 
-						conf, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+						conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
 						Expect(err).NotTo(HaveOccurred())
 						Expect(conf.SpringCloudDefaultMemory).To(Equal(uint64(6144)))
 					})
@@ -287,7 +597,7 @@ var _ = Describe("Config", func() {
 					It("should give the VM the maximum amount of memory", func() {
 						mockSystem.EXPECT().TotalMemory().Return(uint64(60000), nil)
 
-						conf, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+						conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
 						Expect(err).NotTo(HaveOccurred())
 						Expect(conf.SpringCloudDefaultMemory).To(Equal(uint64(8192)))
 					})
@@ -298,7 +608,7 @@ var _ = Describe("Config", func() {
 				It("should return an error", func() {
 					mockSystem.EXPECT().FreeMemory().Return(uint64(0), errors.New("some-error"))
 
-					_, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+					_, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
 					Expect(err).To(MatchError("some-error"))
 				})
 			})
@@ -308,7 +618,7 @@ var _ = Describe("Config", func() {
 					mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
 					mockSystem.EXPECT().TotalMemory().Return(uint64(0), errors.New("some-error"))
 
-					_, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+					_, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
 					Expect(err).To(MatchError("some-error"))
 				})
 			})
@@ -319,7 +629,7 @@ var _ = Describe("Config", func() {
 				mockSystem.EXPECT().FreeMemory().Return(uint64(2000), nil)
 				mockSystem.EXPECT().TotalMemory().Return(uint64(60000), nil)
 				mockSystem.EXPECT().PhysicalCores().Return(4, nil)
-				conf, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+				conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(conf.DefaultCPUs()).To(Equal(4))
 			})
@@ -330,7 +640,7 @@ var _ = Describe("Config", func() {
 					mockSystem.EXPECT().TotalMemory().Return(uint64(60000), nil)
 					mockSystem.EXPECT().PhysicalCores().Return(0, errors.New("some-error"))
 
-					conf, err := config.New("some-vm", "some-md5", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
+					conf, err := config.New("some-vm", "some-md5", "some-sha256", []byte("some-insecure-private-key"), mockSystem, &config.Version{})
 					Expect(err).NotTo(HaveOccurred())
 					_, err = conf.DefaultCPUs()
 					Expect(err).To(MatchError("some-error"))
@@ -338,4 +648,125 @@ var _ = Describe("Config", func() {
 			})
 		})
 	})
+
+	Describe("#SelectRelease", func() {
+		It("should use the OVA and digests of the given release", func() {
+			conf := &config.Config{
+				DefaultVMName:  "pcfdev-v0.22.0",
+				OVADir:         "some-ova-dir",
+				OVAPath:        filepath.Join("some-ova-dir", "pcfdev-v0.22.0.ova"),
+				PartialOVAPath: filepath.Join("some-ova-dir", "pcfdev-v0.22.0.ova.partial"),
+				ExpectedMD5:    "some-md5",
+				ExpectedSHA256: "some-sha256",
+			}
+
+			conf.SelectRelease("v0.21.0", "some-other-md5", "some-other-sha256")
+			Expect(conf.ReleaseVersion).To(Equal("0.21.0"))
+			Expect(conf.DefaultVMName).To(Equal("pcfdev-v0.21.0"))
+			Expect(conf.OVAPath).To(Equal(filepath.Join("some-ova-dir", "pcfdev-v0.21.0.ova")))
+			Expect(conf.PartialOVAPath).To(Equal(filepath.Join("some-ova-dir", "pcfdev-v0.21.0.ova.partial")))
+			Expect(conf.ExpectedMD5).To(Equal("some-other-md5"))
+			Expect(conf.ExpectedSHA256).To(Equal("some-other-sha256"))
+		})
+	})
+
+	Describe("#OVAVersion", func() {
+		It("should return the OVA version of the plugin", func() {
+			conf := &config.Config{Version: &config.Version{OVABuildVersion: "some-ova-version"}}
+			Expect(conf.OVAVersion()).To(Equal("some-ova-version"))
+		})
+
+		Context("when a release is selected", func() {
+			It("should return the release version", func() {
+				conf := &config.Config{Version: &config.Version{OVABuildVersion: "some-ova-version"}}
+				conf.SelectRelease("v0.21.0", "some-md5", "some-sha256")
+				Expect(conf.OVAVersion()).To(Equal("0.21.0"))
+			})
+		})
+	})
+
+	Describe("#SetInstance", func() {
+		var (
+			vmDir string
+			conf  *config.Config
+		)
+
+		BeforeEach(func() {
+			var err error
+			vmDir, err = ioutil.TempDir("", "pcfdev-vms")
+			Expect(err).NotTo(HaveOccurred())
+
+			conf = &config.Config{
+				DefaultVMName:  "some-vm",
+				ExpectedMD5:    "some-md5",
+				ExpectedSHA256: "some-sha256",
+				OVADir:         "some-ova-dir",
+				OVAPath:        filepath.Join("some-ova-dir", "some-vm.ova"),
+				PartialOVAPath: filepath.Join("some-ova-dir", "some-vm.ova.partial"),
+				VMDir:          vmDir,
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(vmDir)
+		})
+
+		Context("when a release is saved for the instance", func() {
+			BeforeEach(func() {
+				Expect(ioutil.WriteFile(filepath.Join(vmDir, "release-some-instance"), []byte(`{"version":"0.21.0","md5":"some-release-md5","sha256":"some-release-sha256"}`), 0644)).To(Succeed())
+			})
+
+			It("should select the saved release", func() {
+				Expect(conf.SetInstance("some-instance")).To(Succeed())
+				Expect(conf.ReleaseVersion).To(Equal("0.21.0"))
+				Expect(conf.DefaultVMName).To(Equal("pcfdev-v0.21.0"))
+				Expect(conf.OVAPath).To(Equal(filepath.Join("some-ova-dir", "pcfdev-v0.21.0.ova")))
+				Expect(conf.ExpectedMD5).To(Equal("some-release-md5"))
+				Expect(conf.ExpectedSHA256).To(Equal("some-release-sha256"))
+			})
+
+			It("should select the release of the plugin for another instance", func() {
+				Expect(conf.SetInstance("some-instance")).To(Succeed())
+				Expect(conf.SetInstance("")).To(Succeed())
+				Expect(conf.ReleaseVersion).To(BeEmpty())
+				Expect(conf.DefaultVMName).To(Equal("some-vm"))
+				Expect(conf.OVAPath).To(Equal(filepath.Join("some-ova-dir", "some-vm.ova")))
+				Expect(conf.PartialOVAPath).To(Equal(filepath.Join("some-ova-dir", "some-vm.ova.partial")))
+				Expect(conf.ExpectedMD5).To(Equal("some-md5"))
+				Expect(conf.ExpectedSHA256).To(Equal("some-sha256"))
+			})
+		})
+
+		Context("when the saved release is invalid", func() {
+			It("should return an error", func() {
+				Expect(ioutil.WriteFile(filepath.Join(vmDir, "release"), []byte("some-bad-release"), 0644)).To(Succeed())
+				Expect(conf.SetInstance("")).To(MatchError(HavePrefix("failed to parse " + filepath.Join(vmDir, "release") + ": ")))
+			})
+		})
+	})
+
+	Describe("#InstanceVMName", func() {
+		It("should return the VM name for the default instance", func() {
+			conf := &config.Config{}
+			Expect(conf.InstanceVMName("pcfdev-some-version")).To(Equal("pcfdev-some-version"))
+		})
+
+		It("should namespace the VM name for a named instance", func() {
+			conf := &config.Config{VMDir: "some-vm-dir"}
+			Expect(conf.SetInstance("some-instance")).To(Succeed())
+			Expect(conf.InstanceVMName("pcfdev-some-version")).To(Equal("pcfdev-some-version--some-instance"))
+		})
+	})
+
+	Describe("InstanceOfVMName", func() {
+		It("should split the VM name and the instance", func() {
+			vmName, instance := config.InstanceOfVMName("pcfdev-some-version--some-instance")
+			Expect(vmName).To(Equal("pcfdev-some-version"))
+			Expect(instance).To(Equal("some-instance"))
+
+			vmName, instance = config.InstanceOfVMName("pcfdev-some-version")
+			Expect(vmName).To(Equal("pcfdev-some-version"))
+			Expect(instance).To(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// Release is a PCF Dev release selected with cf dev download --release. It is
+// saved to Config.ReleasePath and used instead of the release of the plugin
+// by every command of the instance, until its VM is destroyed.
+type Release struct {
+	Version string `json:"version"`
+	MD5     string `json:"md5,omitempty"`
+	SHA256  string `json:"sha256,omitempty"`
+}
+
+// SaveRelease saves release to path.
+func SaveRelease(fs FS, path string, release *Release) error {
+	data, err := json.Marshal(release)
+	if err != nil {
+		return err
+	}
+	return fs.Write(path, strings.NewReader(string(data)), false)
+}
+
+// loadRelease returns the release saved at path, or nil when none is saved.
+func loadRelease(path string) (*Release, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %s", path, err)
+	}
+
+	release := &Release{}
+	if err := json.Unmarshal(data, release); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", path, err)
+	}
+	return release, nil
+}
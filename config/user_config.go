@@ -0,0 +1,198 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	UserConfigCPUs       = "cpus"
+	UserConfigMemory     = "memory"
+	UserConfigServices   = "services"
+	UserConfigRegistries = "registries"
+	UserConfigDomain     = "domain"
+	UserConfigIP         = "ip"
+	UserConfigProvider   = "provider"
+)
+
+var UserConfigKeys = []string{
+	UserConfigCPUs,
+	UserConfigMemory,
+	UserConfigServices,
+	UserConfigRegistries,
+	UserConfigDomain,
+	UserConfigIP,
+	UserConfigProvider,
+}
+
+type UserConfig struct {
+	values map[string]string
+}
+
+type UnknownUserConfigKeyError struct {
+	Key string
+}
+
+func (e *UnknownUserConfigKeyError) Error() string {
+	return fmt.Sprintf("%s is not a supported config key, options: %s", e.Key, strings.Join(UserConfigKeys, ", "))
+}
+
+func NewUserConfig() *UserConfig {
+	return &UserConfig{values: map[string]string{}}
+}
+
+// ParseUserConfig reads a YAML mapping of config keys to values. Lists, such
+// as of services or registries, are joined with commas.
+func ParseUserConfig(data []byte) (*UserConfig, error) {
+	userConfig := NewUserConfig()
+
+	values := yaml.MapSlice{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	for _, item := range values {
+		key := fmt.Sprint(item.Key)
+		if item.Value == nil {
+			if !isUserConfigKey(key) {
+				return nil, &UnknownUserConfigKeyError{Key: key}
+			}
+			continue
+		}
+
+		value, err := userConfigValue(item.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s has an invalid value: %s", key, err)
+		}
+
+		if err := userConfig.Set(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return userConfig, nil
+}
+
+func (u *UserConfig) Get(key string) (value string, err error) {
+	if !isUserConfigKey(key) {
+		return "", &UnknownUserConfigKeyError{Key: key}
+	}
+	return u.values[key], nil
+}
+
+func (u *UserConfig) Set(key string, value string) error {
+	if !isUserConfigKey(key) {
+		return &UnknownUserConfigKeyError{Key: key}
+	}
+
+	switch key {
+	case UserConfigCPUs, UserConfigMemory:
+		if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+			return fmt.Errorf("%s must be a positive number", key)
+		}
+	case UserConfigProvider:
+		if _, ok := parseProvider(value); !ok {
+			return fmt.Errorf("%s is not a supported provider, options: %s, %s", value, ProviderVirtualBox, ProviderQEMU)
+		}
+	}
+
+	u.values[key] = value
+	return nil
+}
+
+func (u *UserConfig) Unset(key string) error {
+	if !isUserConfigKey(key) {
+		return &UnknownUserConfigKeyError{Key: key}
+	}
+
+	delete(u.values, key)
+	return nil
+}
+
+func (u *UserConfig) CPUs() int {
+	cpus, _ := strconv.Atoi(u.values[UserConfigCPUs])
+	return cpus
+}
+
+func (u *UserConfig) Memory() uint64 {
+	memory, _ := strconv.ParseUint(u.values[UserConfigMemory], 10, 64)
+	return memory
+}
+
+func (u *UserConfig) Services() string {
+	return u.values[UserConfigServices]
+}
+
+func (u *UserConfig) Registries() string {
+	return u.values[UserConfigRegistries]
+}
+
+func (u *UserConfig) Domain() string {
+	return u.values[UserConfigDomain]
+}
+
+func (u *UserConfig) IP() string {
+	return u.values[UserConfigIP]
+}
+
+func (u *UserConfig) Provider() string {
+	return u.values[UserConfigProvider]
+}
+
+func (u *UserConfig) Bytes() []byte {
+	values := yaml.MapSlice{}
+	for _, key := range UserConfigKeys {
+		value, ok := u.values[key]
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case UserConfigCPUs, UserConfigMemory:
+			number, _ := strconv.ParseUint(value, 10, 64)
+			values = append(values, yaml.MapItem{Key: key, Value: number})
+		default:
+			values = append(values, yaml.MapItem{Key: key, Value: value})
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	data, _ := yaml.Marshal(values)
+	return data
+}
+
+func isUserConfigKey(key string) bool {
+	for _, userConfigKey := range UserConfigKeys {
+		if key == userConfigKey {
+			return true
+		}
+	}
+	return false
+}
+
+func userConfigValue(value interface{}) (string, error) {
+	switch value := value.(type) {
+	case string:
+		return value, nil
+	case int, int64, uint64, float64, bool:
+		return fmt.Sprint(value), nil
+	case []interface{}:
+		items := []string{}
+		for _, item := range value {
+			itemValue, err := userConfigValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, itemValue)
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return "", errors.New("use a string, a number or a list")
+	}
+}
@@ -0,0 +1,128 @@
+package config_test
+
+import (
+	"github.com/pivotal-cf/pcfdev-cli/config"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UserConfig", func() {
+	Describe("ParseUserConfig", func() {
+		It("should parse keys and values", func() {
+			userConfig, err := config.ParseUserConfig([]byte(`---
+# some comment
+cpus: 4
+memory: 8192 # some other comment
+services: all
+registries: "some-registry:5000"
+domain: 'local2.pcfdev.io'
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(userConfig.CPUs()).To(Equal(4))
+			Expect(userConfig.Memory()).To(Equal(uint64(8192)))
+			Expect(userConfig.Get("services")).To(Equal("all"))
+			Expect(userConfig.Get("registries")).To(Equal("some-registry:5000"))
+			Expect(userConfig.Get("domain")).To(Equal("local2.pcfdev.io"))
+			Expect(userConfig.Get("ip")).To(BeEmpty())
+		})
+
+		Context("when the values are in other YAML styles", func() {
+			It("should parse them", func() {
+				userConfig, err := config.ParseUserConfig([]byte(`cpus: 2
+ip:
+services:
+  - redis
+  - rabbitmq
+registries: [some-registry:5000, some-other-registry:5000]
+domain: >-
+  local2.pcfdev.io
+`))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(userConfig.CPUs()).To(Equal(2))
+				Expect(userConfig.Get("services")).To(Equal("redis,rabbitmq"))
+				Expect(userConfig.Get("registries")).To(Equal("some-registry:5000,some-other-registry:5000"))
+				Expect(userConfig.Get("domain")).To(Equal("local2.pcfdev.io"))
+				Expect(userConfig.Get("ip")).To(BeEmpty())
+			})
+		})
+
+		Context("when the config is not a YAML mapping", func() {
+			It("should return an error", func() {
+				_, err := config.ParseUserConfig([]byte("cpus: 4\nsome-bad-line"))
+				Expect(err).To(MatchError(HavePrefix("yaml: line 3: could not find expected ':'")))
+			})
+		})
+
+		Context("when a value is a mapping", func() {
+			It("should return an error", func() {
+				_, err := config.ParseUserConfig([]byte("domain:\n  some-key: some-value"))
+				Expect(err).To(MatchError("domain has an invalid value: use a string, a number or a list"))
+			})
+		})
+
+		Context("when a key is not supported", func() {
+			It("should return an error", func() {
+				_, err := config.ParseUserConfig([]byte("some-bad-key: some-value"))
+				Expect(err).To(MatchError("some-bad-key is not a supported config key, options: cpus, memory, services, registries, domain, ip, provider"))
+			})
+		})
+
+		Context("when a number is invalid", func() {
+			It("should return an error", func() {
+				_, err := config.ParseUserConfig([]byte("memory: lots"))
+				Expect(err).To(MatchError("memory must be a positive number"))
+			})
+		})
+
+		Context("when the provider is not supported", func() {
+			It("should return an error", func() {
+				_, err := config.ParseUserConfig([]byte("provider: some-bad-provider"))
+				Expect(err).To(MatchError("some-bad-provider is not a supported provider, options: virtualbox, qemu"))
+			})
+		})
+	})
+
+	Describe("#Set", func() {
+		It("should set the value", func() {
+			userConfig := config.NewUserConfig()
+			Expect(userConfig.Set("ip", "192.168.22.11")).To(Succeed())
+			Expect(userConfig.Get("ip")).To(Equal("192.168.22.11"))
+		})
+
+		Context("when the key is not supported", func() {
+			It("should return an error", func() {
+				Expect(config.NewUserConfig().Set("some-bad-key", "some-value")).To(BeAssignableToTypeOf(&config.UnknownUserConfigKeyError{}))
+			})
+		})
+	})
+
+	Describe("#Unset", func() {
+		It("should remove the value", func() {
+			userConfig, err := config.ParseUserConfig([]byte("cpus: 4"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(userConfig.Unset("cpus")).To(Succeed())
+			Expect(userConfig.CPUs()).To(Equal(0))
+			Expect(userConfig.Bytes()).To(BeEmpty())
+		})
+	})
+
+	Describe("#Bytes", func() {
+		It("should serialize the values in a stable order", func() {
+			userConfig := config.NewUserConfig()
+			Expect(userConfig.Set("services", "redis, rabbitmq")).To(Succeed())
+			Expect(userConfig.Set("registries", "some-registry:5000")).To(Succeed())
+			Expect(userConfig.Set("memory", "8192")).To(Succeed())
+			Expect(userConfig.Set("domain", "some: domain")).To(Succeed())
+
+			Expect(string(userConfig.Bytes())).To(Equal("memory: 8192\nservices: redis, rabbitmq\nregistries: some-registry:5000\ndomain: 'some: domain'\n"))
+
+			parsedUserConfig, err := config.ParseUserConfig(userConfig.Bytes())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsedUserConfig).To(Equal(userConfig))
+		})
+	})
+})
@@ -1,5 +1,16 @@
 package config
 
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+//go:generate mockgen -package mocks -destination mocks/fs.go github.com/pivotal-cf/pcfdev-cli/config FS
+type FS interface {
+	Write(path string, contents io.Reader, append bool) error
+}
+
 type VMConfig struct {
 	Name     string
 	OVAPath  string
@@ -9,4 +20,45 @@ type VMConfig struct {
 	CPUs     int
 	SSHPort  string
 	Provider string
+	Mounts   []*Mount
+	Services []string
+}
+
+// Mount is a host folder shared with the VM and mounted at GuestPath.
+type Mount struct {
+	Name      string `json:"name"`
+	HostPath  string `json:"host_path"`
+	GuestPath string `json:"guest_path"`
+}
+
+// SavedVMConfig is the part of a VMConfig that is saved to Config.VMConfigPath.
+type SavedVMConfig struct {
+	IP       string   `json:"ip"`
+	Domain   string   `json:"domain"`
+	Mounts   []*Mount `json:"mounts,omitempty"`
+	Services []string `json:"services,omitempty"`
+}
+
+// HostIP returns the IP address the host reaches the VM on. QEMU VMs use
+// user-mode networking and are only reachable through ports forwarded from
+// 127.0.0.1.
+func (v *VMConfig) HostIP() string {
+	if v.Provider == ProviderQEMU {
+		return "127.0.0.1"
+	}
+	return v.IP
+}
+
+// SaveVMConfig saves the IP, domain, mounts and services of vmConfig to path.
+func SaveVMConfig(fs FS, path string, vmConfig *VMConfig) error {
+	data, err := json.Marshal(&SavedVMConfig{
+		IP:       vmConfig.IP,
+		Domain:   vmConfig.Domain,
+		Mounts:   vmConfig.Mounts,
+		Services: vmConfig.Services,
+	})
+	if err != nil {
+		return err
+	}
+	return fs.Write(path, strings.NewReader(string(data)), false)
 }
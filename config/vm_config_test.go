@@ -0,0 +1,51 @@
+package config_test
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/config/mocks"
+)
+
+var _ = Describe("VMConfig", func() {
+	var (
+		mockCtrl *gomock.Controller
+		mockFS   *mocks.MockFS
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockFS = mocks.NewMockFS(mockCtrl)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe(".SaveVMConfig", func() {
+		It("should save the IP, domain and mounts of the VM", func() {
+			mockFS.EXPECT().Write("some-path", strings.NewReader(`{"ip":"some-ip","domain":"some-domain","mounts":[{"name":"pcfdev-mount-1","host_path":"/some-host-path","guest_path":"/some-guest-path"}]}`), false)
+
+			Expect(config.SaveVMConfig(mockFS, "some-path", &config.VMConfig{
+				Name:    "some-vm",
+				IP:      "some-ip",
+				Domain:  "some-domain",
+				Memory:  4096,
+				SSHPort: "some-port",
+				Mounts:  []*config.Mount{{Name: "pcfdev-mount-1", HostPath: "/some-host-path", GuestPath: "/some-guest-path"}},
+			})).To(Succeed())
+		})
+
+		Context("when writing the file fails", func() {
+			It("should return the error", func() {
+				mockFS.EXPECT().Write("some-path", gomock.Any(), false).Return(errors.New("some-error"))
+
+				Expect(config.SaveVMConfig(mockFS, "some-path", &config.VMConfig{})).To(MatchError("some-error"))
+			})
+		})
+	})
+})
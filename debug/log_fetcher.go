@@ -94,7 +94,7 @@ func (l *LogFetcher) FetchLogs() error {
 			sensitive: false,
 		},
 		logFile{
-			command:   []string{"showvminfo", l.VMConfig.Name},
+			command:   []string{"showvminfo", l.Config.InstanceVMName(l.VMConfig.Name)},
 			filename:  "vm-info",
 			reciever:  ReceiverHost,
 			sensitive: false,
@@ -107,6 +107,10 @@ func (l *LogFetcher) FetchLogs() error {
 		},
 	}
 
+	if l.VMConfig.Provider == config.ProviderQEMU {
+		logFiles = l.guestLogFiles(logFiles)
+	}
+
 	sensitiveInformationScrubber := &SensitiveInformationScrubber{}
 
 	privateKeyBytes, err := l.FS.Read(l.Config.PrivateKeyPath)
@@ -177,6 +181,16 @@ func (l *LogFetcher) FetchLogs() error {
 	return nil
 }
 
+func (l *LogFetcher) guestLogFiles(logFiles []logFile) []logFile {
+	guestLogFiles := []logFile{}
+	for _, logFile := range logFiles {
+		if logFile.reciever == ReceiverGuest {
+			guestLogFiles = append(guestLogFiles, logFile)
+		}
+	}
+	return guestLogFiles
+}
+
 func (l *LogFetcher) getLogFileNames(logFiles []logFile, parentDir string) []string {
 	logFileNames := []string{}
 	for _, logFile := range logFiles {
@@ -106,6 +106,53 @@ var _ = Describe("LogFetcher", func() {
 			Expect(logFetcher.FetchLogs()).To(Succeed())
 		})
 
+		Context("when the VM uses the qemu provider", func() {
+			It("should only fetch the guest logs", func() {
+				logFetcher.VMConfig.Provider = "qemu"
+				addresses := []ssh.SSHAddress{
+					{
+						IP:   "127.0.0.1",
+						Port: "some-port",
+					},
+					{
+						IP:   "some-ip",
+						Port: "22",
+					},
+				}
+
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockFS.EXPECT().TempDir().Return("some-temp-dir", nil),
+					mockSSH.EXPECT().GetSSHOutput("sudo cat /var/pcfdev/provision.log", addresses, []byte("some-private-key"), 20*time.Second).Return("some-pcfdev-provision-log", nil),
+					mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "provision.log"), strings.NewReader("some-pcfdev-provision-log"), false),
+					mockSSH.EXPECT().GetSSHOutput("sudo cat /var/pcfdev/reset.log", addresses, []byte("some-private-key"), 20*time.Second).Return("some-pcfdev-reset-log", nil),
+					mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "reset.log"), strings.NewReader("some-pcfdev-reset-log"), false),
+					mockSSH.EXPECT().GetSSHOutput("sudo cat /var/log/kern.log", addresses, []byte("some-private-key"), 20*time.Second).Return("some-kern-log", nil),
+					mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "kern.log"), strings.NewReader("some-kern-log"), false),
+					mockSSH.EXPECT().GetSSHOutput("sudo cat /var/log/dmesg", addresses, []byte("some-private-key"), 20*time.Second).Return("some-dmesg-log", nil),
+					mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "dmesg"), strings.NewReader("some-dmesg-log"), false),
+					mockSSH.EXPECT().GetSSHOutput("ifconfig", addresses, []byte("some-private-key"), 20*time.Second).Return("some-ifconfig-log", nil),
+					mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "ifconfig"), strings.NewReader("some-ifconfig-log"), false),
+					mockSSH.EXPECT().GetSSHOutput("route -n", addresses, []byte("some-private-key"), 20*time.Second).Return("some-routes-log", nil),
+					mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "routes"), strings.NewReader("some-routes-log"), false),
+
+					mockFS.EXPECT().Compress(
+						"pcfdev-debug",
+						".",
+						[]string{
+							filepath.Join("some-temp-dir", "provision.log"),
+							filepath.Join("some-temp-dir", "reset.log"),
+							filepath.Join("some-temp-dir", "kern.log"),
+							filepath.Join("some-temp-dir", "dmesg"),
+							filepath.Join("some-temp-dir", "ifconfig"),
+							filepath.Join("some-temp-dir", "routes"),
+						}),
+				)
+
+				Expect(logFetcher.FetchLogs()).To(Succeed())
+			})
+		})
+
 		Context("when there is sensitive information", func() {
 			It("should remove the sensitive information", func() {
 				addresses := []ssh.SSHAddress{
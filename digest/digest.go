@@ -0,0 +1,50 @@
+package digest
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+)
+
+type Digests struct {
+	MD5    string
+	SHA256 string
+}
+
+func (d *Digests) Matches(actual *Digests) bool {
+	if actual == nil {
+		return false
+	}
+	if d.SHA256 != "" {
+		return actual.SHA256 == d.SHA256
+	}
+	return d.MD5 != "" && actual.MD5 == d.MD5
+}
+
+type Hasher struct {
+	md5    hash.Hash
+	sha256 hash.Hash
+	writer io.Writer
+}
+
+func NewHasher() *Hasher {
+	h := &Hasher{
+		md5:    md5.New(),
+		sha256: sha256.New(),
+	}
+	h.writer = io.MultiWriter(h.md5, h.sha256)
+	return h
+}
+
+func (h *Hasher) Write(p []byte) (int, error) {
+	return h.writer.Write(p)
+}
+
+func (h *Hasher) Digests() *Digests {
+	return &Digests{
+		MD5:    fmt.Sprintf("%x", h.md5.Sum(nil)),
+		SHA256: fmt.Sprintf("%x", h.sha256.Sum(nil)),
+	}
+}
@@ -0,0 +1,51 @@
+package digest_test
+
+import (
+	"io"
+	"strings"
+
+	"github.com/pivotal-cf/pcfdev-cli/digest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Digests", func() {
+	Describe("#Matches", func() {
+		Context("when a SHA-256 digest is expected", func() {
+			It("should compare the SHA-256 digests", func() {
+				expected := &digest.Digests{MD5: "some-md5", SHA256: "some-sha256"}
+				Expect(expected.Matches(&digest.Digests{MD5: "some-other-md5", SHA256: "some-sha256"})).To(BeTrue())
+				Expect(expected.Matches(&digest.Digests{MD5: "some-md5", SHA256: "some-other-sha256"})).To(BeFalse())
+			})
+		})
+
+		Context("when only an MD5 digest is expected", func() {
+			It("should compare the MD5 digests", func() {
+				expected := &digest.Digests{MD5: "some-md5"}
+				Expect(expected.Matches(&digest.Digests{MD5: "some-md5", SHA256: "some-sha256"})).To(BeTrue())
+				Expect(expected.Matches(&digest.Digests{MD5: "some-other-md5", SHA256: "some-sha256"})).To(BeFalse())
+			})
+		})
+
+		Context("when no digest is expected", func() {
+			It("should not match", func() {
+				Expect((&digest.Digests{}).Matches(&digest.Digests{})).To(BeFalse())
+				Expect((&digest.Digests{SHA256: "some-sha256"}).Matches(nil)).To(BeFalse())
+			})
+		})
+	})
+})
+
+var _ = Describe("Hasher", func() {
+	It("should compute the MD5 and SHA-256 digests of everything written to it", func() {
+		hasher := digest.NewHasher()
+		_, err := io.Copy(hasher, strings.NewReader("some-contents"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(hasher.Digests()).To(Equal(&digest.Digests{
+			MD5:    "0b9791ad102b5f5f06ef68cef2aae26e",
+			SHA256: "6e32ea34db1b3755d7dec972eb72c705338f0dd8e0be881d966963438fb2e800",
+		}))
+	})
+})
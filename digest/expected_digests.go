@@ -0,0 +1,43 @@
+package digest
+
+import "github.com/pivotal-cf/pcfdev-cli/config"
+
+//go:generate mockgen -package mocks -destination mocks/fs.go github.com/pivotal-cf/pcfdev-cli/digest FS
+type FS interface {
+	Read(path string) (contents []byte, err error)
+}
+
+type ExpectedDigests struct {
+	Config    *config.Config
+	FS        FS
+	PublicKey []byte
+}
+
+func (e *ExpectedDigests) Get() (*Digests, error) {
+	if e.Config.OVAManifestPath == "" {
+		return &Digests{
+			MD5:    e.Config.ExpectedMD5,
+			SHA256: e.Config.ExpectedSHA256,
+		}, nil
+	}
+
+	data, err := e.FS.Read(e.Config.OVAManifestPath)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := e.FS.Read(e.Config.OVAManifestPath + ".sig")
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := ParseManifest(data, signature, e.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sha256, err := manifest.SHA256(e.Config.OVAVersion())
+	if err != nil {
+		return nil, err
+	}
+	return &Digests{SHA256: sha256}, nil
+}
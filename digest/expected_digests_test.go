@@ -0,0 +1,124 @@
+package digest_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/digest"
+	"github.com/pivotal-cf/pcfdev-cli/digest/mocks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExpectedDigests", func() {
+	var (
+		expectedDigests *digest.ExpectedDigests
+		mockCtrl        *gomock.Controller
+		mockFS          *mocks.MockFS
+		publicKey       []byte
+		signature       []byte
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockFS = mocks.NewMockFS(mockCtrl)
+		publicKey, signature = signWithRSA([]byte(manifestJSON))
+
+		expectedDigests = &digest.ExpectedDigests{
+			Config: &config.Config{
+				ExpectedMD5:    "some-md5",
+				ExpectedSHA256: "some-compiled-sha256",
+				Version:        &config.Version{OVABuildVersion: "some-version"},
+			},
+			FS:        mockFS,
+			PublicKey: publicKey,
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("#Get", func() {
+		Context("when no manifest is configured", func() {
+			It("should return the digests compiled into the plugin", func() {
+				Expect(expectedDigests.Get()).To(Equal(&digest.Digests{MD5: "some-md5", SHA256: "some-compiled-sha256"}))
+			})
+		})
+
+		Context("when a manifest is configured", func() {
+			BeforeEach(func() {
+				expectedDigests.Config.OVAManifestPath = "some-manifest-path"
+			})
+
+			It("should return the digest listed in the manifest for the OVA version", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-manifest-path").Return([]byte(manifestJSON), nil),
+					mockFS.EXPECT().Read("some-manifest-path.sig").Return(signature, nil),
+				)
+
+				Expect(expectedDigests.Get()).To(Equal(&digest.Digests{SHA256: "some-sha256"}))
+			})
+
+			Context("when a release is selected", func() {
+				It("should return the digest listed in the manifest for the release", func() {
+					expectedDigests.Config.ReleaseVersion = "some-other-version"
+					gomock.InOrder(
+						mockFS.EXPECT().Read("some-manifest-path").Return([]byte(manifestJSON), nil),
+						mockFS.EXPECT().Read("some-manifest-path.sig").Return(signature, nil),
+					)
+
+					Expect(expectedDigests.Get()).To(Equal(&digest.Digests{SHA256: "some-other-sha256"}))
+				})
+			})
+
+			Context("when the signature does not match", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockFS.EXPECT().Read("some-manifest-path").Return([]byte(manifestJSON), nil),
+						mockFS.EXPECT().Read("some-manifest-path.sig").Return([]byte("some-bad-signature"), nil),
+					)
+
+					_, err := expectedDigests.Get()
+					Expect(err).To(MatchError("OVA manifest signature is invalid"))
+				})
+			})
+
+			Context("when the manifest does not list the OVA version", func() {
+				It("should return an error", func() {
+					expectedDigests.Config.Version.OVABuildVersion = "some-missing-version"
+					gomock.InOrder(
+						mockFS.EXPECT().Read("some-manifest-path").Return([]byte(manifestJSON), nil),
+						mockFS.EXPECT().Read("some-manifest-path.sig").Return(signature, nil),
+					)
+
+					_, err := expectedDigests.Get()
+					Expect(err).To(MatchError("OVA manifest does not list OVA version some-missing-version"))
+				})
+			})
+
+			Context("when reading the manifest fails", func() {
+				It("should return the error", func() {
+					mockFS.EXPECT().Read("some-manifest-path").Return(nil, errors.New("some-error"))
+
+					_, err := expectedDigests.Get()
+					Expect(err).To(MatchError("some-error"))
+				})
+			})
+
+			Context("when reading the signature fails", func() {
+				It("should return the error", func() {
+					gomock.InOrder(
+						mockFS.EXPECT().Read("some-manifest-path").Return([]byte(manifestJSON), nil),
+						mockFS.EXPECT().Read("some-manifest-path.sig").Return(nil, errors.New("some-error")),
+					)
+
+					_, err := expectedDigests.Get()
+					Expect(err).To(MatchError("some-error"))
+				})
+			})
+		})
+	})
+})
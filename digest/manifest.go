@@ -0,0 +1,93 @@
+package digest
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+type Manifest struct {
+	OVAs []ManifestEntry `json:"ovas"`
+}
+
+type ManifestEntry struct {
+	Version string `json:"version"`
+	File    string `json:"file"`
+	SHA256  string `json:"sha256"`
+}
+
+type ManifestSignatureError struct{}
+
+func (e *ManifestSignatureError) Error() string {
+	return "OVA manifest signature is invalid"
+}
+
+type ManifestVersionError struct {
+	Version string
+}
+
+func (e *ManifestVersionError) Error() string {
+	return fmt.Sprintf("OVA manifest does not list OVA version %s", e.Version)
+}
+
+func ParseManifest(data []byte, signature []byte, publicKey []byte) (*Manifest, error) {
+	if err := verifySignature(data, signature, publicKey); err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse OVA manifest: %s", err)
+	}
+	return manifest, nil
+}
+
+func (m *Manifest) SHA256(version string) (string, error) {
+	for _, ova := range m.OVAs {
+		if ova.Version == version && ova.SHA256 != "" {
+			return ova.SHA256, nil
+		}
+	}
+	return "", &ManifestVersionError{Version: version}
+}
+
+func verifySignature(data []byte, signature []byte, publicKey []byte) error {
+	block, _ := pem.Decode(publicKey)
+	if block == nil {
+		return errors.New("no public key is available to verify the OVA manifest")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse OVA manifest public key: %s", err)
+	}
+
+	hashed := sha256.Sum256(data)
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		if rsa.VerifyPKCS1v15(key, crypto.SHA256, hashed[:], signature) != nil {
+			return &ManifestSignatureError{}
+		}
+	case *ecdsa.PublicKey:
+		var ecdsaSignature struct {
+			R, S *big.Int
+		}
+		if _, err := asn1.Unmarshal(signature, &ecdsaSignature); err != nil {
+			return &ManifestSignatureError{}
+		}
+		if !ecdsa.Verify(key, hashed[:], ecdsaSignature.R, ecdsaSignature.S) {
+			return &ManifestSignatureError{}
+		}
+	default:
+		return errors.New("OVA manifest public key must be an RSA or ECDSA key")
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package digest_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/pivotal-cf/pcfdev-cli/digest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const manifestJSON = `{"ovas": [
+	{"version": "some-version", "file": "some-file.ova", "sha256": "some-sha256"},
+	{"version": "some-other-version", "file": "some-other-file.ova", "sha256": "some-other-sha256"}
+]}`
+
+var _ = Describe("Manifest", func() {
+	Describe("ParseManifest", func() {
+		Context("when the manifest is signed with an RSA key", func() {
+			It("should parse the manifest", func() {
+				publicKey, signature := signWithRSA([]byte(manifestJSON))
+
+				manifest, err := digest.ParseManifest([]byte(manifestJSON), signature, publicKey)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(manifest.OVAs).To(HaveLen(2))
+				Expect(manifest.SHA256("some-other-version")).To(Equal("some-other-sha256"))
+			})
+		})
+
+		Context("when the manifest is signed with an ECDSA key", func() {
+			It("should parse the manifest", func() {
+				publicKey, signature := signWithECDSA([]byte(manifestJSON))
+
+				manifest, err := digest.ParseManifest([]byte(manifestJSON), signature, publicKey)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(manifest.SHA256("some-version")).To(Equal("some-sha256"))
+			})
+		})
+
+		Context("when the manifest has been modified", func() {
+			It("should return an error", func() {
+				publicKey, signature := signWithRSA([]byte(manifestJSON))
+
+				_, err := digest.ParseManifest([]byte(manifestJSON+" "), signature, publicKey)
+				Expect(err).To(MatchError("OVA manifest signature is invalid"))
+			})
+		})
+
+		Context("when the signature is not valid for an ECDSA key", func() {
+			It("should return an error", func() {
+				publicKey, _ := signWithECDSA([]byte(manifestJSON))
+
+				_, err := digest.ParseManifest([]byte(manifestJSON), []byte("some-bad-signature"), publicKey)
+				Expect(err).To(MatchError("OVA manifest signature is invalid"))
+			})
+		})
+
+		Context("when there is no public key", func() {
+			It("should return an error", func() {
+				_, err := digest.ParseManifest([]byte(manifestJSON), []byte("some-signature"), nil)
+				Expect(err).To(MatchError("no public key is available to verify the OVA manifest"))
+			})
+		})
+
+		Context("when the manifest is not valid JSON", func() {
+			It("should return an error", func() {
+				publicKey, signature := signWithRSA([]byte("some-bad-json"))
+
+				_, err := digest.ParseManifest([]byte("some-bad-json"), signature, publicKey)
+				Expect(err).To(MatchError(ContainSubstring("failed to parse OVA manifest:")))
+			})
+		})
+	})
+
+	Describe("#SHA256", func() {
+		Context("when the version is not in the manifest", func() {
+			It("should return an error", func() {
+				manifest := &digest.Manifest{OVAs: []digest.ManifestEntry{{Version: "some-version", SHA256: "some-sha256"}}}
+
+				_, err := manifest.SHA256("some-missing-version")
+				Expect(err).To(MatchError("OVA manifest does not list OVA version some-missing-version"))
+			})
+		})
+	})
+})
+
+func signWithRSA(data []byte) (publicKey []byte, signature []byte) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).NotTo(HaveOccurred())
+
+	hashed := sha256.Sum256(data)
+	signature, err = rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hashed[:])
+	Expect(err).NotTo(HaveOccurred())
+
+	return encodePublicKey(&privateKey.PublicKey), signature
+}
+
+func signWithECDSA(data []byte) (publicKey []byte, signature []byte) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	hashed := sha256.Sum256(data)
+	signature, err = privateKey.Sign(rand.Reader, hashed[:], crypto.SHA256)
+	Expect(err).NotTo(HaveOccurred())
+
+	return encodePublicKey(&privateKey.PublicKey), signature
+}
+
+func encodePublicKey(key interface{}) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	Expect(err).NotTo(HaveOccurred())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
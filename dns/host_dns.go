@@ -0,0 +1,16 @@
+package dns
+
+// HostDNS points the resolver of the host at the PCF Dev DNS responder for
+// the PCF Dev domain only.
+type HostDNS struct {
+	FS        FS
+	CmdRunner CmdRunner
+	Network   Network
+}
+
+func configName(instance string) string {
+	if instance == "" {
+		return "pcfdev.conf"
+	}
+	return "pcfdev-" + instance + ".conf"
+}
@@ -0,0 +1,140 @@
+package dns
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	resolvedRunDir   = "/run/systemd/resolve"
+	linkStateDir     = "/run/pcfdev"
+	netClassDir      = "/sys/class/net"
+	dnsmasqConfigDir = "/etc/NetworkManager/dnsmasq.d"
+)
+
+// Install routes queries for domain, and only for domain, to the responder
+// on address. With systemd-resolved the route is set on the network link
+// that owns the address, so that no other queries reach the responder.
+func (h *HostDNS) Install(instance string, domain string, address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	resolved, err := h.FS.Exists(resolvedRunDir)
+	if err != nil {
+		return err
+	}
+	if resolved {
+		link, err := h.linkForIP(host)
+		if err != nil {
+			return err
+		}
+		if link == "" {
+			return fmt.Errorf("no network link other than loopback has the address %s, point *.%s at %s manually", host, domain, address)
+		}
+
+		for _, args := range [][]string{
+			{"resolvectl", "dns", link, address},
+			{"resolvectl", "domain", link, "~" + domain},
+			{"resolvectl", "default-route", link, "false"},
+		} {
+			if _, err := h.CmdRunner.Run("sudo", args...); err != nil {
+				return err
+			}
+		}
+		return h.writeConfig(linkStateDir, configName(instance), link)
+	}
+
+	dnsmasq, err := h.FS.Exists(dnsmasqConfigDir)
+	if err != nil {
+		return err
+	}
+	if dnsmasq {
+		contents := fmt.Sprintf("server=/%s/%s#%s\n", domain, host, port)
+		if err := h.writeConfig(dnsmasqConfigDir, configName(instance), contents); err != nil {
+			return err
+		}
+		_, err := h.CmdRunner.Run("sudo", "systemctl", "reload", "NetworkManager")
+		return err
+	}
+
+	return fmt.Errorf("neither systemd-resolved nor the dnsmasq plugin of NetworkManager is in use, point *.%s at %s manually", domain, address)
+}
+
+func (h *HostDNS) Uninstall(instance string) error {
+	if err := h.revertLink(instance); err != nil {
+		return err
+	}
+
+	path := filepath.Join(dnsmasqConfigDir, configName(instance))
+	exists, err := h.FS.Exists(path)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	if _, err := h.CmdRunner.Run("sudo", "rm", "-f", path); err != nil {
+		return err
+	}
+	_, err = h.CmdRunner.Run("sudo", "systemctl", "reload", "NetworkManager")
+	return err
+}
+
+// revertLink drops the resolved settings of the link recorded by Install.
+// They are lost anyway when the link goes away.
+func (h *HostDNS) revertLink(instance string) error {
+	path := filepath.Join(linkStateDir, configName(instance))
+	exists, err := h.FS.Exists(path)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	data, err := h.FS.Read(path)
+	if err != nil {
+		return err
+	}
+	if link := strings.TrimSpace(string(data)); link != "" {
+		linkExists, err := h.FS.Exists(filepath.Join(netClassDir, link))
+		if err != nil {
+			return err
+		}
+		if linkExists {
+			if _, err := h.CmdRunner.Run("sudo", "resolvectl", "revert", link); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = h.CmdRunner.Run("sudo", "rm", "-f", path)
+	return err
+}
+
+func (h *HostDNS) linkForIP(ip string) (string, error) {
+	interfaces, err := h.Network.Interfaces()
+	if err != nil {
+		return "", err
+	}
+
+	for _, iface := range interfaces {
+		if iface.IP == ip && !net.ParseIP(ip).IsLoopback() {
+			return iface.Name, nil
+		}
+	}
+	return "", nil
+}
+
+func (h *HostDNS) writeConfig(dir string, name string, contents string) error {
+	if _, err := h.CmdRunner.Run("sudo", "mkdir", "-p", dir); err != nil {
+		return err
+	}
+	_, err := h.CmdRunner.RunWithInput(strings.NewReader(contents), "sudo", "tee", filepath.Join(dir, name))
+	return err
+}
@@ -0,0 +1,166 @@
+package dns_test
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pivotal-cf/pcfdev-cli/dns"
+	"github.com/pivotal-cf/pcfdev-cli/dns/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/network"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HostDNS", func() {
+	var (
+		mockCtrl      *gomock.Controller
+		mockFS        *mocks.MockFS
+		mockCmdRunner *mocks.MockCmdRunner
+		mockNetwork   *mocks.MockNetwork
+		hostDNS       *dns.HostDNS
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockFS = mocks.NewMockFS(mockCtrl)
+		mockCmdRunner = mocks.NewMockCmdRunner(mockCtrl)
+		mockNetwork = mocks.NewMockNetwork(mockCtrl)
+		hostDNS = &dns.HostDNS{
+			FS:        mockFS,
+			CmdRunner: mockCmdRunner,
+			Network:   mockNetwork,
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("#Install", func() {
+		Context("when systemd-resolved is running", func() {
+			var interfaces []*network.Interface
+
+			BeforeEach(func() {
+				interfaces = []*network.Interface{
+					{Name: "lo", IP: "127.0.0.1"},
+					{Name: "eth0", IP: "10.0.0.5"},
+					{Name: "vboxnet0", IP: "192.168.11.1"},
+				}
+			})
+
+			It("should route only the domain to the responder on the link of its address", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists("/run/systemd/resolve").Return(true, nil),
+					mockNetwork.EXPECT().Interfaces().Return(interfaces, nil),
+					mockCmdRunner.EXPECT().Run("sudo", "resolvectl", "dns", "vboxnet0", "192.168.11.1:10053"),
+					mockCmdRunner.EXPECT().Run("sudo", "resolvectl", "domain", "vboxnet0", "~pcfdev.example.com"),
+					mockCmdRunner.EXPECT().Run("sudo", "resolvectl", "default-route", "vboxnet0", "false"),
+					mockCmdRunner.EXPECT().Run("sudo", "mkdir", "-p", "/run/pcfdev"),
+					mockCmdRunner.EXPECT().RunWithInput(strings.NewReader("vboxnet0"), "sudo", "tee", "/run/pcfdev/pcfdev.conf"),
+				)
+
+				Expect(hostDNS.Install("", "pcfdev.example.com", "192.168.11.1:10053")).To(Succeed())
+			})
+
+			Context("when the responder listens on loopback", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockFS.EXPECT().Exists("/run/systemd/resolve").Return(true, nil),
+						mockNetwork.EXPECT().Interfaces().Return(interfaces, nil),
+					)
+
+					Expect(hostDNS.Install("", "local.pcfdev.test", "127.0.0.1:10053")).To(MatchError("no network link other than loopback has the address 127.0.0.1, point *.local.pcfdev.test at 127.0.0.1:10053 manually"))
+				})
+			})
+
+			Context("when configuring the link fails", func() {
+				It("should return the error", func() {
+					gomock.InOrder(
+						mockFS.EXPECT().Exists("/run/systemd/resolve").Return(true, nil),
+						mockNetwork.EXPECT().Interfaces().Return(interfaces, nil),
+						mockCmdRunner.EXPECT().Run("sudo", "resolvectl", "dns", "vboxnet0", "192.168.11.1:10053").Return(nil, errors.New("some-error")),
+					)
+
+					Expect(hostDNS.Install("", "pcfdev.example.com", "192.168.11.1:10053")).To(MatchError("some-error"))
+				})
+			})
+
+			Context("when listing the network interfaces fails", func() {
+				It("should return the error", func() {
+					gomock.InOrder(
+						mockFS.EXPECT().Exists("/run/systemd/resolve").Return(true, nil),
+						mockNetwork.EXPECT().Interfaces().Return(nil, errors.New("some-error")),
+					)
+
+					Expect(hostDNS.Install("", "pcfdev.example.com", "192.168.11.1:10053")).To(MatchError("some-error"))
+				})
+			})
+		})
+
+		Context("when NetworkManager uses dnsmasq", func() {
+			It("should route the domain to the responder with a dnsmasq config", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists("/run/systemd/resolve").Return(false, nil),
+					mockFS.EXPECT().Exists("/etc/NetworkManager/dnsmasq.d").Return(true, nil),
+					mockCmdRunner.EXPECT().Run("sudo", "mkdir", "-p", "/etc/NetworkManager/dnsmasq.d"),
+					mockCmdRunner.EXPECT().RunWithInput(strings.NewReader("server=/pcfdev.example.com/127.0.0.1#10053\n"), "sudo", "tee", "/etc/NetworkManager/dnsmasq.d/pcfdev-some-instance.conf"),
+					mockCmdRunner.EXPECT().Run("sudo", "systemctl", "reload", "NetworkManager"),
+				)
+
+				Expect(hostDNS.Install("some-instance", "pcfdev.example.com", "127.0.0.1:10053")).To(Succeed())
+			})
+		})
+
+		Context("when neither is in use", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists("/run/systemd/resolve").Return(false, nil),
+					mockFS.EXPECT().Exists("/etc/NetworkManager/dnsmasq.d").Return(false, nil),
+				)
+
+				Expect(hostDNS.Install("", "pcfdev.example.com", "127.0.0.1:10053")).To(MatchError("neither systemd-resolved nor the dnsmasq plugin of NetworkManager is in use, point *.pcfdev.example.com at 127.0.0.1:10053 manually"))
+			})
+		})
+	})
+
+	Describe("#Uninstall", func() {
+		It("should revert the link of the instance and remove the dnsmasq config", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Exists("/run/pcfdev/pcfdev.conf").Return(true, nil),
+				mockFS.EXPECT().Read("/run/pcfdev/pcfdev.conf").Return([]byte("vboxnet0"), nil),
+				mockFS.EXPECT().Exists("/sys/class/net/vboxnet0").Return(true, nil),
+				mockCmdRunner.EXPECT().Run("sudo", "resolvectl", "revert", "vboxnet0"),
+				mockCmdRunner.EXPECT().Run("sudo", "rm", "-f", "/run/pcfdev/pcfdev.conf"),
+				mockFS.EXPECT().Exists("/etc/NetworkManager/dnsmasq.d/pcfdev.conf").Return(true, nil),
+				mockCmdRunner.EXPECT().Run("sudo", "rm", "-f", "/etc/NetworkManager/dnsmasq.d/pcfdev.conf"),
+				mockCmdRunner.EXPECT().Run("sudo", "systemctl", "reload", "NetworkManager"),
+			)
+
+			Expect(hostDNS.Uninstall("")).To(Succeed())
+		})
+
+		Context("when the link is gone", func() {
+			It("should only remove the record of the link", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists("/run/pcfdev/pcfdev-some-instance.conf").Return(true, nil),
+					mockFS.EXPECT().Read("/run/pcfdev/pcfdev-some-instance.conf").Return([]byte("vboxnet0\n"), nil),
+					mockFS.EXPECT().Exists("/sys/class/net/vboxnet0").Return(false, nil),
+					mockCmdRunner.EXPECT().Run("sudo", "rm", "-f", "/run/pcfdev/pcfdev-some-instance.conf"),
+					mockFS.EXPECT().Exists("/etc/NetworkManager/dnsmasq.d/pcfdev-some-instance.conf").Return(false, nil),
+				)
+
+				Expect(hostDNS.Uninstall("some-instance")).To(Succeed())
+			})
+		})
+
+		Context("when nothing was installed", func() {
+			It("should not run any commands", func() {
+				mockFS.EXPECT().Exists(gomock.Any()).Return(false, nil).Times(2)
+
+				Expect(hostDNS.Uninstall("some-instance")).To(Succeed())
+			})
+		})
+	})
+})
@@ -0,0 +1,13 @@
+// +build !linux
+
+package dns
+
+import "fmt"
+
+func (h *HostDNS) Install(instance string, domain string, address string) error {
+	return fmt.Errorf("configuring the host resolver is only supported on Linux, point *.%s at %s manually", domain, address)
+}
+
+func (h *HostDNS) Uninstall(instance string) error {
+	return nil
+}
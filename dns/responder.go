@@ -0,0 +1,122 @@
+package dns
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	answerTTL      = 60
+	forwardTimeout = 5 * time.Second
+	maxMessageSize = 65535
+)
+
+// Responder answers queries for the PCF Dev domain and all of its
+// subdomains with the VM IP, and forwards every other query to Upstream.
+type Responder struct {
+	Domain   string
+	IP       string
+	Upstream string
+}
+
+func (r *Responder) Serve(conn net.PacketConn) error {
+	for {
+		buffer := make([]byte, maxMessageSize)
+		n, addr, err := conn.ReadFrom(buffer)
+		if err != nil {
+			return err
+		}
+
+		go func(query []byte, addr net.Addr) {
+			if response, err := r.Respond(query); err == nil {
+				conn.WriteTo(response, addr)
+			}
+		}(buffer[:n], addr)
+	}
+}
+
+func (r *Responder) Respond(query []byte) ([]byte, error) {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(query)
+	if err != nil {
+		return nil, err
+	}
+	question, err := parser.Question()
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.isPCFDevName(question.Name.String()) {
+		if r.Upstream == "" {
+			return r.reply(header, question, dnsmessage.RCodeServerFailure, nil)
+		}
+		return r.forward(query)
+	}
+
+	answers := []dnsmessage.Resource{}
+	if question.Type == dnsmessage.TypeA || question.Type == dnsmessage.TypeALL {
+		ip := net.ParseIP(r.IP).To4()
+		if ip == nil {
+			return nil, fmt.Errorf("%s is not an IPv4 address", r.IP)
+		}
+
+		var a [4]byte
+		copy(a[:], ip)
+		answers = append(answers, dnsmessage.Resource{
+			Header: dnsmessage.ResourceHeader{
+				Name:  question.Name,
+				Type:  dnsmessage.TypeA,
+				Class: dnsmessage.ClassINET,
+				TTL:   answerTTL,
+			},
+			Body: &dnsmessage.AResource{A: a},
+		})
+	}
+	return r.reply(header, question, dnsmessage.RCodeSuccess, answers)
+}
+
+func (r *Responder) reply(query dnsmessage.Header, question dnsmessage.Question, rcode dnsmessage.RCode, answers []dnsmessage.Resource) ([]byte, error) {
+	response := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:                 query.ID,
+			Response:           true,
+			Authoritative:      rcode == dnsmessage.RCodeSuccess,
+			RecursionDesired:   query.RecursionDesired,
+			RecursionAvailable: true,
+			RCode:              rcode,
+		},
+		Questions: []dnsmessage.Question{question},
+		Answers:   answers,
+	}
+	return response.Pack()
+}
+
+func (r *Responder) forward(query []byte) ([]byte, error) {
+	conn, err := net.DialTimeout("udp", r.Upstream, forwardTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(forwardTimeout))
+	if _, err := conn.Write(query); err != nil {
+		return nil, err
+	}
+
+	buffer := make([]byte, maxMessageSize)
+	n, err := conn.Read(buffer)
+	if err != nil {
+		return nil, err
+	}
+	return buffer[:n], nil
+}
+
+func (r *Responder) isPCFDevName(name string) bool {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	domain := strings.ToLower(strings.TrimSuffix(r.Domain, "."))
+	return name == domain || strings.HasSuffix(name, "."+domain)
+}
@@ -0,0 +1,133 @@
+package dns_test
+
+import (
+	"net"
+
+	"github.com/pivotal-cf/pcfdev-cli/dns"
+	"golang.org/x/net/dns/dnsmessage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Responder", func() {
+	var responder *dns.Responder
+
+	query := func(name string, queryType dnsmessage.Type) []byte {
+		message := dnsmessage.Message{
+			Header: dnsmessage.Header{ID: 42, RecursionDesired: true},
+			Questions: []dnsmessage.Question{{
+				Name:  dnsmessage.MustNewName(name),
+				Type:  queryType,
+				Class: dnsmessage.ClassINET,
+			}},
+		}
+		packed, err := message.Pack()
+		Expect(err).NotTo(HaveOccurred())
+		return packed
+	}
+
+	parse := func(response []byte) *dnsmessage.Message {
+		message := &dnsmessage.Message{}
+		Expect(message.Unpack(response)).To(Succeed())
+		return message
+	}
+
+	BeforeEach(func() {
+		responder = &dns.Responder{
+			Domain: "pcfdev.example.com",
+			IP:     "10.20.30.11",
+		}
+	})
+
+	Describe("#Respond", func() {
+		It("should answer the domain and its subdomains with the VM IP", func() {
+			for _, name := range []string{"pcfdev.example.com.", "api.pcfdev.example.com.", "some-app.PCFDEV.example.com."} {
+				response, err := responder.Respond(query(name, dnsmessage.TypeA))
+				Expect(err).NotTo(HaveOccurred())
+
+				message := parse(response)
+				Expect(message.Header.ID).To(Equal(uint16(42)))
+				Expect(message.Header.RCode).To(Equal(dnsmessage.RCodeSuccess))
+				Expect(message.Answers).To(HaveLen(1))
+				Expect(message.Answers[0].Header.Name.String()).To(Equal(name))
+				Expect(message.Answers[0].Body).To(Equal(&dnsmessage.AResource{A: [4]byte{10, 20, 30, 11}}))
+			}
+		})
+
+		Context("when another record type is queried", func() {
+			It("should answer without records", func() {
+				response, err := responder.Respond(query("api.pcfdev.example.com.", dnsmessage.TypeAAAA))
+				Expect(err).NotTo(HaveOccurred())
+
+				message := parse(response)
+				Expect(message.Header.RCode).To(Equal(dnsmessage.RCodeSuccess))
+				Expect(message.Answers).To(BeEmpty())
+			})
+		})
+
+		Context("when the query is for another domain", func() {
+			var upstream net.PacketConn
+
+			BeforeEach(func() {
+				var err error
+				upstream, err = net.ListenPacket("udp", "127.0.0.1:0")
+				Expect(err).NotTo(HaveOccurred())
+				responder.Upstream = upstream.LocalAddr().String()
+
+				go func() {
+					defer GinkgoRecover()
+					buffer := make([]byte, 512)
+					n, addr, err := upstream.ReadFrom(buffer)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(buffer[:n])).To(Equal(string(query("example.com.", dnsmessage.TypeA))))
+					upstream.WriteTo([]byte("some-response"), addr)
+				}()
+			})
+
+			AfterEach(func() {
+				upstream.Close()
+			})
+
+			It("should forward the query upstream", func() {
+				Expect(responder.Respond(query("example.com.", dnsmessage.TypeA))).To(Equal([]byte("some-response")))
+			})
+		})
+
+		Context("when the query is for another domain and there is no upstream", func() {
+			It("should fail the query", func() {
+				response, err := responder.Respond(query("example.com.", dnsmessage.TypeA))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(parse(response).Header.RCode).To(Equal(dnsmessage.RCodeServerFailure))
+			})
+		})
+
+		Context("when the query is malformed", func() {
+			It("should return an error", func() {
+				_, err := responder.Respond([]byte("some-bad-query"))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("#Serve", func() {
+		It("should respond to queries on the connection", func() {
+			conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+			go responder.Serve(conn)
+
+			client, err := net.Dial("udp", conn.LocalAddr().String())
+			Expect(err).NotTo(HaveOccurred())
+			defer client.Close()
+
+			_, err = client.Write(query("api.pcfdev.example.com.", dnsmessage.TypeA))
+			Expect(err).NotTo(HaveOccurred())
+
+			buffer := make([]byte, 512)
+			n, err := client.Read(buffer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parse(buffer[:n]).Answers).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,98 @@
+package dns
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/pivotal-cf/pcfdev-cli/network"
+)
+
+const (
+	resolvConfPath         = "/etc/resolv.conf"
+	resolvedResolvConfPath = "/run/systemd/resolve/resolv.conf"
+)
+
+// resolvedStubs are the local listeners of systemd-resolved, which may
+// route a forwarded query straight back to the responder.
+var resolvedStubs = []string{"127.0.0.53", "127.0.0.54"}
+
+//go:generate mockgen -package mocks -destination mocks/fs.go github.com/pivotal-cf/pcfdev-cli/dns FS
+type FS interface {
+	Exists(path string) (exists bool, err error)
+	Read(path string) (contents []byte, err error)
+}
+
+//go:generate mockgen -package mocks -destination mocks/cmd_runner.go github.com/pivotal-cf/pcfdev-cli/dns CmdRunner
+type CmdRunner interface {
+	Run(command string, args ...string) (output []byte, err error)
+	RunWithInput(input io.Reader, command string, args ...string) (output []byte, err error)
+}
+
+//go:generate mockgen -package mocks -destination mocks/network.go github.com/pivotal-cf/pcfdev-cli/dns Network
+type Network interface {
+	Interfaces() (interfaces []*network.Interface, err error)
+}
+
+type Server struct {
+	FS FS
+}
+
+// ListenAndServe answers DNS queries on the given UDP address until it fails.
+// Queries outside of domain are forwarded to the first name server of the host.
+func (s *Server) ListenAndServe(address string, domain string, ip string) error {
+	conn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	responder := &Responder{
+		Domain: domain,
+		IP:     ip,
+	}
+	responder.Upstream = s.upstream(address)
+	return responder.Serve(conn)
+}
+
+// upstream falls back to the name servers that systemd-resolved uses itself
+// when /etc/resolv.conf only lists its stub.
+func (s *Server) upstream(address string) string {
+	for _, path := range []string{resolvConfPath, resolvedResolvConfPath} {
+		if resolvConf, err := s.FS.Read(path); err == nil {
+			if upstream := Upstream(resolvConf, address); upstream != "" {
+				return upstream
+			}
+		}
+	}
+	return ""
+}
+
+// Upstream returns the address of the first name server in resolvConf
+// that is neither the given address nor a systemd-resolved stub.
+func Upstream(resolvConf []byte, address string) string {
+	scanner := bufio.NewScanner(bytes.NewReader(resolvConf))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "nameserver" || isResolvedStub(fields[1]) {
+			continue
+		}
+
+		upstream := net.JoinHostPort(fields[1], "53")
+		if upstream != address {
+			return upstream
+		}
+	}
+	return ""
+}
+
+func isResolvedStub(ip string) bool {
+	for _, stub := range resolvedStubs {
+		if ip == stub {
+			return true
+		}
+	}
+	return false
+}
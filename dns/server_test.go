@@ -0,0 +1,34 @@
+package dns_test
+
+import (
+	"github.com/pivotal-cf/pcfdev-cli/dns"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Server", func() {
+	Describe(".Upstream", func() {
+		It("should return the first name server that is not the responder", func() {
+			resolvConf := []byte("# some-comment\nsearch example.com\nnameserver 127.0.0.1\nnameserver 10.0.0.2\nnameserver 10.0.0.3\n")
+
+			Expect(dns.Upstream(resolvConf, "127.0.0.1:53")).To(Equal("10.0.0.2:53"))
+			Expect(dns.Upstream(resolvConf, "127.0.0.1:10053")).To(Equal("127.0.0.1:53"))
+		})
+
+		Context("when the name server is the systemd-resolved stub", func() {
+			It("should skip it", func() {
+				resolvConf := []byte("nameserver 127.0.0.53\noptions edns0 trust-ad\n")
+
+				Expect(dns.Upstream(resolvConf, "192.168.11.1:10053")).To(BeEmpty())
+				Expect(dns.Upstream([]byte("nameserver 127.0.0.53\nnameserver 10.0.0.2\n"), "192.168.11.1:10053")).To(Equal("10.0.0.2:53"))
+			})
+		})
+
+		Context("when there are no name servers", func() {
+			It("should return no upstream", func() {
+				Expect(dns.Upstream([]byte("search example.com\n"), "127.0.0.1:10053")).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -2,9 +2,12 @@ package downloader
 
 import (
 	"io"
+	"path/filepath"
 	"time"
 
+	"github.com/pivotal-cf/pcfdev-cli/cache"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/digest"
 	"github.com/pivotal-cf/pcfdev-cli/helpers"
 	"github.com/pivotal-cf/pcfdev-cli/pivnet"
 )
@@ -14,6 +17,8 @@ type ConcreteOVADownloader struct {
 	PivnetClient         Client
 	Config               *config.Config
 	Token                Token
+	ExpectedDigests      ExpectedDigests
+	Cache                Cache
 	DownloadAttempts     int
 	DownloadAttemptDelay time.Duration
 }
@@ -21,43 +26,44 @@ type ConcreteOVADownloader struct {
 //go:generate mockgen -package mocks -destination mocks/client.go github.com/pivotal-cf/pcfdev-cli/downloader Client
 type Client interface {
 	DownloadOVA(startAtByte int64) (ova *pivnet.DownloadReader, err error)
+	DownloadOVARange(startAtByte int64, endAtByte int64) (ova io.ReadCloser, err error)
+	OVASize() (size int64, err error)
 }
 
 //go:generate mockgen -package mocks -destination mocks/fs.go github.com/pivotal-cf/pcfdev-cli/downloader FS
 type FS interface {
 	Remove(path string) error
 	Exists(path string) (exists bool, err error)
-	MD5(path string) (md5 string, err error)
+	Read(path string) (contents []byte, err error)
+	Open(path string) (file io.ReadCloser, err error)
+	Digests(path string) (digests *digest.Digests, err error)
+	Hash(path string, hasher io.Writer) error
 	CreateDir(path string) error
 	Length(path string) (bytes int64, err error)
 	Write(path string, contents io.Reader, append bool) error
+	Truncate(path string, length int64) error
 	Move(source string, destinationPath string) error
 	DeleteAllExcept(path string, filenames []string) error
 }
 
+//go:generate mockgen -package mocks -destination mocks/cache.go github.com/pivotal-cf/pcfdev-cli/downloader Cache
+type Cache interface {
+	Entry(filename string) (entry *cache.Entry, err error)
+	Save(entry *cache.Entry) error
+}
+
 //go:generate mockgen -package mocks -destination mocks/token.go github.com/pivotal-cf/pcfdev-cli/downloader Token
 type Token interface {
 	Save() error
 }
 
-func (d *ConcreteOVADownloader) IsOVACurrent() (bool, error) {
-	fileExists, err := d.FS.Exists(d.Config.OVAPath)
-	if err != nil {
-		return false, err
-	}
-	if !fileExists {
-		return false, nil
-	}
-
-	md5, err := d.FS.MD5(d.Config.OVAPath)
-	if err != nil {
-		return false, err
-	}
-	if md5 != d.Config.ExpectedMD5 {
-		return false, nil
-	}
+//go:generate mockgen -package mocks -destination mocks/expected_digests.go github.com/pivotal-cf/pcfdev-cli/downloader ExpectedDigests
+type ExpectedDigests interface {
+	Get() (digests *digest.Digests, err error)
+}
 
-	return true, nil
+func (d *ConcreteOVADownloader) IsOVACurrent() (bool, error) {
+	return isOVACurrent(d.FS, d.Config, d.ExpectedDigests, d.Cache)
 }
 
 func (d *ConcreteOVADownloader) Setup() error {
@@ -65,11 +71,18 @@ func (d *ConcreteOVADownloader) Setup() error {
 		return err
 	}
 
-	return d.FS.DeleteAllExcept(d.Config.OVADir, []string{d.Config.DefaultVMName + ".ova", d.Config.DefaultVMName + ".ova.partial"})
+	if !d.Config.PruneOVACache {
+		return nil
+	}
+
+	return d.FS.DeleteAllExcept(d.Config.OVADir, []string{d.Config.DefaultVMName + ".ova", d.Config.DefaultVMName + ".ova.json", d.Config.DefaultVMName + ".ova.partial"})
 }
 
-func (d *ConcreteOVADownloader) Download() (string, error) {
+func (d *ConcreteOVADownloader) Download() (*digest.Digests, error) {
+	var hasher *digest.Hasher
 	err := helpers.ExecuteWithAttempts(func() error {
+		hasher = digest.NewHasher()
+
 		exists, err := d.FS.Exists(d.Config.PartialOVAPath)
 		if err != nil {
 			return err
@@ -81,6 +94,9 @@ func (d *ConcreteOVADownloader) Download() (string, error) {
 			if err != nil {
 				return err
 			}
+			if err := d.FS.Hash(d.Config.PartialOVAPath, hasher); err != nil {
+				return err
+			}
 		} else {
 			startAtBytes = int64(0)
 		}
@@ -95,7 +111,7 @@ func (d *ConcreteOVADownloader) Download() (string, error) {
 			return err
 		}
 
-		if err := d.FS.Write(d.Config.PartialOVAPath, ova, true); err != nil {
+		if err := d.FS.Write(d.Config.PartialOVAPath, io.TeeReader(ova, hasher), true); err != nil {
 			return err
 		}
 
@@ -103,8 +119,57 @@ func (d *ConcreteOVADownloader) Download() (string, error) {
 	}, d.DownloadAttempts, d.DownloadAttemptDelay)
 
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	return hasher.Digests(), nil
+}
+
+func cacheEntry(conf *config.Config, digests *digest.Digests) *cache.Entry {
+	return &cache.Entry{
+		Version: conf.OVAVersion(),
+		File:    filepath.Base(conf.OVAPath),
+		MD5:     digests.MD5,
+		SHA256:  digests.SHA256,
+		Source:  conf.OVASource,
+	}
+}
+
+// isOVACurrent compares the expected digests with the ones recorded in the
+// cache when the OVA was downloaded. Only an OVA cached without the needed
+// digest is hashed, and its digests are recorded if they match.
+func isOVACurrent(fs FS, conf *config.Config, expected ExpectedDigests, c Cache) (bool, error) {
+	fileExists, err := fs.Exists(conf.OVAPath)
+	if err != nil {
+		return false, err
+	}
+	if !fileExists {
+		return false, nil
+	}
+
+	expectedDigests, err := expected.Get()
+	if err != nil {
+		return false, err
+	}
+
+	entry, err := c.Entry(filepath.Base(conf.OVAPath))
+	if err != nil {
+		return false, err
+	}
+
+	if entry.SHA256 != "" || (expectedDigests.SHA256 == "" && entry.MD5 != "") {
+		return expectedDigests.Matches(entry.Digests()), nil
+	}
+
+	digests, err := fs.Digests(conf.OVAPath)
+	if err != nil {
+		return false, err
+	}
+	if !expectedDigests.Matches(digests) {
+		return false, nil
 	}
 
-	return d.FS.MD5(d.Config.PartialOVAPath)
+	entry.MD5 = digests.MD5
+	entry.SHA256 = digests.SHA256
+	return true, c.Save(entry)
 }
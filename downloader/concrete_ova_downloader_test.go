@@ -2,11 +2,14 @@ package downloader_test
 
 import (
 	"errors"
+	"io"
 	"io/ioutil"
 	"strings"
 
 	"github.com/golang/mock/gomock"
+	"github.com/pivotal-cf/pcfdev-cli/cache"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/digest"
 	dl "github.com/pivotal-cf/pcfdev-cli/downloader"
 	"github.com/pivotal-cf/pcfdev-cli/downloader/mocks"
 	"github.com/pivotal-cf/pcfdev-cli/pivnet"
@@ -22,6 +25,9 @@ var _ = Describe("ConcreteOVADownloader", func() {
 		mockClient *mocks.MockClient
 		mockFS     *mocks.MockFS
 		mockToken  *mocks.MockToken
+		mockCache  *mocks.MockCache
+
+		mockExpectedDigests *mocks.MockExpectedDigests
 	)
 
 	BeforeEach(func() {
@@ -29,6 +35,8 @@ var _ = Describe("ConcreteOVADownloader", func() {
 		mockClient = mocks.NewMockClient(mockCtrl)
 		mockFS = mocks.NewMockFS(mockCtrl)
 		mockToken = mocks.NewMockToken(mockCtrl)
+		mockCache = mocks.NewMockCache(mockCtrl)
+		mockExpectedDigests = mocks.NewMockExpectedDigests(mockCtrl)
 
 		downloader = &dl.ConcreteOVADownloader{
 			PivnetClient: mockClient,
@@ -38,9 +46,10 @@ var _ = Describe("ConcreteOVADownloader", func() {
 				OVAPath:        "some-ova-path",
 				PartialOVAPath: "some-partial-ova-path",
 				DefaultVMName:  "some-vm",
-				ExpectedMD5:    "some-md5",
 			},
 			Token:                mockToken,
+			ExpectedDigests:      mockExpectedDigests,
+			Cache:                mockCache,
 			DownloadAttempts:     2,
 			DownloadAttemptDelay: 0,
 		}
@@ -59,28 +68,84 @@ var _ = Describe("ConcreteOVADownloader", func() {
 			})
 		})
 
-		Context("when OVA exists and has correct MD5", func() {
-			It("should return true", func() {
+		Context("when OVA exists and the cache recorded the expected digests", func() {
+			It("should return true without hashing the OVA", func() {
 				gomock.InOrder(
 					mockFS.EXPECT().Exists("some-ova-path").Return(true, nil),
-					mockFS.EXPECT().MD5("some-ova-path").Return("some-md5", nil),
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+					mockCache.EXPECT().Entry("some-ova-path").Return(&cache.Entry{MD5: "some-md5", SHA256: "some-sha256"}, nil),
 				)
 
 				Expect(downloader.IsOVACurrent()).To(BeTrue())
 			})
 		})
 
-		Context("when OVA exists and has incorrect MD5", func() {
+		Context("when OVA exists and the cache recorded unexpected digests", func() {
 			It("should return false", func() {
 				gomock.InOrder(
 					mockFS.EXPECT().Exists("some-ova-path").Return(true, nil),
-					mockFS.EXPECT().MD5("some-ova-path").Return("some-bad-md5", nil),
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+					mockCache.EXPECT().Entry("some-ova-path").Return(&cache.Entry{MD5: "some-md5", SHA256: "some-bad-sha256"}, nil),
 				)
 
 				Expect(downloader.IsOVACurrent()).To(BeFalse())
 			})
 		})
 
+		Context("when the cache recorded only an MD5 and an MD5 is expected", func() {
+			It("should compare the MD5", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists("some-ova-path").Return(true, nil),
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{MD5: "some-md5"}, nil),
+					mockCache.EXPECT().Entry("some-ova-path").Return(&cache.Entry{MD5: "some-md5"}, nil),
+				)
+
+				Expect(downloader.IsOVACurrent()).To(BeTrue())
+			})
+		})
+
+		Context("when the cache has no recorded SHA-256", func() {
+			It("should hash the OVA and record the digests", func() {
+				entry := &cache.Entry{Version: "some-version", File: "some-ova-path", MD5: "some-md5"}
+				gomock.InOrder(
+					mockFS.EXPECT().Exists("some-ova-path").Return(true, nil),
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+					mockCache.EXPECT().Entry("some-ova-path").Return(entry, nil),
+					mockFS.EXPECT().Digests("some-ova-path").Return(&digest.Digests{MD5: "some-md5", SHA256: "some-sha256"}, nil),
+					mockCache.EXPECT().Save(&cache.Entry{Version: "some-version", File: "some-ova-path", MD5: "some-md5", SHA256: "some-sha256"}),
+				)
+
+				Expect(downloader.IsOVACurrent()).To(BeTrue())
+			})
+
+			Context("when the OVA has unexpected digests", func() {
+				It("should return false without recording them", func() {
+					gomock.InOrder(
+						mockFS.EXPECT().Exists("some-ova-path").Return(true, nil),
+						mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+						mockCache.EXPECT().Entry("some-ova-path").Return(&cache.Entry{File: "some-ova-path"}, nil),
+						mockFS.EXPECT().Digests("some-ova-path").Return(&digest.Digests{MD5: "some-md5", SHA256: "some-bad-sha256"}, nil),
+					)
+
+					Expect(downloader.IsOVACurrent()).To(BeFalse())
+				})
+			})
+
+			Context("when computing the digests fails", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockFS.EXPECT().Exists("some-ova-path").Return(true, nil),
+						mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+						mockCache.EXPECT().Entry("some-ova-path").Return(&cache.Entry{File: "some-ova-path"}, nil),
+						mockFS.EXPECT().Digests("some-ova-path").Return(nil, errors.New("some-error")),
+					)
+
+					_, err := downloader.IsOVACurrent()
+					Expect(err).To(MatchError("some-error"))
+				})
+			})
+		})
+
 		Context("when checking if the file exists fails", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
@@ -92,11 +157,24 @@ var _ = Describe("ConcreteOVADownloader", func() {
 			})
 		})
 
-		Context("when checking the MD5 fails", func() {
+		Context("when getting the expected digests fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists("some-ova-path").Return(true, nil),
+					mockExpectedDigests.EXPECT().Get().Return(nil, errors.New("some-error")),
+				)
+
+				_, err := downloader.IsOVACurrent()
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when reading the cache metadata fails", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
 					mockFS.EXPECT().Exists("some-ova-path").Return(true, nil),
-					mockFS.EXPECT().MD5("some-ova-path").Return("", errors.New("some-error")),
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+					mockCache.EXPECT().Entry("some-ova-path").Return(nil, errors.New("some-error")),
 				)
 
 				_, err := downloader.IsOVACurrent()
@@ -106,11 +184,8 @@ var _ = Describe("ConcreteOVADownloader", func() {
 	})
 
 	Describe("#Setup", func() {
-		It("should get ready for a download", func() {
-			gomock.InOrder(
-				mockFS.EXPECT().CreateDir("some-ova-dir"),
-				mockFS.EXPECT().DeleteAllExcept("some-ova-dir", []string{"some-vm.ova", "some-vm.ova.partial"}),
-			)
+		It("should create the ova dir and keep the other cached OVAs", func() {
+			mockFS.EXPECT().CreateDir("some-ova-dir")
 
 			Expect(downloader.Setup()).To(Succeed())
 		})
@@ -123,50 +198,79 @@ var _ = Describe("ConcreteOVADownloader", func() {
 			})
 		})
 
-		Context("when deleting unspecified files in the ova dir", func() {
-			It("should return an error", func() {
+		Context("when asked to prune the OVA cache", func() {
+			BeforeEach(func() {
+				downloader.Config.PruneOVACache = true
+			})
+
+			It("should delete everything except the ova, its metadata and the partial ova", func() {
 				gomock.InOrder(
 					mockFS.EXPECT().CreateDir("some-ova-dir"),
-					mockFS.EXPECT().DeleteAllExcept("some-ova-dir", []string{"some-vm.ova", "some-vm.ova.partial"}).Return(errors.New("some-error")),
+					mockFS.EXPECT().DeleteAllExcept("some-ova-dir", []string{"some-vm.ova", "some-vm.ova.json", "some-vm.ova.partial"}),
 				)
 
-				Expect(downloader.Setup()).To(MatchError("some-error"))
+				Expect(downloader.Setup()).To(Succeed())
+			})
+
+			Context("when deleting unspecified files in the ova dir fails", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockFS.EXPECT().CreateDir("some-ova-dir"),
+						mockFS.EXPECT().DeleteAllExcept("some-ova-dir", []string{"some-vm.ova", "some-vm.ova.json", "some-vm.ova.partial"}).Return(errors.New("some-error")),
+					)
+
+					Expect(downloader.Setup()).To(MatchError("some-error"))
+				})
 			})
 		})
 	})
 
 	Describe("#Download", func() {
+		var (
+			ovaDigests     *digest.Digests
+			resumedDigests *digest.Digests
+		)
+
+		BeforeEach(func() {
+			ovaDigests = &digest.Digests{
+				MD5:    "1c509ad51dcbd17359be1f8c45ef9368",
+				SHA256: "686f754c09668569ee47ce757ff41665b45a860668a41c253a96a1c94f992bfa",
+			}
+			resumedDigests = &digest.Digests{
+				MD5:    "7acf40c6f00a51e53e75d55f92a50900",
+				SHA256: "f0df856e1739af151d67b1e11a1816618806b47330e18fbf540309aa9f77f70f",
+			}
+		})
+
 		Context("when there is no partial ova present", func() {
 			It("should download the file from the beginning", func() {
-				readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents"))}
+				readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents")), Writer: ioutil.Discard, ContentLength: 17}
 				gomock.InOrder(
 					mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, nil),
 					mockClient.EXPECT().DownloadOVA(int64(0)).Return(readCloser, nil),
 					mockToken.EXPECT().Save(),
-					mockFS.EXPECT().Write("some-partial-ova-path", readCloser, true),
-					mockFS.EXPECT().MD5("some-partial-ova-path").Return("some-md5", nil),
+					mockFS.EXPECT().Write("some-partial-ova-path", gomock.Any(), true).Do(readContents),
 				)
 
-				md5, err := downloader.Download()
+				digests, err := downloader.Download()
 				Expect(err).NotTo(HaveOccurred())
-				Expect(md5).To(Equal("some-md5"))
+				Expect(digests).To(Equal(ovaDigests))
 			})
 
 			Context("when there is an issue seeing if the partial ova exists", func() {
 				It("should retry the check", func() {
-					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents"))}
+					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents")), Writer: ioutil.Discard, ContentLength: 17}
 					gomock.InOrder(
 						mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, errors.New("some-error")),
 						mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, nil),
 						mockClient.EXPECT().DownloadOVA(int64(0)).Return(readCloser, nil),
 						mockToken.EXPECT().Save(),
-						mockFS.EXPECT().Write("some-partial-ova-path", readCloser, true),
-						mockFS.EXPECT().MD5("some-partial-ova-path").Return("some-md5", nil),
+						mockFS.EXPECT().Write("some-partial-ova-path", gomock.Any(), true).Do(readContents),
 					)
 
-					md5, err := downloader.Download()
+					digests, err := downloader.Download()
 					Expect(err).NotTo(HaveOccurred())
-					Expect(md5).To(Equal("some-md5"))
+					Expect(digests).To(Equal(ovaDigests))
 				})
 			})
 
@@ -184,7 +288,7 @@ var _ = Describe("ConcreteOVADownloader", func() {
 
 			Context("when there is an issue downloading the OVA", func() {
 				It("should retry the download again", func() {
-					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents"))}
+					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents")), Writer: ioutil.Discard, ContentLength: 17}
 					gomock.InOrder(
 						mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, nil),
 						mockClient.EXPECT().DownloadOVA(int64(0)).Return(nil, errors.New("some-error")),
@@ -193,13 +297,12 @@ var _ = Describe("ConcreteOVADownloader", func() {
 						mockClient.EXPECT().DownloadOVA(int64(0)).Return(readCloser, nil),
 
 						mockToken.EXPECT().Save(),
-						mockFS.EXPECT().Write("some-partial-ova-path", readCloser, true),
-						mockFS.EXPECT().MD5("some-partial-ova-path").Return("some-md5", nil),
+						mockFS.EXPECT().Write("some-partial-ova-path", gomock.Any(), true).Do(readContents),
 					)
 
-					md5, err := downloader.Download()
+					digests, err := downloader.Download()
 					Expect(err).NotTo(HaveOccurred())
-					Expect(md5).To(Equal("some-md5"))
+					Expect(digests).To(Equal(ovaDigests))
 				})
 			})
 
@@ -220,7 +323,7 @@ var _ = Describe("ConcreteOVADownloader", func() {
 
 			Context("when there is an issue saving the token", func() {
 				It("should retry the save again", func() {
-					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents"))}
+					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents")), Writer: ioutil.Discard, ContentLength: 17}
 					gomock.InOrder(
 						mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, nil),
 						mockClient.EXPECT().DownloadOVA(int64(0)).Return(readCloser, nil),
@@ -230,19 +333,18 @@ var _ = Describe("ConcreteOVADownloader", func() {
 						mockClient.EXPECT().DownloadOVA(int64(0)).Return(readCloser, nil),
 						mockToken.EXPECT().Save(),
 
-						mockFS.EXPECT().Write("some-partial-ova-path", readCloser, true),
-						mockFS.EXPECT().MD5("some-partial-ova-path").Return("some-md5", nil),
+						mockFS.EXPECT().Write("some-partial-ova-path", gomock.Any(), true).Do(readContents),
 					)
 
-					md5, err := downloader.Download()
+					digests, err := downloader.Download()
 					Expect(err).NotTo(HaveOccurred())
-					Expect(md5).To(Equal("some-md5"))
+					Expect(digests).To(Equal(ovaDigests))
 				})
 			})
 
 			Context("when there is an issue saving the token twice", func() {
 				It("should return an error", func() {
-					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents"))}
+					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents")), Writer: ioutil.Discard, ContentLength: 17}
 					gomock.InOrder(
 						mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, nil),
 						mockClient.EXPECT().DownloadOVA(int64(0)).Return(readCloser, nil),
@@ -260,102 +362,85 @@ var _ = Describe("ConcreteOVADownloader", func() {
 
 			Context("when there is an issue writing the ova", func() {
 				It("should retry the write again", func() {
-					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents"))}
+					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents")), Writer: ioutil.Discard, ContentLength: 17}
 					gomock.InOrder(
 						mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, nil),
 						mockClient.EXPECT().DownloadOVA(int64(0)).Return(readCloser, nil),
 						mockToken.EXPECT().Save(),
-						mockFS.EXPECT().Write("some-partial-ova-path", readCloser, true).Return(errors.New("some-error")),
+						mockFS.EXPECT().Write("some-partial-ova-path", gomock.Any(), true).Return(errors.New("some-error")),
 
 						mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, nil),
 						mockClient.EXPECT().DownloadOVA(int64(0)).Return(readCloser, nil),
 						mockToken.EXPECT().Save(),
-						mockFS.EXPECT().Write("some-partial-ova-path", readCloser, true),
-						mockFS.EXPECT().MD5("some-partial-ova-path").Return("some-md5", nil),
+						mockFS.EXPECT().Write("some-partial-ova-path", gomock.Any(), true).Do(readContents),
 					)
 
-					md5, err := downloader.Download()
+					digests, err := downloader.Download()
 					Expect(err).NotTo(HaveOccurred())
-					Expect(md5).To(Equal("some-md5"))
+					Expect(digests).To(Equal(ovaDigests))
 				})
 			})
 
 			Context("when there is an issue writing the ova twice", func() {
 				It("should return an error", func() {
-					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents"))}
+					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents")), Writer: ioutil.Discard, ContentLength: 17}
 					gomock.InOrder(
 						mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, nil),
 						mockClient.EXPECT().DownloadOVA(int64(0)).Return(readCloser, nil),
 						mockToken.EXPECT().Save(),
-						mockFS.EXPECT().Write("some-partial-ova-path", readCloser, true).Return(errors.New("some-error")),
+						mockFS.EXPECT().Write("some-partial-ova-path", gomock.Any(), true).Return(errors.New("some-error")),
 
 						mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, nil),
 						mockClient.EXPECT().DownloadOVA(int64(0)).Return(readCloser, nil),
 						mockToken.EXPECT().Save(),
-						mockFS.EXPECT().Write("some-partial-ova-path", readCloser, true).Return(errors.New("some-error")),
+						mockFS.EXPECT().Write("some-partial-ova-path", gomock.Any(), true).Return(errors.New("some-error")),
 					)
 
 					_, err := downloader.Download()
 					Expect(err).To(MatchError("some-error"))
 				})
 			})
-
-			Context("when there is an issue checking the md5 of the partial ova", func() {
-				It("should return the error", func() {
-					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents"))}
-					gomock.InOrder(
-						mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, nil),
-						mockClient.EXPECT().DownloadOVA(int64(0)).Return(readCloser, nil),
-						mockToken.EXPECT().Save(),
-						mockFS.EXPECT().Write("some-partial-ova-path", readCloser, true),
-						mockFS.EXPECT().MD5("some-partial-ova-path").Return("", errors.New("some-error")),
-					)
-
-					md5, err := downloader.Download()
-					Expect(err).To(MatchError("some-error"))
-					Expect(md5).To(BeEmpty())
-				})
-			})
 		})
 
 		Context("when there is a partial ova present", func() {
 			It("should resume the download of the partial ova", func() {
-				readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents"))}
+				readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents")), Writer: ioutil.Discard, ContentLength: 17}
 				gomock.InOrder(
 					mockFS.EXPECT().Exists("some-partial-ova-path").Return(true, nil),
 					mockFS.EXPECT().Length("some-partial-ova-path").Return(int64(24), nil),
+					mockFS.EXPECT().Hash("some-partial-ova-path", gomock.Any()).Do(writePartialContents),
 					mockClient.EXPECT().DownloadOVA(int64(24)).Return(readCloser, nil),
 					mockToken.EXPECT().Save(),
-					mockFS.EXPECT().Write("some-partial-ova-path", readCloser, true),
-					mockFS.EXPECT().MD5("some-partial-ova-path").Return("some-md5", nil),
+					mockFS.EXPECT().Write("some-partial-ova-path", gomock.Any(), true).Do(readContents),
 				)
 
-				md5, err := downloader.Download()
+				digests, err := downloader.Download()
 				Expect(err).NotTo(HaveOccurred())
-				Expect(md5).To(Equal("some-md5"))
+				Expect(digests).To(Equal(resumedDigests))
 			})
 
 			Context("when something goes wrong saving the file", func() {
 				It("should retry the download from where it failed", func() {
-					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents"))}
+					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents")), Writer: ioutil.Discard, ContentLength: 17}
 					gomock.InOrder(
 						mockFS.EXPECT().Exists("some-partial-ova-path").Return(true, nil),
 						mockFS.EXPECT().Length("some-partial-ova-path").Return(int64(24), nil),
+						mockFS.EXPECT().Hash("some-partial-ova-path", gomock.Any()),
 						mockClient.EXPECT().DownloadOVA(int64(24)).Return(readCloser, nil),
 						mockToken.EXPECT().Save(),
-						mockFS.EXPECT().Write("some-partial-ova-path", readCloser, true).Return(errors.New("some-error")),
+						mockFS.EXPECT().Write("some-partial-ova-path", gomock.Any(), true).Return(errors.New("some-error")),
 
 						mockFS.EXPECT().Exists("some-partial-ova-path").Return(true, nil),
 						mockFS.EXPECT().Length("some-partial-ova-path").Return(int64(48), nil),
+						mockFS.EXPECT().Hash("some-partial-ova-path", gomock.Any()).Do(writePartialContents),
 						mockClient.EXPECT().DownloadOVA(int64(48)).Return(readCloser, nil),
 						mockToken.EXPECT().Save(),
-						mockFS.EXPECT().Write("some-partial-ova-path", readCloser, true),
-						mockFS.EXPECT().MD5("some-partial-ova-path").Return("some-md5", nil),
+						mockFS.EXPECT().Write("some-partial-ova-path", gomock.Any(), true).Do(readContents),
 					)
 
-					md5, err := downloader.Download()
+					digests, err := downloader.Download()
 					Expect(err).NotTo(HaveOccurred())
-					Expect(md5).To(Equal("some-md5"))
+					Expect(digests).To(Equal(resumedDigests))
 				})
 			})
 
@@ -373,6 +458,31 @@ var _ = Describe("ConcreteOVADownloader", func() {
 				})
 			})
 
+			Context("when there is an issue hashing the partial ova", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockFS.EXPECT().Exists("some-partial-ova-path").Return(true, nil),
+						mockFS.EXPECT().Length("some-partial-ova-path").Return(int64(24), nil),
+						mockFS.EXPECT().Hash("some-partial-ova-path", gomock.Any()).Return(errors.New("some-error")),
+						mockFS.EXPECT().Exists("some-partial-ova-path").Return(true, nil),
+						mockFS.EXPECT().Length("some-partial-ova-path").Return(int64(24), nil),
+						mockFS.EXPECT().Hash("some-partial-ova-path", gomock.Any()).Return(errors.New("some-error")),
+					)
+
+					_, err := downloader.Download()
+					Expect(err).To(MatchError("some-error"))
+				})
+			})
 		})
 	})
 })
+
+func readContents(_ string, contents io.Reader, _ bool) {
+	_, err := ioutil.ReadAll(contents)
+	Expect(err).NotTo(HaveOccurred())
+}
+
+func writePartialContents(_ string, hasher io.Writer) {
+	_, err := hasher.Write([]byte("some-partial-contents"))
+	Expect(err).NotTo(HaveOccurred())
+}
@@ -1,15 +1,20 @@
 package downloader
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/digest"
+	"github.com/pivotal-cf/pcfdev-cli/source"
 )
 
 //go:generate mockgen -package mocks -destination mocks/ova_downloader.go github.com/pivotal-cf/pcfdev-cli/downloader OVADownloader
 type OVADownloader interface {
 	Setup() error
-	Download() (md5 string, err error)
+	Download() (digests *digest.Digests, err error)
 	IsOVACurrent() (current bool, err error)
 }
 
@@ -23,35 +28,102 @@ type DownloaderFactory struct {
 	Config               *config.Config
 	PivnetClient         Client
 	Token                Token
+	ExpectedDigests      ExpectedDigests
+	Cache                Cache
 	DownloadAttempts     int
 	DownloadAttemptDelay time.Duration
 }
 
 func (f *DownloaderFactory) Create() (Downloader, error) {
+	client, token, err := f.source()
+	if err != nil {
+		return nil, err
+	}
+
 	exists, err := f.FS.Exists(f.Config.PartialOVAPath)
 	if err != nil {
 		return nil, err
 	}
 
-	ovaDownloader := &ConcreteOVADownloader{
-		FS:                   f.FS,
-		Config:               f.Config,
-		PivnetClient:         f.PivnetClient,
-		Token:                f.Token,
-		DownloadAttempts:     f.DownloadAttempts,
-		DownloadAttemptDelay: f.DownloadAttemptDelay,
+	var ovaDownloader OVADownloader
+	if f.Config.DownloadConcurrency > 1 {
+		if !exists {
+			exists, err = f.FS.Exists(SegmentStatePath(f.Config))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		ovaDownloader = &SegmentedOVADownloader{
+			FS:                   f.FS,
+			Config:               f.Config,
+			PivnetClient:         client,
+			Token:                token,
+			ExpectedDigests:      f.ExpectedDigests,
+			Cache:                f.Cache,
+			Concurrency:          f.Config.DownloadConcurrency,
+			DownloadAttempts:     f.DownloadAttempts,
+			DownloadAttemptDelay: f.DownloadAttemptDelay,
+			Writer:               os.Stdout,
+		}
+	} else {
+		ovaDownloader = &ConcreteOVADownloader{
+			FS:                   f.FS,
+			Config:               f.Config,
+			PivnetClient:         client,
+			Token:                token,
+			ExpectedDigests:      f.ExpectedDigests,
+			Cache:                f.Cache,
+			DownloadAttempts:     f.DownloadAttempts,
+			DownloadAttemptDelay: f.DownloadAttemptDelay,
+		}
 	}
+
 	if exists {
 		return &PartialDownloader{
-			Downloader: ovaDownloader,
-			FS:         f.FS,
-			Config:     f.Config,
+			Downloader:      ovaDownloader,
+			FS:              f.FS,
+			Config:          f.Config,
+			ExpectedDigests: f.ExpectedDigests,
+			Cache:           f.Cache,
 		}, nil
 	} else {
 		return &FullDownloader{
-			Downloader: ovaDownloader,
-			FS:         f.FS,
-			Config:     f.Config,
+			Downloader:      ovaDownloader,
+			FS:              f.FS,
+			Config:          f.Config,
+			ExpectedDigests: f.ExpectedDigests,
+			Cache:           f.Cache,
 		}, nil
 	}
 }
+
+func (f *DownloaderFactory) source() (Client, Token, error) {
+	ovaSource := f.Config.OVASource
+	fileName := f.Config.DefaultVMName + ".ova"
+
+	switch {
+	case f.Config.UsesPivNet():
+		return f.PivnetClient, f.Token, nil
+	case strings.HasPrefix(ovaSource, "http://") || strings.HasPrefix(ovaSource, "https://"):
+		return &source.HTTP{
+			URL:         ovaSource,
+			FileName:    fileName,
+			Username:    f.Config.OVASourceUsername,
+			Password:    f.Config.OVASourcePassword,
+			BearerToken: f.Config.OVASourceToken,
+		}, &noToken{}, nil
+	case strings.HasPrefix(ovaSource, "file://"):
+		return &source.Dir{Path: filepath.FromSlash(strings.TrimPrefix(ovaSource, "file://")), FileName: fileName}, &noToken{}, nil
+	case filepath.IsAbs(ovaSource):
+		return &source.Dir{Path: ovaSource, FileName: fileName}, &noToken{}, nil
+	default:
+		return nil, nil, &source.UnsupportedSourceError{Source: ovaSource}
+	}
+}
+
+type noToken struct{}
+
+func (*noToken) Save() error {
+	return nil
+}
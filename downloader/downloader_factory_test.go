@@ -2,11 +2,13 @@ package downloader_test
 
 import (
 	"errors"
+	"path/filepath"
 
 	"github.com/golang/mock/gomock"
 	cfg "github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/downloader"
 	"github.com/pivotal-cf/pcfdev-cli/downloader/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/source"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -18,18 +20,22 @@ var _ = Describe("DownloaderFactory", func() {
 		mockCtrl *gomock.Controller
 		mockFS   *mocks.MockFS
 		config   *cfg.Config
+
+		mockExpectedDigests *mocks.MockExpectedDigests
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockFS = mocks.NewMockFS(mockCtrl)
+		mockExpectedDigests = mocks.NewMockExpectedDigests(mockCtrl)
 		config = &cfg.Config{
 			PartialOVAPath: "some-partial-ova-path",
 		}
 
 		factory = &downloader.DownloaderFactory{
-			FS:     mockFS,
-			Config: config,
+			FS:              mockFS,
+			Config:          config,
+			ExpectedDigests: mockExpectedDigests,
 		}
 
 	})
@@ -50,6 +56,7 @@ var _ = Describe("DownloaderFactory", func() {
 				case *downloader.PartialDownloader:
 					Expect(d.FS).To(Equal(mockFS))
 					Expect(d.Config).To(Equal(config))
+					Expect(d.ExpectedDigests).To(Equal(mockExpectedDigests))
 					Expect(d.Downloader).NotTo(BeNil())
 				default:
 					Fail("wrong type")
@@ -68,6 +75,7 @@ var _ = Describe("DownloaderFactory", func() {
 				case *downloader.FullDownloader:
 					Expect(d.FS).To(Equal(mockFS))
 					Expect(d.Config).To(Equal(config))
+					Expect(d.ExpectedDigests).To(Equal(mockExpectedDigests))
 					Expect(d.Downloader).NotTo(BeNil())
 				default:
 					Fail("wrong type")
@@ -75,6 +83,100 @@ var _ = Describe("DownloaderFactory", func() {
 			})
 		})
 
+		Context("when downloads are segmented", func() {
+			BeforeEach(func() {
+				config.DownloadConcurrency = 4
+			})
+
+			It("should use a segmented ova downloader", func() {
+				mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, nil)
+				mockFS.EXPECT().Exists("some-partial-ova-path.segments").Return(false, nil)
+
+				fullDownloader, err := factory.Create()
+				Expect(err).NotTo(HaveOccurred())
+
+				d, ok := fullDownloader.(*downloader.FullDownloader)
+				Expect(ok).To(BeTrue())
+				segmentedDownloader, ok := d.Downloader.(*downloader.SegmentedOVADownloader)
+				Expect(ok).To(BeTrue())
+				Expect(segmentedDownloader.Concurrency).To(Equal(4))
+			})
+
+			Context("when only segment state is present", func() {
+				It("should return a partial ova downloader", func() {
+					mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, nil)
+					mockFS.EXPECT().Exists("some-partial-ova-path.segments").Return(true, nil)
+
+					partialDownloader, err := factory.Create()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(partialDownloader).To(BeAssignableToTypeOf(&downloader.PartialDownloader{}))
+				})
+			})
+		})
+
+		Context("when the OVA source is an http(s) URL", func() {
+			BeforeEach(func() {
+				config.DefaultVMName = "some-vm-name"
+				config.OVASource = "https://some-mirror/pcfdev/"
+				config.OVASourceUsername = "some-username"
+				config.OVASourcePassword = "some-password"
+			})
+
+			It("should download the OVA from that URL", func() {
+				mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, nil)
+
+				fullDownloader, err := factory.Create()
+				Expect(err).NotTo(HaveOccurred())
+
+				d, ok := fullDownloader.(*downloader.FullDownloader)
+				Expect(ok).To(BeTrue())
+				ovaDownloader, ok := d.Downloader.(*downloader.ConcreteOVADownloader)
+				Expect(ok).To(BeTrue())
+				Expect(ovaDownloader.PivnetClient).To(Equal(&source.HTTP{
+					URL:      "https://some-mirror/pcfdev/",
+					FileName: "some-vm-name.ova",
+					Username: "some-username",
+					Password: "some-password",
+				}))
+				Expect(ovaDownloader.Token.Save()).To(Succeed())
+			})
+		})
+
+		Context("when the OVA source is a directory", func() {
+			BeforeEach(func() {
+				config.DefaultVMName = "some-vm-name"
+				config.OVASource = "file:///some/mirror"
+			})
+
+			It("should copy the OVA from that directory", func() {
+				mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, nil)
+
+				fullDownloader, err := factory.Create()
+				Expect(err).NotTo(HaveOccurred())
+
+				d, ok := fullDownloader.(*downloader.FullDownloader)
+				Expect(ok).To(BeTrue())
+				ovaDownloader, ok := d.Downloader.(*downloader.ConcreteOVADownloader)
+				Expect(ok).To(BeTrue())
+				Expect(ovaDownloader.PivnetClient).To(Equal(&source.Dir{
+					Path:     filepath.FromSlash("/some/mirror"),
+					FileName: "some-vm-name.ova",
+				}))
+			})
+		})
+
+		Context("when the OVA source is not supported", func() {
+			BeforeEach(func() {
+				config.OVASource = "some-bad-source"
+			})
+
+			It("should return an error", func() {
+				partialDownloader, err := factory.Create()
+				Expect(err).To(MatchError("some-bad-source is not a supported OVA source, use pivnet, an http(s) URL or an absolute path to a directory"))
+				Expect(partialDownloader).To(BeNil())
+			})
+		})
+
 		Context("when there is an error seeing if there is a partial ova present", func() {
 			It("should return the error", func() {
 				mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, errors.New("some-error"))
@@ -7,9 +7,11 @@ import (
 )
 
 type FullDownloader struct {
-	Downloader OVADownloader
-	FS         FS
-	Config     *config.Config
+	Downloader      OVADownloader
+	FS              FS
+	Config          *config.Config
+	ExpectedDigests ExpectedDigests
+	Cache           Cache
 }
 
 func (f *FullDownloader) IsOVACurrent() (bool, error) {
@@ -17,18 +19,27 @@ func (f *FullDownloader) IsOVACurrent() (bool, error) {
 }
 
 func (f *FullDownloader) Download() error {
+	expectedDigests, err := f.ExpectedDigests.Get()
+	if err != nil {
+		return err
+	}
+
 	if err := f.Downloader.Setup(); err != nil {
 		return err
 	}
 
-	md5, err := f.Downloader.Download()
+	digests, err := f.Downloader.Download()
 	if err != nil {
 		return err
 	}
 
-	if md5 != f.Config.ExpectedMD5 {
+	if !expectedDigests.Matches(digests) {
 		return errors.New("download failed")
 	}
 
-	return f.FS.Move(f.Config.PartialOVAPath, f.Config.OVAPath)
+	if err := f.FS.Move(f.Config.PartialOVAPath, f.Config.OVAPath); err != nil {
+		return err
+	}
+
+	return f.Cache.Save(cacheEntry(f.Config, digests))
 }
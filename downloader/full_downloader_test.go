@@ -4,7 +4,9 @@ import (
 	"errors"
 
 	"github.com/golang/mock/gomock"
+	"github.com/pivotal-cf/pcfdev-cli/cache"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/digest"
 	dl "github.com/pivotal-cf/pcfdev-cli/downloader"
 	"github.com/pivotal-cf/pcfdev-cli/downloader/mocks"
 
@@ -18,12 +20,17 @@ var _ = Describe("FullDownloader", func() {
 		mockCtrl          *gomock.Controller
 		mockOVADownloader *mocks.MockOVADownloader
 		mockFS            *mocks.MockFS
+		mockCache         *mocks.MockCache
+
+		mockExpectedDigests *mocks.MockExpectedDigests
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockOVADownloader = mocks.NewMockOVADownloader(mockCtrl)
 		mockFS = mocks.NewMockFS(mockCtrl)
+		mockCache = mocks.NewMockCache(mockCtrl)
+		mockExpectedDigests = mocks.NewMockExpectedDigests(mockCtrl)
 		downloader = &dl.FullDownloader{
 			Downloader: mockOVADownloader,
 			FS:         mockFS,
@@ -31,8 +38,11 @@ var _ = Describe("FullDownloader", func() {
 				OVAPath:        "some-ova-path",
 				PartialOVAPath: "some-partial-ova-path",
 				DefaultVMName:  "some-vm",
-				ExpectedMD5:    "some-md5",
+				OVASource:      "pivnet",
+				Version:        &config.Version{OVABuildVersion: "some-ova-version"},
 			},
+			ExpectedDigests: mockExpectedDigests,
+			Cache:           mockCache,
 		}
 
 	})
@@ -69,27 +79,46 @@ var _ = Describe("FullDownloader", func() {
 	Describe("#Download", func() {
 		It("should download the file", func() {
 			gomock.InOrder(
+				mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 				mockOVADownloader.EXPECT().Setup(),
-				mockOVADownloader.EXPECT().Download().Return("some-md5", nil),
+				mockOVADownloader.EXPECT().Download().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 				mockFS.EXPECT().Move("some-partial-ova-path", "some-ova-path"),
+				mockCache.EXPECT().Save(&cache.Entry{
+					Version: "some-ova-version",
+					File:    "some-ova-path",
+					SHA256:  "some-sha256",
+					Source:  "pivnet",
+				}),
 			)
 
 			Expect(downloader.Download()).To(Succeed())
 		})
 
+		Context("when getting the expected digests fails", func() {
+			It("should return an error", func() {
+				mockExpectedDigests.EXPECT().Get().Return(nil, errors.New("some-error"))
+
+				Expect(downloader.Download()).To(MatchError("some-error"))
+			})
+		})
+
 		Context("when setup fails", func() {
 			It("should return an error", func() {
-				mockOVADownloader.EXPECT().Setup().Return(errors.New("some-error"))
+				gomock.InOrder(
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+					mockOVADownloader.EXPECT().Setup().Return(errors.New("some-error")),
+				)
 
 				Expect(downloader.Download()).To(MatchError("some-error"))
 			})
 		})
 
-		Context("when the downloaded MD5 does not match", func() {
+		Context("when the downloaded digests do not match", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 					mockOVADownloader.EXPECT().Setup(),
-					mockOVADownloader.EXPECT().Download().Return("some-other-md5", nil),
+					mockOVADownloader.EXPECT().Download().Return(&digest.Digests{SHA256: "some-other-sha256"}, nil),
 				)
 
 				Expect(downloader.Download()).To(MatchError("download failed"))
@@ -99,8 +128,9 @@ var _ = Describe("FullDownloader", func() {
 		Context("when downloading the ova fails", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 					mockOVADownloader.EXPECT().Setup(),
-					mockOVADownloader.EXPECT().Download().Return("", errors.New("some-error")),
+					mockOVADownloader.EXPECT().Download().Return(nil, errors.New("some-error")),
 				)
 
 				Expect(downloader.Download()).To(MatchError("some-error"))
@@ -110,13 +140,28 @@ var _ = Describe("FullDownloader", func() {
 		Context("when moving the partial file fails", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 					mockOVADownloader.EXPECT().Setup(),
-					mockOVADownloader.EXPECT().Download().Return("some-md5", nil),
+					mockOVADownloader.EXPECT().Download().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 					mockFS.EXPECT().Move("some-partial-ova-path", "some-ova-path").Return(errors.New("some-error")),
 				)
 
 				Expect(downloader.Download()).To(MatchError("some-error"))
 			})
 		})
+
+		Context("when saving the cache metadata fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+					mockOVADownloader.EXPECT().Setup(),
+					mockOVADownloader.EXPECT().Download().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+					mockFS.EXPECT().Move("some-partial-ova-path", "some-ova-path"),
+					mockCache.EXPECT().Save(gomock.Any()).Return(errors.New("some-error")),
+				)
+
+				Expect(downloader.Download()).To(MatchError("some-error"))
+			})
+		})
 	})
 })
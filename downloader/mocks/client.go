@@ -6,6 +6,7 @@ package mocks
 import (
 	gomock "github.com/golang/mock/gomock"
 	pivnet "github.com/pivotal-cf/pcfdev-cli/pivnet"
+	io "io"
 )
 
 // Mock of Client interface
@@ -39,3 +40,25 @@ func (_m *MockClient) DownloadOVA(_param0 int64) (*pivnet.DownloadReader, error)
 func (_mr *_MockClientRecorder) DownloadOVA(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DownloadOVA", arg0)
 }
+
+func (_m *MockClient) DownloadOVARange(_param0 int64, _param1 int64) (io.ReadCloser, error) {
+	ret := _m.ctrl.Call(_m, "DownloadOVARange", _param0, _param1)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockClientRecorder) DownloadOVARange(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DownloadOVARange", arg0, arg1)
+}
+
+func (_m *MockClient) OVASize() (int64, error) {
+	ret := _m.ctrl.Call(_m, "OVASize")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockClientRecorder) OVASize() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "OVASize")
+}
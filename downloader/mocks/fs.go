@@ -5,6 +5,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	digest "github.com/pivotal-cf/pcfdev-cli/digest"
 	io "io"
 )
 
@@ -49,6 +50,17 @@ func (_mr *_MockFSRecorder) DeleteAllExcept(arg0, arg1 interface{}) *gomock.Call
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DeleteAllExcept", arg0, arg1)
 }
 
+func (_m *MockFS) Digests(_param0 string) (*digest.Digests, error) {
+	ret := _m.ctrl.Call(_m, "Digests", _param0)
+	ret0, _ := ret[0].(*digest.Digests)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockFSRecorder) Digests(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Digests", arg0)
+}
+
 func (_m *MockFS) Exists(_param0 string) (bool, error) {
 	ret := _m.ctrl.Call(_m, "Exists", _param0)
 	ret0, _ := ret[0].(bool)
@@ -60,6 +72,16 @@ func (_mr *_MockFSRecorder) Exists(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Exists", arg0)
 }
 
+func (_m *MockFS) Hash(_param0 string, _param1 io.Writer) error {
+	ret := _m.ctrl.Call(_m, "Hash", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockFSRecorder) Hash(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Hash", arg0, arg1)
+}
+
 func (_m *MockFS) Length(_param0 string) (int64, error) {
 	ret := _m.ctrl.Call(_m, "Length", _param0)
 	ret0, _ := ret[0].(int64)
@@ -71,17 +93,6 @@ func (_mr *_MockFSRecorder) Length(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Length", arg0)
 }
 
-func (_m *MockFS) MD5(_param0 string) (string, error) {
-	ret := _m.ctrl.Call(_m, "MD5", _param0)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-func (_mr *_MockFSRecorder) MD5(arg0 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "MD5", arg0)
-}
-
 func (_m *MockFS) Move(_param0 string, _param1 string) error {
 	ret := _m.ctrl.Call(_m, "Move", _param0, _param1)
 	ret0, _ := ret[0].(error)
@@ -92,6 +103,28 @@ func (_mr *_MockFSRecorder) Move(arg0, arg1 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Move", arg0, arg1)
 }
 
+func (_m *MockFS) Open(_param0 string) (io.ReadCloser, error) {
+	ret := _m.ctrl.Call(_m, "Open", _param0)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockFSRecorder) Open(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Open", arg0)
+}
+
+func (_m *MockFS) Read(_param0 string) ([]byte, error) {
+	ret := _m.ctrl.Call(_m, "Read", _param0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockFSRecorder) Read(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Read", arg0)
+}
+
 func (_m *MockFS) Remove(_param0 string) error {
 	ret := _m.ctrl.Call(_m, "Remove", _param0)
 	ret0, _ := ret[0].(error)
@@ -102,6 +135,16 @@ func (_mr *_MockFSRecorder) Remove(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Remove", arg0)
 }
 
+func (_m *MockFS) Truncate(_param0 string, _param1 int64) error {
+	ret := _m.ctrl.Call(_m, "Truncate", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockFSRecorder) Truncate(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Truncate", arg0, arg1)
+}
+
 func (_m *MockFS) Write(_param0 string, _param1 io.Reader, _param2 bool) error {
 	ret := _m.ctrl.Call(_m, "Write", _param0, _param1, _param2)
 	ret0, _ := ret[0].(error)
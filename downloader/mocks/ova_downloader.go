@@ -5,6 +5,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	digest "github.com/pivotal-cf/pcfdev-cli/digest"
 )
 
 // Mock of OVADownloader interface
@@ -28,9 +29,9 @@ func (_m *MockOVADownloader) EXPECT() *_MockOVADownloaderRecorder {
 	return _m.recorder
 }
 
-func (_m *MockOVADownloader) Download() (string, error) {
+func (_m *MockOVADownloader) Download() (*digest.Digests, error) {
 	ret := _m.ctrl.Call(_m, "Download")
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(*digest.Digests)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
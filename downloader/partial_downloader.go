@@ -7,9 +7,11 @@ import (
 )
 
 type PartialDownloader struct {
-	Downloader OVADownloader
-	FS         FS
-	Config     *config.Config
+	Downloader      OVADownloader
+	FS              FS
+	Config          *config.Config
+	ExpectedDigests ExpectedDigests
+	Cache           Cache
 }
 
 func (p *PartialDownloader) IsOVACurrent() (bool, error) {
@@ -17,30 +19,39 @@ func (p *PartialDownloader) IsOVACurrent() (bool, error) {
 }
 
 func (p *PartialDownloader) Download() error {
+	expectedDigests, err := p.ExpectedDigests.Get()
+	if err != nil {
+		return err
+	}
+
 	if err := p.Downloader.Setup(); err != nil {
 		return err
 	}
 
-	md5, err := p.Downloader.Download()
+	digests, err := p.Downloader.Download()
 	if err != nil {
 		return err
 	}
 
-	if md5 != p.Config.ExpectedMD5 {
+	if !expectedDigests.Matches(digests) {
 		if err := p.FS.Remove(p.Config.PartialOVAPath); err != nil {
 			return err
 		}
 
-		md5, err = p.Downloader.Download()
+		digests, err = p.Downloader.Download()
 		if err != nil {
 			return err
 		}
 
-		if md5 != p.Config.ExpectedMD5 {
+		if !expectedDigests.Matches(digests) {
 			return errors.New("download failed")
 		}
 
 	}
 
-	return p.FS.Move(p.Config.PartialOVAPath, p.Config.OVAPath)
+	if err := p.FS.Move(p.Config.PartialOVAPath, p.Config.OVAPath); err != nil {
+		return err
+	}
+
+	return p.Cache.Save(cacheEntry(p.Config, digests))
 }
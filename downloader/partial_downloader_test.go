@@ -4,7 +4,9 @@ import (
 	"errors"
 
 	"github.com/golang/mock/gomock"
+	"github.com/pivotal-cf/pcfdev-cli/cache"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/digest"
 	dl "github.com/pivotal-cf/pcfdev-cli/downloader"
 	"github.com/pivotal-cf/pcfdev-cli/downloader/mocks"
 
@@ -18,11 +20,16 @@ var _ = Describe("PartialDownloader", func() {
 		mockCtrl          *gomock.Controller
 		mockOVADownloader *mocks.MockOVADownloader
 		mockFS            *mocks.MockFS
+		mockCache         *mocks.MockCache
+
+		mockExpectedDigests *mocks.MockExpectedDigests
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockFS = mocks.NewMockFS(mockCtrl)
+		mockCache = mocks.NewMockCache(mockCtrl)
+		mockExpectedDigests = mocks.NewMockExpectedDigests(mockCtrl)
 		mockOVADownloader = mocks.NewMockOVADownloader(mockCtrl)
 		downloader = &dl.PartialDownloader{
 			FS:         mockFS,
@@ -31,8 +38,11 @@ var _ = Describe("PartialDownloader", func() {
 				OVAPath:        "some-ova-path",
 				PartialOVAPath: "some-partial-ova-path",
 				DefaultVMName:  "some-vm",
-				ExpectedMD5:    "some-md5",
+				OVASource:      "pivnet",
+				Version:        &config.Version{OVABuildVersion: "some-ova-version"},
 			},
+			ExpectedDigests: mockExpectedDigests,
+			Cache:           mockCache,
 		}
 
 	})
@@ -69,17 +79,33 @@ var _ = Describe("PartialDownloader", func() {
 	Describe("#Download", func() {
 		It("should download the file", func() {
 			gomock.InOrder(
+				mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 				mockOVADownloader.EXPECT().Setup(),
-				mockOVADownloader.EXPECT().Download().Return("some-md5", nil),
+				mockOVADownloader.EXPECT().Download().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 				mockFS.EXPECT().Move("some-partial-ova-path", "some-ova-path"),
+				mockCache.EXPECT().Save(&cache.Entry{
+					Version: "some-ova-version",
+					File:    "some-ova-path",
+					SHA256:  "some-sha256",
+					Source:  "pivnet",
+				}),
 			)
 
 			Expect(downloader.Download()).To(Succeed())
 		})
 
+		Context("when getting the expected digests fails", func() {
+			It("should return the error", func() {
+				mockExpectedDigests.EXPECT().Get().Return(nil, errors.New("some-error"))
+
+				Expect(downloader.Download()).To(MatchError("some-error"))
+			})
+		})
+
 		Context("when the download setup fails", func() {
 			It("should return the error", func() {
 				gomock.InOrder(
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 					mockOVADownloader.EXPECT().Setup().Return(errors.New("some-error")),
 				)
 
@@ -90,22 +116,25 @@ var _ = Describe("PartialDownloader", func() {
 		Context("when the download fails", func() {
 			It("should return the error", func() {
 				gomock.InOrder(
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 					mockOVADownloader.EXPECT().Setup(),
-					mockOVADownloader.EXPECT().Download().Return("", errors.New("some-error")),
+					mockOVADownloader.EXPECT().Download().Return(nil, errors.New("some-error")),
 				)
 
 				Expect(downloader.Download()).To(MatchError("some-error"))
 			})
 		})
 
-		Context("when the downloaded MD5 does not match", func() {
+		Context("when the downloaded digests do not match", func() {
 			It("should delete the partially downloaded file and download again", func() {
 				gomock.InOrder(
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 					mockOVADownloader.EXPECT().Setup(),
-					mockOVADownloader.EXPECT().Download().Return("some-other-md5", nil),
+					mockOVADownloader.EXPECT().Download().Return(&digest.Digests{SHA256: "some-other-sha256"}, nil),
 					mockFS.EXPECT().Remove("some-partial-ova-path"),
-					mockOVADownloader.EXPECT().Download().Return("some-md5", nil),
+					mockOVADownloader.EXPECT().Download().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 					mockFS.EXPECT().Move("some-partial-ova-path", "some-ova-path"),
+					mockCache.EXPECT().Save(gomock.Any()),
 				)
 
 				Expect(downloader.Download()).To(Succeed())
@@ -115,8 +144,9 @@ var _ = Describe("PartialDownloader", func() {
 		Context("when removing the ova of a corrupted download fails", func() {
 			It("return the error", func() {
 				gomock.InOrder(
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 					mockOVADownloader.EXPECT().Setup(),
-					mockOVADownloader.EXPECT().Download().Return("some-other-md5", nil),
+					mockOVADownloader.EXPECT().Download().Return(&digest.Digests{SHA256: "some-other-sha256"}, nil),
 					mockFS.EXPECT().Remove("some-partial-ova-path").Return(errors.New("some-error")),
 				)
 
@@ -124,13 +154,14 @@ var _ = Describe("PartialDownloader", func() {
 			})
 		})
 
-		Context("when the downloaded MD5 does not match of the redownloaded OVA", func() {
+		Context("when the downloaded digests do not match of the redownloaded OVA", func() {
 			It("should delete the partially downloaded file and download again", func() {
 				gomock.InOrder(
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 					mockOVADownloader.EXPECT().Setup(),
-					mockOVADownloader.EXPECT().Download().Return("some-other-md5", nil),
+					mockOVADownloader.EXPECT().Download().Return(&digest.Digests{SHA256: "some-other-sha256"}, nil),
 					mockFS.EXPECT().Remove("some-partial-ova-path"),
-					mockOVADownloader.EXPECT().Download().Return("some-other-bad-md5", nil),
+					mockOVADownloader.EXPECT().Download().Return(&digest.Digests{SHA256: "some-other-bad-sha256"}, nil),
 				)
 
 				Expect(downloader.Download()).To(MatchError("download failed"))
@@ -140,10 +171,11 @@ var _ = Describe("PartialDownloader", func() {
 		Context("when there is an error download the ova a second time", func() {
 			It("should return the error", func() {
 				gomock.InOrder(
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 					mockOVADownloader.EXPECT().Setup(),
-					mockOVADownloader.EXPECT().Download().Return("some-other-md5", nil),
+					mockOVADownloader.EXPECT().Download().Return(&digest.Digests{SHA256: "some-other-sha256"}, nil),
 					mockFS.EXPECT().Remove("some-partial-ova-path"),
-					mockOVADownloader.EXPECT().Download().Return("", errors.New("some-error")),
+					mockOVADownloader.EXPECT().Download().Return(nil, errors.New("some-error")),
 				)
 
 				Expect(downloader.Download()).To(MatchError("some-error"))
@@ -153,13 +185,28 @@ var _ = Describe("PartialDownloader", func() {
 		Context("when there is an error moving the partial ova to the ova path", func() {
 			It("should return the error", func() {
 				gomock.InOrder(
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 					mockOVADownloader.EXPECT().Setup(),
-					mockOVADownloader.EXPECT().Download().Return("some-md5", nil),
+					mockOVADownloader.EXPECT().Download().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
 					mockFS.EXPECT().Move("some-partial-ova-path", "some-ova-path").Return(errors.New("some-error")),
 				)
 
 				Expect(downloader.Download()).To(MatchError("some-error"))
 			})
 		})
+
+		Context("when saving the cache metadata fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+					mockOVADownloader.EXPECT().Setup(),
+					mockOVADownloader.EXPECT().Download().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+					mockFS.EXPECT().Move("some-partial-ova-path", "some-ova-path"),
+					mockCache.EXPECT().Save(gomock.Any()).Return(errors.New("some-error")),
+				)
+
+				Expect(downloader.Download()).To(MatchError("some-error"))
+			})
+		})
 	})
 })
@@ -0,0 +1,392 @@
+package downloader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/digest"
+	"github.com/pivotal-cf/pcfdev-cli/helpers"
+)
+
+type SegmentedOVADownloader struct {
+	FS                   FS
+	PivnetClient         Client
+	Config               *config.Config
+	Token                Token
+	ExpectedDigests      ExpectedDigests
+	Cache                Cache
+	Concurrency          int
+	DownloadAttempts     int
+	DownloadAttemptDelay time.Duration
+	Writer               io.Writer
+}
+
+// maxSegments is the highest PCFDEV_DOWNLOAD_CONCURRENCY, and so the most
+// segment files a download can leave behind.
+const maxSegments = 16
+
+type segment struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+type segmentState struct {
+	Size     int64     `json:"size"`
+	Segments []segment `json:"segments"`
+}
+
+type IncompleteSegmentError struct {
+	Segment int
+}
+
+func (e *IncompleteSegmentError) Error() string {
+	return fmt.Sprintf("segment %d of the OVA download is incomplete", e.Segment)
+}
+
+func SegmentStatePath(conf *config.Config) string {
+	return conf.PartialOVAPath + ".segments"
+}
+
+func (d *SegmentedOVADownloader) IsOVACurrent() (bool, error) {
+	return isOVACurrent(d.FS, d.Config, d.ExpectedDigests, d.Cache)
+}
+
+func (d *SegmentedOVADownloader) Setup() error {
+	if err := d.FS.CreateDir(d.Config.OVADir); err != nil {
+		return err
+	}
+
+	if !d.Config.PruneOVACache {
+		return nil
+	}
+
+	filenames := []string{
+		d.Config.DefaultVMName + ".ova",
+		d.Config.DefaultVMName + ".ova.json",
+		filepath.Base(d.Config.PartialOVAPath),
+		filepath.Base(SegmentStatePath(d.Config)),
+	}
+	if state, err := d.loadState(); err == nil && state != nil {
+		for i := 1; i < len(state.Segments); i++ {
+			filenames = append(filenames, filepath.Base(d.segmentPath(i)))
+		}
+	}
+
+	return d.FS.DeleteAllExcept(d.Config.OVADir, filenames)
+}
+
+func (d *SegmentedOVADownloader) Download() (*digest.Digests, error) {
+	var size int64
+	err := helpers.ExecuteWithAttempts(func() (err error) {
+		size, err = d.PivnetClient.OVASize()
+		return err
+	}, d.DownloadAttempts, d.DownloadAttemptDelay)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.Token.Save(); err != nil {
+		return nil, err
+	}
+
+	state, err := d.plan(size)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, pending, err := d.prepare(state)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make(chan error, len(pending))
+	var wg sync.WaitGroup
+	for _, index := range pending {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			errs <- helpers.ExecuteWithAttempts(func() error {
+				return d.downloadSegment(index, state.Segments[index], progress)
+			}, d.DownloadAttempts, d.DownloadAttemptDelay)
+		}(index)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return d.reassemble(state)
+}
+
+func (d *SegmentedOVADownloader) plan(size int64) (*segmentState, error) {
+	state, err := d.loadState()
+	if err != nil {
+		return nil, err
+	}
+	if state != nil && state.Size == size && len(state.Segments) > 0 {
+		return state, nil
+	}
+	if state != nil {
+		if err := d.removeSegments(state); err != nil {
+			return nil, err
+		}
+		if err := d.FS.Remove(d.Config.PartialOVAPath); err != nil {
+			return nil, err
+		}
+	} else if err := d.removeSegments(&segmentState{Segments: make([]segment, maxSegments)}); err != nil {
+		return nil, err
+	}
+
+	existing, err := d.segmentLength(0)
+	if err != nil {
+		return nil, err
+	}
+	if existing > size {
+		if err := d.FS.Remove(d.Config.PartialOVAPath); err != nil {
+			return nil, err
+		}
+		existing = 0
+	}
+
+	state = &segmentState{Size: size, Segments: splitSegments(existing, size, d.Concurrency)}
+	return state, d.saveState(state)
+}
+
+func splitSegments(existing int64, size int64, concurrency int) []segment {
+	remaining := size - existing
+	count := int64(concurrency)
+	if count < 1 || remaining < count {
+		count = 1
+	}
+
+	chunk := remaining / count
+	segments := make([]segment, count)
+	for i := int64(0); i < count; i++ {
+		segments[i] = segment{Start: existing + i*chunk, End: existing + (i+1)*chunk - 1}
+	}
+	segments[0].Start = 0
+	segments[count-1].End = size - 1
+	return segments
+}
+
+func (d *SegmentedOVADownloader) prepare(state *segmentState) (*segmentProgress, []int, error) {
+	merged, err := d.segmentLength(0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var existing int64
+	var pending []int
+	for i, seg := range state.Segments {
+		if i > 0 && merged > seg.End {
+			existing += seg.End - seg.Start + 1
+			continue
+		}
+		if i > 0 && merged > seg.Start {
+			// Appending the segment was interrupted, its file is only removed
+			// once it is fully appended.
+			if err := d.FS.Truncate(d.Config.PartialOVAPath, seg.Start); err != nil {
+				return nil, nil, err
+			}
+			merged = seg.Start
+		}
+
+		length, err := d.segmentLength(i)
+		if err != nil {
+			return nil, nil, err
+		}
+		existing += int64(math.Min(float64(length), float64(seg.End-seg.Start+1)))
+		pending = append(pending, i)
+	}
+
+	return &segmentProgress{Writer: d.Writer, TotalLength: state.Size, ExistingLength: existing}, pending, nil
+}
+
+func (d *SegmentedOVADownloader) downloadSegment(index int, seg segment, progress *segmentProgress) error {
+	length, err := d.segmentLength(index)
+	if err != nil {
+		return err
+	}
+
+	startAtByte := seg.Start + length
+	if startAtByte <= seg.End {
+		ova, err := d.PivnetClient.DownloadOVARange(startAtByte, seg.End)
+		if err != nil {
+			return err
+		}
+		defer ova.Close()
+
+		if err := d.FS.Write(d.segmentPath(index), &progressReader{Reader: ova, Progress: progress}, true); err != nil {
+			return err
+		}
+
+		length, err = d.segmentLength(index)
+		if err != nil {
+			return err
+		}
+	}
+
+	if seg.Start+length < seg.End+1 {
+		return &IncompleteSegmentError{Segment: index}
+	}
+	return nil
+}
+
+func (d *SegmentedOVADownloader) reassemble(state *segmentState) (*digest.Digests, error) {
+	merged, err := d.segmentLength(0)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher := digest.NewHasher()
+	if err := d.FS.Hash(d.Config.PartialOVAPath, hasher); err != nil {
+		return nil, err
+	}
+
+	for i := 1; i < len(state.Segments); i++ {
+		if merged <= state.Segments[i].Start {
+			if err := d.appendSegment(i, hasher); err != nil {
+				return nil, err
+			}
+		}
+		if err := d.FS.Remove(d.segmentPath(i)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := d.FS.Remove(SegmentStatePath(d.Config)); err != nil {
+		return nil, err
+	}
+
+	return hasher.Digests(), nil
+}
+
+func (d *SegmentedOVADownloader) appendSegment(index int, hasher io.Writer) error {
+	file, err := d.FS.Open(d.segmentPath(index))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return d.FS.Write(d.Config.PartialOVAPath, io.TeeReader(file, hasher), true)
+}
+
+func (d *SegmentedOVADownloader) loadState() (*segmentState, error) {
+	exists, err := d.FS.Exists(SegmentStatePath(d.Config))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	data, err := d.FS.Read(SegmentStatePath(d.Config))
+	if err != nil {
+		return nil, err
+	}
+
+	state := &segmentState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, nil
+	}
+	return state, nil
+}
+
+func (d *SegmentedOVADownloader) saveState(state *segmentState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return d.FS.Write(SegmentStatePath(d.Config), bytes.NewReader(data), false)
+}
+
+func (d *SegmentedOVADownloader) removeSegments(state *segmentState) error {
+	for i := 1; i < len(state.Segments); i++ {
+		if err := d.FS.Remove(d.segmentPath(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *SegmentedOVADownloader) segmentLength(index int) (int64, error) {
+	exists, err := d.FS.Exists(d.segmentPath(index))
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, nil
+	}
+	return d.FS.Length(d.segmentPath(index))
+}
+
+func (d *SegmentedOVADownloader) segmentPath(index int) string {
+	if index == 0 {
+		return d.Config.PartialOVAPath
+	}
+	return fmt.Sprintf("%s.segment-%d", d.Config.PartialOVAPath, index)
+}
+
+type progressReader struct {
+	io.Reader
+	Progress *segmentProgress
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	length, err := r.Reader.Read(p)
+	r.Progress.Add(int64(length))
+	return length, err
+}
+
+type segmentProgress struct {
+	sync.Mutex
+	Writer           io.Writer
+	TotalLength      int64
+	ExistingLength   int64
+	downloadedLength int64
+	lastPercentage   int
+}
+
+func (p *segmentProgress) Add(length int64) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.downloadedLength += length
+	if p.TotalLength <= 0 {
+		return
+	}
+
+	totalLength := float64(p.TotalLength)
+	existingPercentage := float64(p.ExistingLength) / totalLength
+	downloadedPercentage := float64(p.downloadedLength) / totalLength
+
+	plusses := int(math.Ceil(20 * existingPercentage))
+	bars := int(math.Ceil(20 * downloadedPercentage))
+	bars = int(math.Min(float64(bars), float64(20-plusses)))
+	spaces := 20 - bars - plusses
+	percentage := int(math.Ceil((existingPercentage + downloadedPercentage) * 100))
+
+	if percentage == p.lastPercentage {
+		return
+	}
+	p.lastPercentage = percentage
+
+	fmt.Fprintf(p.Writer,
+		"\rProgress: |%s%s>%s| %d%% ",
+		strings.Repeat("+", plusses),
+		strings.Repeat("=", bars),
+		strings.Repeat(" ", spaces),
+		percentage)
+}
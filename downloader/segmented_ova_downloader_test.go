@@ -0,0 +1,268 @@
+package downloader_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/digest"
+	dl "github.com/pivotal-cf/pcfdev-cli/downloader"
+	"github.com/pivotal-cf/pcfdev-cli/downloader/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/fs"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SegmentedOVADownloader", func() {
+	var (
+		downloader  *dl.SegmentedOVADownloader
+		mockCtrl    *gomock.Controller
+		mockClient  *mocks.MockClient
+		mockToken   *mocks.MockToken
+		tmpDir      string
+		partialPath string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "pcfdev-segmented-downloader")
+		Expect(err).NotTo(HaveOccurred())
+		partialPath = filepath.Join(tmpDir, "some-vm.ova.partial")
+
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockClient = mocks.NewMockClient(mockCtrl)
+		mockToken = mocks.NewMockToken(mockCtrl)
+
+		downloader = &dl.SegmentedOVADownloader{
+			PivnetClient: mockClient,
+			FS:           &fs.FS{},
+			Config: &config.Config{
+				OVADir:         tmpDir,
+				OVAPath:        filepath.Join(tmpDir, "some-vm.ova"),
+				PartialOVAPath: partialPath,
+				DefaultVMName:  "some-vm",
+			},
+			Token:                mockToken,
+			Concurrency:          3,
+			DownloadAttempts:     2,
+			DownloadAttemptDelay: 0,
+			Writer:               ioutil.Discard,
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+		os.RemoveAll(tmpDir)
+	})
+
+	body := func(contents string) *readCloser {
+		return &readCloser{strings.NewReader(contents)}
+	}
+
+	Describe("Setup", func() {
+		BeforeEach(func() {
+			for _, name := range []string{"some-vm.ova", "some-vm.ova.json", "some-vm.ova.partial", "some-vm.ova.partial.segment-1", "some-vm.ova.partial.segment-2", "some-other-vm.ova"} {
+				Expect(ioutil.WriteFile(filepath.Join(tmpDir, name), []byte("some-contents"), 0644)).To(Succeed())
+			}
+			Expect(ioutil.WriteFile(partialPath+".segments", []byte(`{"size":12,"segments":[{"start":0,"end":3},{"start":4,"end":7},{"start":8,"end":11}]}`), 0644)).To(Succeed())
+		})
+
+		names := func() []string {
+			files, err := ioutil.ReadDir(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+			var names []string
+			for _, file := range files {
+				names = append(names, file.Name())
+			}
+			return names
+		}
+
+		It("should keep the other cached OVAs", func() {
+			Expect(downloader.Setup()).To(Succeed())
+
+			Expect(names()).To(ContainElement("some-other-vm.ova"))
+		})
+
+		Context("when asked to prune the OVA cache", func() {
+			It("should delete everything except the ova, its metadata, the partial ova and its segments", func() {
+				downloader.Config.PruneOVACache = true
+
+				Expect(downloader.Setup()).To(Succeed())
+
+				Expect(names()).To(ConsistOf("some-vm.ova", "some-vm.ova.json", "some-vm.ova.partial", "some-vm.ova.partial.segment-1", "some-vm.ova.partial.segment-2", "some-vm.ova.partial.segments"))
+			})
+		})
+	})
+
+	Describe("Download", func() {
+		It("should download the ova in segments and reassemble them", func() {
+			mockClient.EXPECT().OVASize().Return(int64(12), nil)
+			mockToken.EXPECT().Save()
+			mockClient.EXPECT().DownloadOVARange(int64(0), int64(3)).Return(body("ova "), nil)
+			mockClient.EXPECT().DownloadOVARange(int64(4), int64(7)).Return(body("cont"), nil)
+			mockClient.EXPECT().DownloadOVARange(int64(8), int64(11)).Return(body("ents"), nil)
+
+			digests, err := downloader.Download()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(digests).To(Equal(&digest.Digests{
+				MD5:    "662204731e40cab768c23855235587c0",
+				SHA256: "a900c692e5c903157dd155c9f8568d53225b68da9b1d3d6c8210c04e19dc298f",
+			}))
+
+			Expect(ioutil.ReadFile(partialPath)).To(Equal([]byte("ova contents")))
+			files, err := ioutil.ReadDir(tmpDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(HaveLen(1))
+		})
+
+		Context("when a partial ova from a sequential download exists", func() {
+			It("should keep the existing bytes and split the remainder", func() {
+				Expect(ioutil.WriteFile(partialPath, []byte("ova co"), 0644)).To(Succeed())
+
+				mockClient.EXPECT().OVASize().Return(int64(12), nil)
+				mockToken.EXPECT().Save()
+				mockClient.EXPECT().DownloadOVARange(int64(6), int64(7)).Return(body("nt"), nil)
+				mockClient.EXPECT().DownloadOVARange(int64(8), int64(9)).Return(body("en"), nil)
+				mockClient.EXPECT().DownloadOVARange(int64(10), int64(11)).Return(body("ts"), nil)
+
+				_, err := downloader.Download()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.ReadFile(partialPath)).To(Equal([]byte("ova contents")))
+			})
+		})
+
+		Context("when segments were partially downloaded", func() {
+			BeforeEach(func() {
+				Expect(ioutil.WriteFile(partialPath+".segments", []byte(`{"size":12,"segments":[{"start":0,"end":3},{"start":4,"end":7},{"start":8,"end":11}]}`), 0644)).To(Succeed())
+				Expect(ioutil.WriteFile(partialPath, []byte("ova "), 0644)).To(Succeed())
+				Expect(ioutil.WriteFile(partialPath+".segment-1", []byte("co"), 0644)).To(Succeed())
+			})
+
+			It("should resume each segment", func() {
+				mockClient.EXPECT().OVASize().Return(int64(12), nil)
+				mockToken.EXPECT().Save()
+				mockClient.EXPECT().DownloadOVARange(int64(6), int64(7)).Return(body("nt"), nil)
+				mockClient.EXPECT().DownloadOVARange(int64(8), int64(11)).Return(body("ents"), nil)
+
+				digests, err := downloader.Download()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(digests.SHA256).To(Equal("a900c692e5c903157dd155c9f8568d53225b68da9b1d3d6c8210c04e19dc298f"))
+				Expect(ioutil.ReadFile(partialPath)).To(Equal([]byte("ova contents")))
+			})
+
+			Context("when the size of the ova has changed", func() {
+				It("should discard the segments and start over", func() {
+					mockClient.EXPECT().OVASize().Return(int64(6), nil)
+					mockToken.EXPECT().Save()
+					mockClient.EXPECT().DownloadOVARange(int64(0), int64(1)).Return(body("ne"), nil)
+					mockClient.EXPECT().DownloadOVARange(int64(2), int64(3)).Return(body("w "), nil)
+					mockClient.EXPECT().DownloadOVARange(int64(4), int64(5)).Return(body("ov"), nil)
+
+					_, err := downloader.Download()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ioutil.ReadFile(partialPath)).To(Equal([]byte("new ov")))
+				})
+			})
+		})
+
+		Context("when reassembling the segments was interrupted", func() {
+			BeforeEach(func() {
+				Expect(ioutil.WriteFile(partialPath+".segments", []byte(`{"size":12,"segments":[{"start":0,"end":3},{"start":4,"end":7},{"start":8,"end":11}]}`), 0644)).To(Succeed())
+				Expect(ioutil.WriteFile(partialPath, []byte("ova conten"), 0644)).To(Succeed())
+				Expect(ioutil.WriteFile(partialPath+".segment-2", []byte("ents"), 0644)).To(Succeed())
+			})
+
+			It("should append the interrupted segment again", func() {
+				mockClient.EXPECT().OVASize().Return(int64(12), nil)
+				mockToken.EXPECT().Save()
+
+				digests, err := downloader.Download()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(digests.SHA256).To(Equal("a900c692e5c903157dd155c9f8568d53225b68da9b1d3d6c8210c04e19dc298f"))
+				Expect(ioutil.ReadFile(partialPath)).To(Equal([]byte("ova contents")))
+			})
+		})
+
+		Context("when the segment state is corrupt", func() {
+			It("should keep the partial ova and split the remainder again", func() {
+				Expect(ioutil.WriteFile(partialPath+".segments", []byte("some-bad-json"), 0644)).To(Succeed())
+				Expect(ioutil.WriteFile(partialPath, []byte("ova co"), 0644)).To(Succeed())
+				Expect(ioutil.WriteFile(partialPath+".segment-1", []byte("some-stale-segment"), 0644)).To(Succeed())
+
+				mockClient.EXPECT().OVASize().Return(int64(12), nil)
+				mockToken.EXPECT().Save()
+				mockClient.EXPECT().DownloadOVARange(int64(6), int64(7)).Return(body("nt"), nil)
+				mockClient.EXPECT().DownloadOVARange(int64(8), int64(9)).Return(body("en"), nil)
+				mockClient.EXPECT().DownloadOVARange(int64(10), int64(11)).Return(body("ts"), nil)
+
+				_, err := downloader.Download()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.ReadFile(partialPath)).To(Equal([]byte("ova contents")))
+			})
+		})
+
+		Context("when a segment is cut short", func() {
+			It("should retry the remainder of the segment", func() {
+				mockClient.EXPECT().OVASize().Return(int64(12), nil)
+				mockToken.EXPECT().Save()
+				mockClient.EXPECT().DownloadOVARange(int64(0), int64(3)).Return(body("ova "), nil)
+				gomock.InOrder(
+					mockClient.EXPECT().DownloadOVARange(int64(4), int64(7)).Return(body("co"), nil),
+					mockClient.EXPECT().DownloadOVARange(int64(6), int64(7)).Return(body("nt"), nil),
+				)
+				mockClient.EXPECT().DownloadOVARange(int64(8), int64(11)).Return(body("ents"), nil)
+
+				_, err := downloader.Download()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.ReadFile(partialPath)).To(Equal([]byte("ova contents")))
+			})
+		})
+
+		Context("when a segment fails on every attempt", func() {
+			It("should return the error and keep the segments for resuming", func() {
+				mockClient.EXPECT().OVASize().Return(int64(12), nil)
+				mockToken.EXPECT().Save()
+				mockClient.EXPECT().DownloadOVARange(int64(0), int64(3)).Return(body("ova "), nil)
+				mockClient.EXPECT().DownloadOVARange(int64(4), int64(7)).Return(nil, errors.New("some-error")).Times(2)
+				mockClient.EXPECT().DownloadOVARange(int64(8), int64(11)).Return(body("ents"), nil)
+
+				_, err := downloader.Download()
+				Expect(err).To(MatchError("some-error"))
+				Expect(ioutil.ReadFile(partialPath + ".segment-2")).To(Equal([]byte("ents")))
+				Expect(partialPath + ".segments").To(BeAnExistingFile())
+			})
+		})
+
+		Context("when getting the size of the ova fails", func() {
+			It("should return the error", func() {
+				mockClient.EXPECT().OVASize().Return(int64(0), errors.New("some-error")).Times(2)
+
+				_, err := downloader.Download()
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when saving the token fails", func() {
+			It("should return the error", func() {
+				mockClient.EXPECT().OVASize().Return(int64(12), nil)
+				mockToken.EXPECT().Save().Return(errors.New("some-error"))
+
+				_, err := downloader.Download()
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+	})
+})
+
+type readCloser struct {
+	*strings.Reader
+}
+
+func (r *readCloser) Close() error {
+	return nil
+}
@@ -8,3 +8,7 @@ func (*Exit) Exit() {
 	someStatusCodeThatCfCliNeverReads := 1
 	os.Exit(someStatusCodeThatCfCliNeverReads)
 }
+
+func (*Exit) ExitWithStatus(status int) {
+	os.Exit(status)
+}
@@ -3,13 +3,14 @@ package fs
 import (
 	"archive/tar"
 	"compress/gzip"
-	cMD5 "crypto/md5"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
+
+	"github.com/pivotal-cf/pcfdev-cli/digest"
 )
 
 type FS struct{}
@@ -28,6 +29,14 @@ func (fs *FS) Read(path string) (contents []byte, err error) {
 	return ioutil.ReadFile(path)
 }
 
+func (fs *FS) Open(path string) (io.ReadCloser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %s", path, err)
+	}
+	return file, nil
+}
+
 func (fs *FS) Write(path string, contents io.Reader, append bool) error {
 	var flag int
 	if append {
@@ -73,6 +82,19 @@ func (fs *FS) DeleteAllExcept(path string, filenames []string) error {
 	return nil
 }
 
+func (fs *FS) List(path string) ([]string, error) {
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %s", err)
+	}
+
+	filenames := []string{}
+	for _, file := range files {
+		filenames = append(filenames, file.Name())
+	}
+	return filenames, nil
+}
+
 func (fs *FS) Remove(path string) error {
 	if err := os.RemoveAll(path); err != nil {
 		return fmt.Errorf("failed to remove file %s: %s", path, err)
@@ -81,20 +103,25 @@ func (fs *FS) Remove(path string) error {
 	return nil
 }
 
-func (fs *FS) MD5(path string) (md5 string, err error) {
+func (fs *FS) Digests(path string) (*digest.Digests, error) {
+	hasher := digest.NewHasher()
+	if err := fs.Hash(path, hasher); err != nil {
+		return nil, err
+	}
+	return hasher.Digests(), nil
+}
+
+func (fs *FS) Hash(path string, hasher io.Writer) error {
 	file, err := os.Open(path)
 	if err != nil {
-		return "", fmt.Errorf("failed to open %s: %s", path, err)
+		return fmt.Errorf("failed to open %s: %s", path, err)
 	}
 	defer file.Close()
 
-	hash := cMD5.New()
-
-	if _, err = io.Copy(hash, file); err != nil {
-		return "", fmt.Errorf("failed to read %s: %s", path, err)
+	if _, err = io.Copy(hasher, file); err != nil {
+		return fmt.Errorf("failed to read %s: %s", path, err)
 	}
-
-	return fmt.Sprintf("%x", hash.Sum([]byte{})), nil
+	return nil
 }
 
 func (fs *FS) Length(path string) (int64, error) {
@@ -112,6 +139,14 @@ func (fs *FS) Length(path string) (int64, error) {
 	return fileInfo.Size(), nil
 }
 
+func (fs *FS) Truncate(path string, length int64) error {
+	if err := os.Truncate(path, length); err != nil {
+		return fmt.Errorf("failed to truncate %s: %s", path, err)
+	}
+
+	return nil
+}
+
 func (fs *FS) Move(source string, destination string) error {
 	if err := os.Rename(source, destination); err != nil {
 		return fmt.Errorf("failed to move %s to %s: %s", source, destination, err)
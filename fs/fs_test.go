@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/pivotal-cf/pcfdev-cli/digest"
 	pcfdevfs "github.com/pivotal-cf/pcfdev-cli/fs"
 
 	"runtime"
@@ -43,6 +44,24 @@ var _ = Describe("Filesystem", func() {
 		})
 	})
 
+	Describe("#Open", func() {
+		It("should return a reader for the file", func() {
+			Expect(ioutil.WriteFile(filepath.Join(tmpDir, "some-file"), []byte("some-contents"), 0644)).To(Succeed())
+
+			file, err := fs.Open(filepath.Join(tmpDir, "some-file"))
+			Expect(err).NotTo(HaveOccurred())
+			defer file.Close()
+			Expect(ioutil.ReadAll(file)).To(Equal([]byte("some-contents")))
+		})
+
+		Context("when the file does not exist", func() {
+			It("should return an error", func() {
+				_, err := fs.Open(filepath.Join(tmpDir, "some-bad-file"))
+				Expect(err).To(MatchError(ContainSubstring("failed to open")))
+			})
+		})
+	})
+
 	Describe("#Exists", func() {
 		Context("when the file exists", func() {
 			BeforeEach(func() {
@@ -157,26 +176,55 @@ var _ = Describe("Filesystem", func() {
 		})
 	})
 
-	Describe("#MD5", func() {
+	Describe("#List", func() {
+		It("should return the names of the files in the directory", func() {
+			Expect(ioutil.WriteFile(filepath.Join(tmpDir, "some-file-name"), []byte("some-contents"), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(tmpDir, "some-other-file-name"), []byte("some-contents"), 0644)).To(Succeed())
+
+			Expect(fs.List(tmpDir)).To(Equal([]string{"some-file-name", "some-other-file-name"}))
+		})
+
+		Context("when the directory does not exist", func() {
+			It("should return an error", func() {
+				_, err := fs.List("some-bad-path")
+				Expect(err).To(MatchError(ContainSubstring("failed to list files:")))
+			})
+		})
+	})
+
+	Describe("#Digests", func() {
 		Context("when the file exists", func() {
 			BeforeEach(func() {
 				Expect(ioutil.WriteFile(filepath.Join(tmpDir, "some-file"), []byte("some-contents"), 0644)).To(Succeed())
 			})
 
-			It("should return the md5 of the given file", func() {
-				Expect(fs.MD5(filepath.Join(tmpDir, "some-file"))).To(Equal("0b9791ad102b5f5f06ef68cef2aae26e"))
+			It("should return the md5 and sha256 of the given file", func() {
+				Expect(fs.Digests(filepath.Join(tmpDir, "some-file"))).To(Equal(&digest.Digests{
+					MD5:    "0b9791ad102b5f5f06ef68cef2aae26e",
+					SHA256: "6e32ea34db1b3755d7dec972eb72c705338f0dd8e0be881d966963438fb2e800",
+				}))
 			})
 		})
 
 		Context("when the file does not exist", func() {
 			It("should return an error", func() {
-				md5, err := fs.MD5(filepath.Join(tmpDir, "some-non-existent-file"))
+				digests, err := fs.Digests(filepath.Join(tmpDir, "some-non-existent-file"))
 				Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("failed to open %s:", filepath.Join(tmpDir, "some-non-existent-file")))))
-				Expect(md5).To(Equal(""))
+				Expect(digests).To(BeNil())
 			})
 		})
 	})
 
+	Describe("#Hash", func() {
+		It("should write the contents of the file to the hasher", func() {
+			Expect(ioutil.WriteFile(filepath.Join(tmpDir, "some-file"), []byte("some-contents"), 0644)).To(Succeed())
+
+			hasher := digest.NewHasher()
+			Expect(fs.Hash(filepath.Join(tmpDir, "some-file"), hasher)).To(Succeed())
+			Expect(hasher.Digests().MD5).To(Equal("0b9791ad102b5f5f06ef68cef2aae26e"))
+		})
+	})
+
 	Describe("#Length", func() {
 		Context("when the file exists", func() {
 			BeforeEach(func() {
@@ -197,6 +245,26 @@ var _ = Describe("Filesystem", func() {
 		})
 	})
 
+	Describe("#Truncate", func() {
+		Context("when the file exists", func() {
+			BeforeEach(func() {
+				Expect(ioutil.WriteFile(filepath.Join(tmpDir, "some-file"), []byte("some-contents"), 0644)).To(Succeed())
+			})
+
+			It("should cut the file to the given length", func() {
+				Expect(fs.Truncate(filepath.Join(tmpDir, "some-file"), 4)).To(Succeed())
+				Expect(ioutil.ReadFile(filepath.Join(tmpDir, "some-file"))).To(Equal([]byte("some")))
+			})
+		})
+
+		Context("when the file does not exist", func() {
+			It("should return an error", func() {
+				err := fs.Truncate(filepath.Join(tmpDir, "some-non-existent-file"), 4)
+				Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("failed to truncate %s:", filepath.Join(tmpDir, "some-non-existent-file")))))
+			})
+		})
+	})
+
 	Describe("#Remove", func() {
 		BeforeEach(func() {
 			Expect(ioutil.WriteFile(filepath.Join(tmpDir, "some-file"), []byte("some-contents"), 0644)).To(Succeed())
@@ -0,0 +1,100 @@
+package hosts
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+//go:generate mockgen -package mocks -destination mocks/fs.go github.com/pivotal-cf/pcfdev-cli/hosts FS
+type FS interface {
+	Read(path string) (contents []byte, err error)
+	Write(path string, contents io.Reader, append bool) error
+}
+
+//go:generate mockgen -package mocks -destination mocks/cmd_runner.go github.com/pivotal-cf/pcfdev-cli/hosts CmdRunner
+type CmdRunner interface {
+	RunWithInput(input io.Reader, command string, args ...string) (output []byte, err error)
+}
+
+// Hosts manages a block of entries for a PCF Dev instance in the hosts file.
+type Hosts struct {
+	FS        FS
+	CmdRunner CmdRunner
+}
+
+func (h *Hosts) Sync(instance string, ip string, hostnames []string) error {
+	entries := []string{}
+	for _, hostname := range hostnames {
+		entries = append(entries, fmt.Sprintf("%s %s", ip, hostname))
+	}
+	return h.update(instance, entries)
+}
+
+func (h *Hosts) Clean(instance string) error {
+	return h.update(instance, nil)
+}
+
+func (h *Hosts) update(instance string, entries []string) error {
+	contents, err := h.FS.Read(hostsPath)
+	if err != nil {
+		return err
+	}
+
+	updated, err := Update(string(contents), instance, entries)
+	if err != nil {
+		return err
+	}
+	if updated == string(contents) {
+		return nil
+	}
+	return h.write(updated)
+}
+
+// Update replaces the block of instance in the contents of a hosts file with
+// entries, and removes the block when there are no entries. A block without
+// an end marker is an error, since the rest of the file would be dropped.
+func Update(contents string, instance string, entries []string) (string, error) {
+	begin, end := "# BEGIN PCF Dev", "# END PCF Dev"
+	if instance != "" {
+		begin += " " + instance
+		end += " " + instance
+	}
+
+	lines := []string{}
+	blockIndex := -1
+	inBlock := false
+	for _, line := range strings.Split(strings.TrimSuffix(contents, "\n"), "\n") {
+		switch strings.TrimSpace(line) {
+		case begin:
+			inBlock = true
+			blockIndex = len(lines)
+			continue
+		case end:
+			inBlock = false
+			continue
+		}
+		if !inBlock {
+			lines = append(lines, line)
+		}
+	}
+	if inBlock {
+		return contents, fmt.Errorf("the hosts file has a '%s' line without a matching '%s' line, fix it by hand", begin, end)
+	}
+	if len(lines) == 1 && lines[0] == "" {
+		lines = []string{}
+	}
+
+	if len(entries) > 0 {
+		block := append(append([]string{begin}, entries...), end)
+		if blockIndex < 0 {
+			blockIndex = len(lines)
+		}
+		lines = append(lines[:blockIndex], append(block, lines[blockIndex:]...)...)
+	}
+
+	if len(lines) == 0 {
+		return "", nil
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
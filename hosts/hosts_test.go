@@ -0,0 +1,74 @@
+package hosts_test
+
+import (
+	"github.com/pivotal-cf/pcfdev-cli/hosts"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hosts", func() {
+	Describe(".Update", func() {
+		It("should append a block with the entries", func() {
+			Expect(hosts.Update("127.0.0.1 localhost\n", "", []string{"192.168.11.11 api.local.pcfdev.io", "192.168.11.11 uaa.local.pcfdev.io"})).To(Equal(
+				"127.0.0.1 localhost\n" +
+					"# BEGIN PCF Dev\n" +
+					"192.168.11.11 api.local.pcfdev.io\n" +
+					"192.168.11.11 uaa.local.pcfdev.io\n" +
+					"# END PCF Dev\n"))
+		})
+
+		It("should replace the block of the instance in place", func() {
+			contents := "127.0.0.1 localhost\n" +
+				"# BEGIN PCF Dev some-instance\n" +
+				"192.168.22.11 api.local2.pcfdev.io\n" +
+				"# END PCF Dev some-instance\n" +
+				"# BEGIN PCF Dev\n" +
+				"192.168.11.11 api.local.pcfdev.io\n" +
+				"# END PCF Dev\n" +
+				"10.0.0.5 some-host\n"
+
+			Expect(hosts.Update(contents, "some-instance", []string{"192.168.22.11 some-app.local2.pcfdev.io"})).To(Equal(
+				"127.0.0.1 localhost\n" +
+					"# BEGIN PCF Dev some-instance\n" +
+					"192.168.22.11 some-app.local2.pcfdev.io\n" +
+					"# END PCF Dev some-instance\n" +
+					"# BEGIN PCF Dev\n" +
+					"192.168.11.11 api.local.pcfdev.io\n" +
+					"# END PCF Dev\n" +
+					"10.0.0.5 some-host\n"))
+		})
+
+		Context("when there are no entries", func() {
+			It("should remove the block", func() {
+				contents := "127.0.0.1 localhost\n" +
+					"# BEGIN PCF Dev\n" +
+					"192.168.11.11 api.local.pcfdev.io\n" +
+					"# END PCF Dev\n"
+
+				Expect(hosts.Update(contents, "", nil)).To(Equal("127.0.0.1 localhost\n"))
+			})
+
+			It("should leave a file without a block untouched", func() {
+				Expect(hosts.Update("127.0.0.1 localhost\n", "", nil)).To(Equal("127.0.0.1 localhost\n"))
+			})
+		})
+
+		Context("when the block of the instance has no end marker", func() {
+			It("should return the contents unchanged and an error", func() {
+				contents := "127.0.0.1 localhost\n" +
+					"# BEGIN PCF Dev\n" +
+					"192.168.11.11 api.local.pcfdev.io\n" +
+					"10.0.0.5 some-host\n"
+
+				updated, err := hosts.Update(contents, "", []string{"192.168.11.11 uaa.local.pcfdev.io"})
+				Expect(err).To(MatchError("the hosts file has a '# BEGIN PCF Dev' line without a matching '# END PCF Dev' line, fix it by hand"))
+				Expect(updated).To(Equal(contents))
+
+				updated, err = hosts.Update(contents, "", nil)
+				Expect(err).To(HaveOccurred())
+				Expect(updated).To(Equal(contents))
+			})
+		})
+	})
+})
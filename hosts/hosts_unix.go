@@ -0,0 +1,19 @@
+// +build !windows
+
+package hosts
+
+import (
+	"fmt"
+	"strings"
+)
+
+const hostsPath = "/etc/hosts"
+
+// replaceHostsScript writes the hosts file next to it and renames it into
+// place, so that the hosts file is never left half written.
+var replaceHostsScript = fmt.Sprintf("umask 022 && cat > %[1]s.pcfdev && mv %[1]s.pcfdev %[1]s", hostsPath)
+
+func (h *Hosts) write(contents string) error {
+	_, err := h.CmdRunner.RunWithInput(strings.NewReader(contents), "sudo", "sh", "-c", replaceHostsScript)
+	return err
+}
@@ -0,0 +1,95 @@
+// +build !windows
+
+package hosts_test
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pivotal-cf/pcfdev-cli/hosts"
+	"github.com/pivotal-cf/pcfdev-cli/hosts/mocks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Hosts", func() {
+	var (
+		mockCtrl      *gomock.Controller
+		mockFS        *mocks.MockFS
+		mockCmdRunner *mocks.MockCmdRunner
+		hostsFile     *hosts.Hosts
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockFS = mocks.NewMockFS(mockCtrl)
+		mockCmdRunner = mocks.NewMockCmdRunner(mockCtrl)
+		hostsFile = &hosts.Hosts{
+			FS:        mockFS,
+			CmdRunner: mockCmdRunner,
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("#Sync", func() {
+		It("should map the hostnames to the IP in /etc/hosts by replacing it", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Read("/etc/hosts").Return([]byte("127.0.0.1 localhost\n"), nil),
+				mockCmdRunner.EXPECT().RunWithInput(strings.NewReader("127.0.0.1 localhost\n# BEGIN PCF Dev\n192.168.11.11 api.local.pcfdev.io\n# END PCF Dev\n"), "sudo", "sh", "-c", "umask 022 && cat > /etc/hosts.pcfdev && mv /etc/hosts.pcfdev /etc/hosts"),
+			)
+
+			Expect(hostsFile.Sync("", "192.168.11.11", []string{"api.local.pcfdev.io"})).To(Succeed())
+		})
+
+		Context("when the entries are already in /etc/hosts", func() {
+			It("should not write /etc/hosts", func() {
+				mockFS.EXPECT().Read("/etc/hosts").Return([]byte("# BEGIN PCF Dev\n192.168.11.11 api.local.pcfdev.io\n# END PCF Dev\n"), nil)
+
+				Expect(hostsFile.Sync("", "192.168.11.11", []string{"api.local.pcfdev.io"})).To(Succeed())
+			})
+		})
+
+		Context("when the block in /etc/hosts has no end marker", func() {
+			It("should not write /etc/hosts and return an error", func() {
+				mockFS.EXPECT().Read("/etc/hosts").Return([]byte("# BEGIN PCF Dev\n192.168.11.11 api.local.pcfdev.io\n10.0.0.5 some-host\n"), nil)
+
+				Expect(hostsFile.Sync("", "192.168.11.11", []string{"api.local.pcfdev.io"})).To(MatchError("the hosts file has a '# BEGIN PCF Dev' line without a matching '# END PCF Dev' line, fix it by hand"))
+			})
+		})
+
+		Context("when writing /etc/hosts fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("/etc/hosts").Return([]byte{}, nil),
+					mockCmdRunner.EXPECT().RunWithInput(gomock.Any(), "sudo", "sh", "-c", "umask 022 && cat > /etc/hosts.pcfdev && mv /etc/hosts.pcfdev /etc/hosts").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(hostsFile.Sync("", "192.168.11.11", []string{"api.local.pcfdev.io"})).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#Clean", func() {
+		It("should remove the block of the instance from /etc/hosts", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Read("/etc/hosts").Return([]byte("127.0.0.1 localhost\n# BEGIN PCF Dev some-instance\n192.168.22.11 api.local2.pcfdev.io\n# END PCF Dev some-instance\n"), nil),
+				mockCmdRunner.EXPECT().RunWithInput(strings.NewReader("127.0.0.1 localhost\n"), "sudo", "sh", "-c", "umask 022 && cat > /etc/hosts.pcfdev && mv /etc/hosts.pcfdev /etc/hosts"),
+			)
+
+			Expect(hostsFile.Clean("some-instance")).To(Succeed())
+		})
+
+		Context("when reading /etc/hosts fails", func() {
+			It("should return the error", func() {
+				mockFS.EXPECT().Read("/etc/hosts").Return(nil, errors.New("some-error"))
+
+				Expect(hostsFile.Clean("")).To(MatchError("some-error"))
+			})
+		})
+	})
+})
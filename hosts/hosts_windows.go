@@ -0,0 +1,13 @@
+package hosts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var hostsPath = filepath.Join(os.Getenv("SystemRoot"), "System32", "drivers", "etc", "hosts")
+
+func (h *Hosts) write(contents string) error {
+	return h.FS.Write(hostsPath, strings.NewReader(contents), false)
+}
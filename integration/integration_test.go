@@ -118,7 +118,7 @@ var _ = Describe("PCF Dev", func() {
 			session, err := gexec.Start(pcfdevCommand, GinkgoWriter, GinkgoWriter)
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(session, "10m").Should(gexec.Exit(0))
-			Expect(session).To(gbytes.Say("Services started"))
+			Expect(session).To(gbytes.Say("Starting services done"))
 			Expect(filepath.Join(tempHome, "pcfdev", "vms", VmName, VmName+"-disk1.vmdk")).To(BeAnExistingFile())
 		})
 
@@ -162,7 +162,7 @@ var _ = Describe("PCF Dev", func() {
 		session, err := gexec.Start(pcfdevCommand, GinkgoWriter, GinkgoWriter)
 		Expect(err).NotTo(HaveOccurred())
 		Eventually(session, "10m").Should(gexec.Exit(0))
-		Expect(session).To(gbytes.Say("Services started"))
+		Expect(session).To(gbytes.Say("Starting services done"))
 
 		sshCommand := exec.Command("cf", "dev", "ssh")
 		sshPty, err := pty.Start(sshCommand)
@@ -187,8 +187,8 @@ var _ = Describe("PCF Dev", func() {
 		session, err := gexec.Start(pcfdevCommand, GinkgoWriter, GinkgoWriter)
 		Expect(err).NotTo(HaveOccurred())
 		Eventually(session, "10m").Should(gexec.Exit(0))
-		Expect(session).To(gbytes.Say("Waiting for services to start..."))
-		Expect(session).To(gbytes.Say("Services started"))
+		Expect(session).To(gbytes.Say("Starting services..."))
+		Expect(session).To(gbytes.Say("Starting services done"))
 		pcfdevCommand = exec.Command("cf", "dev", "status")
 		session, err = gexec.Start(pcfdevCommand, GinkgoWriter, GinkgoWriter)
 		Expect(err).NotTo(HaveOccurred())
@@ -258,8 +258,8 @@ var _ = Describe("PCF Dev", func() {
 		session, err = gexec.Start(pcfdevCommand, GinkgoWriter, GinkgoWriter)
 		Expect(err).NotTo(HaveOccurred())
 		Eventually(session, "5m").Should(gexec.Exit(0))
-		Expect(session).To(gbytes.Say("Waiting for services to start..."))
-		Expect(session).To(gbytes.Say("Services started"))
+		Expect(session).To(gbytes.Say("Starting services..."))
+		Expect(session).To(gbytes.Say("Starting services done"))
 
 		pcfdevCommand = exec.Command("cf", "dev", "destroy")
 		session, err = gexec.Start(pcfdevCommand, GinkgoWriter, GinkgoWriter)
@@ -294,8 +294,8 @@ var _ = Describe("PCF Dev", func() {
 		session, err = gexec.Start(pcfdevCommand, GinkgoWriter, GinkgoWriter)
 		Expect(err).NotTo(HaveOccurred())
 		Eventually(session, "10m").Should(gexec.Exit(0))
-		Expect(session).To(gbytes.Say("Waiting for services to start..."))
-		Expect(session).To(gbytes.Say("Services started"))
+		Expect(session).To(gbytes.Say("Starting services..."))
+		Expect(session).To(gbytes.Say("Starting services done"))
 		pcfdevCommand = exec.Command("cf", "dev", "status")
 		session, err = gexec.Start(pcfdevCommand, GinkgoWriter, GinkgoWriter)
 		Expect(err).NotTo(HaveOccurred())
@@ -351,8 +351,8 @@ var _ = Describe("PCF Dev", func() {
 		session, err := gexec.Start(pcfdevCommand, GinkgoWriter, GinkgoWriter)
 		Expect(err).NotTo(HaveOccurred())
 		Eventually(session, "10m").Should(gexec.Exit(0))
-		Expect(session).To(gbytes.Say("Waiting for services to start..."))
-		Expect(session).To(gbytes.Say("Services started"))
+		Expect(session).To(gbytes.Say("Starting services..."))
+		Expect(session).To(gbytes.Say("Starting services done"))
 		pcfdevCommand = exec.Command("cf", "dev", "status")
 		session, err = gexec.Start(pcfdevCommand, GinkgoWriter, GinkgoWriter)
 		Expect(err).NotTo(HaveOccurred())
@@ -417,8 +417,8 @@ var _ = Describe("PCF Dev", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(session, "1h").Should(gexec.Exit(0))
 			Expect(session).To(gbytes.Say("Provisioning VM..."))
-			Expect(session).To(gbytes.Say("Waiting for services to start..."))
-			Expect(session).To(gbytes.Say("Services started"))
+			Expect(session).To(gbytes.Say("Starting services..."))
+			Expect(session).To(gbytes.Say("Starting services done"))
 
 			By("running 'cf dev debug'")
 			pcfdevCommand := exec.Command("cf", "dev", "debug")
@@ -9,7 +9,9 @@ import (
 
 	"github.com/cloudfoundry/cli/cf/trace"
 	"github.com/pivotal-cf/pcfdev-cli/address"
+	"github.com/pivotal-cf/pcfdev-cli/cache"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/digest"
 	"github.com/pivotal-cf/pcfdev-cli/downloader"
 	"github.com/pivotal-cf/pcfdev-cli/exit"
 	"github.com/pivotal-cf/pcfdev-cli/fs"
@@ -17,6 +19,9 @@ import (
 	"github.com/pivotal-cf/pcfdev-cli/pivnet"
 	"github.com/pivotal-cf/pcfdev-cli/plugin"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/qemu"
+	"github.com/pivotal-cf/pcfdev-cli/qemudriver"
+	"github.com/pivotal-cf/pcfdev-cli/readiness"
 	"github.com/pivotal-cf/pcfdev-cli/runner"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
 	"github.com/pivotal-cf/pcfdev-cli/system"
@@ -39,8 +44,10 @@ var (
 	releaseId          string
 	productFileId      string
 	md5                string
+	sha256             string
 	vmName             string
 	insecurePrivateKey string
+	manifestPublicKey  string
 )
 
 func main() {
@@ -61,6 +68,7 @@ func main() {
 	conf, err := config.New(
 		vmName,
 		md5,
+		sha256,
 		[]byte(insecurePrivateKey),
 		&system.System{
 			FS: fileSystem,
@@ -77,6 +85,7 @@ func main() {
 	token := &pivnet.Token{
 		Config: conf,
 		FS:     fileSystem,
+		Store:  pivnet.NewCredentialStore(conf, fileSystem, &runner.CmdRunner{}),
 		UI:     cfui,
 	}
 	client := &pivnet.Client{
@@ -86,55 +95,90 @@ func main() {
 		Token:         token,
 	}
 	token.Client = client
+	expectedDigests := &digest.ExpectedDigests{
+		Config:    conf,
+		FS:        fileSystem,
+		PublicKey: []byte(manifestPublicKey),
+	}
+	ovaCache := &cache.Cache{
+		Dir: conf.OVADir,
+		FS:  fileSystem,
+	}
 	sshClient := &ssh.SSH{
 		Terminal: &ssh.TerminalWrapper{},
 		WindowResizer: &ssh.ConcreteWindowResizer{
 			DoneChannel: make(chan bool),
 		},
 	}
-	vbx := &vbox.VBox{
-		SSH:    sshClient,
-		FS:     fileSystem,
-		Driver: driver,
-		Picker: &address.Picker{
+	var provider cmd.Provider
+	switch conf.Provider {
+	case config.ProviderQEMU:
+		provider = &qemu.Qemu{
+			SSH:     sshClient,
+			FS:      fileSystem,
 			Network: &network.Network{},
-			Driver:  driver,
-		},
-		Config: conf,
+			Driver: &qemudriver.QemuDriver{
+				FS:        fileSystem,
+				CmdRunner: &runner.CmdRunner{},
+				VMDir:     conf.VMDir,
+			},
+			Config: conf,
+		}
+	default:
+		provider = &vbox.VBox{
+			SSH:    sshClient,
+			FS:     fileSystem,
+			Driver: driver,
+			Picker: &address.Picker{
+				Network: &network.Network{},
+				Driver:  driver,
+			},
+			Config: conf,
+		}
 	}
 	httpClientIgnoringEnvironmentProxies := &http.Client{
 		Transport: &http.Transport{
 			Proxy: nil,
 		},
 	}
+	pcfdevClient := &vmClient.Client{
+		Timeout:    time.Second * 20,
+		HttpClient: httpClientIgnoringEnvironmentProxies,
+		SSHClient:  sshClient,
+	}
 	cfplugin.Start(&plugin.Plugin{
 		UI:     &plugin.NonTranslatingUI{cfui},
 		Config: conf,
 		Exit:   &exit.Exit{},
 		CmdBuilder: &cmd.Builder{
+			Cache:  ovaCache,
 			Client: client,
 			Config: conf,
 			DownloaderFactory: &downloader.DownloaderFactory{
 				PivnetClient:         client,
 				FS:                   fileSystem,
 				Token:                token,
+				ExpectedDigests:      expectedDigests,
+				Cache:                ovaCache,
 				Config:               conf,
 				DownloadAttempts:     10,
 				DownloadAttemptDelay: time.Second,
 			},
-			EULAUI: &ui.UI{},
-			FS:     fileSystem,
-			UI:     cfui,
-			VBox:   vbx,
-			VMBuilder: &vm.VBoxBuilder{
-				VBox:   vbx,
-				Config: conf,
-				FS:     fileSystem,
-				SSH:    sshClient,
-				Client: &vmClient.Client{
-					Timeout:    time.Second * 20,
-					HttpClient: httpClientIgnoringEnvironmentProxies,
-					SSHClient:  sshClient,
+			EULAUI:          &ui.UI{},
+			ExpectedDigests: expectedDigests,
+			FS:              fileSystem,
+			Token:           token,
+			UI:              cfui,
+			Provider:        provider,
+			VMBuilder: &vm.ProviderBuilder{
+				Provider: provider,
+				Config:   conf,
+				FS:       fileSystem,
+				SSH:      sshClient,
+				Client:   pcfdevClient,
+				Readiness: &readiness.Checker{
+					Client:   pcfdevClient,
+					Interval: 5 * time.Second,
 				},
 			},
 		},
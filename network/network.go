@@ -48,6 +48,7 @@ func (n *Network) Interfaces() (interfaces []*Interface, err error) {
 				interfaces = append(interfaces, &Interface{
 					IP:              addrString,
 					HardwareAddress: iface.HardwareAddr.String(),
+					Name:            iface.Name,
 					Exists:          true,
 				})
 			}
@@ -57,6 +58,15 @@ func (n *Network) Interfaces() (interfaces []*Interface, err error) {
 	return interfaces, nil
 }
 
+// CheckListen returns the error from listening on the TCP address, if any.
+func (n *Network) CheckListen(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	return listener.Close()
+}
+
 func IsIPV4(ip string) bool {
 	ip4 := net.ParseIP(ip).To4()
 	if ip4 == nil {
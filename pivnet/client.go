@@ -3,9 +3,12 @@ package pivnet
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/kennygrant/sanitize"
 	. "github.com/pivotal-cf/pcfdev-cli/helpers"
@@ -63,11 +66,67 @@ func (c *Client) DownloadOVA(startAtByte int64) (ova *DownloadReader, err error)
 	}
 }
 
-func (c *Client) IsEULAAccepted() (bool, error) {
+func (c *Client) DownloadOVARange(startAtByte int64, endAtByte int64) (io.ReadCloser, error) {
+	resp, err := c.requestOva(fmt.Sprintf("bytes=%d-%d", startAtByte, endAtByte))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusPartialContent {
+		return resp.Body, nil
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		IgnoreErrorFrom(c.Token.Destroy())
+		return nil, &InvalidTokenError{}
+	default:
+		return nil, c.unexpectedResponseError(resp)
+	}
+}
+
+func (c *Client) OVASize() (int64, error) {
 	resp, err := c.requestOva("bytes=0-0")
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		return parseContentRangeSize(resp.Header.Get("Content-Range"))
+	case http.StatusUnauthorized:
+		IgnoreErrorFrom(c.Token.Destroy())
+		return 0, &InvalidTokenError{}
+	default:
+		return 0, c.unexpectedResponseError(resp)
+	}
+}
+
+func parseContentRangeSize(contentRange string) (int64, error) {
+	index := strings.LastIndex(contentRange, "/")
+	if index < 0 {
+		return 0, &UnexpectedResponseError{fmt.Errorf("invalid Content-Range: %q", contentRange)}
+	}
+
+	size, err := strconv.ParseInt(contentRange[index+1:], 10, 64)
+	if err != nil {
+		return 0, &UnexpectedResponseError{fmt.Errorf("invalid Content-Range: %q", contentRange)}
+	}
+	return size, nil
+}
+
+func (c *Client) IsEULAAccepted() (bool, error) {
+	return c.isEULAAccepted(c.ReleaseId, c.ProductFileId)
+}
+
+func (c *Client) isEULAAccepted(releaseId string, productFileId string) (bool, error) {
+	resp, err := c.requestReleaseOva(releaseId, productFileId, "bytes=0-0")
 	if err != nil {
 		return false, err
 	}
+	defer resp.Body.Close()
 
 	switch resp.StatusCode {
 	case http.StatusPartialContent, http.StatusOK:
@@ -235,7 +294,11 @@ func (c *Client) unexpectedResponseError(resp *http.Response) error {
 }
 
 func (c *Client) requestOva(byteRange string) (*http.Response, error) {
-	uri := fmt.Sprintf("%s/api/v2/products/pcfdev/releases/%s/product_files/%s/download", c.Host, c.ReleaseId, c.ProductFileId)
+	return c.requestReleaseOva(c.ReleaseId, c.ProductFileId, byteRange)
+}
+
+func (c *Client) requestReleaseOva(releaseId string, productFileId string, byteRange string) (*http.Response, error) {
+	uri := fmt.Sprintf("%s/api/v2/products/pcfdev/releases/%s/product_files/%s/download", c.Host, releaseId, productFileId)
 	client := &http.Client{
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			req.Header.Set("Range", byteRange)
@@ -153,6 +153,149 @@ var _ = Describe("Pivnet Client", func() {
 		})
 	})
 
+	Describe("#DownloadOVARange", func() {
+		It("should download the requested byte range", func() {
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				switch r.URL.Path {
+				case "/api/v2/products/pcfdev/releases/some-release-id/product_files/some-product-file-id/download":
+					Expect(r.Method).To(Equal("POST"))
+					Expect(r.Header["Authorization"][0]).To(Equal("Token some-token"))
+					w.Header().Add("Location", "http://"+r.Host+"/some-path")
+					w.WriteHeader(302)
+				case "/some-path":
+					Expect(r.Method).To(Equal("GET"))
+					Expect(r.Header["Range"][0]).To(Equal("bytes=4-15"))
+					w.WriteHeader(206)
+					w.Write([]byte("ova contents"))
+				default:
+					Fail("unexpected server request")
+				}
+			}
+			client.Host = httptest.NewServer(http.HandlerFunc(handler)).URL
+
+			mockToken.EXPECT().Get().Return("some-token", nil)
+			ova, err := client.DownloadOVARange(int64(4), int64(15))
+			Expect(err).NotTo(HaveOccurred())
+			buf, err := ioutil.ReadAll(ova)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(buf)).To(Equal("ova contents"))
+		})
+
+		Context("when Pivnet ignores the range", func() {
+			It("should return an error", func() {
+				handler := func(w http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+					w.WriteHeader(200)
+				}
+				client.Host = httptest.NewServer(http.HandlerFunc(handler)).URL
+
+				mockToken.EXPECT().Get().Return("some-token", nil)
+				_, err := client.DownloadOVARange(int64(4), int64(15))
+				Expect(err).To(MatchError("Pivotal Network returned: 200 OK"))
+			})
+		})
+
+		Context("when Pivnet is unreachable", func() {
+			It("should return an appropriate error", func() {
+				client.Host = "some-bad-host"
+
+				mockToken.EXPECT().Get().Return("some-token", nil)
+				_, err := client.DownloadOVARange(int64(0), int64(1))
+				Expect(err).To(MatchError(ContainSubstring("failed to reach Pivotal Network:")))
+			})
+		})
+
+		Context("when Pivnet returns status 401", func() {
+			It("should return an error telling user that their pivnet token is bad", func() {
+				handler := func(w http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+					w.WriteHeader(401)
+				}
+				client.Host = httptest.NewServer(http.HandlerFunc(handler)).URL
+
+				gomock.InOrder(
+					mockToken.EXPECT().Get().Return("some-bad-token", nil),
+					mockToken.EXPECT().Destroy(),
+				)
+				_, err := client.DownloadOVARange(int64(0), int64(1))
+				Expect(err).To(MatchError("invalid Pivotal Network API token"))
+			})
+		})
+	})
+
+	Describe("#OVASize", func() {
+		It("should return the total size of the ova", func() {
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				switch r.URL.Path {
+				case "/api/v2/products/pcfdev/releases/some-release-id/product_files/some-product-file-id/download":
+					w.Header().Add("Location", "http://"+r.Host+"/some-path")
+					w.WriteHeader(302)
+				case "/some-path":
+					Expect(r.Header["Range"][0]).To(Equal("bytes=0-0"))
+					w.Header().Add("Content-Range", "bytes 0-0/4096")
+					w.WriteHeader(206)
+					w.Write([]byte("o"))
+				default:
+					Fail("unexpected server request")
+				}
+			}
+			client.Host = httptest.NewServer(http.HandlerFunc(handler)).URL
+
+			mockToken.EXPECT().Get().Return("some-token", nil)
+			Expect(client.OVASize()).To(Equal(int64(4096)))
+		})
+
+		Context("when the Content-Range header is invalid", func() {
+			It("should return an error", func() {
+				handler := func(w http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+					w.Header().Add("Content-Range", "bytes 0-0/*")
+					w.WriteHeader(206)
+				}
+				client.Host = httptest.NewServer(http.HandlerFunc(handler)).URL
+
+				mockToken.EXPECT().Get().Return("some-token", nil)
+				_, err := client.OVASize()
+				Expect(err).To(MatchError(`invalid Content-Range: "bytes 0-0/*"`))
+			})
+		})
+
+		Context("when Pivnet returns status 401", func() {
+			It("should return an error telling user that their pivnet token is bad", func() {
+				handler := func(w http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+					w.WriteHeader(401)
+				}
+				client.Host = httptest.NewServer(http.HandlerFunc(handler)).URL
+
+				gomock.InOrder(
+					mockToken.EXPECT().Get().Return("some-bad-token", nil),
+					mockToken.EXPECT().Destroy(),
+				)
+				_, err := client.OVASize()
+				Expect(err).To(MatchError("invalid Pivotal Network API token"))
+			})
+		})
+
+		Context("when Pivnet returns something unexpected", func() {
+			It("should return an error", func() {
+				handler := func(w http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+					w.WriteHeader(500)
+				}
+				client.Host = httptest.NewServer(http.HandlerFunc(handler)).URL
+
+				mockToken.EXPECT().Get().Return("some-token", nil)
+				_, err := client.OVASize()
+				Expect(err).To(MatchError("Pivotal Network returned: 500 Internal Server Error"))
+			})
+		})
+	})
+
 	Describe("#AcceptEULA", func() {
 		It("should accept the EULA", func() {
 			handler := func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,231 @@
+package pivnet
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/helpers"
+	"github.com/pivotal-cf/pcfdev-cli/runner"
+)
+
+//go:generate mockgen -package mocks -destination mocks/credential_store.go github.com/pivotal-cf/pcfdev-cli/pivnet CredentialStore
+type CredentialStore interface {
+	Get() (token string, err error)
+	Save(token string) error
+	Delete() error
+}
+
+//go:generate mockgen -package mocks -destination mocks/cmd_runner.go github.com/pivotal-cf/pcfdev-cli/pivnet CmdRunner
+type CmdRunner interface {
+	Run(command string, args ...string) (output []byte, err error)
+	RunWithInput(input io.Reader, command string, args ...string) (output []byte, err error)
+}
+
+var secretServiceAttributes = []string{"service", "pcfdev", "account", "pivnet-api-token"}
+
+// SecretServiceStore keeps the token in the desktop keyring through the
+// Secret Service D-Bus API, using the secret-tool client from libsecret.
+type SecretServiceStore struct {
+	CmdRunner CmdRunner
+}
+
+func (s *SecretServiceStore) Get() (string, error) {
+	output, err := s.CmdRunner.Run("secret-tool", append([]string{"lookup"}, secretServiceAttributes...)...)
+	if err != nil {
+		if isSecretNotFound(err) {
+			return "", nil
+		}
+		return "", &CredentialStoreError{Err: err}
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// isSecretNotFound tells a lookup without a match, where secret-tool exits
+// with status 1 and prints nothing, from a keyring that could not be used.
+func isSecretNotFound(err error) bool {
+	cmdErr, ok := err.(*runner.CmdError)
+	return ok && cmdErr.Status == 1 && len(cmdErr.Output) == 0
+}
+
+func (s *SecretServiceStore) Save(token string) error {
+	args := append([]string{"store", "--label=PCF Dev Pivotal Network API token"}, secretServiceAttributes...)
+	if _, err := s.CmdRunner.RunWithInput(strings.NewReader(token), "secret-tool", args...); err != nil {
+		return &CredentialStoreError{Err: err}
+	}
+	return nil
+}
+
+func (s *SecretServiceStore) Delete() error {
+	if _, err := s.CmdRunner.Run("secret-tool", append([]string{"clear"}, secretServiceAttributes...)...); err != nil {
+		return &CredentialStoreError{Err: err}
+	}
+	return nil
+}
+
+// FallbackStore uses Primary, and Fallback when Primary is unavailable, e.g.
+// when the keyring is locked or cannot be reached.
+type FallbackStore struct {
+	Primary  CredentialStore
+	Fallback CredentialStore
+}
+
+func (f *FallbackStore) Get() (string, error) {
+	if token, err := f.Primary.Get(); err == nil && token != "" {
+		return token, nil
+	}
+	return f.Fallback.Get()
+}
+
+func (f *FallbackStore) Save(token string) error {
+	if err := f.Primary.Save(token); err != nil {
+		return f.Fallback.Save(token)
+	}
+	return f.Fallback.Delete()
+}
+
+func (f *FallbackStore) Delete() error {
+	helpers.IgnoreErrorFrom(f.Primary.Delete())
+	return f.Fallback.Delete()
+}
+
+// FileStore keeps the token in a file readable only by the user, encrypted
+// with a random key kept in KeyPath, which is also readable only by the user.
+// The file permissions are what protect the token from other local users;
+// the encryption only keeps a copy of the token file on its own useless.
+type FileStore struct {
+	Path    string
+	KeyPath string
+	FS      FS
+}
+
+func (f *FileStore) Get() (string, error) {
+	exists, err := f.FS.Exists(f.Path)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", nil
+	}
+
+	key, err := f.readKey()
+	if err != nil {
+		return "", err
+	}
+	if key == nil {
+		return "", nil
+	}
+
+	data, err := f.FS.Read(f.Path)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil {
+		return "", &CorruptTokenError{Path: f.Path}
+	}
+	gcm, err := newCipher(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", &CorruptTokenError{Path: f.Path}
+	}
+	token, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", &CorruptTokenError{Path: f.Path}
+	}
+	return string(token), nil
+}
+
+func (f *FileStore) Save(token string) error {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return err
+	}
+	gcm, err := newCipher(key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(token), nil)
+
+	if err := f.writePrivate(f.KeyPath, base64.StdEncoding.EncodeToString(key)); err != nil {
+		return err
+	}
+	return f.writePrivate(f.Path, base64.StdEncoding.EncodeToString(sealed))
+}
+
+func (f *FileStore) Delete() error {
+	for _, path := range []string{f.Path, f.KeyPath} {
+		exists, err := f.FS.Exists(path)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		if err := f.FS.Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readKey returns no key when the key file is missing, e.g. for a token
+// saved by an older version of the plugin, so that the token is asked for again.
+func (f *FileStore) readKey() ([]byte, error) {
+	exists, err := f.FS.Exists(f.KeyPath)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	data, err := f.FS.Read(f.KeyPath)
+	if err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(string(data))
+	if err != nil || len(key) != 32 {
+		return nil, &CorruptTokenError{Path: f.Path}
+	}
+	return key, nil
+}
+
+func (f *FileStore) writePrivate(path string, contents string) error {
+	if err := f.FS.Write(path, bytes.NewReader(nil), false); err != nil {
+		return err
+	}
+	if err := f.FS.Chmod(path, 0600); err != nil {
+		return err
+	}
+	return f.FS.Write(path, strings.NewReader(contents), false)
+}
+
+func newCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func newFileStore(conf *config.Config, fs FS) *FileStore {
+	return &FileStore{
+		Path:    filepath.Join(conf.PCFDevHome, "token.enc"),
+		KeyPath: filepath.Join(conf.PCFDevHome, "token.key"),
+		FS:      fs,
+	}
+}
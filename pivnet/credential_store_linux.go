@@ -0,0 +1,20 @@
+package pivnet
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/pivotal-cf/pcfdev-cli/config"
+)
+
+func NewCredentialStore(conf *config.Config, fs FS, cmdRunner CmdRunner) CredentialStore {
+	fileStore := newFileStore(conf, fs)
+	if _, err := exec.LookPath("secret-tool"); err != nil || os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		return fileStore
+	}
+
+	return &FallbackStore{
+		Primary:  &SecretServiceStore{CmdRunner: cmdRunner},
+		Fallback: fileStore,
+	}
+}
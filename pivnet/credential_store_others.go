@@ -0,0 +1,9 @@
+// +build !linux
+
+package pivnet
+
+import "github.com/pivotal-cf/pcfdev-cli/config"
+
+func NewCredentialStore(conf *config.Config, fs FS, cmdRunner CmdRunner) CredentialStore {
+	return newFileStore(conf, fs)
+}
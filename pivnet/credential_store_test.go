@@ -0,0 +1,269 @@
+package pivnet_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pivotal-cf/pcfdev-cli/fs"
+	"github.com/pivotal-cf/pcfdev-cli/pivnet"
+	"github.com/pivotal-cf/pcfdev-cli/pivnet/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/runner"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Credential stores", func() {
+	var mockCtrl *gomock.Controller
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("SecretServiceStore", func() {
+		var (
+			mockCmdRunner *mocks.MockCmdRunner
+			store         *pivnet.SecretServiceStore
+		)
+
+		BeforeEach(func() {
+			mockCmdRunner = mocks.NewMockCmdRunner(mockCtrl)
+			store = &pivnet.SecretServiceStore{CmdRunner: mockCmdRunner}
+		})
+
+		Describe("#Get", func() {
+			It("should look up the token in the keyring", func() {
+				mockCmdRunner.EXPECT().Run("secret-tool", "lookup", "service", "pcfdev", "account", "pivnet-api-token").Return([]byte("some-token\n"), nil)
+
+				Expect(store.Get()).To(Equal("some-token"))
+			})
+
+			Context("when the token is not in the keyring", func() {
+				It("should return no token", func() {
+					mockCmdRunner.EXPECT().Run("secret-tool", "lookup", "service", "pcfdev", "account", "pivnet-api-token").Return(nil, &runner.CmdError{Command: "secret-tool", Status: 1, Err: errors.New("exit status 1")})
+
+					Expect(store.Get()).To(BeEmpty())
+				})
+			})
+
+			Context("when secret-tool fails with an error message", func() {
+				It("should return an error", func() {
+					mockCmdRunner.EXPECT().Run("secret-tool", "lookup", "service", "pcfdev", "account", "pivnet-api-token").Return(nil, &runner.CmdError{Command: "secret-tool", Status: 1, Output: []byte("some-message"), Err: errors.New("exit status 1")})
+
+					_, err := store.Get()
+					Expect(err).To(MatchError(HavePrefix("failed to access the keyring: ")))
+				})
+			})
+
+			Context("when the keyring cannot be used", func() {
+				It("should return an error", func() {
+					mockCmdRunner.EXPECT().Run("secret-tool", "lookup", "service", "pcfdev", "account", "pivnet-api-token").Return(nil, errors.New("some-error"))
+
+					_, err := store.Get()
+					Expect(err).To(MatchError("failed to access the keyring: some-error"))
+				})
+			})
+		})
+
+		Describe("#Save", func() {
+			It("should store the token in the keyring", func() {
+				mockCmdRunner.EXPECT().RunWithInput(strings.NewReader("some-token"), "secret-tool", "store", "--label=PCF Dev Pivotal Network API token", "service", "pcfdev", "account", "pivnet-api-token")
+
+				Expect(store.Save("some-token")).To(Succeed())
+			})
+
+			Context("when storing the token fails", func() {
+				It("should return an error", func() {
+					mockCmdRunner.EXPECT().RunWithInput(gomock.Any(), "secret-tool", gomock.Any()).Return(nil, errors.New("some-error"))
+
+					Expect(store.Save("some-token")).To(MatchError("failed to access the keyring: some-error"))
+				})
+			})
+		})
+
+		Describe("#Delete", func() {
+			It("should clear the token from the keyring", func() {
+				mockCmdRunner.EXPECT().Run("secret-tool", "clear", "service", "pcfdev", "account", "pivnet-api-token")
+
+				Expect(store.Delete()).To(Succeed())
+			})
+		})
+	})
+
+	Describe("FallbackStore", func() {
+		var (
+			mockPrimary  *mocks.MockCredentialStore
+			mockFallback *mocks.MockCredentialStore
+			store        *pivnet.FallbackStore
+		)
+
+		BeforeEach(func() {
+			mockPrimary = mocks.NewMockCredentialStore(mockCtrl)
+			mockFallback = mocks.NewMockCredentialStore(mockCtrl)
+			store = &pivnet.FallbackStore{Primary: mockPrimary, Fallback: mockFallback}
+		})
+
+		Describe("#Get", func() {
+			It("should return the token from the primary store", func() {
+				mockPrimary.EXPECT().Get().Return("some-token", nil)
+
+				Expect(store.Get()).To(Equal("some-token"))
+			})
+
+			Context("when the primary store has no token", func() {
+				It("should return the token from the fallback store", func() {
+					gomock.InOrder(
+						mockPrimary.EXPECT().Get().Return("", nil),
+						mockFallback.EXPECT().Get().Return("some-token", nil),
+					)
+
+					Expect(store.Get()).To(Equal("some-token"))
+				})
+			})
+
+			Context("when the primary store fails", func() {
+				It("should return the token from the fallback store", func() {
+					gomock.InOrder(
+						mockPrimary.EXPECT().Get().Return("", errors.New("some-error")),
+						mockFallback.EXPECT().Get().Return("some-token", nil),
+					)
+
+					Expect(store.Get()).To(Equal("some-token"))
+				})
+
+				Context("when the fallback store has no token", func() {
+					It("should return no token", func() {
+						gomock.InOrder(
+							mockPrimary.EXPECT().Get().Return("", errors.New("some-error")),
+							mockFallback.EXPECT().Get().Return("", nil),
+						)
+
+						Expect(store.Get()).To(BeEmpty())
+					})
+				})
+			})
+		})
+
+		Describe("#Save", func() {
+			It("should save the token to the primary store and delete it from the fallback store", func() {
+				gomock.InOrder(
+					mockPrimary.EXPECT().Save("some-token"),
+					mockFallback.EXPECT().Delete(),
+				)
+
+				Expect(store.Save("some-token")).To(Succeed())
+			})
+
+			Context("when the primary store is unavailable", func() {
+				It("should save the token to the fallback store", func() {
+					gomock.InOrder(
+						mockPrimary.EXPECT().Save("some-token").Return(errors.New("some-error")),
+						mockFallback.EXPECT().Save("some-token"),
+					)
+
+					Expect(store.Save("some-token")).To(Succeed())
+				})
+			})
+		})
+
+		Describe("#Delete", func() {
+			It("should delete the token from both stores", func() {
+				gomock.InOrder(
+					mockPrimary.EXPECT().Delete().Return(errors.New("some-error")),
+					mockFallback.EXPECT().Delete(),
+				)
+
+				Expect(store.Delete()).To(Succeed())
+			})
+		})
+	})
+
+	Describe("FileStore", func() {
+		var (
+			dir   string
+			store *pivnet.FileStore
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "pcfdev-token")
+			Expect(err).NotTo(HaveOccurred())
+
+			store = &pivnet.FileStore{
+				Path:    filepath.Join(dir, "token.enc"),
+				KeyPath: filepath.Join(dir, "token.key"),
+				FS:      &fs.FS{},
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should save the token encrypted and readable only by the user", func() {
+			Expect(store.Save("some-token")).To(Succeed())
+
+			data, err := ioutil.ReadFile(store.Path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).NotTo(ContainSubstring("some-token"))
+
+			if runtime.GOOS != "windows" {
+				for _, path := range []string{store.Path, store.KeyPath} {
+					info, err := os.Stat(path)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+				}
+			}
+
+			Expect(store.Get()).To(Equal("some-token"))
+		})
+
+		Context("when no token is saved", func() {
+			It("should return no token", func() {
+				Expect(store.Get()).To(BeEmpty())
+				Expect(store.Delete()).To(Succeed())
+			})
+		})
+
+		Context("when the key file is missing", func() {
+			It("should return no token", func() {
+				Expect(store.Save("some-token")).To(Succeed())
+				Expect(os.Remove(store.KeyPath)).To(Succeed())
+
+				Expect(store.Get()).To(BeEmpty())
+			})
+		})
+
+		Context("when the token was saved with another key", func() {
+			It("should return an error", func() {
+				Expect(store.Save("some-token")).To(Succeed())
+				Expect(ioutil.WriteFile(store.KeyPath, []byte(base64.StdEncoding.EncodeToString(make([]byte, 32))), 0600)).To(Succeed())
+
+				_, err := store.Get()
+				Expect(err).To(MatchError("failed to decrypt the saved Pivotal Network API token, run `cf dev logout` to remove " + store.Path))
+			})
+		})
+
+		Describe("#Delete", func() {
+			It("should remove the token and key files", func() {
+				Expect(store.Save("some-token")).To(Succeed())
+				Expect(store.Delete()).To(Succeed())
+
+				_, err := os.Stat(store.Path)
+				Expect(os.IsNotExist(err)).To(BeTrue())
+				_, err = os.Stat(store.KeyPath)
+				Expect(os.IsNotExist(err)).To(BeTrue())
+			})
+		})
+	})
+})
@@ -11,6 +11,7 @@ type DownloadReader struct {
 	io.ReadCloser
 	accumulatedLength int64
 	lastPercentage    int
+	lastMegabytes     int64
 	Writer            io.Writer
 	ContentLength     int64
 	ExistingLength    int64
@@ -30,6 +31,11 @@ func (dr *DownloadReader) Read(p []byte) (int, error) {
 }
 
 func (dr *DownloadReader) displayProgress(length int64) {
+	if dr.ContentLength < 0 || dr.ExistingLength+dr.ContentLength <= 0 {
+		dr.displayBytes(length)
+		return
+	}
+
 	totalLength := float64(dr.ExistingLength + dr.ContentLength)
 	totalPercentage := float64(dr.ExistingLength+length) / totalLength
 	downloadedPercentage := float64(length) / totalLength
@@ -53,3 +59,14 @@ func (dr *DownloadReader) displayProgress(length int64) {
 		strings.Repeat(" ", spaces),
 		percentage)
 }
+
+// displayBytes reports progress without a bar when the total length is unknown.
+func (dr *DownloadReader) displayBytes(length int64) {
+	megabytes := (dr.ExistingLength + length) / 1024 / 1024
+	if megabytes != 0 && dr.lastMegabytes == megabytes {
+		return
+	}
+	dr.lastMegabytes = megabytes
+
+	fmt.Fprintf(dr.Writer, "\rProgress: %d MB ", megabytes)
+}
@@ -97,5 +97,38 @@ var _ = Describe("Download Reader", func() {
 				Eventually(stdout).Should(gbytes.Say(`\r\QProgress: |+++++++++++++=======>| 100%\E`))
 			})
 		})
+
+		Context("when the length of the file is unknown", func() {
+			It("should display the downloaded size without a progress bar", func() {
+				reader := &pivnet.DownloadReader{
+					ReadCloser:     file,
+					Writer:         stdout,
+					ContentLength:  -1,
+					ExistingLength: 2 * 1024 * 1024,
+				}
+				defer reader.Close()
+
+				_, err := io.Copy(ioutil.Discard, reader)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(stdout).Should(gbytes.Say(`\r\QProgress: 2 MB \E`))
+				Expect(stdout.Contents()).NotTo(ContainSubstring("|"))
+			})
+		})
+
+		Context("when the length of the file is zero", func() {
+			It("should not fail", func() {
+				reader := &pivnet.DownloadReader{
+					ReadCloser: file,
+					Writer:     stdout,
+				}
+				defer reader.Close()
+
+				_, err := io.Copy(ioutil.Discard, reader)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(stdout).Should(gbytes.Say(`\r\QProgress: 0 MB \E`))
+			})
+		})
 	})
 })
@@ -43,3 +43,27 @@ type JSONUnmarshalError struct {
 func (e *JSONUnmarshalError) Error() string {
 	return fmt.Sprintf("failed to parse network response: %s", e.Err)
 }
+
+type ReleaseNotFoundError struct {
+	Version string
+}
+
+func (e *ReleaseNotFoundError) Error() string {
+	return fmt.Sprintf("PCF Dev release %s was not found on Pivotal Network, run `cf dev releases` to see the available releases", e.Version)
+}
+
+type CredentialStoreError struct {
+	Err error
+}
+
+func (e *CredentialStoreError) Error() string {
+	return fmt.Sprintf("failed to access the keyring: %s", e.Err)
+}
+
+type CorruptTokenError struct {
+	Path string
+}
+
+func (e *CorruptTokenError) Error() string {
+	return fmt.Sprintf("failed to decrypt the saved Pivotal Network API token, run `cf dev logout` to remove %s", e.Path)
+}
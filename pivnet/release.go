@@ -0,0 +1,183 @@
+package pivnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	. "github.com/pivotal-cf/pcfdev-cli/helpers"
+)
+
+type Release struct {
+	ID           string
+	Version      string
+	ReleaseDate  string
+	ProductFile  *ProductFile
+	EULAAccepted bool
+}
+
+type ProductFile struct {
+	ID     string
+	Name   string
+	MD5    string
+	SHA256 string
+	Size   int64
+}
+
+type ReleasesResponse struct {
+	Releases []struct {
+		ID          int    `json:"id"`
+		Version     string `json:"version"`
+		ReleaseDate string `json:"release_date"`
+	} `json:"releases"`
+}
+
+type ProductFilesResponse struct {
+	ProductFiles []struct {
+		ID           int    `json:"id"`
+		Name         string `json:"name"`
+		AWSObjectKey string `json:"aws_object_key"`
+		MD5          string `json:"md5"`
+		SHA256       string `json:"sha256"`
+		Size         int64  `json:"size"`
+	} `json:"product_files"`
+}
+
+func (c *Client) Releases() ([]*Release, error) {
+	releases, err := c.listReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make([]error, len(releases))
+	var wg sync.WaitGroup
+	for i, release := range releases {
+		wg.Add(1)
+		go func(i int, release *Release) {
+			defer wg.Done()
+			errs[i] = c.fetchOVA(release)
+		}(i, release)
+	}
+	wg.Wait()
+
+	ovaReleases := []*Release{}
+	for i, release := range releases {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if release.ProductFile != nil {
+			ovaReleases = append(ovaReleases, release)
+		}
+	}
+	return ovaReleases, nil
+}
+
+// fetchOVA fetches the OVA of release and whether its EULA is accepted. The
+// product file is left nil when release has no OVA.
+func (c *Client) fetchOVA(release *Release) (err error) {
+	if release.ProductFile, err = c.ovaProductFile(release.ID); err != nil {
+		return err
+	}
+	if release.ProductFile == nil {
+		return nil
+	}
+
+	release.EULAAccepted, err = c.isEULAAccepted(release.ID, release.ProductFile.ID)
+	return err
+}
+
+func (c *Client) Release(version string) (*Release, error) {
+	releases, err := c.listReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, release := range releases {
+		if strings.TrimPrefix(release.Version, "v") != strings.TrimPrefix(version, "v") {
+			continue
+		}
+
+		if release.ProductFile, err = c.ovaProductFile(release.ID); err != nil {
+			return nil, err
+		}
+		if release.ProductFile == nil {
+			return nil, &ReleaseNotFoundError{version}
+		}
+		return release, nil
+	}
+	return nil, &ReleaseNotFoundError{version}
+}
+
+func (c *Client) SelectRelease(release *Release) {
+	c.ReleaseId = release.ID
+	c.ProductFileId = release.ProductFile.ID
+}
+
+func (c *Client) listReleases() ([]*Release, error) {
+	releasesResponse := &ReleasesResponse{}
+	if err := c.getJSON(fmt.Sprintf("%s/api/v2/products/pcfdev/releases", c.Host), releasesResponse); err != nil {
+		return nil, err
+	}
+
+	releases := []*Release{}
+	for _, release := range releasesResponse.Releases {
+		releases = append(releases, &Release{
+			ID:          strconv.Itoa(release.ID),
+			Version:     release.Version,
+			ReleaseDate: release.ReleaseDate,
+		})
+	}
+	return releases, nil
+}
+
+func (c *Client) ovaProductFile(releaseId string) (*ProductFile, error) {
+	productFilesResponse := &ProductFilesResponse{}
+	if err := c.getJSON(fmt.Sprintf("%s/api/v2/products/pcfdev/releases/%s/product_files", c.Host, releaseId), productFilesResponse); err != nil {
+		return nil, err
+	}
+
+	for _, productFile := range productFilesResponse.ProductFiles {
+		if strings.HasSuffix(productFile.AWSObjectKey, ".ova") {
+			return &ProductFile{
+				ID:     strconv.Itoa(productFile.ID),
+				Name:   productFile.Name,
+				MD5:    productFile.MD5,
+				SHA256: productFile.SHA256,
+				Size:   productFile.Size,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (c *Client) getJSON(uri string, response interface{}) error {
+	resp, err := c.makeRequest(uri, "GET", http.DefaultClient)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		break
+	case http.StatusUnauthorized:
+		IgnoreErrorFrom(c.Token.Destroy())
+		return &InvalidTokenError{}
+	default:
+		return c.unexpectedResponseError(resp)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, response); err != nil {
+		return &JSONUnmarshalError{err}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package pivnet_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pivotal-cf/pcfdev-cli/pivnet"
+	"github.com/pivotal-cf/pcfdev-cli/pivnet/mocks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pivnet Client releases", func() {
+	var (
+		client    *pivnet.Client
+		mockCtrl  *gomock.Controller
+		mockToken *mocks.MockPivnetToken
+		server    *httptest.Server
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockToken = mocks.NewMockPivnetToken(mockCtrl)
+		mockToken.EXPECT().Get().Return("some-token", nil).AnyTimes()
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+
+			Expect(r.Header.Get("Authorization")).To(Equal("Token some-token"))
+			switch r.URL.Path {
+			case "/api/v2/products/pcfdev/releases":
+				w.Write([]byte(`{"releases": [
+					{"id": 2, "version": "v0.22.0", "release_date": "2016-10-05"},
+					{"id": 1, "version": "v0.21.0", "release_date": "2016-09-14"},
+					{"id": 3, "version": "v0.20.0", "release_date": "2016-08-31"}
+				]}`))
+			case "/api/v2/products/pcfdev/releases/2/product_files":
+				w.Write([]byte(`{"product_files": [
+					{"id": 20, "name": "PCF Dev Plugin", "aws_object_key": "product-files/pcfdev/pcfdev-v0.22.0-osx.zip"},
+					{"id": 21, "name": "PCF Dev OVA", "aws_object_key": "product-files/pcfdev/pcfdev-v0.22.0.ova", "md5": "some-md5", "sha256": "some-sha256", "size": 3221225472}
+				]}`))
+			case "/api/v2/products/pcfdev/releases/1/product_files":
+				w.Write([]byte(`{"product_files": [
+					{"id": 11, "name": "PCF Dev OVA", "aws_object_key": "product-files/pcfdev/pcfdev-v0.21.0.ova", "md5": "some-other-md5", "size": 2147483648}
+				]}`))
+			case "/api/v2/products/pcfdev/releases/3/product_files":
+				w.Write([]byte(`{"product_files": []}`))
+			case "/api/v2/products/pcfdev/releases/2/product_files/21/download":
+				Expect(r.Method).To(Equal("POST"))
+				w.WriteHeader(http.StatusPartialContent)
+			case "/api/v2/products/pcfdev/releases/1/product_files/11/download":
+				Expect(r.Method).To(Equal("POST"))
+				w.WriteHeader(451)
+			default:
+				Fail("unexpected server request: " + r.URL.Path)
+			}
+		}))
+
+		client = &pivnet.Client{
+			Host:          server.URL,
+			ReleaseId:     "some-release-id",
+			ProductFileId: "some-product-file-id",
+			Token:         mockToken,
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+		mockCtrl.Finish()
+	})
+
+	Describe("#Releases", func() {
+		It("should return the releases with an OVA and their EULA status", func() {
+			Expect(client.Releases()).To(Equal([]*pivnet.Release{
+				{
+					ID:          "2",
+					Version:     "v0.22.0",
+					ReleaseDate: "2016-10-05",
+					ProductFile: &pivnet.ProductFile{
+						ID:     "21",
+						Name:   "PCF Dev OVA",
+						MD5:    "some-md5",
+						SHA256: "some-sha256",
+						Size:   3221225472,
+					},
+					EULAAccepted: true,
+				},
+				{
+					ID:          "1",
+					Version:     "v0.21.0",
+					ReleaseDate: "2016-09-14",
+					ProductFile: &pivnet.ProductFile{
+						ID:   "11",
+						Name: "PCF Dev OVA",
+						MD5:  "some-other-md5",
+						Size: 2147483648,
+					},
+					EULAAccepted: false,
+				},
+			}))
+		})
+
+		Context("when the token is invalid", func() {
+			It("should destroy the token and return an error", func() {
+				server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusUnauthorized)
+				})
+
+				mockToken.EXPECT().Destroy()
+				_, err := client.Releases()
+				Expect(err).To(MatchError("invalid Pivotal Network API token"))
+			})
+		})
+
+		Context("when Pivotal Network returns an unexpected response", func() {
+			It("should return an error", func() {
+				server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusInternalServerError)
+				})
+
+				_, err := client.Releases()
+				Expect(err).To(MatchError("Pivotal Network returned: 500 Internal Server Error"))
+			})
+		})
+
+		Context("when Pivotal Network returns invalid JSON", func() {
+			It("should return an error", func() {
+				server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Write([]byte("some-bad-json"))
+				})
+
+				_, err := client.Releases()
+				Expect(err).To(MatchError(ContainSubstring("failed to parse network response:")))
+			})
+		})
+
+		Context("when fetching the OVA of a release fails", func() {
+			It("should return an error", func() {
+				handler := server.Config.Handler
+				server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/api/v2/products/pcfdev/releases/1/product_files" {
+						w.WriteHeader(http.StatusInternalServerError)
+						return
+					}
+					handler.ServeHTTP(w, r)
+				})
+
+				_, err := client.Releases()
+				Expect(err).To(MatchError("Pivotal Network returned: 500 Internal Server Error"))
+			})
+		})
+	})
+
+	Describe("#Release", func() {
+		It("should return the release with the given version", func() {
+			release, err := client.Release("0.21.0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(release.ID).To(Equal("1"))
+			Expect(release.Version).To(Equal("v0.21.0"))
+			Expect(release.ProductFile.ID).To(Equal("11"))
+			Expect(release.ProductFile.MD5).To(Equal("some-other-md5"))
+		})
+
+		It("should accept versions prefixed with v", func() {
+			release, err := client.Release("v0.22.0")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(release.ID).To(Equal("2"))
+		})
+
+		Context("when the release does not exist", func() {
+			It("should return an error", func() {
+				_, err := client.Release("0.1.0")
+				Expect(err).To(MatchError("PCF Dev release 0.1.0 was not found on Pivotal Network, run `cf dev releases` to see the available releases"))
+			})
+		})
+
+		Context("when the release does not have an OVA", func() {
+			It("should return an error", func() {
+				_, err := client.Release("0.20.0")
+				Expect(err).To(MatchError("PCF Dev release 0.20.0 was not found on Pivotal Network, run `cf dev releases` to see the available releases"))
+			})
+		})
+	})
+
+	Describe("#SelectRelease", func() {
+		It("should download the OVA of the given release", func() {
+			client.SelectRelease(&pivnet.Release{
+				ID:          "1",
+				ProductFile: &pivnet.ProductFile{ID: "11"},
+			})
+			Expect(client.ReleaseId).To(Equal("1"))
+			Expect(client.ProductFileId).To(Equal("11"))
+			Expect(client.IsEULAAccepted()).To(BeFalse())
+		})
+	})
+})
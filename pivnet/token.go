@@ -11,6 +11,7 @@ import (
 
 //go:generate mockgen -package mocks -destination mocks/fs.go github.com/pivotal-cf/pcfdev-cli/pivnet FS
 type FS interface {
+	Chmod(path string, mode os.FileMode) error
 	Exists(path string) (bool, error)
 	Read(path string) (contents []byte, err error)
 	Write(path string, contents io.Reader, append bool) error
@@ -32,6 +33,7 @@ type UI interface {
 type Token struct {
 	Config *config.Config
 	FS     FS
+	Store  CredentialStore
 	Client PivnetClient
 	UI     UI
 	token  string
@@ -48,13 +50,22 @@ func (t *Token) Get() (string, error) {
 		return t.token, nil
 	}
 
-	exists, err := t.FS.Exists(filepath.Join(t.Config.PCFDevHome, "token"))
+	token, err := t.Store.Get()
+	if err != nil {
+		return "", err
+	}
+	if token != "" {
+		t.token = token
+		return t.token, nil
+	}
+
+	exists, err := t.FS.Exists(t.plaintextPath())
 	if err != nil {
 		return "", err
 	}
 
 	if exists {
-		token, err := t.FS.Read(filepath.Join(t.Config.PCFDevHome, "token"))
+		token, err := t.FS.Read(t.plaintextPath())
 		if err != nil {
 			return "", err
 		}
@@ -79,21 +90,38 @@ func (t *Token) Save() error {
 		return nil
 	}
 
-	return t.FS.Write(filepath.Join(t.Config.PCFDevHome, "token"), strings.NewReader(t.token), false)
+	if err := t.Store.Save(t.token); err != nil {
+		return err
+	}
+	return t.removePlaintext()
 }
 
 func (t *Token) Destroy() error {
 	if os.Getenv("PIVNET_TOKEN") != "" {
 		return nil
 	}
-	exists, err := t.FS.Exists(filepath.Join(t.Config.PCFDevHome, "token"))
+
+	if err := t.Store.Delete(); err != nil {
+		return err
+	}
+	return t.removePlaintext()
+}
+
+// Tokens saved by earlier versions of the plugin are kept in plaintext, and
+// are removed once the token has been saved to the credential store.
+func (t *Token) removePlaintext() error {
+	exists, err := t.FS.Exists(t.plaintextPath())
 	if err != nil {
 		return err
 	}
 	if exists {
-		if err := t.FS.Remove(filepath.Join(t.Config.PCFDevHome, "token")); err != nil {
+		if err := t.FS.Remove(t.plaintextPath()); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+func (t *Token) plaintextPath() string {
+	return filepath.Join(t.Config.PCFDevHome, "token")
+}
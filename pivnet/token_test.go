@@ -4,7 +4,6 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/pivnet"
@@ -19,6 +18,7 @@ var _ = Describe("Pivnet Token", func() {
 	var (
 		mockCtrl   *gomock.Controller
 		mockFS     *mocks.MockFS
+		mockStore  *mocks.MockCredentialStore
 		mockUI     *mocks.MockUI
 		mockClient *mocks.MockPivnetClient
 		token      *pivnet.Token
@@ -27,6 +27,7 @@ var _ = Describe("Pivnet Token", func() {
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockFS = mocks.NewMockFS(mockCtrl)
+		mockStore = mocks.NewMockCredentialStore(mockCtrl)
 		mockUI = mocks.NewMockUI(mockCtrl)
 		mockClient = mocks.NewMockPivnetClient(mockCtrl)
 		token = &pivnet.Token{
@@ -34,6 +35,7 @@ var _ = Describe("Pivnet Token", func() {
 				PCFDevHome: "some-pcfdev-home",
 			},
 			FS:     mockFS,
+			Store:  mockStore,
 			UI:     mockUI,
 			Client: mockClient,
 		}
@@ -85,9 +87,27 @@ var _ = Describe("Pivnet Token", func() {
 				os.Setenv("PIVNET_TOKEN", savedToken)
 			})
 
-			Context("when a token exists at the token file path", func() {
+			Context("when a token is saved in the credential store", func() {
+				It("should return the saved token", func() {
+					mockStore.EXPECT().Get().Return("some-stored-token", nil)
+
+					Expect(token.Get()).To(Equal("some-stored-token"))
+				})
+			})
+
+			Context("when reading the credential store fails", func() {
+				It("should return an error", func() {
+					mockStore.EXPECT().Get().Return("", errors.New("some-error"))
+
+					_, err := token.Get()
+					Expect(err).To(MatchError("some-error"))
+				})
+			})
+
+			Context("when a plaintext token saved by an earlier version exists", func() {
 				It("should return the token from the file path", func() {
 					gomock.InOrder(
+						mockStore.EXPECT().Get().Return("", nil),
 						mockFS.EXPECT().Exists(filepath.Join("some-pcfdev-home", "token")).Return(true, nil),
 						mockFS.EXPECT().Read(filepath.Join("some-pcfdev-home", "token")).Return([]byte("some-saved-token"), nil),
 					)
@@ -99,6 +119,7 @@ var _ = Describe("Pivnet Token", func() {
 			Context("when a token does not exist at the token file path", func() {
 				It("should prompt the user to enter their Pivnet username and password", func() {
 					gomock.InOrder(
+						mockStore.EXPECT().Get().Return("", nil),
 						mockFS.EXPECT().Exists(filepath.Join("some-pcfdev-home", "token")).Return(false, nil),
 						mockUI.EXPECT().Say("Please sign in with your Pivotal Network account."),
 						mockUI.EXPECT().Say("Need an account? Join Pivotal Network: https://network.pivotal.io"),
@@ -114,6 +135,7 @@ var _ = Describe("Pivnet Token", func() {
 			Context("when pivnet token has already been fetched", func() {
 				It("should return the same value", func() {
 					gomock.InOrder(
+						mockStore.EXPECT().Get().Return("", nil),
 						mockFS.EXPECT().Exists(filepath.Join("some-pcfdev-home", "token")).Times(1),
 						mockUI.EXPECT().Say("Please sign in with your Pivotal Network account."),
 						mockUI.EXPECT().Say("Need an account? Join Pivotal Network: https://network.pivotal.io"),
@@ -130,6 +152,7 @@ var _ = Describe("Pivnet Token", func() {
 			Context("when call to determine whether a token's presence fails", func() {
 				It("should return an error", func() {
 					gomock.InOrder(
+						mockStore.EXPECT().Get().Return("", nil),
 						mockFS.EXPECT().Exists(filepath.Join("some-pcfdev-home", "token")).Return(false, errors.New("some-error")),
 					)
 
@@ -141,6 +164,7 @@ var _ = Describe("Pivnet Token", func() {
 			Context("when call to read token file fails", func() {
 				It("should return an error", func() {
 					gomock.InOrder(
+						mockStore.EXPECT().Get().Return("", nil),
 						mockFS.EXPECT().Exists(filepath.Join("some-pcfdev-home", "token")).Return(true, nil),
 						mockFS.EXPECT().Read(filepath.Join("some-pcfdev-home", "token")).Return(nil, errors.New("some-error")),
 					)
@@ -153,6 +177,7 @@ var _ = Describe("Pivnet Token", func() {
 			Context("when getting the token from Pivnet fails", func() {
 				It("should return an error", func() {
 					gomock.InOrder(
+						mockStore.EXPECT().Get().Return("", nil),
 						mockFS.EXPECT().Exists(filepath.Join("some-pcfdev-home", "token")).Times(1),
 						mockUI.EXPECT().Say("Please sign in with your Pivotal Network account."),
 						mockUI.EXPECT().Say("Need an account? Join Pivotal Network: https://network.pivotal.io"),
@@ -181,16 +206,31 @@ var _ = Describe("Pivnet Token", func() {
 				os.Setenv("PIVNET_TOKEN", savedToken)
 			})
 
-			It("should save the token", func() {
+			It("should save the token to the credential store and remove the plaintext token", func() {
 				gomock.InOrder(
+					mockStore.EXPECT().Get().Return("", nil),
 					mockFS.EXPECT().Exists(filepath.Join("some-pcfdev-home", "token")).Return(true, nil),
 					mockFS.EXPECT().Read(filepath.Join("some-pcfdev-home", "token")).Return([]byte("some-user-provided-token"), nil),
-					mockFS.EXPECT().Write(filepath.Join("some-pcfdev-home", "token"), strings.NewReader("some-user-provided-token"), false),
+					mockStore.EXPECT().Save("some-user-provided-token"),
+					mockFS.EXPECT().Exists(filepath.Join("some-pcfdev-home", "token")).Return(true, nil),
+					mockFS.EXPECT().Remove(filepath.Join("some-pcfdev-home", "token")),
 				)
 
 				token.Get()
 				Expect(token.Save()).To(Succeed())
 			})
+
+			Context("when saving to the credential store fails", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockStore.EXPECT().Get().Return("some-token", nil),
+						mockStore.EXPECT().Save("some-token").Return(errors.New("some-error")),
+					)
+
+					token.Get()
+					Expect(token.Save()).To(MatchError("some-error"))
+				})
+			})
 		})
 
 		Context("when PIVNET_TOKEN env var is set", func() {
@@ -231,6 +271,7 @@ var _ = Describe("Pivnet Token", func() {
 		Context("when the token is saved to file", func() {
 			It("should delete the token file", func() {
 				gomock.InOrder(
+					mockStore.EXPECT().Delete(),
 					mockFS.EXPECT().Exists(filepath.Join("some-pcfdev-home", "token")).Return(true, nil),
 					mockFS.EXPECT().Remove(filepath.Join("some-pcfdev-home", "token")),
 				)
@@ -240,14 +281,20 @@ var _ = Describe("Pivnet Token", func() {
 
 		Context("when the token is not saved to file", func() {
 			It("should not throw an error", func() {
-				mockFS.EXPECT().Exists(filepath.Join("some-pcfdev-home", "token")).Return(false, nil)
+				gomock.InOrder(
+					mockStore.EXPECT().Delete(),
+					mockFS.EXPECT().Exists(filepath.Join("some-pcfdev-home", "token")).Return(false, nil),
+				)
 				Expect(token.Destroy()).To(Succeed())
 			})
 		})
 
 		Context("when there is an error seeing if token exists", func() {
 			It("should throw an error", func() {
-				mockFS.EXPECT().Exists(filepath.Join("some-pcfdev-home", "token")).Return(false, errors.New("some-error"))
+				gomock.InOrder(
+					mockStore.EXPECT().Delete(),
+					mockFS.EXPECT().Exists(filepath.Join("some-pcfdev-home", "token")).Return(false, errors.New("some-error")),
+				)
 				Expect(token.Destroy()).To(MatchError("some-error"))
 			})
 		})
@@ -255,6 +302,7 @@ var _ = Describe("Pivnet Token", func() {
 		Context("when there is an error removing the token", func() {
 			It("should throw an error", func() {
 				gomock.InOrder(
+					mockStore.EXPECT().Delete(),
 					mockFS.EXPECT().Exists(filepath.Join("some-pcfdev-home", "token")).Return(true, nil),
 					mockFS.EXPECT().Remove(filepath.Join("some-pcfdev-home", "token")).Return(errors.New("some-error")),
 				)
@@ -262,6 +310,13 @@ var _ = Describe("Pivnet Token", func() {
 			})
 		})
 
+		Context("when deleting the token from the credential store fails", func() {
+			It("should throw an error", func() {
+				mockStore.EXPECT().Delete().Return(errors.New("some-error"))
+				Expect(token.Destroy()).To(MatchError("some-error"))
+			})
+		})
+
 		Context("when PIVNET_TOKEN is set", func() {
 			BeforeEach(func() {
 				os.Setenv("PIVNET_TOKEN", "some-pivnet-token")
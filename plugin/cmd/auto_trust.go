@@ -7,7 +7,7 @@ import (
 
 type AutoTrustCmd struct {
 	VMBuilder VMBuilder
-	VBox      VBox
+	Provider  Provider
 	Config    *config.Config
 }
 
@@ -20,7 +20,7 @@ func (t *AutoTrustCmd) Run() error {
 }
 
 func (t *AutoTrustCmd) getVM() (vm vm.VM, err error) {
-	name, err := t.VBox.GetVMName()
+	name, err := t.Provider.GetVMName()
 	if err != nil {
 		return nil, err
 	}
@@ -17,18 +17,18 @@ var _ = Describe("AutoTrustCmd", func() {
 	var (
 		autoTrustCmd  *cmd.AutoTrustCmd
 		mockCtrl      *gomock.Controller
-		mockVBox      *mocks.MockVBox
+		mockProvider  *mocks.MockProvider
 		mockVMBuilder *mocks.MockVMBuilder
 		mockVM        *vmMocks.MockVM
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
 		mockVM = vmMocks.NewMockVM(mockCtrl)
 		autoTrustCmd = &cmd.AutoTrustCmd{
-			VBox:      mockVBox,
+			Provider:  mockProvider,
 			VMBuilder: mockVMBuilder,
 			Config: &config.Config{
 				DefaultVMName: "some-default-vm-name",
@@ -43,7 +43,7 @@ var _ = Describe("AutoTrustCmd", func() {
 	Describe("Run", func() {
 		It("should call Trust on the VM", func() {
 			gomock.InOrder(
-				mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 				mockVM.EXPECT().Trust(&vm.StartOpts{}),
 			)
@@ -53,7 +53,7 @@ var _ = Describe("AutoTrustCmd", func() {
 
 		Context("when there is an error getting the VM name", func() {
 			It("should return the error", func() {
-				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+				mockProvider.EXPECT().GetVMName().Return("", errors.New("some-error"))
 
 				Expect(autoTrustCmd.Run()).To(MatchError("some-error"))
 			})
@@ -62,7 +62,7 @@ var _ = Describe("AutoTrustCmd", func() {
 		Context("when there is an error building the VM", func() {
 			It("should return the error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
 				)
 
@@ -73,7 +73,7 @@ var _ = Describe("AutoTrustCmd", func() {
 		Context("when there is an error trusting the cert", func() {
 			It("should return the error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Trust(&vm.StartOpts{}).Return(errors.New("some-error")),
 				)
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/cache"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+)
+
+//go:generate mockgen -package mocks -destination mocks/cache.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd Cache
+type Cache interface {
+	Entries() (entries []*cache.Entry, err error)
+	Prune(olderThan time.Duration, keep int, except string) (pruned []*cache.Entry, err error)
+	Save(entry *cache.Entry) error
+	Verify(entry *cache.Entry) (ok bool, err error)
+}
+
+type CacheCmd struct {
+	Cache  Cache
+	UI     UI
+	Config *config.Config
+
+	subcommand  string
+	flagContext flags.FlagContext
+}
+
+func (c *CacheCmd) Parse(args []string) error {
+	c.flagContext = flags.New()
+	c.flagContext.NewIntFlag("older-than", "", "<days>")
+	c.flagContext.NewIntFlag("keep", "", "<count>")
+	if err := c.flagContext.Parse(args...); err != nil {
+		return err
+	}
+
+	args = c.flagContext.Args()
+	if len(args) != 1 {
+		return errors.New("wrong number of arguments")
+	}
+
+	c.subcommand = args[0]
+	switch c.subcommand {
+	case "list", "verify":
+		if c.flagContext.IsSet("older-than") || c.flagContext.IsSet("keep") {
+			return fmt.Errorf("cache %s does not take any flags", c.subcommand)
+		}
+	case "prune":
+		if c.flagContext.Int("older-than") < 0 || c.flagContext.Int("keep") < 0 {
+			return errors.New("--older-than and --keep must not be negative")
+		}
+	default:
+		return fmt.Errorf("unknown cache command: %s", c.subcommand)
+	}
+	return nil
+}
+
+func (c *CacheCmd) Run() error {
+	switch c.subcommand {
+	case "list":
+		return c.list()
+	case "verify":
+		return c.verify()
+	case "prune":
+		return c.prune()
+	}
+	return nil
+}
+
+func (c *CacheCmd) list() error {
+	entries, err := c.Cache.Entries()
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		c.UI.Say("No OVAs are cached.")
+		return nil
+	}
+
+	for _, entry := range entries {
+		marker := " "
+		if entry.File == c.currentOVA() {
+			marker = "*"
+		}
+
+		downloadedAt := "unknown"
+		if !entry.DownloadedAt.IsZero() {
+			downloadedAt = entry.DownloadedAt.Local().Format("2006-01-02")
+		}
+
+		source := entry.Source
+		if source == "" {
+			source = "unknown"
+		}
+
+		c.UI.Say(fmt.Sprintf("%s %-12s %-12s %6d MB   %s", marker, entry.Version, downloadedAt, entry.Size/1024/1024, source))
+	}
+	return nil
+}
+
+func (c *CacheCmd) verify() error {
+	entries, err := c.Cache.Entries()
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		c.UI.Say("No OVAs are cached.")
+		return nil
+	}
+
+	failed := []string{}
+	for _, entry := range entries {
+		ok, err := c.Cache.Verify(entry)
+		switch err.(type) {
+		case nil:
+		case *cache.MissingDigestError:
+			c.UI.Say(fmt.Sprintf("%s: no recorded digest, skipped", entry.File))
+			continue
+		default:
+			return err
+		}
+
+		if ok {
+			c.UI.Say(fmt.Sprintf("%s: OK", entry.File))
+		} else {
+			c.UI.Say(fmt.Sprintf("%s: FAILED", entry.File))
+			failed = append(failed, entry.File)
+		}
+	}
+
+	if len(failed) > 0 {
+		return &CacheVerificationError{Files: failed}
+	}
+	return nil
+}
+
+func (c *CacheCmd) prune() error {
+	olderThan := time.Duration(c.flagContext.Int("older-than")) * 24 * time.Hour
+	keep := -1
+	if c.flagContext.IsSet("keep") {
+		keep = c.flagContext.Int("keep")
+	} else if !c.flagContext.IsSet("older-than") {
+		keep = 0
+	}
+
+	pruned, err := c.Cache.Prune(olderThan, keep, c.currentOVA())
+	if err != nil {
+		return err
+	}
+
+	if len(pruned) == 0 {
+		c.UI.Say("No OVAs were pruned.")
+		return nil
+	}
+
+	for _, entry := range pruned {
+		c.UI.Say(fmt.Sprintf("Removed %s.", entry.File))
+	}
+	return nil
+}
+
+func (c *CacheCmd) currentOVA() string {
+	return filepath.Base(c.Config.OVAPath)
+}
@@ -0,0 +1,212 @@
+package cmd_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/cache"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+)
+
+var _ = Describe("CacheCmd", func() {
+	var (
+		cacheCmd  *cmd.CacheCmd
+		mockCtrl  *gomock.Controller
+		mockCache *mocks.MockCache
+		mockUI    *mocks.MockUI
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockCache = mocks.NewMockCache(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		cacheCmd = &cmd.CacheCmd{
+			Cache: mockCache,
+			UI:    mockUI,
+			Config: &config.Config{
+				OVAPath: "/some-ova-dir/pcfdev-v0.22.0.ova",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		It("should accept the list, verify and prune subcommands", func() {
+			Expect(cacheCmd.Parse([]string{"list"})).To(Succeed())
+			Expect(cacheCmd.Parse([]string{"verify"})).To(Succeed())
+			Expect(cacheCmd.Parse([]string{"prune", "--older-than", "30", "--keep", "2"})).To(Succeed())
+		})
+
+		Context("when no subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(cacheCmd.Parse([]string{})).To(MatchError("wrong number of arguments"))
+			})
+		})
+
+		Context("when an unknown subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(cacheCmd.Parse([]string{"some-subcommand"})).To(MatchError("unknown cache command: some-subcommand"))
+			})
+		})
+
+		Context("when prune flags are passed to another subcommand", func() {
+			It("should fail", func() {
+				Expect(cacheCmd.Parse([]string{"list", "--keep", "2"})).To(MatchError("cache list does not take any flags"))
+			})
+		})
+
+		Context("when a negative prune flag is passed", func() {
+			It("should fail", func() {
+				Expect(cacheCmd.Parse([]string{"prune", "--keep", "-1"})).To(MatchError("--older-than and --keep must not be negative"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		Context("list", func() {
+			BeforeEach(func() {
+				Expect(cacheCmd.Parse([]string{"list"})).To(Succeed())
+			})
+
+			It("should print each cached OVA and mark the one used by the plugin", func() {
+				gomock.InOrder(
+					mockCache.EXPECT().Entries().Return([]*cache.Entry{
+						{Version: "0.22.0", File: "pcfdev-v0.22.0.ova", Source: "pivnet", DownloadedAt: time.Date(2016, 10, 5, 12, 0, 0, 0, time.Local), Size: 3 * 1024 * 1024 * 1024},
+						{Version: "0.21.0", File: "pcfdev-v0.21.0.ova", Size: 2 * 1024 * 1024 * 1024},
+					}, nil),
+					mockUI.EXPECT().Say("* 0.22.0       2016-10-05     3072 MB   pivnet"),
+					mockUI.EXPECT().Say("  0.21.0       unknown        2048 MB   unknown"),
+				)
+
+				Expect(cacheCmd.Run()).To(Succeed())
+			})
+
+			Context("when no OVAs are cached", func() {
+				It("should say so", func() {
+					gomock.InOrder(
+						mockCache.EXPECT().Entries().Return([]*cache.Entry{}, nil),
+						mockUI.EXPECT().Say("No OVAs are cached."),
+					)
+
+					Expect(cacheCmd.Run()).To(Succeed())
+				})
+			})
+
+			Context("when listing the cache fails", func() {
+				It("should return the error", func() {
+					mockCache.EXPECT().Entries().Return(nil, errors.New("some-error"))
+
+					Expect(cacheCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+		})
+
+		Context("verify", func() {
+			var entries []*cache.Entry
+
+			BeforeEach(func() {
+				Expect(cacheCmd.Parse([]string{"verify"})).To(Succeed())
+				entries = []*cache.Entry{
+					{File: "pcfdev-v0.22.0.ova", SHA256: "some-sha256"},
+					{File: "pcfdev-v0.21.0.ova"},
+				}
+			})
+
+			It("should verify each cached OVA against its recorded digest", func() {
+				gomock.InOrder(
+					mockCache.EXPECT().Entries().Return(entries, nil),
+					mockCache.EXPECT().Verify(entries[0]).Return(true, nil),
+					mockUI.EXPECT().Say("pcfdev-v0.22.0.ova: OK"),
+					mockCache.EXPECT().Verify(entries[1]).Return(false, &cache.MissingDigestError{File: "pcfdev-v0.21.0.ova"}),
+					mockUI.EXPECT().Say("pcfdev-v0.21.0.ova: no recorded digest, skipped"),
+				)
+
+				Expect(cacheCmd.Run()).To(Succeed())
+			})
+
+			Context("when an OVA does not match its recorded digest", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockCache.EXPECT().Entries().Return(entries[:1], nil),
+						mockCache.EXPECT().Verify(entries[0]).Return(false, nil),
+						mockUI.EXPECT().Say("pcfdev-v0.22.0.ova: FAILED"),
+					)
+
+					Expect(cacheCmd.Run()).To(MatchError("cached OVAs failed verification: pcfdev-v0.22.0.ova"))
+				})
+			})
+
+			Context("when verifying an OVA fails", func() {
+				It("should return the error", func() {
+					gomock.InOrder(
+						mockCache.EXPECT().Entries().Return(entries, nil),
+						mockCache.EXPECT().Verify(entries[0]).Return(false, errors.New("some-error")),
+					)
+
+					Expect(cacheCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+		})
+
+		Context("prune", func() {
+			It("should prune by age and count, keeping the OVA used by the plugin", func() {
+				Expect(cacheCmd.Parse([]string{"prune", "--older-than", "30", "--keep", "2"})).To(Succeed())
+
+				gomock.InOrder(
+					mockCache.EXPECT().Prune(30*24*time.Hour, 2, "pcfdev-v0.22.0.ova").Return([]*cache.Entry{
+						{File: "pcfdev-v0.20.0.ova"},
+						{File: "pcfdev-v0.19.0.ova"},
+					}, nil),
+					mockUI.EXPECT().Say("Removed pcfdev-v0.20.0.ova."),
+					mockUI.EXPECT().Say("Removed pcfdev-v0.19.0.ova."),
+				)
+
+				Expect(cacheCmd.Run()).To(Succeed())
+			})
+
+			Context("when only an age is given", func() {
+				It("should not limit the count", func() {
+					Expect(cacheCmd.Parse([]string{"prune", "--older-than", "30"})).To(Succeed())
+
+					gomock.InOrder(
+						mockCache.EXPECT().Prune(30*24*time.Hour, -1, "pcfdev-v0.22.0.ova").Return([]*cache.Entry{}, nil),
+						mockUI.EXPECT().Say("No OVAs were pruned."),
+					)
+
+					Expect(cacheCmd.Run()).To(Succeed())
+				})
+			})
+
+			Context("when no age or count is given", func() {
+				It("should prune every OVA except the one used by the plugin", func() {
+					Expect(cacheCmd.Parse([]string{"prune"})).To(Succeed())
+
+					gomock.InOrder(
+						mockCache.EXPECT().Prune(time.Duration(0), 0, "pcfdev-v0.22.0.ova").Return([]*cache.Entry{{File: "pcfdev-v0.21.0.ova"}}, nil),
+						mockUI.EXPECT().Say("Removed pcfdev-v0.21.0.ova."),
+					)
+
+					Expect(cacheCmd.Run()).To(Succeed())
+				})
+			})
+
+			Context("when pruning fails", func() {
+				It("should return the error", func() {
+					Expect(cacheCmd.Parse([]string{"prune"})).To(Succeed())
+
+					mockCache.EXPECT().Prune(time.Duration(0), 0, "pcfdev-v0.22.0.ova").Return(nil, errors.New("some-error"))
+
+					Expect(cacheCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+		})
+	})
+})
@@ -3,13 +3,18 @@ package cmd
 import (
 	"errors"
 	"io"
+	"os"
 
 	"github.com/cloudfoundry/cli/cf/flags"
 	"github.com/pivotal-cf/pcfdev-cli/cert"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/digest"
+	"github.com/pivotal-cf/pcfdev-cli/dns"
 	"github.com/pivotal-cf/pcfdev-cli/downloader"
+	"github.com/pivotal-cf/pcfdev-cli/hosts"
+	"github.com/pivotal-cf/pcfdev-cli/network"
+	"github.com/pivotal-cf/pcfdev-cli/provider"
 	"github.com/pivotal-cf/pcfdev-cli/runner"
-	"github.com/pivotal-cf/pcfdev-cli/vboxdriver"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
 )
 
@@ -20,12 +25,17 @@ type UI interface {
 	Confirm(message string) bool
 }
 
-//go:generate mockgen -package mocks -destination mocks/vbox.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd VBox
-type VBox interface {
+//go:generate mockgen -package mocks -destination mocks/provider.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd Provider
+type Provider interface {
+	vm.Provider
 	GetVMName() (name string, err error)
-	VMConfig(vmName string) (vmConfig *config.VMConfig, err error)
 	DestroyPCFDevVMs() (err error)
-	Version() (version *vboxdriver.VBoxDriverVersion, err error)
+	Version() (version *provider.Version, err error)
+	TakeSnapshot(vmName string, snapshotName string) error
+	RestoreSnapshot(vmName string, snapshotName string) error
+	DeleteSnapshot(vmName string, snapshotName string) error
+	Snapshots(vmName string) (snapshots []string, err error)
+	Instances() (instances []*provider.Instance, err error)
 }
 
 //go:generate mockgen -package mocks -destination mocks/fs.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd FS
@@ -33,10 +43,11 @@ type FS interface {
 	Write(path string, contents io.Reader, append bool) error
 	Copy(source string, destination string) error
 	Exists(path string) (exists bool, err error)
-	MD5(path string) (md5 string, err error)
+	Digests(path string) (digests *digest.Digests, err error)
 	Read(path string) (contents []byte, err error)
 	Remove(path string) error
 	TempDir() (string, error)
+	CreateDir(path string) error
 }
 
 //go:generate mockgen -package mocks -destination mocks/vm_builder.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd VMBuilder
@@ -51,6 +62,11 @@ type DownloaderFactory interface {
 
 //go:generate mockgen -package mocks -destination mocks/downloader.go github.com/pivotal-cf/pcfdev-cli/downloader Downloader
 
+//go:generate mockgen -package mocks -destination mocks/expected_digests.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd ExpectedDigests
+type ExpectedDigests interface {
+	Get() (digests *digest.Digests, err error)
+}
+
 //go:generate mockgen -package mocks -destination mocks/cmd.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd Cmd
 type Cmd interface {
 	Parse([]string) error
@@ -78,13 +94,16 @@ func parse(flagContext flags.FlagContext, args []string, expectedLength int) err
 }
 
 type Builder struct {
+	Cache             Cache
 	Client            Client
 	Config            *config.Config
 	DownloaderFactory DownloaderFactory
 	EULAUI            EULAUI
+	ExpectedDigests   ExpectedDigests
 	FS                FS
+	Token             Token
 	UI                UI
-	VBox              VBox
+	Provider          Provider
 	VMBuilder         VMBuilder
 }
 
@@ -92,22 +111,63 @@ func (b *Builder) Cmd(subcommand string) (Cmd, error) {
 	switch subcommand {
 	case "destroy":
 		return &DestroyCmd{
-			VBox:   b.VBox,
-			UI:     b.UI,
-			FS:     b.FS,
-			Config: b.Config,
+			Provider: b.Provider,
+			UI:       b.UI,
+			FS:       b.FS,
+			Config:   b.Config,
 			UntrustCmd: &UntrustCmd{
 				CertStore: &cert.CertStore{
+					FS: b.FS,
 					SystemStore: &cert.ConcreteSystemStore{
 						FS:        b.FS,
 						CmdRunner: &runner.CmdRunner{},
 					},
+					NSSStore: &cert.NSSStore{
+						CmdRunner: &runner.CmdRunner{},
+					},
+					JavaStore: &cert.JavaStore{
+						FS:        b.FS,
+						CmdRunner: &runner.CmdRunner{},
+						JavaHome:  os.Getenv("JAVA_HOME"),
+					},
+					StoresPath: b.Config.TrustedStoresPath,
 				},
 			},
+			HostDNS: &dns.HostDNS{
+				FS:        b.FS,
+				CmdRunner: &runner.CmdRunner{},
+				Network:   &network.Network{},
+			},
+			Hosts: &hosts.Hosts{
+				FS:        b.FS,
+				CmdRunner: &runner.CmdRunner{},
+			},
+		}, nil
+	case "dns":
+		return &DNSCmd{
+			Provider:  b.Provider,
+			DNSServer: &dns.Server{FS: b.FS},
+			HostDNS: &dns.HostDNS{
+				FS:        b.FS,
+				CmdRunner: &runner.CmdRunner{},
+				Network:   &network.Network{},
+			},
+			UI:     b.UI,
+			Config: b.Config,
+		}, nil
+	case "hosts":
+		return &HostsCmd{
+			Provider: b.Provider,
+			Hosts: &hosts.Hosts{
+				FS:        b.FS,
+				CmdRunner: &runner.CmdRunner{},
+			},
+			UI:     b.UI,
+			Config: b.Config,
 		}, nil
 	case "download":
 		return &DownloadCmd{
-			VBox:              b.VBox,
+			Provider:          b.Provider,
 			UI:                b.UI,
 			EULAUI:            b.EULAUI,
 			Client:            b.Client,
@@ -115,26 +175,35 @@ func (b *Builder) Cmd(subcommand string) (Cmd, error) {
 			FS:                b.FS,
 			Config:            b.Config,
 		}, nil
+	case "eula":
+		return &EULACmd{
+			Client: b.Client,
+			UI:     b.UI,
+			FS:     b.FS,
+			Config: b.Config,
+		}, nil
 	case "import":
 		return &ImportCmd{
 			DownloaderFactory: b.DownloaderFactory,
+			ExpectedDigests:   b.ExpectedDigests,
 			UI:                b.UI,
 			Config:            b.Config,
 			FS:                b.FS,
+			Cache:             b.Cache,
 		}, nil
 	case "resume":
 		return &ResumeCmd{
-			VBox:      b.VBox,
+			Provider:  b.Provider,
 			VMBuilder: b.VMBuilder,
 			Config:    b.Config,
 		}, nil
 	case "start":
 		return &StartCmd{
-			VBox:      b.VBox,
+			Provider:  b.Provider,
 			VMBuilder: b.VMBuilder,
 			Config:    b.Config,
 			DownloadCmd: &DownloadCmd{
-				VBox:              b.VBox,
+				Provider:          b.Provider,
 				UI:                b.UI,
 				EULAUI:            b.EULAUI,
 				Client:            b.Client,
@@ -143,12 +212,12 @@ func (b *Builder) Cmd(subcommand string) (Cmd, error) {
 				Config:            b.Config,
 			},
 			AutoTrustCmd: &AutoTrustCmd{
-				VBox:      b.VBox,
+				Provider:  b.Provider,
 				VMBuilder: b.VMBuilder,
 				Config:    b.Config,
 			},
 			TargetCmd: &TargetCmd{
-				VBox:       b.VBox,
+				Provider:   b.Provider,
 				VMBuilder:  b.VMBuilder,
 				Config:     b.Config,
 				AutoTarget: true,
@@ -156,20 +225,20 @@ func (b *Builder) Cmd(subcommand string) (Cmd, error) {
 		}, nil
 	case "status":
 		return &StatusCmd{
-			VBox:      b.VBox,
+			Provider:  b.Provider,
 			VMBuilder: b.VMBuilder,
 			Config:    b.Config,
 			UI:        b.UI,
 		}, nil
 	case "stop":
 		return &StopCmd{
-			VBox:      b.VBox,
+			Provider:  b.Provider,
 			VMBuilder: b.VMBuilder,
 			Config:    b.Config,
 		}, nil
 	case "suspend":
 		return &SuspendCmd{
-			VBox:      b.VBox,
+			Provider:  b.Provider,
 			VMBuilder: b.VMBuilder,
 			Config:    b.Config,
 		}, nil
@@ -181,37 +250,131 @@ func (b *Builder) Cmd(subcommand string) (Cmd, error) {
 	case "debug":
 		return &DebugCmd{
 			VMBuilder: b.VMBuilder,
-			VBox:      b.VBox,
+			Provider:  b.Provider,
 			Config:    b.Config,
 		}, nil
 	case "trust":
 		return &TrustCmd{
-			VBox:      b.VBox,
+			Provider:  b.Provider,
 			VMBuilder: b.VMBuilder,
 			Config:    b.Config,
 		}, nil
 	case "untrust":
 		return &UntrustCmd{
 			CertStore: &cert.CertStore{
+				FS: b.FS,
 				SystemStore: &cert.ConcreteSystemStore{
 					FS:        b.FS,
 					CmdRunner: &runner.CmdRunner{},
 				},
+				NSSStore: &cert.NSSStore{
+					CmdRunner: &runner.CmdRunner{},
+				},
+				JavaStore: &cert.JavaStore{
+					FS:        b.FS,
+					CmdRunner: &runner.CmdRunner{},
+					JavaHome:  os.Getenv("JAVA_HOME"),
+				},
+				StoresPath: b.Config.TrustedStoresPath,
 			},
 		}, nil
 	case "target":
 		return &TargetCmd{
-			VBox:       b.VBox,
+			Provider:   b.Provider,
 			VMBuilder:  b.VMBuilder,
 			Config:     b.Config,
 			AutoTarget: false,
 		}, nil
 	case "ssh":
 		return &SSHCmd{
-			VBox:      b.VBox,
+			Provider:  b.Provider,
+			VMBuilder: b.VMBuilder,
+			Config:    b.Config,
+		}, nil
+	case "config":
+		return &ConfigCmd{
+			Config: b.Config,
+			FS:     b.FS,
+			UI:     b.UI,
+		}, nil
+	case "list":
+		return &ListCmd{
+			Provider: b.Provider,
+			UI:       b.UI,
+			Config:   b.Config,
+		}, nil
+	case "logout":
+		return &LogoutCmd{
+			Token: b.Token,
+			UI:    b.UI,
+		}, nil
+	case "releases":
+		return &ReleasesCmd{
+			Client: b.Client,
+			UI:     b.UI,
+			Config: b.Config,
+		}, nil
+	case "scp":
+		return &SCPCmd{
+			Provider:  b.Provider,
+			VMBuilder: b.VMBuilder,
+			Config:    b.Config,
+		}, nil
+	case "forward":
+		return &ForwardCmd{
+			Provider:  b.Provider,
+			VMBuilder: b.VMBuilder,
+			Config:    b.Config,
+		}, nil
+	case "cache":
+		return &CacheCmd{
+			Cache:  b.Cache,
+			UI:     b.UI,
+			Config: b.Config,
+		}, nil
+	case "resize":
+		return &ResizeCmd{
+			Provider:  b.Provider,
 			VMBuilder: b.VMBuilder,
 			Config:    b.Config,
 		}, nil
+	case "disk":
+		return &DiskCmd{
+			Provider:  b.Provider,
+			VMBuilder: b.VMBuilder,
+			Config:    b.Config,
+		}, nil
+	case "mount":
+		return &MountCmd{
+			Provider:  b.Provider,
+			VMBuilder: b.VMBuilder,
+			FS:        b.FS,
+			Config:    b.Config,
+		}, nil
+	case "mounts":
+		return &MountsCmd{
+			Provider:  b.Provider,
+			VMBuilder: b.VMBuilder,
+			Config:    b.Config,
+		}, nil
+	case "services":
+		return &ServicesCmd{
+			Provider:  b.Provider,
+			VMBuilder: b.VMBuilder,
+			Config:    b.Config,
+		}, nil
+	case "wait":
+		return &WaitCmd{
+			Provider:  b.Provider,
+			VMBuilder: b.VMBuilder,
+			Config:    b.Config,
+		}, nil
+	case "snapshot":
+		return &SnapshotCmd{
+			Provider: b.Provider,
+			UI:       b.UI,
+			Config:   b.Config,
+		}, nil
 	default:
 		return nil, errors.New("")
 	}
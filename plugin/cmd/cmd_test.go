@@ -7,7 +7,9 @@ import (
 	"github.com/cloudfoundry/cli/cf/trace"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/cache"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/digest"
 	"github.com/pivotal-cf/pcfdev-cli/downloader"
 	"github.com/pivotal-cf/pcfdev-cli/fs"
 	"github.com/pivotal-cf/pcfdev-cli/pivnet"
@@ -22,8 +24,10 @@ var _ = Describe("Builder", func() {
 		var builder *cmd.Builder
 		BeforeEach(func() {
 			builder = &cmd.Builder{
-				VBox:              &vbox.VBox{},
+				Cache:             &cache.Cache{},
+				Provider:          &vbox.VBox{},
 				DownloaderFactory: &downloader.DownloaderFactory{},
+				ExpectedDigests:   &digest.ExpectedDigests{},
 				FS:                &fs.FS{},
 				UI: terminal.NewUI(
 					os.Stdin,
@@ -31,10 +35,11 @@ var _ = Describe("Builder", func() {
 					terminal.NewTeePrinter(os.Stdout),
 					trace.NewWriterPrinter(os.Stdout, true),
 				),
-				VMBuilder: &vm.VBoxBuilder{},
+				VMBuilder: &vm.ProviderBuilder{},
 				Config:    &config.Config{},
 				EULAUI:    &ui.UI{},
 				Client:    &pivnet.Client{},
+				Token:     &pivnet.Token{},
 			}
 		})
 
@@ -45,11 +50,48 @@ var _ = Describe("Builder", func() {
 
 				switch c := destroyCmd.(type) {
 				case *cmd.DestroyCmd:
-					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
 					Expect(c.UI).To(BeIdenticalTo(builder.UI))
 					Expect(c.FS).To(BeIdenticalTo(builder.FS))
 					Expect(c.Config).To(BeIdenticalTo(builder.Config))
 					Expect(c.UntrustCmd).NotTo(BeNil())
+					Expect(c.HostDNS).NotTo(BeNil())
+					Expect(c.Hosts).NotTo(BeNil())
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when it is passed hosts", func() {
+			It("should return a hosts command", func() {
+				hostsCmd, err := builder.Cmd("hosts")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := hostsCmd.(type) {
+				case *cmd.HostsCmd:
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+					Expect(c.Hosts).NotTo(BeNil())
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when it is passed dns", func() {
+			It("should return a dns command", func() {
+				dnsCmd, err := builder.Cmd("dns")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := dnsCmd.(type) {
+				case *cmd.DNSCmd:
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+					Expect(c.DNSServer).NotTo(BeNil())
+					Expect(c.HostDNS).NotTo(BeNil())
 				default:
 					Fail("wrong type")
 				}
@@ -63,7 +105,7 @@ var _ = Describe("Builder", func() {
 
 				switch c := downloadCmd.(type) {
 				case *cmd.DownloadCmd:
-					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
 					Expect(c.UI).To(BeIdenticalTo(builder.UI))
 					Expect(c.EULAUI).To(BeIdenticalTo(builder.EULAUI))
 					Expect(c.Client).To(BeIdenticalTo(builder.Client))
@@ -84,9 +126,11 @@ var _ = Describe("Builder", func() {
 				switch c := importCmd.(type) {
 				case *cmd.ImportCmd:
 					Expect(c.DownloaderFactory).To(BeIdenticalTo(builder.DownloaderFactory))
+					Expect(c.ExpectedDigests).To(BeIdenticalTo(builder.ExpectedDigests))
 					Expect(c.UI).To(BeIdenticalTo(builder.UI))
 					Expect(c.FS).To(BeIdenticalTo(builder.FS))
 					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+					Expect(c.Cache).To(BeIdenticalTo(builder.Cache))
 				default:
 					Fail("wrong type")
 				}
@@ -100,7 +144,7 @@ var _ = Describe("Builder", func() {
 
 				switch c := resumeCmd.(type) {
 				case *cmd.ResumeCmd:
-					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
 					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
 					Expect(c.Config).To(BeIdenticalTo(builder.Config))
 				default:
@@ -116,11 +160,11 @@ var _ = Describe("Builder", func() {
 
 				switch c := startCmd.(type) {
 				case *cmd.StartCmd:
-					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
 					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
 					Expect(c.Config).To(BeIdenticalTo(builder.Config))
 					Expect(c.DownloadCmd).To(Equal(&cmd.DownloadCmd{
-						VBox:              builder.VBox,
+						Provider:          builder.Provider,
 						UI:                builder.UI,
 						EULAUI:            builder.EULAUI,
 						Client:            builder.Client,
@@ -129,12 +173,12 @@ var _ = Describe("Builder", func() {
 						Config:            builder.Config,
 					}))
 					Expect(c.AutoTrustCmd).To(Equal(&cmd.AutoTrustCmd{
-						VBox:      builder.VBox,
+						Provider:  builder.Provider,
 						VMBuilder: builder.VMBuilder,
 						Config:    builder.Config,
 					}))
 					Expect(c.TargetCmd).To(Equal(&cmd.TargetCmd{
-						VBox:       builder.VBox,
+						Provider:   builder.Provider,
 						VMBuilder:  builder.VMBuilder,
 						Config:     builder.Config,
 						AutoTarget: true,
@@ -152,7 +196,7 @@ var _ = Describe("Builder", func() {
 
 				switch c := statusCmd.(type) {
 				case *cmd.StatusCmd:
-					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
 					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
 					Expect(c.Config).To(BeIdenticalTo(builder.Config))
 					Expect(c.UI).To(BeIdenticalTo(builder.UI))
@@ -169,7 +213,7 @@ var _ = Describe("Builder", func() {
 
 				switch c := stopCmd.(type) {
 				case *cmd.StopCmd:
-					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
 					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
 					Expect(c.Config).To(BeIdenticalTo(builder.Config))
 				default:
@@ -185,7 +229,7 @@ var _ = Describe("Builder", func() {
 
 				switch c := suspendCmd.(type) {
 				case *cmd.SuspendCmd:
-					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
 					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
 					Expect(c.Config).To(BeIdenticalTo(builder.Config))
 				default:
@@ -230,7 +274,7 @@ var _ = Describe("Builder", func() {
 
 				switch c := debugCmd.(type) {
 				case *cmd.DebugCmd:
-					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
 					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
 					Expect(c.Config).To(BeIdenticalTo(builder.Config))
 				default:
@@ -246,7 +290,7 @@ var _ = Describe("Builder", func() {
 
 				switch c := trustCmd.(type) {
 				case *cmd.TrustCmd:
-					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
 					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
 					Expect(c.Config).To(BeIdenticalTo(builder.Config))
 				default:
@@ -276,7 +320,7 @@ var _ = Describe("Builder", func() {
 
 				switch c := targetCmd.(type) {
 				case *cmd.TargetCmd:
-					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
 					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
 					Expect(c.Config).To(BeIdenticalTo(builder.Config))
 					Expect(c.AutoTarget).To(BeFalse())
@@ -286,6 +330,247 @@ var _ = Describe("Builder", func() {
 			})
 		})
 
+		Context("when it is passed 'config'", func() {
+			It("should return a config command", func() {
+				configCmd, err := builder.Cmd("config")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := configCmd.(type) {
+				case *cmd.ConfigCmd:
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+					Expect(c.FS).To(BeIdenticalTo(builder.FS))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when it is passed 'list'", func() {
+			It("should return a list command", func() {
+				listCmd, err := builder.Cmd("list")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := listCmd.(type) {
+				case *cmd.ListCmd:
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when it is passed 'cache'", func() {
+			It("should return a cache command", func() {
+				cacheCmd, err := builder.Cmd("cache")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := cacheCmd.(type) {
+				case *cmd.CacheCmd:
+					Expect(c.Cache).To(BeIdenticalTo(builder.Cache))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when it is passed 'eula'", func() {
+			It("should return a eula command", func() {
+				eulaCmd, err := builder.Cmd("eula")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := eulaCmd.(type) {
+				case *cmd.EULACmd:
+					Expect(c.Client).To(BeIdenticalTo(builder.Client))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+					Expect(c.FS).To(BeIdenticalTo(builder.FS))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when it is passed 'resize'", func() {
+			It("should return a resize command", func() {
+				resizeCmd, err := builder.Cmd("resize")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := resizeCmd.(type) {
+				case *cmd.ResizeCmd:
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
+					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when it is passed 'disk'", func() {
+			It("should return a disk command", func() {
+				diskCmd, err := builder.Cmd("disk")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := diskCmd.(type) {
+				case *cmd.DiskCmd:
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
+					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when it is passed 'mount'", func() {
+			It("should return a mount command", func() {
+				mountCmd, err := builder.Cmd("mount")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := mountCmd.(type) {
+				case *cmd.MountCmd:
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
+					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
+					Expect(c.FS).To(BeIdenticalTo(builder.FS))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when it is passed 'mounts'", func() {
+			It("should return a mounts command", func() {
+				mountsCmd, err := builder.Cmd("mounts")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := mountsCmd.(type) {
+				case *cmd.MountsCmd:
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
+					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when it is passed 'services'", func() {
+			It("should return a services command", func() {
+				servicesCmd, err := builder.Cmd("services")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := servicesCmd.(type) {
+				case *cmd.ServicesCmd:
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
+					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when it is passed 'wait'", func() {
+			It("should return a wait command", func() {
+				waitCmd, err := builder.Cmd("wait")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := waitCmd.(type) {
+				case *cmd.WaitCmd:
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
+					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when it is passed 'logout'", func() {
+			It("should return a logout command", func() {
+				logoutCmd, err := builder.Cmd("logout")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := logoutCmd.(type) {
+				case *cmd.LogoutCmd:
+					Expect(c.Token).To(BeIdenticalTo(builder.Token))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when it is passed 'releases'", func() {
+			It("should return a releases command", func() {
+				releasesCmd, err := builder.Cmd("releases")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := releasesCmd.(type) {
+				case *cmd.ReleasesCmd:
+					Expect(c.Client).To(BeIdenticalTo(builder.Client))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when it is passed 'scp'", func() {
+			It("should return a scp command", func() {
+				scpCmd, err := builder.Cmd("scp")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := scpCmd.(type) {
+				case *cmd.SCPCmd:
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
+					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when it is passed 'forward'", func() {
+			It("should return a forward command", func() {
+				forwardCmd, err := builder.Cmd("forward")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := forwardCmd.(type) {
+				case *cmd.ForwardCmd:
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
+					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when it is passed 'snapshot'", func() {
+			It("should return a snapshot command", func() {
+				snapshotCmd, err := builder.Cmd("snapshot")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := snapshotCmd.(type) {
+				case *cmd.SnapshotCmd:
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
 		Context("when is is passed 'ssh'", func() {
 			It("should return a ssh command", func() {
 				sshCmd, err := builder.Cmd("ssh")
@@ -293,7 +578,7 @@ var _ = Describe("Builder", func() {
 
 				switch c := sshCmd.(type) {
 				case *cmd.SSHCmd:
-					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.Provider).To(BeIdenticalTo(builder.Provider))
 					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
 					Expect(c.Config).To(BeIdenticalTo(builder.Config))
 				default:
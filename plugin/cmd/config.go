@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+)
+
+type ConfigCmd struct {
+	Config *config.Config
+	FS     FS
+	UI     UI
+
+	subcommand string
+	key        string
+	value      string
+}
+
+func (c *ConfigCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	if err := flagContext.Parse(args...); err != nil {
+		return err
+	}
+
+	args = flagContext.Args()
+	if len(args) == 0 {
+		return errors.New("wrong number of arguments")
+	}
+
+	c.subcommand = args[0]
+	switch c.subcommand {
+	case "get":
+		if len(args) > 2 {
+			return errors.New("wrong number of arguments")
+		}
+		if len(args) == 2 {
+			c.key = args[1]
+		}
+	case "set":
+		if len(args) != 3 {
+			return errors.New("wrong number of arguments")
+		}
+		c.key = args[1]
+		c.value = args[2]
+	case "unset":
+		if len(args) != 2 {
+			return errors.New("wrong number of arguments")
+		}
+		c.key = args[1]
+	default:
+		return fmt.Errorf("unknown config command: %s", c.subcommand)
+	}
+	return nil
+}
+
+func (c *ConfigCmd) Run() error {
+	switch c.subcommand {
+	case "get":
+		return c.get()
+	case "set":
+		return c.set()
+	case "unset":
+		return c.unset()
+	}
+	return nil
+}
+
+func (c *ConfigCmd) get() error {
+	if c.key == "" {
+		c.UI.Say(string(bytes.TrimSpace(c.Config.UserConfig.Bytes())))
+		return nil
+	}
+
+	value, err := c.Config.UserConfig.Get(c.key)
+	if err != nil {
+		return err
+	}
+	c.UI.Say(value)
+	return nil
+}
+
+func (c *ConfigCmd) set() error {
+	if err := c.Config.UserConfig.Set(c.key, c.value); err != nil {
+		return err
+	}
+
+	if err := startOptsFromUserConfig(c.Config.UserConfig).Verify(); err != nil {
+		return err
+	}
+
+	if err := c.save(); err != nil {
+		return err
+	}
+	c.UI.Say(fmt.Sprintf("Set %s to %s.", c.key, c.value))
+	return nil
+}
+
+func (c *ConfigCmd) unset() error {
+	if err := c.Config.UserConfig.Unset(c.key); err != nil {
+		return err
+	}
+
+	if err := c.save(); err != nil {
+		return err
+	}
+	c.UI.Say(fmt.Sprintf("Unset %s.", c.key))
+	return nil
+}
+
+func (c *ConfigCmd) save() error {
+	if err := c.FS.CreateDir(c.Config.PCFDevHome); err != nil {
+		return err
+	}
+	return c.FS.Write(c.Config.UserConfigPath, bytes.NewReader(c.Config.UserConfig.Bytes()), false)
+}
+
+func startOptsFromUserConfig(userConfig *config.UserConfig) *vm.StartOpts {
+	return &vm.StartOpts{
+		CPUs:       userConfig.CPUs(),
+		Memory:     userConfig.Memory(),
+		Services:   userConfig.Services(),
+		Registries: userConfig.Registries(),
+		Domain:     userConfig.Domain(),
+		IP:         userConfig.IP(),
+	}
+}
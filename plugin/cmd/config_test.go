@@ -0,0 +1,169 @@
+package cmd_test
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+)
+
+var _ = Describe("ConfigCmd", func() {
+	var (
+		configCmd  *cmd.ConfigCmd
+		mockCtrl   *gomock.Controller
+		mockFS     *mocks.MockFS
+		mockUI     *mocks.MockUI
+		userConfig *config.UserConfig
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockFS = mocks.NewMockFS(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+
+		var err error
+		userConfig, err = config.ParseUserConfig([]byte("cpus: 4\nservices: all\n"))
+		Expect(err).NotTo(HaveOccurred())
+
+		configCmd = &cmd.ConfigCmd{
+			FS: mockFS,
+			UI: mockUI,
+			Config: &config.Config{
+				PCFDevHome:     "some-pcfdev-home",
+				UserConfigPath: "some-user-config-path",
+				UserConfig:     userConfig,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when the correct number of arguments are passed", func() {
+			It("should succeed", func() {
+				Expect(configCmd.Parse([]string{"get"})).To(Succeed())
+				Expect(configCmd.Parse([]string{"get", "memory"})).To(Succeed())
+				Expect(configCmd.Parse([]string{"set", "memory", "8192"})).To(Succeed())
+				Expect(configCmd.Parse([]string{"unset", "memory"})).To(Succeed())
+			})
+		})
+
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(configCmd.Parse([]string{})).To(MatchError("wrong number of arguments"))
+				Expect(configCmd.Parse([]string{"get", "memory", "some-bad-arg"})).To(MatchError("wrong number of arguments"))
+				Expect(configCmd.Parse([]string{"set", "memory"})).To(MatchError("wrong number of arguments"))
+				Expect(configCmd.Parse([]string{"unset"})).To(MatchError("wrong number of arguments"))
+			})
+		})
+
+		Context("when an unknown subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(configCmd.Parse([]string{"some-bad-subcommand"})).To(MatchError("unknown config command: some-bad-subcommand"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		Context("when getting a value", func() {
+			It("should print the value", func() {
+				Expect(configCmd.Parse([]string{"get", "cpus"})).To(Succeed())
+				mockUI.EXPECT().Say("4")
+
+				Expect(configCmd.Run()).To(Succeed())
+			})
+
+			Context("when no key is given", func() {
+				It("should print every value", func() {
+					Expect(configCmd.Parse([]string{"get"})).To(Succeed())
+					mockUI.EXPECT().Say("cpus: 4\nservices: all")
+
+					Expect(configCmd.Run()).To(Succeed())
+				})
+			})
+
+			Context("when the key is not supported", func() {
+				It("should return an error", func() {
+					Expect(configCmd.Parse([]string{"get", "some-bad-key"})).To(Succeed())
+
+					Expect(configCmd.Run()).To(MatchError("some-bad-key is not a supported config key, options: cpus, memory, services, registries, domain, ip, provider"))
+				})
+			})
+		})
+
+		Context("when setting a value", func() {
+			It("should save the config file", func() {
+				Expect(configCmd.Parse([]string{"set", "memory", "8192"})).To(Succeed())
+				gomock.InOrder(
+					mockFS.EXPECT().CreateDir("some-pcfdev-home"),
+					mockFS.EXPECT().Write("some-user-config-path", bytes.NewReader([]byte("cpus: 4\nmemory: 8192\nservices: all\n")), false),
+					mockUI.EXPECT().Say("Set memory to 8192."),
+				)
+
+				Expect(configCmd.Run()).To(Succeed())
+			})
+
+			Context("when the value is invalid", func() {
+				It("should return an error", func() {
+					Expect(configCmd.Parse([]string{"set", "memory", "lots"})).To(Succeed())
+
+					Expect(configCmd.Run()).To(MatchError("memory must be a positive number"))
+				})
+			})
+
+			Context("when the resulting start options are invalid", func() {
+				It("should return the error without saving", func() {
+					Expect(configCmd.Parse([]string{"set", "services", "some-bad-service"})).To(Succeed())
+
+					Expect(configCmd.Run()).To(MatchError("invalid services specified: some-bad-service"))
+				})
+			})
+
+			Context("when the memory is more than the host has free", func() {
+				It("should save the config file without checking the host", func() {
+					configCmd.Config.FreeMemory = uint64(1024)
+					Expect(configCmd.Parse([]string{"set", "memory", "8192"})).To(Succeed())
+					gomock.InOrder(
+						mockFS.EXPECT().CreateDir("some-pcfdev-home"),
+						mockFS.EXPECT().Write("some-user-config-path", bytes.NewReader([]byte("cpus: 4\nmemory: 8192\nservices: all\n")), false),
+						mockUI.EXPECT().Say("Set memory to 8192."),
+					)
+
+					Expect(configCmd.Run()).To(Succeed())
+				})
+			})
+
+			Context("when saving the config file fails", func() {
+				It("should return the error", func() {
+					Expect(configCmd.Parse([]string{"set", "memory", "8192"})).To(Succeed())
+					gomock.InOrder(
+						mockFS.EXPECT().CreateDir("some-pcfdev-home"),
+						mockFS.EXPECT().Write("some-user-config-path", gomock.Any(), false).Return(errors.New("some-error")),
+					)
+
+					Expect(configCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+		})
+
+		Context("when unsetting a value", func() {
+			It("should save the config file without the value", func() {
+				Expect(configCmd.Parse([]string{"unset", "cpus"})).To(Succeed())
+				gomock.InOrder(
+					mockFS.EXPECT().CreateDir("some-pcfdev-home"),
+					mockFS.EXPECT().Write("some-user-config-path", bytes.NewReader([]byte("services: all\n")), false),
+					mockUI.EXPECT().Say("Unset cpus."),
+				)
+
+				Expect(configCmd.Run()).To(Succeed())
+			})
+		})
+	})
+})
@@ -7,7 +7,7 @@ import (
 )
 
 type DebugCmd struct {
-	VBox      VBox
+	Provider  Provider
 	VMBuilder VMBuilder
 	Config    *config.Config
 }
@@ -27,7 +27,7 @@ func (d *DebugCmd) Run() error {
 }
 
 func (d *DebugCmd) getVM() (vm vm.VM, err error) {
-	name, err := d.VBox.GetVMName()
+	name, err := d.Provider.GetVMName()
 	if err != nil {
 		return nil, err
 	}
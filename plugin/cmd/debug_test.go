@@ -17,17 +17,17 @@ var _ = Describe("DebugCmd", func() {
 		debugCmd      *cmd.DebugCmd
 		mockCtrl      *gomock.Controller
 		mockVMBuilder *mocks.MockVMBuilder
-		mockVBox      *mocks.MockVBox
+		mockProvider  *mocks.MockProvider
 		mockVM        *vmMocks.MockVM
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockVM = vmMocks.NewMockVM(mockCtrl)
 		debugCmd = &cmd.DebugCmd{
-			VBox:      mockVBox,
+			Provider:  mockProvider,
 			VMBuilder: mockVMBuilder,
 			Config: &config.Config{
 				DefaultVMName: "some-default-vm-name",
@@ -61,7 +61,7 @@ var _ = Describe("DebugCmd", func() {
 		Context("when the default vm is present", func() {
 			It("should succeed", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().GetDebugLogs(),
 				)
@@ -73,7 +73,7 @@ var _ = Describe("DebugCmd", func() {
 		Context("when the custom vm is present", func() {
 			It("should return the status", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("pcfdev-custom", nil),
+					mockProvider.EXPECT().GetVMName().Return("pcfdev-custom", nil),
 					mockVMBuilder.EXPECT().VM("pcfdev-custom").Return(mockVM, nil),
 					mockVM.EXPECT().GetDebugLogs(),
 				)
@@ -85,7 +85,7 @@ var _ = Describe("DebugCmd", func() {
 		Context("when there is no vm present", func() {
 			It("should return the status of the default VM", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().GetDebugLogs(),
 				)
@@ -96,7 +96,7 @@ var _ = Describe("DebugCmd", func() {
 
 		Context("when there is an old vm present", func() {
 			It("should tell the user to destroy pcfdev", func() {
-				mockVBox.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+				mockProvider.EXPECT().GetVMName().Return("some-old-vm-name", nil)
 
 				Expect(debugCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
 			})
@@ -104,7 +104,7 @@ var _ = Describe("DebugCmd", func() {
 
 		Context("when there is an error getting the VM name", func() {
 			It("should return the error", func() {
-				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+				mockProvider.EXPECT().GetVMName().Return("", errors.New("some-error"))
 
 				Expect(debugCmd.Run()).To(MatchError("some-error"))
 			})
@@ -113,7 +113,7 @@ var _ = Describe("DebugCmd", func() {
 		Context("when it fails to get VM", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
 				)
 
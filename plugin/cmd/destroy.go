@@ -3,6 +3,7 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/cloudfoundry/cli/cf/flags"
@@ -12,10 +13,12 @@ import (
 const DESTROY_ARGS = 0
 
 type DestroyCmd struct {
-	VBox       VBox
+	Provider   Provider
 	UI         UI
 	FS         FS
 	UntrustCmd Cmd
+	HostDNS    HostDNS
+	Hosts      Hosts
 	Config     *config.Config
 }
 
@@ -30,14 +33,24 @@ func (d *DestroyCmd) Run() error {
 		errs = append(errs, fmt.Sprintf("error removing certificates from trust store: %s", err))
 	}
 
-	if err := d.VBox.DestroyPCFDevVMs(); err != nil {
+	if err := d.HostDNS.Uninstall(d.Config.Instance); err != nil {
+		errs = append(errs, fmt.Sprintf("error removing DNS configuration: %s", err))
+	}
+
+	if err := d.Hosts.Clean(d.Config.Instance); err != nil {
+		errs = append(errs, fmt.Sprintf("error removing hosts file entries: %s", err))
+	}
+
+	if err := d.Provider.DestroyPCFDevVMs(); err != nil {
 		errs = append(errs, fmt.Sprintf("error destroying PCF Dev VM: %s", err))
 	} else {
 		d.UI.Say("PCF Dev VM has been destroyed.")
 	}
 
-	if err := d.FS.Remove(d.Config.VMDir); err != nil {
-		errs = append(errs, fmt.Sprintf("error removing %s: %s", d.Config.VMDir, err))
+	for _, path := range d.instancePaths() {
+		if err := d.FS.Remove(path); err != nil {
+			errs = append(errs, fmt.Sprintf("error removing %s: %s", path, err))
+		}
 	}
 
 	if len(errs) > 0 {
@@ -46,3 +59,15 @@ func (d *DestroyCmd) Run() error {
 
 	return nil
 }
+
+// instancePaths are the files and VM folders of the selected instance only.
+// The VM dir is shared with every other instance, so it is never removed.
+func (d *DestroyCmd) instancePaths() []string {
+	return []string{
+		d.Config.PrivateKeyPath,
+		d.Config.VMConfigPath,
+		d.Config.ReleasePath,
+		filepath.Join(d.Config.VMDir, d.Config.InstanceVMName(d.Config.DefaultVMName)),
+		filepath.Join(d.Config.VMDir, d.Config.InstanceVMName("pcfdev-custom")),
+	}
+}
@@ -2,11 +2,15 @@ package cmd_test
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/fs"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
 )
@@ -15,27 +19,35 @@ var _ = Describe("DestroyCmd", func() {
 	var (
 		mockCtrl       *gomock.Controller
 		mockUI         *mocks.MockUI
-		mockVBox       *mocks.MockVBox
+		mockProvider   *mocks.MockProvider
 		mockFS         *mocks.MockFS
 		mockUntrustCmd *mocks.MockCmd
+		mockHostDNS    *mocks.MockHostDNS
+		mockHosts      *mocks.MockHosts
 		destroyCmd     *cmd.DestroyCmd
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockUI = mocks.NewMockUI(mockCtrl)
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockFS = mocks.NewMockFS(mockCtrl)
 		mockUntrustCmd = mocks.NewMockCmd(mockCtrl)
+		mockHostDNS = mocks.NewMockHostDNS(mockCtrl)
+		mockHosts = mocks.NewMockHosts(mockCtrl)
 		destroyCmd = &cmd.DestroyCmd{
 			UI:         mockUI,
-			VBox:       mockVBox,
+			Provider:   mockProvider,
 			FS:         mockFS,
 			UntrustCmd: mockUntrustCmd,
+			HostDNS:    mockHostDNS,
+			Hosts:      mockHosts,
 			Config: &config.Config{
-				VMDir: "some-vm-dir",
+				DefaultVMName: "some-vm-name",
+				VMDir:         "some-vm-dir",
 			},
 		}
+		Expect(destroyCmd.Config.SetInstance("")).To(Succeed())
 	})
 
 	AfterEach(func() {
@@ -61,61 +73,195 @@ var _ = Describe("DestroyCmd", func() {
 	})
 
 	Describe("Run", func() {
-		It("should destroy all PCF Dev VMs created by the CLI and the VM dir", func() {
+		It("should destroy all PCF Dev VMs created by the CLI and the files of the default instance", func() {
 			gomock.InOrder(
 				mockUntrustCmd.EXPECT().Run(),
-				mockVBox.EXPECT().DestroyPCFDevVMs(),
+				mockHostDNS.EXPECT().Uninstall(""),
+				mockHosts.EXPECT().Clean(""),
+				mockProvider.EXPECT().DestroyPCFDevVMs(),
 				mockUI.EXPECT().Say("PCF Dev VM has been destroyed."),
-				mockFS.EXPECT().Remove("some-vm-dir"),
+				mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "key.pem")),
+				mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "vm_config")),
+				mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "release")),
+				mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "some-vm-name")),
+				mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "pcfdev-custom")),
 			)
 
 			Expect(destroyCmd.Run()).To(Succeed())
 		})
 
 		Context("when there is an error destroying PCF Dev VMs", func() {
-			It("should remove the VM dir and return an errpr", func() {
+			It("should remove the instance files and return an error", func() {
 				gomock.InOrder(
 					mockUntrustCmd.EXPECT().Run(),
-					mockVBox.EXPECT().DestroyPCFDevVMs().Return(errors.New("some-error")),
-					mockFS.EXPECT().Remove("some-vm-dir"),
+					mockHostDNS.EXPECT().Uninstall(""),
+					mockHosts.EXPECT().Clean(""),
+					mockProvider.EXPECT().DestroyPCFDevVMs().Return(errors.New("some-error")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "key.pem")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "vm_config")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "release")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "some-vm-name")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "pcfdev-custom")),
 				)
 
 				Expect(destroyCmd.Run()).To(MatchError("error destroying PCF Dev VM: some-error"))
 			})
 		})
 
-		Context("when there is an error removing the VM dir", func() {
+		Context("when there is an error removing the instance files", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
 					mockUntrustCmd.EXPECT().Run(),
-					mockVBox.EXPECT().DestroyPCFDevVMs(),
+					mockHostDNS.EXPECT().Uninstall(""),
+					mockHosts.EXPECT().Clean(""),
+					mockProvider.EXPECT().DestroyPCFDevVMs(),
 					mockUI.EXPECT().Say("PCF Dev VM has been destroyed."),
-					mockFS.EXPECT().Remove("some-vm-dir").Return(errors.New("some-error")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "key.pem")).Return(errors.New("some-error")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "vm_config")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "release")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "some-vm-name")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "pcfdev-custom")),
 				)
 
-				Expect(destroyCmd.Run()).To(MatchError("error removing some-vm-dir: some-error"))
+				Expect(destroyCmd.Run()).To(MatchError("error removing " + filepath.Join("some-vm-dir", "key.pem") + ": some-error"))
 			})
 		})
 
-		Context("when there is an error destroying PCF Dev VMs and removing the VM dir", func() {
+		Context("when there is an error destroying PCF Dev VMs and removing the instance files", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
 					mockUntrustCmd.EXPECT().Run(),
-					mockVBox.EXPECT().DestroyPCFDevVMs().Return(errors.New("some-error")),
-					mockFS.EXPECT().Remove("some-vm-dir").Return(errors.New("some-error")),
+					mockHostDNS.EXPECT().Uninstall(""),
+					mockHosts.EXPECT().Clean(""),
+					mockProvider.EXPECT().DestroyPCFDevVMs().Return(errors.New("some-error")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "key.pem")).Return(errors.New("some-error")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "vm_config")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "release")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "some-vm-name")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "pcfdev-custom")),
 				)
 
-				Expect(destroyCmd.Run()).To(MatchError("error destroying PCF Dev VM: some-error\nerror removing some-vm-dir: some-error"))
+				Expect(destroyCmd.Run()).To(MatchError("error destroying PCF Dev VM: some-error\nerror removing " + filepath.Join("some-vm-dir", "key.pem") + ": some-error"))
+			})
+		})
+
+		Context("when an instance is selected", func() {
+			It("should only remove the files of that instance", func() {
+				Expect(destroyCmd.Config.SetInstance("some-instance")).To(Succeed())
+
+				gomock.InOrder(
+					mockUntrustCmd.EXPECT().Run(),
+					mockHostDNS.EXPECT().Uninstall("some-instance"),
+					mockHosts.EXPECT().Clean("some-instance"),
+					mockProvider.EXPECT().DestroyPCFDevVMs(),
+					mockUI.EXPECT().Say("PCF Dev VM has been destroyed."),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "key-some-instance.pem")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "vm_config-some-instance")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "release-some-instance")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "some-vm-name--some-instance")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "pcfdev-custom--some-instance")),
+				)
+
+				Expect(destroyCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when a named instance is present", func() {
+			var vmDir string
+
+			BeforeEach(func() {
+				var err error
+				vmDir, err = ioutil.TempDir("", "pcfdev-vms")
+				Expect(err).NotTo(HaveOccurred())
+
+				destroyCmd.FS = &fs.FS{}
+				destroyCmd.Config.VMDir = vmDir
+				Expect(destroyCmd.Config.SetInstance("")).To(Succeed())
+
+				for _, dir := range []string{"some-vm-name", "some-vm-name--some-instance"} {
+					Expect(os.MkdirAll(filepath.Join(vmDir, dir), 0755)).To(Succeed())
+				}
+				for _, file := range []string{"key.pem", "vm_config", "release", "key-some-instance.pem", "vm_config-some-instance", "release-some-instance"} {
+					Expect(ioutil.WriteFile(filepath.Join(vmDir, file), []byte("some-contents"), 0644)).To(Succeed())
+				}
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(vmDir)
+			})
+
+			It("should only remove the files of the default instance", func() {
+				gomock.InOrder(
+					mockUntrustCmd.EXPECT().Run(),
+					mockHostDNS.EXPECT().Uninstall(""),
+					mockHosts.EXPECT().Clean(""),
+					mockProvider.EXPECT().DestroyPCFDevVMs(),
+					mockUI.EXPECT().Say("PCF Dev VM has been destroyed."),
+				)
+
+				Expect(destroyCmd.Run()).To(Succeed())
+
+				files, err := ioutil.ReadDir(vmDir)
+				Expect(err).NotTo(HaveOccurred())
+				names := []string{}
+				for _, file := range files {
+					names = append(names, file.Name())
+				}
+				Expect(names).To(ConsistOf("key-some-instance.pem", "some-vm-name--some-instance", "vm_config-some-instance", "release-some-instance"))
+			})
+		})
+
+		Context("when there is an error removing the DNS configuration", func() {
+			It("should destroy the VM and return an error", func() {
+				gomock.InOrder(
+					mockUntrustCmd.EXPECT().Run(),
+					mockHostDNS.EXPECT().Uninstall("").Return(errors.New("some-error")),
+					mockHosts.EXPECT().Clean(""),
+					mockProvider.EXPECT().DestroyPCFDevVMs(),
+					mockUI.EXPECT().Say("PCF Dev VM has been destroyed."),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "key.pem")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "vm_config")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "release")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "some-vm-name")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "pcfdev-custom")),
+				)
+
+				Expect(destroyCmd.Run()).To(MatchError("error removing DNS configuration: some-error"))
+			})
+		})
+
+		Context("when there is an error removing the hosts file entries", func() {
+			It("should destroy the VM and return an error", func() {
+				gomock.InOrder(
+					mockUntrustCmd.EXPECT().Run(),
+					mockHostDNS.EXPECT().Uninstall(""),
+					mockHosts.EXPECT().Clean("").Return(errors.New("some-error")),
+					mockProvider.EXPECT().DestroyPCFDevVMs(),
+					mockUI.EXPECT().Say("PCF Dev VM has been destroyed."),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "key.pem")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "vm_config")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "release")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "some-vm-name")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "pcfdev-custom")),
+				)
+
+				Expect(destroyCmd.Run()).To(MatchError("error removing hosts file entries: some-error"))
 			})
 		})
 
 		Context("when there is an error deleting from the trust store", func() {
-			It("should remove the VM dir and keep going and return an error", func() {
+			It("should remove the instance files and keep going and return an error", func() {
 				gomock.InOrder(
 					mockUntrustCmd.EXPECT().Run().Return(errors.New("some-error")),
-					mockVBox.EXPECT().DestroyPCFDevVMs(),
+					mockHostDNS.EXPECT().Uninstall(""),
+					mockHosts.EXPECT().Clean(""),
+					mockProvider.EXPECT().DestroyPCFDevVMs(),
 					mockUI.EXPECT().Say("PCF Dev VM has been destroyed."),
-					mockFS.EXPECT().Remove("some-vm-dir"),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "key.pem")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "vm_config")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "release")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "some-vm-name")),
+					mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "pcfdev-custom")),
 				)
 
 				Expect(destroyCmd.Run()).To(MatchError("error removing certificates from trust store: some-error"))
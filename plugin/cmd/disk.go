@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+)
+
+type DiskCmd struct {
+	Provider  Provider
+	VMBuilder VMBuilder
+	Config    *config.Config
+
+	sizeInGB uint64
+}
+
+func (d *DiskCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	if err := flagContext.Parse(args...); err != nil {
+		return err
+	}
+
+	args = flagContext.Args()
+	if len(args) == 0 {
+		return errors.New("wrong number of arguments")
+	}
+	if args[0] != "resize" {
+		return fmt.Errorf("unknown disk command: %s", args[0])
+	}
+	if len(args) != 2 {
+		return errors.New("wrong number of arguments")
+	}
+
+	size, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil || size == 0 {
+		return fmt.Errorf("the disk size must be a positive number of GB: %s", args[1])
+	}
+
+	d.sizeInGB = size
+	return nil
+}
+
+func (d *DiskCmd) Run() error {
+	vm, err := d.getVM()
+	if err != nil {
+		return err
+	}
+	return vm.ResizeDisk(d.sizeInGB)
+}
+
+func (d *DiskCmd) getVM() (vm vm.VM, err error) {
+	name, err := d.Provider.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = d.Config.DefaultVMName
+	}
+	if name != d.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	return d.VMBuilder.VM(name)
+}
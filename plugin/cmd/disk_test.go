@@ -0,0 +1,119 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
+)
+
+var _ = Describe("DiskCmd", func() {
+	var (
+		diskCmd       *cmd.DiskCmd
+		mockCtrl      *gomock.Controller
+		mockProvider  *mocks.MockProvider
+		mockVM        *vmMocks.MockVM
+		mockVMBuilder *mocks.MockVMBuilder
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockProvider = mocks.NewMockProvider(mockCtrl)
+		mockVM = vmMocks.NewMockVM(mockCtrl)
+		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
+		diskCmd = &cmd.DiskCmd{
+			Provider:  mockProvider,
+			VMBuilder: mockVMBuilder,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		It("should accept a size in GB", func() {
+			Expect(diskCmd.Parse([]string{"resize", "100"})).To(Succeed())
+		})
+
+		Context("when no subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(diskCmd.Parse([]string{})).To(MatchError("wrong number of arguments"))
+			})
+		})
+
+		Context("when an unknown subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(diskCmd.Parse([]string{"some-subcommand"})).To(MatchError("unknown disk command: some-subcommand"))
+			})
+		})
+
+		Context("when no size is passed", func() {
+			It("should fail", func() {
+				Expect(diskCmd.Parse([]string{"resize"})).To(MatchError("wrong number of arguments"))
+			})
+		})
+
+		Context("when the size is not a positive number", func() {
+			It("should fail", func() {
+				Expect(diskCmd.Parse([]string{"resize", "0"})).To(MatchError("the disk size must be a positive number of GB: 0"))
+				Expect(diskCmd.Parse([]string{"resize", "100GB"})).To(MatchError("the disk size must be a positive number of GB: 100GB"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		BeforeEach(func() {
+			Expect(diskCmd.Parse([]string{"resize", "100"})).To(Succeed())
+		})
+
+		It("should resize the VM disk", func() {
+			gomock.InOrder(
+				mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+				mockVM.EXPECT().ResizeDisk(uint64(100)),
+			)
+
+			Expect(diskCmd.Run()).To(Succeed())
+		})
+
+		Context("when there is an old vm present", func() {
+			It("should tell the user to destroy pcfdev", func() {
+				mockProvider.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(diskCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
+			})
+		})
+
+		Context("when it fails to get VM", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(diskCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when resizing the disk fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("pcfdev-custom", nil),
+					mockVMBuilder.EXPECT().VM("pcfdev-custom").Return(mockVM, nil),
+					mockVM.EXPECT().ResizeDisk(uint64(100)).Return(errors.New("some-error")),
+				)
+
+				Expect(diskCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/address"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/provider"
+)
+
+const DEFAULT_DNS_PORT = 10053
+
+//go:generate mockgen -package mocks -destination mocks/dns_server.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd DNSServer
+type DNSServer interface {
+	ListenAndServe(address string, domain string, ip string) error
+}
+
+//go:generate mockgen -package mocks -destination mocks/host_dns.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd HostDNS
+type HostDNS interface {
+	Install(instance string, domain string, address string) error
+	Uninstall(instance string) error
+}
+
+type DNSCmd struct {
+	Provider  Provider
+	DNSServer DNSServer
+	HostDNS   HostDNS
+	UI        UI
+	Config    *config.Config
+
+	port int
+}
+
+func (d *DNSCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	flagContext.NewIntFlagWithDefault("port", "p", "<port>", DEFAULT_DNS_PORT)
+	if err := flagContext.Parse(args...); err != nil {
+		return err
+	}
+
+	args = flagContext.Args()
+	if len(args) != 1 {
+		return errors.New("wrong number of arguments")
+	}
+	if args[0] != "start" {
+		return fmt.Errorf("unknown dns command: %s", args[0])
+	}
+
+	d.port = flagContext.Int("port")
+	if d.port <= 0 || d.port > 65535 {
+		return fmt.Errorf("%d is not a valid port", d.port)
+	}
+	return nil
+}
+
+// Run answers queries for the VM domain on the host address of the VM
+// network until it is interrupted, and removes the host resolver
+// configuration again before returning.
+func (d *DNSCmd) Run() error {
+	vmConfig, err := d.getVMConfig()
+	if err != nil {
+		return err
+	}
+
+	host, err := address.SubnetForIP(vmConfig.HostIP())
+	if err != nil {
+		return err
+	}
+	listenAddress := net.JoinHostPort(host, strconv.Itoa(d.port))
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(interrupts)
+
+	installed := true
+	if err := d.HostDNS.Install(d.Config.Instance, vmConfig.Domain, listenAddress); err != nil {
+		d.UI.Say(fmt.Sprintf("Warning: %s.", err))
+		installed = false
+	}
+
+	d.UI.Say(fmt.Sprintf("Resolving *.%s to %s on %s, press Ctrl-C to stop...", vmConfig.Domain, vmConfig.HostIP(), listenAddress))
+	served := make(chan error, 1)
+	go func() {
+		served <- d.DNSServer.ListenAndServe(listenAddress, vmConfig.Domain, vmConfig.HostIP())
+	}()
+
+	select {
+	case err = <-served:
+	case <-interrupts:
+		err = nil
+	}
+
+	if installed {
+		if err := d.HostDNS.Uninstall(d.Config.Instance); err != nil {
+			d.UI.Say(fmt.Sprintf("Warning: %s.", err))
+		}
+	}
+	return err
+}
+
+func (d *DNSCmd) getVMConfig() (*config.VMConfig, error) {
+	name, err := d.Provider.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = d.Config.DefaultVMName
+	}
+	if name != d.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	status, err := d.Provider.VMStatus(name)
+	if err != nil {
+		return nil, err
+	}
+	if status == provider.StatusNotCreated {
+		return nil, &DNSNoVMError{}
+	}
+
+	return d.Provider.VMConfig(name)
+}
@@ -0,0 +1,206 @@
+package cmd_test
+
+import (
+	"errors"
+	"os"
+	"syscall"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+)
+
+var _ = Describe("DNSCmd", func() {
+	var (
+		dnsCmd        *cmd.DNSCmd
+		mockCtrl      *gomock.Controller
+		mockProvider  *mocks.MockProvider
+		mockDNSServer *mocks.MockDNSServer
+		mockHostDNS   *mocks.MockHostDNS
+		mockUI        *mocks.MockUI
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockProvider = mocks.NewMockProvider(mockCtrl)
+		mockDNSServer = mocks.NewMockDNSServer(mockCtrl)
+		mockHostDNS = mocks.NewMockHostDNS(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		dnsCmd = &cmd.DNSCmd{
+			Provider:  mockProvider,
+			DNSServer: mockDNSServer,
+			HostDNS:   mockHostDNS,
+			UI:        mockUI,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+				Instance:      "some-instance",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		It("should accept the start subcommand and a port", func() {
+			Expect(dnsCmd.Parse([]string{"start"})).To(Succeed())
+			Expect(dnsCmd.Parse([]string{"start", "--port", "10054"})).To(Succeed())
+		})
+
+		Context("when no subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(dnsCmd.Parse([]string{})).To(MatchError("wrong number of arguments"))
+			})
+		})
+
+		Context("when an unknown subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(dnsCmd.Parse([]string{"some-subcommand"})).To(MatchError("unknown dns command: some-subcommand"))
+			})
+		})
+
+		Context("when an invalid port is passed", func() {
+			It("should fail", func() {
+				Expect(dnsCmd.Parse([]string{"start", "--port", "0"})).To(MatchError("0 is not a valid port"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		BeforeEach(func() {
+			Expect(dnsCmd.Parse([]string{"start"})).To(Succeed())
+		})
+
+		It("should configure the host, answer queries for the VM domain and remove the configuration again", func() {
+			gomock.InOrder(
+				mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockProvider.EXPECT().VMStatus("some-default-vm-name").Return("Running", nil),
+				mockProvider.EXPECT().VMConfig("some-default-vm-name").Return(&config.VMConfig{
+					Domain: "pcfdev.example.com",
+					IP:     "10.20.30.11",
+				}, nil),
+				mockHostDNS.EXPECT().Install("some-instance", "pcfdev.example.com", "10.20.30.1:10053"),
+				mockUI.EXPECT().Say("Resolving *.pcfdev.example.com to 10.20.30.11 on 10.20.30.1:10053, press Ctrl-C to stop..."),
+				mockDNSServer.EXPECT().ListenAndServe("10.20.30.1:10053", "pcfdev.example.com", "10.20.30.11").Return(errors.New("some-error")),
+				mockHostDNS.EXPECT().Uninstall("some-instance"),
+			)
+
+			Expect(dnsCmd.Run()).To(MatchError("some-error"))
+		})
+
+		Context("when the command is stopped by a signal", func() {
+			var stop chan struct{}
+
+			BeforeEach(func() {
+				stop = make(chan struct{})
+			})
+
+			AfterEach(func() {
+				close(stop)
+			})
+
+			It("should remove the host configuration and return", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().VMStatus("some-default-vm-name").Return("Running", nil),
+					mockProvider.EXPECT().VMConfig("some-default-vm-name").Return(&config.VMConfig{
+						Domain: "pcfdev.example.com",
+						IP:     "10.20.30.11",
+					}, nil),
+					mockHostDNS.EXPECT().Install("some-instance", "pcfdev.example.com", "10.20.30.1:10053"),
+					mockUI.EXPECT().Say("Resolving *.pcfdev.example.com to 10.20.30.11 on 10.20.30.1:10053, press Ctrl-C to stop..."),
+					mockDNSServer.EXPECT().ListenAndServe("10.20.30.1:10053", "pcfdev.example.com", "10.20.30.11").Do(func(string, string, string) {
+						process, _ := os.FindProcess(os.Getpid())
+						process.Signal(syscall.SIGHUP)
+						<-stop
+					}),
+					mockHostDNS.EXPECT().Uninstall("some-instance"),
+				)
+
+				Expect(dnsCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when the host configuration cannot be removed", func() {
+			It("should warn", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().VMStatus("some-default-vm-name").Return("Running", nil),
+					mockProvider.EXPECT().VMConfig("some-default-vm-name").Return(&config.VMConfig{
+						Domain: "pcfdev.example.com",
+						IP:     "10.20.30.11",
+					}, nil),
+					mockHostDNS.EXPECT().Install("some-instance", "pcfdev.example.com", "10.20.30.1:10053"),
+					mockUI.EXPECT().Say("Resolving *.pcfdev.example.com to 10.20.30.11 on 10.20.30.1:10053, press Ctrl-C to stop..."),
+					mockDNSServer.EXPECT().ListenAndServe("10.20.30.1:10053", "pcfdev.example.com", "10.20.30.11").Return(errors.New("some-error")),
+					mockHostDNS.EXPECT().Uninstall("some-instance").Return(errors.New("some-other-error")),
+					mockUI.EXPECT().Say("Warning: some-other-error."),
+				)
+
+				Expect(dnsCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when the VM runs on QEMU", func() {
+			It("should resolve the VM domain to the forwarded ports on 127.0.0.1", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().VMStatus("some-default-vm-name").Return("Running", nil),
+					mockProvider.EXPECT().VMConfig("some-default-vm-name").Return(&config.VMConfig{
+						Domain:   "local.pcfdev.test",
+						IP:       "10.0.2.15",
+						Provider: "qemu",
+					}, nil),
+					mockHostDNS.EXPECT().Install("some-instance", "local.pcfdev.test", "127.0.0.1:10053"),
+					mockUI.EXPECT().Say("Resolving *.local.pcfdev.test to 127.0.0.1 on 127.0.0.1:10053, press Ctrl-C to stop..."),
+					mockDNSServer.EXPECT().ListenAndServe("127.0.0.1:10053", "local.pcfdev.test", "127.0.0.1").Return(errors.New("some-error")),
+					mockHostDNS.EXPECT().Uninstall("some-instance"),
+				)
+
+				Expect(dnsCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when the host cannot be configured", func() {
+			It("should warn and answer queries anyway", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().VMStatus("some-default-vm-name").Return("Stopped", nil),
+					mockProvider.EXPECT().VMConfig("some-default-vm-name").Return(&config.VMConfig{
+						Domain: "pcfdev.example.com",
+						IP:     "10.20.30.11",
+					}, nil),
+					mockHostDNS.EXPECT().Install("some-instance", "pcfdev.example.com", "10.20.30.1:10053").Return(errors.New("some-error")),
+					mockUI.EXPECT().Say("Warning: some-error."),
+					mockUI.EXPECT().Say("Resolving *.pcfdev.example.com to 10.20.30.11 on 10.20.30.1:10053, press Ctrl-C to stop..."),
+					mockDNSServer.EXPECT().ListenAndServe("10.20.30.1:10053", "pcfdev.example.com", "10.20.30.11").Return(errors.New("some-error")),
+				)
+
+				Expect(dnsCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when the VM is not created", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().VMStatus("some-default-vm-name").Return("Not created", nil),
+				)
+
+				Expect(dnsCmd.Run()).To(MatchError("no VM created, cannot start the DNS resolver"))
+			})
+		})
+
+		Context("when an old VM exists", func() {
+			It("should return an error", func() {
+				mockProvider.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(dnsCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
+			})
+		})
+	})
+})
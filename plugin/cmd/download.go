@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/cloudfoundry/cli/cf/flags"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/pivnet"
 )
 
 const DOWNLOAD_ARGS = 0
@@ -12,12 +15,16 @@ type Client interface {
 	AcceptEULA() error
 	IsEULAAccepted() (bool, error)
 	GetEULA() (eula string, err error)
+	Releases() (releases []*pivnet.Release, err error)
+	Release(version string) (release *pivnet.Release, err error)
+	SelectRelease(release *pivnet.Release)
 }
 
 //go:generate mockgen -package mocks -destination mocks/eula_ui.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd EULAUI
 type EULAUI interface {
 	ConfirmText(string) bool
 	Init() error
+	Interactive() bool
 	Close() error
 }
 
@@ -27,21 +34,35 @@ type Downloader interface {
 }
 
 type DownloadCmd struct {
-	VBox              VBox
+	Provider          Provider
 	UI                UI
 	EULAUI            EULAUI
 	Client            Client
 	DownloaderFactory DownloaderFactory
 	FS                FS
 	Config            *config.Config
+	release           string
+	prune             bool
+	acceptEULA        bool
 }
 
 func (d *DownloadCmd) Parse(args []string) error {
-	return parse(flags.New(), args, DOWNLOAD_ARGS)
+	flagContext := flags.New()
+	flagContext.NewStringFlag("release", "", "<release version>")
+	flagContext.NewBoolFlag("prune", "", "<prune other cached OVAs>")
+	flagContext.NewBoolFlag("accept-eula", "", "<accept the EULA>")
+	if err := parse(flagContext, args, DOWNLOAD_ARGS); err != nil {
+		return err
+	}
+
+	d.release = flagContext.String("release")
+	d.prune = flagContext.Bool("prune")
+	d.acceptEULA = flagContext.Bool("accept-eula")
+	return nil
 }
 
 func (d *DownloadCmd) Run() error {
-	existingVMName, err := d.VBox.GetVMName()
+	existingVMName, err := d.Provider.GetVMName()
 	if err != nil {
 		return err
 	}
@@ -49,6 +70,16 @@ func (d *DownloadCmd) Run() error {
 		return &OldVMError{}
 	}
 
+	if d.release != "" {
+		if err := d.selectRelease(d.release); err != nil {
+			return err
+		}
+		if existingVMName != "" && existingVMName != d.Config.DefaultVMName {
+			return &ReleaseVMExistsError{Release: d.Config.ReleaseVersion}
+		}
+	}
+
+	d.Config.PruneOVACache = d.prune
 	downloader, err := d.DownloaderFactory.Create()
 	if err != nil {
 		return err
@@ -60,20 +91,30 @@ func (d *DownloadCmd) Run() error {
 	}
 	if current {
 		d.UI.Say("Using existing image.")
-		return nil
+		return d.saveRelease()
 	}
 
-	accepted, err := d.Client.IsEULAAccepted()
-	if err != nil {
-		return err
+	if d.release == "" && d.Config.ReleaseVersion != "" {
+		if err := d.selectRelease(d.Config.ReleaseVersion); err != nil {
+			return err
+		}
 	}
 
-	if !accepted {
-		if err := d.confirmEULA(); err != nil {
+	if d.Config.UsesPivNet() {
+		accepted, err := d.Client.IsEULAAccepted()
+		if err != nil {
 			return err
 		}
-		if err := d.Client.AcceptEULA(); err != nil {
-			return err
+
+		if !accepted {
+			if d.acceptEULA || d.Config.AcceptEULA {
+				d.UI.Say("Accepting the PCF Dev EULA.")
+			} else if err := d.confirmEULA(); err != nil {
+				return err
+			}
+			if err := d.Client.AcceptEULA(); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -84,6 +125,44 @@ func (d *DownloadCmd) Run() error {
 	}
 
 	d.UI.Say("\nVM downloaded.")
+	return d.saveRelease()
+}
+
+// saveRelease saves the release passed with --release, so that every command
+// of the instance uses it until its VM is destroyed.
+func (d *DownloadCmd) saveRelease() error {
+	if d.release == "" {
+		return nil
+	}
+
+	if err := d.FS.CreateDir(d.Config.VMDir); err != nil {
+		return err
+	}
+	if err := config.SaveRelease(d.FS, d.Config.ReleasePath, &config.Release{
+		Version: d.Config.ReleaseVersion,
+		MD5:     d.Config.ExpectedMD5,
+		SHA256:  d.Config.ExpectedSHA256,
+	}); err != nil {
+		return err
+	}
+	d.UI.Say(fmt.Sprintf("PCF Dev %s is selected, start it with: cf dev start", d.Config.ReleaseVersion))
+	return nil
+}
+
+// selectRelease selects version on Pivotal Network, and uses its OVA and
+// digests.
+func (d *DownloadCmd) selectRelease(version string) error {
+	if !d.Config.UsesPivNet() {
+		return &ReleasesFromMirrorError{}
+	}
+
+	release, err := d.Client.Release(version)
+	if err != nil {
+		return err
+	}
+
+	d.Client.SelectRelease(release)
+	d.Config.SelectRelease(release.Version, release.ProductFile.MD5, release.ProductFile.SHA256)
 	return nil
 }
 
@@ -93,6 +172,14 @@ func (d *DownloadCmd) confirmEULA() error {
 		return err
 	}
 
+	if !d.EULAUI.Interactive() {
+		d.UI.Say(eula)
+		if !d.UI.Confirm("Accept the PCF Dev EULA? (y/N)") {
+			return &EULARefusedError{}
+		}
+		return nil
+	}
+
 	if err := d.EULAUI.Init(); err != nil {
 		return err
 	}
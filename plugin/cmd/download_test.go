@@ -2,11 +2,14 @@ package cmd_test
 
 import (
 	"errors"
+	"path/filepath"
+	"strings"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/pivnet"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
 )
@@ -16,7 +19,7 @@ var _ = Describe("DownloadCmd", func() {
 		mockCtrl              *gomock.Controller
 		mockUI                *mocks.MockUI
 		mockEULAUI            *mocks.MockEULAUI
-		mockVBox              *mocks.MockVBox
+		mockProvider          *mocks.MockProvider
 		mockFS                *mocks.MockFS
 		mockDownloader        *mocks.MockDownloader
 		mockDownloaderFactory *mocks.MockDownloaderFactory
@@ -29,7 +32,7 @@ var _ = Describe("DownloadCmd", func() {
 		mockUI = mocks.NewMockUI(mockCtrl)
 		mockEULAUI = mocks.NewMockEULAUI(mockCtrl)
 		mockClient = mocks.NewMockClient(mockCtrl)
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockDownloader = mocks.NewMockDownloader(mockCtrl)
 		mockDownloaderFactory = mocks.NewMockDownloaderFactory(mockCtrl)
 		mockFS = mocks.NewMockFS(mockCtrl)
@@ -37,8 +40,9 @@ var _ = Describe("DownloadCmd", func() {
 			UI:                mockUI,
 			EULAUI:            mockEULAUI,
 			Client:            mockClient,
-			VBox:              mockVBox,
+			Provider:          mockProvider,
 			DownloaderFactory: mockDownloaderFactory,
+			FS:                mockFS,
 			Config: &config.Config{
 				DefaultVMName: "some-vm-name",
 			},
@@ -68,13 +72,25 @@ var _ = Describe("DownloadCmd", func() {
 				Expect(downloadCommand.Parse([]string{"--some-bad-flag"})).NotTo(Succeed())
 			})
 		})
+		Context("when a release is passed", func() {
+			It("should succeed", func() {
+				downloadCommand := &cmd.DownloadCmd{}
+				Expect(downloadCommand.Parse([]string{"--release", "0.21.0"})).To(Succeed())
+			})
+		})
+		Context("when --prune is passed", func() {
+			It("should succeed", func() {
+				downloadCommand := &cmd.DownloadCmd{}
+				Expect(downloadCommand.Parse([]string{"--prune"})).To(Succeed())
+			})
+		})
 	})
 
 	Describe("Run", func() {
 		Context("when OVA is current", func() {
 			It("should not download", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
 					mockDownloader.EXPECT().IsOVACurrent().Return(true, nil),
 					mockUI.EXPECT().Say("Using existing image."),
@@ -86,7 +102,7 @@ var _ = Describe("DownloadCmd", func() {
 
 		Context("when there is an old vm present", func() {
 			It("should tell the user to destroy downloadCmd", func() {
-				mockVBox.EXPECT().GetVMName().Return("some-old-downloadCmd-ova", nil)
+				mockProvider.EXPECT().GetVMName().Return("some-old-downloadCmd-ova", nil)
 
 				Expect(downloadCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
 			})
@@ -94,7 +110,7 @@ var _ = Describe("DownloadCmd", func() {
 
 		Context("when there is an error checking for an old vm present", func() {
 			It("should return the error", func() {
-				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+				mockProvider.EXPECT().GetVMName().Return("", errors.New("some-error"))
 
 				Expect(downloadCmd.Run()).To(MatchError("some-error"))
 			})
@@ -103,7 +119,7 @@ var _ = Describe("DownloadCmd", func() {
 		Context("when creating a downloader fails", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockDownloaderFactory.EXPECT().Create().Return(nil, errors.New("some-error")),
 				)
 
@@ -114,7 +130,7 @@ var _ = Describe("DownloadCmd", func() {
 		Context("when calling IsOVACurrent fails", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
 					mockDownloader.EXPECT().IsOVACurrent().Return(false, errors.New("some-error")),
 				)
@@ -126,7 +142,7 @@ var _ = Describe("DownloadCmd", func() {
 		Context("when OVA is not current", func() {
 			It("should download the OVA", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
 					mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
 					mockClient.EXPECT().IsEULAAccepted().Return(true, nil),
@@ -138,10 +154,47 @@ var _ = Describe("DownloadCmd", func() {
 				downloadCmd.Run()
 			})
 
+			Context("when asked to prune the other cached OVAs", func() {
+				It("should prune them while downloading", func() {
+					Expect(downloadCmd.Parse([]string{"--prune"})).To(Succeed())
+
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("", nil),
+						mockDownloaderFactory.EXPECT().Create().Do(func() {
+							Expect(downloadCmd.Config.PruneOVACache).To(BeTrue())
+						}).Return(mockDownloader, nil),
+						mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
+						mockClient.EXPECT().IsEULAAccepted().Return(true, nil),
+						mockUI.EXPECT().Say("Downloading VM..."),
+						mockDownloader.EXPECT().Download(),
+						mockUI.EXPECT().Say("\nVM downloaded."),
+					)
+
+					Expect(downloadCmd.Run()).To(Succeed())
+				})
+			})
+
+			Context("when the OVA is downloaded from a mirror", func() {
+				It("should download the OVA without checking the EULA on Pivotal Network", func() {
+					downloadCmd.Config.OVASource = "https://some-mirror/pcfdev/"
+
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("", nil),
+						mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
+						mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
+						mockUI.EXPECT().Say("Downloading VM..."),
+						mockDownloader.EXPECT().Download(),
+						mockUI.EXPECT().Say("\nVM downloaded."),
+					)
+
+					Expect(downloadCmd.Run()).To(Succeed())
+				})
+			})
+
 			Context("when EULA check fails", func() {
 				It("should print an error", func() {
 					gomock.InOrder(
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
 						mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
 						mockClient.EXPECT().IsEULAAccepted().Return(false, errors.New("some-error")),
@@ -155,7 +208,7 @@ var _ = Describe("DownloadCmd", func() {
 			Context("when downloading the OVA fails", func() {
 				It("should print an error", func() {
 					gomock.InOrder(
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
 						mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
 						mockClient.EXPECT().IsEULAAccepted().Return(true, nil),
@@ -170,11 +223,12 @@ var _ = Describe("DownloadCmd", func() {
 			Context("when EULA has not been accepted and user accepts the EULA", func() {
 				It("should download the ova", func() {
 					gomock.InOrder(
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
 						mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
 						mockClient.EXPECT().IsEULAAccepted().Return(false, nil),
 						mockClient.EXPECT().GetEULA().Return("some-eula", nil),
+						mockEULAUI.EXPECT().Interactive().Return(true),
 						mockEULAUI.EXPECT().Init(),
 						mockEULAUI.EXPECT().ConfirmText("some-eula").Return(true),
 						mockEULAUI.EXPECT().Close(),
@@ -191,11 +245,12 @@ var _ = Describe("DownloadCmd", func() {
 			Context("when EULA has not been accepted and user denies the EULA", func() {
 				It("should not accept and fail gracefully", func() {
 					gomock.InOrder(
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
 						mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
 						mockClient.EXPECT().IsEULAAccepted().Return(false, nil),
 						mockClient.EXPECT().GetEULA().Return("some-eula", nil),
+						mockEULAUI.EXPECT().Interactive().Return(true),
 						mockEULAUI.EXPECT().Init(),
 						mockEULAUI.EXPECT().ConfirmText("some-eula").Return(false),
 						mockEULAUI.EXPECT().Close(),
@@ -208,11 +263,12 @@ var _ = Describe("DownloadCmd", func() {
 			Context("when EULA has not been accepted and it fails to accept the EULA", func() {
 				It("should return the error", func() {
 					gomock.InOrder(
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
 						mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
 						mockClient.EXPECT().IsEULAAccepted().Return(false, nil),
 						mockClient.EXPECT().GetEULA().Return("some-eula", nil),
+						mockEULAUI.EXPECT().Interactive().Return(true),
 						mockEULAUI.EXPECT().Init(),
 						mockEULAUI.EXPECT().ConfirmText("some-eula").Return(true),
 						mockEULAUI.EXPECT().Close(),
@@ -226,11 +282,12 @@ var _ = Describe("DownloadCmd", func() {
 			Context("when EULA fails to close after not being accepted", func() {
 				It("should return the error", func() {
 					gomock.InOrder(
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
 						mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
 						mockClient.EXPECT().IsEULAAccepted().Return(false, nil),
 						mockClient.EXPECT().GetEULA().Return("some-eula", nil),
+						mockEULAUI.EXPECT().Interactive().Return(true),
 						mockEULAUI.EXPECT().Init(),
 						mockEULAUI.EXPECT().ConfirmText("some-eula").Return(false),
 						mockEULAUI.EXPECT().Close().Return(errors.New("some-error")),
@@ -243,11 +300,12 @@ var _ = Describe("DownloadCmd", func() {
 			Context("when EULA fails to close after being accepted", func() {
 				It("should return the error", func() {
 					gomock.InOrder(
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
 						mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
 						mockClient.EXPECT().IsEULAAccepted().Return(false, nil),
 						mockClient.EXPECT().GetEULA().Return("some-eula", nil),
+						mockEULAUI.EXPECT().Interactive().Return(true),
 						mockEULAUI.EXPECT().Init(),
 						mockEULAUI.EXPECT().ConfirmText("some-eula").Return(true),
 						mockEULAUI.EXPECT().Close().Return(errors.New("some-error")),
@@ -257,10 +315,88 @@ var _ = Describe("DownloadCmd", func() {
 				})
 			})
 
+			Context("when EULA has not been accepted and stdin is not a terminal", func() {
+				It("should print the EULA and ask for confirmation in plain text", func() {
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("", nil),
+						mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
+						mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
+						mockClient.EXPECT().IsEULAAccepted().Return(false, nil),
+						mockClient.EXPECT().GetEULA().Return("some-eula", nil),
+						mockEULAUI.EXPECT().Interactive().Return(false),
+						mockUI.EXPECT().Say("some-eula"),
+						mockUI.EXPECT().Confirm("Accept the PCF Dev EULA? (y/N)").Return(true),
+						mockClient.EXPECT().AcceptEULA(),
+						mockUI.EXPECT().Say("Downloading VM..."),
+						mockDownloader.EXPECT().Download(),
+						mockUI.EXPECT().Say("\nVM downloaded."),
+					)
+
+					Expect(downloadCmd.Run()).To(Succeed())
+				})
+
+				Context("when the user denies the EULA", func() {
+					It("should return an error", func() {
+						gomock.InOrder(
+							mockProvider.EXPECT().GetVMName().Return("", nil),
+							mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
+							mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
+							mockClient.EXPECT().IsEULAAccepted().Return(false, nil),
+							mockClient.EXPECT().GetEULA().Return("some-eula", nil),
+							mockEULAUI.EXPECT().Interactive().Return(false),
+							mockUI.EXPECT().Say("some-eula"),
+							mockUI.EXPECT().Confirm("Accept the PCF Dev EULA? (y/N)").Return(false),
+						)
+
+						Expect(downloadCmd.Run()).To(MatchError("you must accept the end user license agreement to use PCF Dev"))
+					})
+				})
+			})
+
+			Context("when EULA has not been accepted and --accept-eula is passed", func() {
+				It("should accept the EULA without prompting", func() {
+					Expect(downloadCmd.Parse([]string{"--accept-eula"})).To(Succeed())
+
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("", nil),
+						mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
+						mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
+						mockClient.EXPECT().IsEULAAccepted().Return(false, nil),
+						mockUI.EXPECT().Say("Accepting the PCF Dev EULA."),
+						mockClient.EXPECT().AcceptEULA(),
+						mockUI.EXPECT().Say("Downloading VM..."),
+						mockDownloader.EXPECT().Download(),
+						mockUI.EXPECT().Say("\nVM downloaded."),
+					)
+
+					Expect(downloadCmd.Run()).To(Succeed())
+				})
+			})
+
+			Context("when EULA has not been accepted and PCFDEV_ACCEPT_EULA is set", func() {
+				It("should accept the EULA without prompting", func() {
+					downloadCmd.Config.AcceptEULA = true
+
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("", nil),
+						mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
+						mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
+						mockClient.EXPECT().IsEULAAccepted().Return(false, nil),
+						mockUI.EXPECT().Say("Accepting the PCF Dev EULA."),
+						mockClient.EXPECT().AcceptEULA(),
+						mockUI.EXPECT().Say("Downloading VM..."),
+						mockDownloader.EXPECT().Download(),
+						mockUI.EXPECT().Say("\nVM downloaded."),
+					)
+
+					Expect(downloadCmd.Run()).To(Succeed())
+				})
+			})
+
 			Context("when EULA is not accepted and getting the EULA fails", func() {
 				It("should print an error", func() {
 					gomock.InOrder(
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
 						mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
 						mockClient.EXPECT().IsEULAAccepted().Return(false, nil),
@@ -271,5 +407,153 @@ var _ = Describe("DownloadCmd", func() {
 				})
 			})
 		})
+
+		Context("when a release is passed", func() {
+			var release *pivnet.Release
+
+			BeforeEach(func() {
+				Expect(downloadCmd.Parse([]string{"--release", "0.21.0"})).To(Succeed())
+				downloadCmd.Config.OVADir = "some-ova-dir"
+				downloadCmd.Config.VMDir = "some-vm-dir"
+				downloadCmd.Config.ReleasePath = filepath.Join("some-vm-dir", "release")
+				release = &pivnet.Release{
+					ID:          "some-release-id",
+					Version:     "v0.21.0",
+					ProductFile: &pivnet.ProductFile{ID: "some-product-file-id", MD5: "some-md5", SHA256: "some-sha256"},
+				}
+			})
+
+			It("should download the OVA of that release", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("", nil),
+					mockClient.EXPECT().Release("0.21.0").Return(release, nil),
+					mockClient.EXPECT().SelectRelease(release),
+					mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
+					mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
+					mockClient.EXPECT().IsEULAAccepted().Return(true, nil),
+					mockUI.EXPECT().Say("Downloading VM..."),
+					mockDownloader.EXPECT().Download(),
+					mockUI.EXPECT().Say("\nVM downloaded."),
+					mockFS.EXPECT().CreateDir("some-vm-dir"),
+					mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "release"), strings.NewReader(`{"version":"0.21.0","md5":"some-md5","sha256":"some-sha256"}`), false),
+					mockUI.EXPECT().Say("PCF Dev 0.21.0 is selected, start it with: cf dev start"),
+				)
+
+				Expect(downloadCmd.Run()).To(Succeed())
+				Expect(downloadCmd.Config.DefaultVMName).To(Equal("pcfdev-v0.21.0"))
+				Expect(downloadCmd.Config.ExpectedMD5).To(Equal("some-md5"))
+				Expect(downloadCmd.Config.ExpectedSHA256).To(Equal("some-sha256"))
+			})
+
+			Context("when the OVA of that release is current", func() {
+				It("should select the release without downloading", func() {
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("", nil),
+						mockClient.EXPECT().Release("0.21.0").Return(release, nil),
+						mockClient.EXPECT().SelectRelease(release),
+						mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
+						mockDownloader.EXPECT().IsOVACurrent().Return(true, nil),
+						mockUI.EXPECT().Say("Using existing image."),
+						mockFS.EXPECT().CreateDir("some-vm-dir"),
+						mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "release"), strings.NewReader(`{"version":"0.21.0","md5":"some-md5","sha256":"some-sha256"}`), false),
+						mockUI.EXPECT().Say("PCF Dev 0.21.0 is selected, start it with: cf dev start"),
+					)
+
+					Expect(downloadCmd.Run()).To(Succeed())
+				})
+			})
+
+			Context("when a VM of another release exists", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("some-vm-name", nil),
+						mockClient.EXPECT().Release("0.21.0").Return(release, nil),
+						mockClient.EXPECT().SelectRelease(release),
+					)
+
+					Expect(downloadCmd.Run()).To(MatchError("a PCF Dev VM of another release already exists, run `cf dev destroy` before selecting PCF Dev 0.21.0"))
+				})
+			})
+
+			Context("when saving the release fails", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("", nil),
+						mockClient.EXPECT().Release("0.21.0").Return(release, nil),
+						mockClient.EXPECT().SelectRelease(release),
+						mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
+						mockDownloader.EXPECT().IsOVACurrent().Return(true, nil),
+						mockUI.EXPECT().Say("Using existing image."),
+						mockFS.EXPECT().CreateDir("some-vm-dir"),
+						mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "release"), strings.NewReader(`{"version":"0.21.0","md5":"some-md5","sha256":"some-sha256"}`), false).Return(errors.New("some-error")),
+					)
+
+					Expect(downloadCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+
+			Context("when the release is not found", func() {
+				It("should return the error", func() {
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("", nil),
+						mockClient.EXPECT().Release("0.21.0").Return(nil, errors.New("some-error")),
+					)
+
+					Expect(downloadCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+
+			Context("when the OVA is downloaded from a mirror", func() {
+				It("should return an error", func() {
+					downloadCmd.Config.OVASource = "https://some-mirror/pcfdev/"
+					mockProvider.EXPECT().GetVMName().Return("", nil)
+
+					Expect(downloadCmd.Run()).To(MatchError("releases are only available from Pivotal Network, unset PCFDEV_OVA_SOURCE to continue"))
+				})
+			})
+		})
+
+		Context("when a release was selected", func() {
+			var release *pivnet.Release
+
+			BeforeEach(func() {
+				downloadCmd.Config.OVADir = "some-ova-dir"
+				downloadCmd.Config.SelectRelease("0.21.0", "some-md5", "some-sha256")
+				release = &pivnet.Release{
+					ID:          "some-release-id",
+					Version:     "v0.21.0",
+					ProductFile: &pivnet.ProductFile{ID: "some-product-file-id", MD5: "some-md5", SHA256: "some-sha256"},
+				}
+			})
+
+			It("should download the OVA of that release", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("", nil),
+					mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
+					mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
+					mockClient.EXPECT().Release("0.21.0").Return(release, nil),
+					mockClient.EXPECT().SelectRelease(release),
+					mockClient.EXPECT().IsEULAAccepted().Return(true, nil),
+					mockUI.EXPECT().Say("Downloading VM..."),
+					mockDownloader.EXPECT().Download(),
+					mockUI.EXPECT().Say("\nVM downloaded."),
+				)
+
+				Expect(downloadCmd.Run()).To(Succeed())
+			})
+
+			Context("when the OVA of that release is current", func() {
+				It("should not look the release up", func() {
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("pcfdev-v0.21.0", nil),
+						mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
+						mockDownloader.EXPECT().IsOVACurrent().Return(true, nil),
+						mockUI.EXPECT().Say("Using existing image."),
+					)
+
+					Expect(downloadCmd.Run()).To(Succeed())
+				})
+			})
+		})
 	})
 })
@@ -1,6 +1,9 @@
 package cmd
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type EULARefusedError struct{}
 
@@ -27,3 +30,63 @@ type OldDriverError struct{}
 func (e *OldDriverError) Error() string {
 	return "please install Virtualbox version 5 or greater"
 }
+
+type SnapshotVMRunningError struct{}
+
+func (e *SnapshotVMRunningError) Error() string {
+	return "cannot restore a snapshot while PCF Dev is running, use `cf dev snapshot restore --force` to power off PCF Dev first"
+}
+
+type OldQEMUError struct{}
+
+func (e *OldQEMUError) Error() string {
+	return "please install QEMU version 5 or greater"
+}
+
+type InvalidForwardError struct {
+	Spec string
+}
+
+func (e *InvalidForwardError) Error() string {
+	return fmt.Sprintf("%s is not a valid port forward, use LOCAL_PORT:REMOTE_HOST:REMOTE_PORT", e.Spec)
+}
+
+type ReleasesFromMirrorError struct{}
+
+func (e *ReleasesFromMirrorError) Error() string {
+	return "releases are only available from Pivotal Network, unset PCFDEV_OVA_SOURCE to continue"
+}
+
+type ReleaseVMExistsError struct {
+	Release string
+}
+
+func (e *ReleaseVMExistsError) Error() string {
+	return fmt.Sprintf("a PCF Dev VM of another release already exists, run `cf dev destroy` before selecting PCF Dev %s", e.Release)
+}
+
+type EULAFromMirrorError struct{}
+
+func (e *EULAFromMirrorError) Error() string {
+	return "the EULA is only available from Pivotal Network, unset PCFDEV_OVA_SOURCE to continue"
+}
+
+type CacheVerificationError struct {
+	Files []string
+}
+
+func (e *CacheVerificationError) Error() string {
+	return fmt.Sprintf("cached OVAs failed verification: %s", strings.Join(e.Files, ", "))
+}
+
+type DNSNoVMError struct{}
+
+func (e *DNSNoVMError) Error() string {
+	return "no VM created, cannot start the DNS resolver"
+}
+
+type HostsNoVMError struct{}
+
+func (e *HostsNoVMError) Error() string {
+	return "no VM created, cannot sync the hosts file"
+}
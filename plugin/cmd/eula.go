@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+)
+
+const EULA_ARGS = 0
+
+type EULACmd struct {
+	Client Client
+	UI     UI
+	FS     FS
+	Config *config.Config
+	output string
+}
+
+func (e *EULACmd) Parse(args []string) error {
+	flagContext := flags.New()
+	flagContext.NewStringFlag("output", "", "<path to write the EULA to>")
+	if err := parse(flagContext, args, EULA_ARGS); err != nil {
+		return err
+	}
+
+	e.output = flagContext.String("output")
+	return nil
+}
+
+func (e *EULACmd) Run() error {
+	if !e.Config.UsesPivNet() {
+		return &EULAFromMirrorError{}
+	}
+
+	eula, err := e.Client.GetEULA()
+	if err != nil {
+		return err
+	}
+
+	if e.output == "" {
+		e.UI.Say(eula)
+		return nil
+	}
+
+	if err := e.FS.Write(e.output, strings.NewReader(eula), false); err != nil {
+		return err
+	}
+	e.UI.Say(fmt.Sprintf("EULA written to %s.", e.output))
+	return nil
+}
@@ -0,0 +1,111 @@
+package cmd_test
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+)
+
+var _ = Describe("EULACmd", func() {
+	var (
+		eulaCmd    *cmd.EULACmd
+		mockCtrl   *gomock.Controller
+		mockClient *mocks.MockClient
+		mockUI     *mocks.MockUI
+		mockFS     *mocks.MockFS
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockClient = mocks.NewMockClient(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		mockFS = mocks.NewMockFS(mockCtrl)
+		eulaCmd = &cmd.EULACmd{
+			Client: mockClient,
+			UI:     mockUI,
+			FS:     mockFS,
+			Config: &config.Config{},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when the correct number of arguments are passed", func() {
+			It("should succeed", func() {
+				Expect(eulaCmd.Parse([]string{})).To(Succeed())
+				Expect(eulaCmd.Parse([]string{"--output", "some-file"})).To(Succeed())
+			})
+		})
+
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(eulaCmd.Parse([]string{"some-bad-arg"})).To(MatchError("wrong number of arguments"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		It("should print the EULA", func() {
+			Expect(eulaCmd.Parse([]string{})).To(Succeed())
+
+			gomock.InOrder(
+				mockClient.EXPECT().GetEULA().Return("some-eula", nil),
+				mockUI.EXPECT().Say("some-eula"),
+			)
+
+			Expect(eulaCmd.Run()).To(Succeed())
+		})
+
+		Context("when an output file is passed", func() {
+			It("should write the EULA to the file", func() {
+				Expect(eulaCmd.Parse([]string{"--output", "some-file"})).To(Succeed())
+
+				gomock.InOrder(
+					mockClient.EXPECT().GetEULA().Return("some-eula", nil),
+					mockFS.EXPECT().Write("some-file", strings.NewReader("some-eula"), false),
+					mockUI.EXPECT().Say("EULA written to some-file."),
+				)
+
+				Expect(eulaCmd.Run()).To(Succeed())
+			})
+
+			Context("when writing the file fails", func() {
+				It("should return the error", func() {
+					Expect(eulaCmd.Parse([]string{"--output", "some-file"})).To(Succeed())
+
+					gomock.InOrder(
+						mockClient.EXPECT().GetEULA().Return("some-eula", nil),
+						mockFS.EXPECT().Write("some-file", gomock.Any(), false).Return(errors.New("some-error")),
+					)
+
+					Expect(eulaCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+		})
+
+		Context("when getting the EULA fails", func() {
+			It("should return the error", func() {
+				mockClient.EXPECT().GetEULA().Return("", errors.New("some-error"))
+
+				Expect(eulaCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when the OVA is downloaded from a mirror", func() {
+			It("should return an error", func() {
+				eulaCmd.Config.OVASource = "https://some-mirror/pcfdev/"
+
+				Expect(eulaCmd.Run()).To(MatchError("the EULA is only available from Pivotal Network, unset PCFDEV_OVA_SOURCE to continue"))
+			})
+		})
+	})
+})
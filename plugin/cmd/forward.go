@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+)
+
+type ForwardCmd struct {
+	VMBuilder VMBuilder
+	Provider  Provider
+	Config    *config.Config
+
+	specs []string
+}
+
+func (f *ForwardCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	if err := flagContext.Parse(args...); err != nil {
+		return err
+	}
+
+	if len(flagContext.Args()) == 0 {
+		return errors.New("wrong number of arguments")
+	}
+
+	f.specs = flagContext.Args()
+	return nil
+}
+
+func (f *ForwardCmd) Run() error {
+	forwards := []ssh.Forward{}
+	for _, spec := range f.specs {
+		forward, err := parseForward(spec)
+		if err != nil {
+			return err
+		}
+		forwards = append(forwards, forward)
+	}
+
+	vm, err := f.getVM()
+	if err != nil {
+		return err
+	}
+	return vm.Forward(forwards)
+}
+
+func parseForward(spec string) (ssh.Forward, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) != 3 || parts[1] == "" || !isPort(parts[0]) || !isPort(parts[2]) {
+		return ssh.Forward{}, &InvalidForwardError{Spec: spec}
+	}
+
+	return ssh.Forward{
+		LocalAddress:  net.JoinHostPort("127.0.0.1", parts[0]),
+		RemoteAddress: net.JoinHostPort(parts[1], parts[2]),
+	}, nil
+}
+
+func isPort(port string) bool {
+	number, err := strconv.ParseUint(port, 10, 16)
+	return err == nil && number > 0
+}
+
+func (f *ForwardCmd) getVM() (vm vm.VM, err error) {
+	name, err := f.Provider.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = f.Config.DefaultVMName
+	}
+	if name != f.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	return f.VMBuilder.VM(name)
+}
@@ -0,0 +1,110 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
+	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
+)
+
+var _ = Describe("ForwardCmd", func() {
+	var (
+		forwardCmd    *cmd.ForwardCmd
+		mockCtrl      *gomock.Controller
+		mockProvider  *mocks.MockProvider
+		mockVMBuilder *mocks.MockVMBuilder
+		mockVM        *vmMocks.MockVM
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockProvider = mocks.NewMockProvider(mockCtrl)
+		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
+		mockVM = vmMocks.NewMockVM(mockCtrl)
+		forwardCmd = &cmd.ForwardCmd{
+			Provider:  mockProvider,
+			VMBuilder: mockVMBuilder,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when the correct number of arguments are passed", func() {
+			It("should succeed", func() {
+				Expect(forwardCmd.Parse([]string{"5432:10.244.0.5:5432"})).To(Succeed())
+				Expect(forwardCmd.Parse([]string{"5432:10.244.0.5:5432", "15672:10.244.0.6:15672"})).To(Succeed())
+			})
+		})
+
+		Context("when no arguments are passed", func() {
+			It("should fail", func() {
+				Expect(forwardCmd.Parse([]string{})).To(MatchError("wrong number of arguments"))
+			})
+		})
+
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(forwardCmd.Parse([]string{"--some-bad-flag", "5432:10.244.0.5:5432"})).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		It("should forward the ports to the VM", func() {
+			Expect(forwardCmd.Parse([]string{"5432:10.244.0.5:5432", "15672:some-host:15673"})).To(Succeed())
+			gomock.InOrder(
+				mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+				mockVM.EXPECT().Forward([]ssh.Forward{
+					{LocalAddress: "127.0.0.1:5432", RemoteAddress: "10.244.0.5:5432"},
+					{LocalAddress: "127.0.0.1:15672", RemoteAddress: "some-host:15673"},
+				}),
+			)
+
+			Expect(forwardCmd.Run()).To(Succeed())
+		})
+
+		Context("when a port forward is invalid", func() {
+			It("should return an error", func() {
+				for _, spec := range []string{"5432", "5432:10.244.0.5", "some-port:10.244.0.5:5432", "5432::5432", "5432:10.244.0.5:70000"} {
+					Expect(forwardCmd.Parse([]string{spec})).To(Succeed())
+					Expect(forwardCmd.Run()).To(MatchError(spec + " is not a valid port forward, use LOCAL_PORT:REMOTE_HOST:REMOTE_PORT"))
+				}
+			})
+		})
+
+		Context("when an old VM is present", func() {
+			It("should return an error", func() {
+				Expect(forwardCmd.Parse([]string{"5432:10.244.0.5:5432"})).To(Succeed())
+				mockProvider.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(forwardCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
+			})
+		})
+
+		Context("when forwarding the ports fails", func() {
+			It("should return the error", func() {
+				Expect(forwardCmd.Parse([]string{"5432:10.244.0.5:5432"})).To(Succeed())
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Forward(gomock.Any()).Return(errors.New("some-error")),
+				)
+
+				Expect(forwardCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
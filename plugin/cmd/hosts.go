@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/provider"
+)
+
+const (
+	HOSTS_ARGS = 1
+	routesPath = "/v2/routes?inline-relations-depth=1&results-per-page=100"
+)
+
+//go:generate mockgen -package mocks -destination mocks/hosts.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd Hosts
+type Hosts interface {
+	Sync(instance string, ip string, hostnames []string) error
+	Clean(instance string) error
+}
+
+//go:generate mockgen -package mocks -destination mocks/cf_cli.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd CFCLI
+type CFCLI interface {
+	CliCommandWithoutTerminalOutput(args ...string) ([]string, error)
+}
+
+type HostsCmd struct {
+	Provider Provider
+	Hosts    Hosts
+	UI       UI
+	Config   *config.Config
+	CFCLI    CFCLI
+
+	subcommand string
+}
+
+type routesPage struct {
+	NextURL     string `json:"next_url"`
+	Description string `json:"description"`
+	Resources   []struct {
+		Entity struct {
+			Host   string `json:"host"`
+			Domain struct {
+				Entity struct {
+					Name string `json:"name"`
+				} `json:"entity"`
+			} `json:"domain"`
+		} `json:"entity"`
+	} `json:"resources"`
+}
+
+// SetCFCLI is called with the connection to the cf CLI that runs the plugin.
+func (h *HostsCmd) SetCFCLI(cfCLI CFCLI) {
+	h.CFCLI = cfCLI
+}
+
+func (h *HostsCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	if err := parse(flagContext, args, HOSTS_ARGS); err != nil {
+		return err
+	}
+
+	h.subcommand = flagContext.Args()[0]
+	if h.subcommand != "sync" && h.subcommand != "clean" {
+		return fmt.Errorf("unknown hosts command: %s", h.subcommand)
+	}
+	return nil
+}
+
+func (h *HostsCmd) Run() error {
+	if h.subcommand == "clean" {
+		if err := h.Hosts.Clean(h.Config.Instance); err != nil {
+			return err
+		}
+		h.UI.Say("Removed the PCF Dev entries from the hosts file.")
+		return nil
+	}
+
+	vmConfig, err := h.getVMConfig()
+	if err != nil {
+		return err
+	}
+
+	hostnames := []string{"api." + vmConfig.Domain, "uaa." + vmConfig.Domain, "login." + vmConfig.Domain}
+	routes, err := h.appRoutes(vmConfig.Domain)
+	if err != nil {
+		h.UI.Say(fmt.Sprintf("Warning: failed to list app routes, run `cf dev target` and `cf dev hosts sync` again: %s.", err))
+	} else {
+		hostnames = uniqueHostnames(append(hostnames, routes...))
+	}
+
+	if err := h.Hosts.Sync(h.Config.Instance, vmConfig.HostIP(), hostnames); err != nil {
+		return err
+	}
+	h.UI.Say(fmt.Sprintf("Mapped %d hostnames to %s in the hosts file.", len(hostnames), vmConfig.HostIP()))
+	return nil
+}
+
+func (h *HostsCmd) appRoutes(domain string) ([]string, error) {
+	output, err := h.CFCLI.CliCommandWithoutTerminalOutput("api")
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(strings.Join(output, "\n"), "api."+domain) {
+		return nil, fmt.Errorf("the cf CLI is not targeted at api.%s", domain)
+	}
+
+	routes := []string{}
+	for path := routesPath; path != ""; {
+		output, err := h.CFCLI.CliCommandWithoutTerminalOutput("curl", path)
+		if err != nil {
+			return nil, err
+		}
+
+		page := &routesPage{}
+		if err := json.Unmarshal([]byte(strings.Join(output, "\n")), page); err != nil {
+			return nil, fmt.Errorf("failed to parse routes: %s", err)
+		}
+		if page.Description != "" {
+			return nil, errors.New(page.Description)
+		}
+
+		for _, resource := range page.Resources {
+			route := resource.Entity
+			switch route.Host {
+			case "*":
+			case "":
+				routes = append(routes, route.Domain.Entity.Name)
+			default:
+				routes = append(routes, route.Host+"."+route.Domain.Entity.Name)
+			}
+		}
+		path = page.NextURL
+	}
+	return routes, nil
+}
+
+func uniqueHostnames(hostnames []string) []string {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, hostname := range hostnames {
+		if !seen[hostname] {
+			seen[hostname] = true
+			unique = append(unique, hostname)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
+
+func (h *HostsCmd) getVMConfig() (*config.VMConfig, error) {
+	name, err := h.Provider.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = h.Config.DefaultVMName
+	}
+	if name != h.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	status, err := h.Provider.VMStatus(name)
+	if err != nil {
+		return nil, err
+	}
+	if status == provider.StatusNotCreated {
+		return nil, &HostsNoVMError{}
+	}
+
+	return h.Provider.VMConfig(name)
+}
@@ -0,0 +1,192 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+)
+
+var _ = Describe("HostsCmd", func() {
+	var (
+		hostsCmd     *cmd.HostsCmd
+		mockCtrl     *gomock.Controller
+		mockProvider *mocks.MockProvider
+		mockHosts    *mocks.MockHosts
+		mockUI       *mocks.MockUI
+		mockCFCLI    *mocks.MockCFCLI
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockProvider = mocks.NewMockProvider(mockCtrl)
+		mockHosts = mocks.NewMockHosts(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		mockCFCLI = mocks.NewMockCFCLI(mockCtrl)
+		hostsCmd = &cmd.HostsCmd{
+			Provider: mockProvider,
+			Hosts:    mockHosts,
+			UI:       mockUI,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+		hostsCmd.SetCFCLI(mockCFCLI)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		It("should accept the sync and clean subcommands", func() {
+			Expect(hostsCmd.Parse([]string{"sync"})).To(Succeed())
+			Expect(hostsCmd.Parse([]string{"clean"})).To(Succeed())
+		})
+
+		Context("when no subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(hostsCmd.Parse([]string{})).To(MatchError("wrong number of arguments"))
+			})
+		})
+
+		Context("when an unknown subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(hostsCmd.Parse([]string{"some-subcommand"})).To(MatchError("unknown hosts command: some-subcommand"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		Context("sync", func() {
+			BeforeEach(func() {
+				Expect(hostsCmd.Parse([]string{"sync"})).To(Succeed())
+			})
+
+			It("should map the system hostnames and every app route to the VM IP", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().VMStatus("some-default-vm-name").Return("Running", nil),
+					mockProvider.EXPECT().VMConfig("some-default-vm-name").Return(&config.VMConfig{
+						Domain: "local.pcfdev.io",
+						IP:     "192.168.11.11",
+					}, nil),
+					mockCFCLI.EXPECT().CliCommandWithoutTerminalOutput("api").Return([]string{"API endpoint: https://api.local.pcfdev.io (API version: 2.58.0)"}, nil),
+					mockCFCLI.EXPECT().CliCommandWithoutTerminalOutput("curl", "/v2/routes?inline-relations-depth=1&results-per-page=100").Return([]string{
+						`{"next_url": "/v2/routes?page=2", "resources": [`,
+						`{"entity": {"host": "some-app", "domain": {"entity": {"name": "local.pcfdev.io"}}}},`,
+						`{"entity": {"host": "*", "domain": {"entity": {"name": "local.pcfdev.io"}}}}`,
+						`]}`,
+					}, nil),
+					mockCFCLI.EXPECT().CliCommandWithoutTerminalOutput("curl", "/v2/routes?page=2").Return([]string{
+						`{"next_url": null, "resources": [`,
+						`{"entity": {"host": "", "domain": {"entity": {"name": "tcp.local.pcfdev.io"}}}},`,
+						`{"entity": {"host": "api", "domain": {"entity": {"name": "local.pcfdev.io"}}}}`,
+						`]}`,
+					}, nil),
+					mockHosts.EXPECT().Sync("", "192.168.11.11", []string{"api.local.pcfdev.io", "login.local.pcfdev.io", "some-app.local.pcfdev.io", "tcp.local.pcfdev.io", "uaa.local.pcfdev.io"}),
+					mockUI.EXPECT().Say("Mapped 5 hostnames to 192.168.11.11 in the hosts file."),
+				)
+
+				Expect(hostsCmd.Run()).To(Succeed())
+			})
+
+			Context("when the cf CLI is not targeted at PCF Dev", func() {
+				It("should only map the system hostnames and warn", func() {
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().VMStatus("some-default-vm-name").Return("Running", nil),
+						mockProvider.EXPECT().VMConfig("some-default-vm-name").Return(&config.VMConfig{
+							Domain: "local.pcfdev.io",
+							IP:     "192.168.11.11",
+						}, nil),
+						mockCFCLI.EXPECT().CliCommandWithoutTerminalOutput("api").Return([]string{"API endpoint: https://api.run.example.com"}, nil),
+						mockUI.EXPECT().Say("Warning: failed to list app routes, run `cf dev target` and `cf dev hosts sync` again: the cf CLI is not targeted at api.local.pcfdev.io."),
+						mockHosts.EXPECT().Sync("", "192.168.11.11", []string{"api.local.pcfdev.io", "uaa.local.pcfdev.io", "login.local.pcfdev.io"}),
+						mockUI.EXPECT().Say("Mapped 3 hostnames to 192.168.11.11 in the hosts file."),
+					)
+
+					Expect(hostsCmd.Run()).To(Succeed())
+				})
+			})
+
+			Context("when listing routes returns an error", func() {
+				It("should warn", func() {
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().VMStatus("some-default-vm-name").Return("Running", nil),
+						mockProvider.EXPECT().VMConfig("some-default-vm-name").Return(&config.VMConfig{
+							Domain: "local.pcfdev.io",
+							IP:     "192.168.11.11",
+						}, nil),
+						mockCFCLI.EXPECT().CliCommandWithoutTerminalOutput("api").Return([]string{"API endpoint: https://api.local.pcfdev.io"}, nil),
+						mockCFCLI.EXPECT().CliCommandWithoutTerminalOutput("curl", gomock.Any()).Return([]string{`{"code": 1000, "description": "Invalid Auth Token"}`}, nil),
+						mockUI.EXPECT().Say("Warning: failed to list app routes, run `cf dev target` and `cf dev hosts sync` again: Invalid Auth Token."),
+						mockHosts.EXPECT().Sync("", "192.168.11.11", []string{"api.local.pcfdev.io", "uaa.local.pcfdev.io", "login.local.pcfdev.io"}),
+						mockUI.EXPECT().Say("Mapped 3 hostnames to 192.168.11.11 in the hosts file."),
+					)
+
+					Expect(hostsCmd.Run()).To(Succeed())
+				})
+			})
+
+			Context("when writing the hosts file fails", func() {
+				It("should return the error", func() {
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().VMStatus("some-default-vm-name").Return("Running", nil),
+						mockProvider.EXPECT().VMConfig("some-default-vm-name").Return(&config.VMConfig{
+							Domain: "local.pcfdev.io",
+							IP:     "192.168.11.11",
+						}, nil),
+						mockCFCLI.EXPECT().CliCommandWithoutTerminalOutput("api").Return([]string{"API endpoint: https://api.local.pcfdev.io"}, nil),
+						mockCFCLI.EXPECT().CliCommandWithoutTerminalOutput("curl", gomock.Any()).Return([]string{`{"next_url": null, "resources": []}`}, nil),
+						mockHosts.EXPECT().Sync("", "192.168.11.11", gomock.Any()).Return(errors.New("some-error")),
+					)
+
+					Expect(hostsCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+
+			Context("when the VM is not created", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().VMStatus("some-default-vm-name").Return("Not created", nil),
+					)
+
+					Expect(hostsCmd.Run()).To(MatchError("no VM created, cannot sync the hosts file"))
+				})
+			})
+		})
+
+		Context("clean", func() {
+			BeforeEach(func() {
+				Expect(hostsCmd.Parse([]string{"clean"})).To(Succeed())
+			})
+
+			It("should remove the entries of the instance from the hosts file", func() {
+				hostsCmd.Config.Instance = "some-instance"
+
+				gomock.InOrder(
+					mockHosts.EXPECT().Clean("some-instance"),
+					mockUI.EXPECT().Say("Removed the PCF Dev entries from the hosts file."),
+				)
+
+				Expect(hostsCmd.Run()).To(Succeed())
+			})
+
+			Context("when cleaning fails", func() {
+				It("should return the error", func() {
+					mockHosts.EXPECT().Clean("").Return(errors.New("some-error"))
+
+					Expect(hostsCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 
 	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/cache"
 	"github.com/pivotal-cf/pcfdev-cli/config"
 )
 
@@ -13,9 +14,11 @@ const IMPORT_ARGS = 1
 type ImportCmd struct {
 	OVAPath           string
 	DownloaderFactory DownloaderFactory
+	ExpectedDigests   ExpectedDigests
 	UI                UI
 	Config            *config.Config
 	FS                FS
+	Cache             Cache
 }
 
 func (i *ImportCmd) Parse(args []string) error {
@@ -27,11 +30,15 @@ func (i *ImportCmd) Parse(args []string) error {
 }
 
 func (i *ImportCmd) Run() error {
-	md5, err := i.FS.MD5(i.OVAPath)
+	expectedDigests, err := i.ExpectedDigests.Get()
 	if err != nil {
 		return err
 	}
-	if md5 != i.Config.ExpectedMD5 {
+	digests, err := i.FS.Digests(i.OVAPath)
+	if err != nil {
+		return err
+	}
+	if !expectedDigests.Matches(digests) {
 		return fmt.Errorf("specified OVA version does not match the expected OVA version (%s) for this version of the cf CLI plugin", i.Config.Version.OVABuildVersion)
 	}
 	downloader, err := i.DownloaderFactory.Create()
@@ -49,6 +56,15 @@ func (i *ImportCmd) Run() error {
 	if err := i.FS.Copy(i.OVAPath, filepath.Join(i.Config.OVADir, i.Config.DefaultVMName+".ova")); err != nil {
 		return err
 	}
+	if err := i.Cache.Save(&cache.Entry{
+		Version: i.Config.OVAVersion(),
+		File:    i.Config.DefaultVMName + ".ova",
+		MD5:     digests.MD5,
+		SHA256:  digests.SHA256,
+		Source:  i.OVAPath,
+	}); err != nil {
+		return err
+	}
 	i.UI.Say(fmt.Sprintf("OVA version %s imported successfully.", i.Config.Version.OVABuildVersion))
 	return nil
 }
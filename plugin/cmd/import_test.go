@@ -7,7 +7,9 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/cache"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/digest"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
 )
@@ -18,6 +20,8 @@ var _ = Describe("ImportCmd", func() {
 		mockDownloader        *mocks.MockDownloader
 		mockDownloaderFactory *mocks.MockDownloaderFactory
 		mockUI                *mocks.MockUI
+		mockExpectedDigests   *mocks.MockExpectedDigests
+		mockCache             *mocks.MockCache
 		mockCtrl              *gomock.Controller
 	)
 
@@ -27,6 +31,8 @@ var _ = Describe("ImportCmd", func() {
 		mockUI = mocks.NewMockUI(mockCtrl)
 		mockDownloader = mocks.NewMockDownloader(mockCtrl)
 		mockDownloaderFactory = mocks.NewMockDownloaderFactory(mockCtrl)
+		mockExpectedDigests = mocks.NewMockExpectedDigests(mockCtrl)
+		mockCache = mocks.NewMockCache(mockCtrl)
 	})
 
 	AfterEach(func() {
@@ -66,10 +72,11 @@ var _ = Describe("ImportCmd", func() {
 				UI:                mockUI,
 				FS:                mockFS,
 				DownloaderFactory: mockDownloaderFactory,
+				ExpectedDigests:   mockExpectedDigests,
+				Cache:             mockCache,
 				Config: &config.Config{
 					DefaultVMName: "some-vm-name",
 					OVADir:        "some-ova-dir",
-					ExpectedMD5:   "some-md5",
 					Version: &config.Version{
 						BuildVersion:    "some-build-version",
 						BuildSHA:        "some-build-sha",
@@ -81,10 +88,18 @@ var _ = Describe("ImportCmd", func() {
 
 		It("should copy an ova to the specified path", func() {
 			gomock.InOrder(
-				mockFS.EXPECT().MD5("some-ova-path").Return("some-md5", nil),
+				mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+				mockFS.EXPECT().Digests("some-ova-path").Return(&digest.Digests{MD5: "some-md5", SHA256: "some-sha256"}, nil),
 				mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
 				mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
 				mockFS.EXPECT().Copy("some-ova-path", filepath.Join("some-ova-dir", "some-vm-name.ova")),
+				mockCache.EXPECT().Save(&cache.Entry{
+					Version: "some-ova-version",
+					File:    "some-vm-name.ova",
+					MD5:     "some-md5",
+					SHA256:  "some-sha256",
+					Source:  "some-ova-path",
+				}),
 				mockUI.EXPECT().Say("OVA version some-ova-version imported successfully."),
 			)
 
@@ -94,7 +109,8 @@ var _ = Describe("ImportCmd", func() {
 		Context("when move returns an error", func() {
 			It("should print an error message", func() {
 				gomock.InOrder(
-					mockFS.EXPECT().MD5("some-ova-path").Return("some-md5", nil),
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+					mockFS.EXPECT().Digests("some-ova-path").Return(&digest.Digests{MD5: "some-md5", SHA256: "some-sha256"}, nil),
 					mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
 					mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
 					mockFS.EXPECT().Copy("some-ova-path", filepath.Join("some-ova-dir", "some-vm-name.ova")).Return(errors.New("some-error")),
@@ -103,9 +119,26 @@ var _ = Describe("ImportCmd", func() {
 			})
 		})
 
+		Context("when saving the cache metadata returns an error", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+					mockFS.EXPECT().Digests("some-ova-path").Return(&digest.Digests{MD5: "some-md5", SHA256: "some-sha256"}, nil),
+					mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
+					mockDownloader.EXPECT().IsOVACurrent().Return(false, nil),
+					mockFS.EXPECT().Copy("some-ova-path", filepath.Join("some-ova-dir", "some-vm-name.ova")),
+					mockCache.EXPECT().Save(gomock.Any()).Return(errors.New("some-error")),
+				)
+				Expect(importCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
 		Context("when the ova is not the correct ova for the plugin", func() {
 			It("should print an error message", func() {
-				mockFS.EXPECT().MD5("some-ova-path").Return("some-bad-md5", nil)
+				gomock.InOrder(
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+					mockFS.EXPECT().Digests("some-ova-path").Return(&digest.Digests{MD5: "some-md5", SHA256: "some-bad-sha256"}, nil),
+				)
 
 				Expect(importCmd.Run()).To(MatchError("specified OVA version does not match the expected OVA version (some-ova-version) for this version of the cf CLI plugin"))
 			})
@@ -113,7 +146,18 @@ var _ = Describe("ImportCmd", func() {
 
 		Context("when the checksum returns an error", func() {
 			It("should print an error message", func() {
-				mockFS.EXPECT().MD5("some-ova-path").Return("some-bad-md5", errors.New("some-error"))
+				gomock.InOrder(
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+					mockFS.EXPECT().Digests("some-ova-path").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(importCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when getting the expected digests returns an error", func() {
+			It("should return the error", func() {
+				mockExpectedDigests.EXPECT().Get().Return(nil, errors.New("some-error"))
 
 				Expect(importCmd.Run()).To(MatchError("some-error"))
 			})
@@ -122,7 +166,8 @@ var _ = Describe("ImportCmd", func() {
 		Context("when creating a downloader returns an error", func() {
 			It("should return the error", func() {
 				gomock.InOrder(
-					mockFS.EXPECT().MD5("some-ova-path").Return("some-md5", nil),
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+					mockFS.EXPECT().Digests("some-ova-path").Return(&digest.Digests{MD5: "some-md5", SHA256: "some-sha256"}, nil),
 					mockDownloaderFactory.EXPECT().Create().Return(nil, errors.New("some-error")),
 				)
 				Expect(importCmd.Run()).To(MatchError("some-error"))
@@ -132,7 +177,8 @@ var _ = Describe("ImportCmd", func() {
 		Context("when the ova is already installed", func() {
 			It("should print a message", func() {
 				gomock.InOrder(
-					mockFS.EXPECT().MD5("some-ova-path").Return("some-md5", nil),
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+					mockFS.EXPECT().Digests("some-ova-path").Return(&digest.Digests{MD5: "some-md5", SHA256: "some-sha256"}, nil),
 					mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
 					mockDownloader.EXPECT().IsOVACurrent().Return(true, nil),
 
@@ -146,7 +192,8 @@ var _ = Describe("ImportCmd", func() {
 		Context("when there is an error checking if the ova is current", func() {
 			It("should print an error message", func() {
 				gomock.InOrder(
-					mockFS.EXPECT().MD5("some-ova-path").Return("some-md5", nil),
+					mockExpectedDigests.EXPECT().Get().Return(&digest.Digests{SHA256: "some-sha256"}, nil),
+					mockFS.EXPECT().Digests("some-ova-path").Return(&digest.Digests{MD5: "some-md5", SHA256: "some-sha256"}, nil),
 					mockDownloaderFactory.EXPECT().Create().Return(mockDownloader, nil),
 					mockDownloader.EXPECT().IsOVACurrent().Return(true, errors.New("some-error")),
 				)
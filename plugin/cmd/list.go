@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+)
+
+const LIST_ARGS = 0
+
+type ListCmd struct {
+	Provider Provider
+	UI       UI
+	Config   *config.Config
+}
+
+func (l *ListCmd) Parse(args []string) error {
+	return parse(flags.New(), args, LIST_ARGS)
+}
+
+func (l *ListCmd) Run() error {
+	instances, err := l.Provider.Instances()
+	if err != nil {
+		return err
+	}
+
+	if len(instances) == 0 {
+		l.UI.Say("No PCF Dev instances found.")
+		return nil
+	}
+
+	for _, instance := range instances {
+		marker := " "
+		if instance.Name == l.Config.Instance {
+			marker = "*"
+		}
+
+		name := instance.Name
+		if name == "" {
+			name = "default"
+		}
+
+		l.UI.Say(fmt.Sprintf("%s %-20s %-12s %s", marker, name, instance.Status, instance.VMName))
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/provider"
+)
+
+var _ = Describe("ListCmd", func() {
+	var (
+		listCmd      *cmd.ListCmd
+		mockCtrl     *gomock.Controller
+		mockProvider *mocks.MockProvider
+		mockUI       *mocks.MockUI
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockProvider = mocks.NewMockProvider(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		listCmd = &cmd.ListCmd{
+			Provider: mockProvider,
+			UI:       mockUI,
+			Config: &config.Config{
+				Instance: "some-instance",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when the correct number of arguments are passed", func() {
+			It("should succeed", func() {
+				Expect(listCmd.Parse([]string{})).To(Succeed())
+			})
+		})
+
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(listCmd.Parse([]string{"some-bad-arg"})).To(MatchError("wrong number of arguments"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		It("should print each instance and mark the selected one", func() {
+			gomock.InOrder(
+				mockProvider.EXPECT().Instances().Return([]*provider.Instance{
+					{Name: "", VMName: "pcfdev-v0.0.0", Status: provider.StatusRunning},
+					{Name: "some-instance", VMName: "pcfdev-v0.0.0", Status: provider.StatusStopped},
+				}, nil),
+				mockUI.EXPECT().Say("  default              Running      pcfdev-v0.0.0"),
+				mockUI.EXPECT().Say("* some-instance        Stopped      pcfdev-v0.0.0"),
+			)
+
+			Expect(listCmd.Run()).To(Succeed())
+		})
+
+		Context("when there are no instances", func() {
+			It("should say so", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().Instances().Return([]*provider.Instance{}, nil),
+					mockUI.EXPECT().Say("No PCF Dev instances found."),
+				)
+
+				Expect(listCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when listing the instances fails", func() {
+			It("should return the error", func() {
+				mockProvider.EXPECT().Instances().Return(nil, errors.New("some-error"))
+
+				Expect(listCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
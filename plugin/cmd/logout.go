@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+)
+
+const LOGOUT_ARGS = 0
+
+//go:generate mockgen -package mocks -destination mocks/token.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd Token
+type Token interface {
+	Destroy() error
+}
+
+type LogoutCmd struct {
+	Token Token
+	UI    UI
+}
+
+func (l *LogoutCmd) Parse(args []string) error {
+	return parse(flags.New(), args, LOGOUT_ARGS)
+}
+
+func (l *LogoutCmd) Run() error {
+	if os.Getenv("PIVNET_TOKEN") != "" {
+		l.UI.Say("PIVNET_TOKEN set, unset it to stop using that PivNet API token.")
+		return nil
+	}
+
+	if err := l.Token.Destroy(); err != nil {
+		return err
+	}
+	l.UI.Say("Logged out of Pivotal Network.")
+	return nil
+}
@@ -0,0 +1,82 @@
+package cmd_test
+
+import (
+	"errors"
+	"os"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+)
+
+var _ = Describe("LogoutCmd", func() {
+	var (
+		logoutCmd  *cmd.LogoutCmd
+		mockToken  *mocks.MockToken
+		mockUI     *mocks.MockUI
+		mockCtrl   *gomock.Controller
+		savedToken string
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockToken = mocks.NewMockToken(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		logoutCmd = &cmd.LogoutCmd{
+			Token: mockToken,
+			UI:    mockUI,
+		}
+		savedToken = os.Getenv("PIVNET_TOKEN")
+		os.Setenv("PIVNET_TOKEN", "")
+	})
+
+	AfterEach(func() {
+		os.Setenv("PIVNET_TOKEN", savedToken)
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when the correct number of arguments are passed", func() {
+			It("should succeed", func() {
+				Expect(logoutCmd.Parse([]string{})).To(Succeed())
+			})
+		})
+
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(logoutCmd.Parse([]string{"some-bad-arg"})).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		It("should destroy the saved token", func() {
+			gomock.InOrder(
+				mockToken.EXPECT().Destroy(),
+				mockUI.EXPECT().Say("Logged out of Pivotal Network."),
+			)
+
+			Expect(logoutCmd.Run()).To(Succeed())
+		})
+
+		Context("when destroying the token fails", func() {
+			It("should return the error", func() {
+				mockToken.EXPECT().Destroy().Return(errors.New("some-error"))
+
+				Expect(logoutCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when PIVNET_TOKEN is set", func() {
+			It("should tell the user to unset it", func() {
+				os.Setenv("PIVNET_TOKEN", "some-token")
+
+				mockUI.EXPECT().Say("PIVNET_TOKEN set, unset it to stop using that PivNet API token.")
+
+				Expect(logoutCmd.Run()).To(Succeed())
+			})
+		})
+	})
+})
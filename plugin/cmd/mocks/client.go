@@ -5,6 +5,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	pivnet "github.com/pivotal-cf/pcfdev-cli/pivnet"
 )
 
 // Mock of Client interface
@@ -59,3 +60,33 @@ func (_m *MockClient) IsEULAAccepted() (bool, error) {
 func (_mr *_MockClientRecorder) IsEULAAccepted() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "IsEULAAccepted")
 }
+
+func (_m *MockClient) Release(_param0 string) (*pivnet.Release, error) {
+	ret := _m.ctrl.Call(_m, "Release", _param0)
+	ret0, _ := ret[0].(*pivnet.Release)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockClientRecorder) Release(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Release", arg0)
+}
+
+func (_m *MockClient) Releases() ([]*pivnet.Release, error) {
+	ret := _m.ctrl.Call(_m, "Releases")
+	ret0, _ := ret[0].([]*pivnet.Release)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockClientRecorder) Releases() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Releases")
+}
+
+func (_m *MockClient) SelectRelease(_param0 *pivnet.Release) {
+	_m.ctrl.Call(_m, "SelectRelease", _param0)
+}
+
+func (_mr *_MockClientRecorder) SelectRelease(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SelectRelease", arg0)
+}
@@ -57,3 +57,13 @@ func (_m *MockEULAUI) Init() error {
 func (_mr *_MockEULAUIRecorder) Init() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Init")
 }
+
+func (_m *MockEULAUI) Interactive() bool {
+	ret := _m.ctrl.Call(_m, "Interactive")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+func (_mr *_MockEULAUIRecorder) Interactive() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Interactive")
+}
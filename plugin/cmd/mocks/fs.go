@@ -5,6 +5,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	digest "github.com/pivotal-cf/pcfdev-cli/digest"
 	io "io"
 )
 
@@ -39,26 +40,36 @@ func (_mr *_MockFSRecorder) Copy(arg0, arg1 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Copy", arg0, arg1)
 }
 
-func (_m *MockFS) Exists(_param0 string) (bool, error) {
-	ret := _m.ctrl.Call(_m, "Exists", _param0)
-	ret0, _ := ret[0].(bool)
+func (_m *MockFS) CreateDir(_param0 string) error {
+	ret := _m.ctrl.Call(_m, "CreateDir", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockFSRecorder) CreateDir(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CreateDir", arg0)
+}
+
+func (_m *MockFS) Digests(_param0 string) (*digest.Digests, error) {
+	ret := _m.ctrl.Call(_m, "Digests", _param0)
+	ret0, _ := ret[0].(*digest.Digests)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (_mr *_MockFSRecorder) Exists(arg0 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "Exists", arg0)
+func (_mr *_MockFSRecorder) Digests(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Digests", arg0)
 }
 
-func (_m *MockFS) MD5(_param0 string) (string, error) {
-	ret := _m.ctrl.Call(_m, "MD5", _param0)
-	ret0, _ := ret[0].(string)
+func (_m *MockFS) Exists(_param0 string) (bool, error) {
+	ret := _m.ctrl.Call(_m, "Exists", _param0)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (_mr *_MockFSRecorder) MD5(arg0 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "MD5", arg0)
+func (_mr *_MockFSRecorder) Exists(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Exists", arg0)
 }
 
 func (_m *MockFS) Read(_param0 string) ([]byte, error) {
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+)
+
+const (
+	MOUNT_ARGS  = 2
+	MOUNTS_ARGS = 0
+)
+
+type MountCmd struct {
+	Provider  Provider
+	VMBuilder VMBuilder
+	FS        FS
+	Config    *config.Config
+
+	hostPath  string
+	guestPath string
+}
+
+func (m *MountCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	if err := parse(flagContext, args, MOUNT_ARGS); err != nil {
+		return err
+	}
+
+	hostPath, err := filepath.Abs(flagContext.Args()[0])
+	if err != nil {
+		return err
+	}
+
+	guestPath := flagContext.Args()[1]
+	if !path.IsAbs(guestPath) {
+		return fmt.Errorf("the guest path must be absolute: %s", guestPath)
+	}
+
+	m.hostPath = hostPath
+	m.guestPath = path.Clean(guestPath)
+	return nil
+}
+
+func (m *MountCmd) Run() error {
+	exists, err := m.FS.Exists(m.hostPath)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%s does not exist", m.hostPath)
+	}
+
+	vm, err := m.getVM()
+	if err != nil {
+		return err
+	}
+	return vm.Mount(m.hostPath, m.guestPath)
+}
+
+func (m *MountCmd) getVM() (vm vm.VM, err error) {
+	name, err := m.Provider.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = m.Config.DefaultVMName
+	}
+	if name != m.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	return m.VMBuilder.VM(name)
+}
+
+type MountsCmd struct {
+	Provider  Provider
+	VMBuilder VMBuilder
+	Config    *config.Config
+}
+
+func (m *MountsCmd) Parse(args []string) error {
+	return parse(flags.New(), args, MOUNTS_ARGS)
+}
+
+func (m *MountsCmd) Run() error {
+	vm, err := m.getVM()
+	if err != nil {
+		return err
+	}
+	return vm.Mounts()
+}
+
+func (m *MountsCmd) getVM() (vm vm.VM, err error) {
+	name, err := m.Provider.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = m.Config.DefaultVMName
+	}
+	if name != m.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	return m.VMBuilder.VM(name)
+}
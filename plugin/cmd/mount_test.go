@@ -0,0 +1,170 @@
+package cmd_test
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
+)
+
+var _ = Describe("MountCmd", func() {
+	var (
+		mountCmd      *cmd.MountCmd
+		mockCtrl      *gomock.Controller
+		mockProvider  *mocks.MockProvider
+		mockFS        *mocks.MockFS
+		mockVM        *vmMocks.MockVM
+		mockVMBuilder *mocks.MockVMBuilder
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockProvider = mocks.NewMockProvider(mockCtrl)
+		mockFS = mocks.NewMockFS(mockCtrl)
+		mockVM = vmMocks.NewMockVM(mockCtrl)
+		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
+		mountCmd = &cmd.MountCmd{
+			Provider:  mockProvider,
+			VMBuilder: mockVMBuilder,
+			FS:        mockFS,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(mountCmd.Parse([]string{"/some-host-path"})).To(MatchError("wrong number of arguments"))
+			})
+		})
+
+		Context("when the guest path is relative", func() {
+			It("should fail", func() {
+				Expect(mountCmd.Parse([]string{"/some-host-path", "some-guest-path"})).To(MatchError("the guest path must be absolute: some-guest-path"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		It("should mount the absolute host path in the VM", func() {
+			Expect(mountCmd.Parse([]string{"some-host-path", "/some-guest-path/"})).To(Succeed())
+			hostPath, err := filepath.Abs("some-host-path")
+			Expect(err).NotTo(HaveOccurred())
+
+			gomock.InOrder(
+				mockFS.EXPECT().Exists(hostPath).Return(true, nil),
+				mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+				mockVM.EXPECT().Mount(hostPath, "/some-guest-path"),
+			)
+
+			Expect(mountCmd.Run()).To(Succeed())
+		})
+
+		Context("when the host path does not exist", func() {
+			It("should return an error", func() {
+				Expect(mountCmd.Parse([]string{"/some-host-path", "/some-guest-path"})).To(Succeed())
+
+				mockFS.EXPECT().Exists(filepath.Clean("/some-host-path")).Return(false, nil)
+
+				Expect(mountCmd.Run()).To(MatchError(filepath.Clean("/some-host-path") + " does not exist"))
+			})
+		})
+
+		Context("when there is an old vm present", func() {
+			It("should tell the user to destroy pcfdev", func() {
+				Expect(mountCmd.Parse([]string{"/some-host-path", "/some-guest-path"})).To(Succeed())
+
+				gomock.InOrder(
+					mockFS.EXPECT().Exists(gomock.Any()).Return(true, nil),
+					mockProvider.EXPECT().GetVMName().Return("some-old-vm-name", nil),
+				)
+
+				Expect(mountCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
+			})
+		})
+
+		Context("when mounting fails", func() {
+			It("should return the error", func() {
+				Expect(mountCmd.Parse([]string{"/some-host-path", "/some-guest-path"})).To(Succeed())
+
+				gomock.InOrder(
+					mockFS.EXPECT().Exists(gomock.Any()).Return(true, nil),
+					mockProvider.EXPECT().GetVMName().Return("pcfdev-custom", nil),
+					mockVMBuilder.EXPECT().VM("pcfdev-custom").Return(mockVM, nil),
+					mockVM.EXPECT().Mount(gomock.Any(), "/some-guest-path").Return(errors.New("some-error")),
+				)
+
+				Expect(mountCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
+
+var _ = Describe("MountsCmd", func() {
+	var (
+		mountsCmd     *cmd.MountsCmd
+		mockCtrl      *gomock.Controller
+		mockProvider  *mocks.MockProvider
+		mockVM        *vmMocks.MockVM
+		mockVMBuilder *mocks.MockVMBuilder
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockProvider = mocks.NewMockProvider(mockCtrl)
+		mockVM = vmMocks.NewMockVM(mockCtrl)
+		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
+		mountsCmd = &cmd.MountsCmd{
+			Provider:  mockProvider,
+			VMBuilder: mockVMBuilder,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when arguments are passed", func() {
+			It("should fail", func() {
+				Expect(mountsCmd.Parse([]string{"some-arg"})).To(MatchError("wrong number of arguments"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		It("should list the mounted folders", func() {
+			gomock.InOrder(
+				mockProvider.EXPECT().GetVMName().Return("", nil),
+				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+				mockVM.EXPECT().Mounts(),
+			)
+
+			Expect(mountsCmd.Run()).To(Succeed())
+		})
+
+		Context("when there is an error getting the VM name", func() {
+			It("should return the error", func() {
+				mockProvider.EXPECT().GetVMName().Return("", errors.New("some-error"))
+
+				Expect(mountsCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+)
+
+const RELEASES_ARGS = 0
+
+type ReleasesCmd struct {
+	Client Client
+	UI     UI
+	Config *config.Config
+}
+
+func (r *ReleasesCmd) Parse(args []string) error {
+	return parse(flags.New(), args, RELEASES_ARGS)
+}
+
+func (r *ReleasesCmd) Run() error {
+	if !r.Config.UsesPivNet() {
+		return &ReleasesFromMirrorError{}
+	}
+
+	releases, err := r.Client.Releases()
+	if err != nil {
+		return err
+	}
+
+	if len(releases) == 0 {
+		r.UI.Say("No PCF Dev releases found.")
+		return nil
+	}
+
+	for _, release := range releases {
+		marker := " "
+		if strings.TrimPrefix(release.Version, "v") == r.Config.Version.OVABuildVersion {
+			marker = "*"
+		}
+
+		eula := "EULA not accepted"
+		if release.EULAAccepted {
+			eula = "EULA accepted"
+		}
+
+		r.UI.Say(fmt.Sprintf("%s %-12s %-12s %6d MB   %s", marker, release.Version, release.ReleaseDate, release.ProductFile.Size/1024/1024, eula))
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/pivnet"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+)
+
+var _ = Describe("ReleasesCmd", func() {
+	var (
+		releasesCmd *cmd.ReleasesCmd
+		mockCtrl    *gomock.Controller
+		mockClient  *mocks.MockClient
+		mockUI      *mocks.MockUI
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockClient = mocks.NewMockClient(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		releasesCmd = &cmd.ReleasesCmd{
+			Client: mockClient,
+			UI:     mockUI,
+			Config: &config.Config{
+				Version: &config.Version{OVABuildVersion: "0.22.0"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when the correct number of arguments are passed", func() {
+			It("should succeed", func() {
+				Expect(releasesCmd.Parse([]string{})).To(Succeed())
+			})
+		})
+
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(releasesCmd.Parse([]string{"some-bad-arg"})).To(MatchError("wrong number of arguments"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		It("should print each release and mark the one compiled into the plugin", func() {
+			gomock.InOrder(
+				mockClient.EXPECT().Releases().Return([]*pivnet.Release{
+					{Version: "v0.22.0", ReleaseDate: "2016-10-05", ProductFile: &pivnet.ProductFile{Size: 3 * 1024 * 1024 * 1024}, EULAAccepted: true},
+					{Version: "v0.21.0", ReleaseDate: "2016-09-14", ProductFile: &pivnet.ProductFile{Size: 2 * 1024 * 1024 * 1024}},
+				}, nil),
+				mockUI.EXPECT().Say("* v0.22.0      2016-10-05     3072 MB   EULA accepted"),
+				mockUI.EXPECT().Say("  v0.21.0      2016-09-14     2048 MB   EULA not accepted"),
+			)
+
+			Expect(releasesCmd.Run()).To(Succeed())
+		})
+
+		Context("when there are no releases", func() {
+			It("should say so", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Releases().Return([]*pivnet.Release{}, nil),
+					mockUI.EXPECT().Say("No PCF Dev releases found."),
+				)
+
+				Expect(releasesCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when the OVA is downloaded from a mirror", func() {
+			It("should return an error", func() {
+				releasesCmd.Config.OVASource = "https://some-mirror/pcfdev/"
+
+				Expect(releasesCmd.Run()).To(MatchError("releases are only available from Pivotal Network, unset PCFDEV_OVA_SOURCE to continue"))
+			})
+		})
+
+		Context("when listing the releases fails", func() {
+			It("should return the error", func() {
+				mockClient.EXPECT().Releases().Return(nil, errors.New("some-error"))
+
+				Expect(releasesCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
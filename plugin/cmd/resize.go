@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+)
+
+const RESIZE_ARGS = 0
+
+type ResizeCmd struct {
+	Provider  Provider
+	VMBuilder VMBuilder
+	Config    *config.Config
+
+	opts *vm.ResizeOpts
+}
+
+func (r *ResizeCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	flagContext.NewIntFlag("m", "", "<memory in MB>")
+	flagContext.NewIntFlag("c", "", "<number of cpus>")
+	if err := parse(flagContext, args, RESIZE_ARGS); err != nil {
+		return err
+	}
+
+	if !flagContext.IsSet("m") && !flagContext.IsSet("c") {
+		return errors.New("pass the memory with -m or the number of cores with -c")
+	}
+	if (flagContext.IsSet("m") && flagContext.Int("m") <= 0) || (flagContext.IsSet("c") && flagContext.Int("c") <= 0) {
+		return errors.New("-m and -c must be positive")
+	}
+
+	r.opts = &vm.ResizeOpts{
+		Memory: uint64(flagContext.Int("m")),
+		CPUs:   flagContext.Int("c"),
+	}
+	return nil
+}
+
+func (r *ResizeCmd) Run() error {
+	vm, err := r.getVM()
+	if err != nil {
+		return err
+	}
+	return vm.Resize(r.opts)
+}
+
+func (r *ResizeCmd) getVM() (vm vm.VM, err error) {
+	name, err := r.Provider.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = r.Config.DefaultVMName
+	}
+	if name != r.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	return r.VMBuilder.VM(name)
+}
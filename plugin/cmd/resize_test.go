@@ -0,0 +1,130 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
+)
+
+var _ = Describe("ResizeCmd", func() {
+	var (
+		resizeCmd     *cmd.ResizeCmd
+		mockCtrl      *gomock.Controller
+		mockProvider  *mocks.MockProvider
+		mockVM        *vmMocks.MockVM
+		mockVMBuilder *mocks.MockVMBuilder
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockProvider = mocks.NewMockProvider(mockCtrl)
+		mockVM = vmMocks.NewMockVM(mockCtrl)
+		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
+		resizeCmd = &cmd.ResizeCmd{
+			Provider:  mockProvider,
+			VMBuilder: mockVMBuilder,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		It("should accept the memory, the cores or both", func() {
+			Expect(resizeCmd.Parse([]string{"-m", "6144"})).To(Succeed())
+			Expect(resizeCmd.Parse([]string{"-c", "4"})).To(Succeed())
+			Expect(resizeCmd.Parse([]string{"-m", "6144", "-c", "4"})).To(Succeed())
+		})
+
+		Context("when neither the memory nor the cores are passed", func() {
+			It("should fail", func() {
+				Expect(resizeCmd.Parse([]string{})).To(MatchError("pass the memory with -m or the number of cores with -c"))
+			})
+		})
+
+		Context("when the memory or cores are not positive", func() {
+			It("should fail", func() {
+				Expect(resizeCmd.Parse([]string{"-m", "0"})).To(MatchError("-m and -c must be positive"))
+				Expect(resizeCmd.Parse([]string{"-c", "-1"})).To(MatchError("-m and -c must be positive"))
+			})
+		})
+
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(resizeCmd.Parse([]string{"-m", "6144", "some-bad-arg"})).To(MatchError("wrong number of arguments"))
+			})
+		})
+
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(resizeCmd.Parse([]string{"--some-bad-flag"})).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		BeforeEach(func() {
+			Expect(resizeCmd.Parse([]string{"-m", "6144", "-c", "4"})).To(Succeed())
+		})
+
+		It("should resize the VM", func() {
+			gomock.InOrder(
+				mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+				mockVM.EXPECT().Resize(&vm.ResizeOpts{Memory: 6144, CPUs: 4}),
+			)
+
+			Expect(resizeCmd.Run()).To(Succeed())
+		})
+
+		Context("when there is an old vm present", func() {
+			It("should tell the user to destroy pcfdev", func() {
+				mockProvider.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(resizeCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
+			})
+		})
+
+		Context("when there is an error getting the VM name", func() {
+			It("should return the error", func() {
+				mockProvider.EXPECT().GetVMName().Return("", errors.New("some-error"))
+
+				Expect(resizeCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when it fails to get VM", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(resizeCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when resizing the VM fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("pcfdev-custom", nil),
+					mockVMBuilder.EXPECT().VM("pcfdev-custom").Return(mockVM, nil),
+					mockVM.EXPECT().Resize(&vm.ResizeOpts{Memory: 6144, CPUs: 4}).Return(errors.New("some-error")),
+				)
+
+				Expect(resizeCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
@@ -9,7 +9,7 @@ import (
 const RESUME_ARGS = 0
 
 type ResumeCmd struct {
-	VBox      VBox
+	Provider  Provider
 	VMBuilder VMBuilder
 	Config    *config.Config
 }
@@ -27,7 +27,7 @@ func (r *ResumeCmd) Run() error {
 }
 
 func (r *ResumeCmd) getVM() (vm vm.VM, err error) {
-	name, err := r.VBox.GetVMName()
+	name, err := r.Provider.GetVMName()
 	if err != nil {
 		return nil, err
 	}
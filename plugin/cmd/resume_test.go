@@ -16,18 +16,18 @@ var _ = Describe("ResumeCmd", func() {
 	var (
 		resumeCmd     *cmd.ResumeCmd
 		mockCtrl      *gomock.Controller
-		mockVBox      *mocks.MockVBox
+		mockProvider  *mocks.MockProvider
 		mockVM        *vmMocks.MockVM
 		mockVMBuilder *mocks.MockVMBuilder
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockVM = vmMocks.NewMockVM(mockCtrl)
 		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
 		resumeCmd = &cmd.ResumeCmd{
-			VBox:      mockVBox,
+			Provider:  mockProvider,
 			VMBuilder: mockVMBuilder,
 			Config: &config.Config{
 				DefaultVMName: "some-default-vm-name",
@@ -61,7 +61,7 @@ var _ = Describe("ResumeCmd", func() {
 		Context("when the default VM is present", func() {
 			It("should resume the VM", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Resume(),
 				)
@@ -73,7 +73,7 @@ var _ = Describe("ResumeCmd", func() {
 		Context("when the custom vm is present", func() {
 			It("should resume the VM", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("pcfdev-custom", nil),
+					mockProvider.EXPECT().GetVMName().Return("pcfdev-custom", nil),
 					mockVMBuilder.EXPECT().VM("pcfdev-custom").Return(mockVM, nil),
 					mockVM.EXPECT().Resume(),
 				)
@@ -85,7 +85,7 @@ var _ = Describe("ResumeCmd", func() {
 		Context("when there is no vm present", func() {
 			It("should resume the default VM", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Resume(),
 				)
@@ -96,7 +96,7 @@ var _ = Describe("ResumeCmd", func() {
 
 		Context("when there is an old vm present", func() {
 			It("should tell the user to destroy pcfdev", func() {
-				mockVBox.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+				mockProvider.EXPECT().GetVMName().Return("some-old-vm-name", nil)
 
 				Expect(resumeCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
 			})
@@ -104,7 +104,7 @@ var _ = Describe("ResumeCmd", func() {
 
 		Context("when there is an error getting the VM name", func() {
 			It("should return the error", func() {
-				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+				mockProvider.EXPECT().GetVMName().Return("", errors.New("some-error"))
 
 				Expect(resumeCmd.Run()).To(MatchError("some-error"))
 			})
@@ -113,7 +113,7 @@ var _ = Describe("ResumeCmd", func() {
 		Context("when it fails to get VM", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
 				)
 
@@ -124,7 +124,7 @@ var _ = Describe("ResumeCmd", func() {
 		Context("when it fails to resume VM", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Resume().Return(errors.New("some-error")),
 				)
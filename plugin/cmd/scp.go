@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+)
+
+const (
+	SCP_ARGS        = 2
+	guestPathPrefix = "vm:"
+)
+
+type SCPCmd struct {
+	VMBuilder VMBuilder
+	Provider  Provider
+	Config    *config.Config
+
+	source      string
+	destination string
+}
+
+func (s *SCPCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	if err := parse(flagContext, args, SCP_ARGS); err != nil {
+		return err
+	}
+
+	s.source = flagContext.Args()[0]
+	s.destination = flagContext.Args()[1]
+	return nil
+}
+
+func (s *SCPCmd) Run() error {
+	opts, err := s.copyOpts()
+	if err != nil {
+		return err
+	}
+
+	vm, err := s.getVM()
+	if err != nil {
+		return err
+	}
+	return vm.Copy(opts)
+}
+
+func (s *SCPCmd) copyOpts() (*vm.CopyOpts, error) {
+	sourceIsGuest := strings.HasPrefix(s.source, guestPathPrefix)
+	destinationIsGuest := strings.HasPrefix(s.destination, guestPathPrefix)
+
+	switch {
+	case sourceIsGuest && !destinationIsGuest:
+		return &vm.CopyOpts{
+			GuestPath: strings.TrimPrefix(s.source, guestPathPrefix),
+			HostPath:  s.destination,
+		}, nil
+	case destinationIsGuest && !sourceIsGuest:
+		return &vm.CopyOpts{
+			GuestPath: strings.TrimPrefix(s.destination, guestPathPrefix),
+			HostPath:  s.source,
+			ToGuest:   true,
+		}, nil
+	default:
+		return nil, errors.New("exactly one path must refer to the PCF Dev VM using the 'vm:' prefix")
+	}
+}
+
+func (s *SCPCmd) getVM() (vm vm.VM, err error) {
+	name, err := s.Provider.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = s.Config.DefaultVMName
+	}
+	if name != s.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	return s.VMBuilder.VM(name)
+}
@@ -0,0 +1,122 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
+)
+
+var _ = Describe("SCPCmd", func() {
+	var (
+		scpCmd        *cmd.SCPCmd
+		mockCtrl      *gomock.Controller
+		mockProvider  *mocks.MockProvider
+		mockVMBuilder *mocks.MockVMBuilder
+		mockVM        *vmMocks.MockVM
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockProvider = mocks.NewMockProvider(mockCtrl)
+		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
+		mockVM = vmMocks.NewMockVM(mockCtrl)
+		scpCmd = &cmd.SCPCmd{
+			Provider:  mockProvider,
+			VMBuilder: mockVMBuilder,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when the correct number of arguments are passed", func() {
+			It("should succeed", func() {
+				Expect(scpCmd.Parse([]string{"some-source", "vm:some-destination"})).To(Succeed())
+			})
+		})
+
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(scpCmd.Parse([]string{"some-source"})).To(MatchError("wrong number of arguments"))
+			})
+		})
+
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(scpCmd.Parse([]string{"--some-bad-flag", "some-source", "vm:some-destination"})).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		Context("when copying to the VM", func() {
+			It("should copy the host path to the guest path", func() {
+				Expect(scpCmd.Parse([]string{"some-host-path", "vm:some-guest-path"})).To(Succeed())
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Copy(&vm.CopyOpts{HostPath: "some-host-path", GuestPath: "some-guest-path", ToGuest: true}),
+				)
+
+				Expect(scpCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when copying from the VM", func() {
+			It("should copy the guest path to the host path", func() {
+				Expect(scpCmd.Parse([]string{"vm:some-guest-path", "some-host-path"})).To(Succeed())
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("pcfdev-custom", nil),
+					mockVMBuilder.EXPECT().VM("pcfdev-custom").Return(mockVM, nil),
+					mockVM.EXPECT().Copy(&vm.CopyOpts{HostPath: "some-host-path", GuestPath: "some-guest-path"}),
+				)
+
+				Expect(scpCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when neither or both paths refer to the VM", func() {
+			It("should return an error", func() {
+				Expect(scpCmd.Parse([]string{"some-host-path", "some-other-host-path"})).To(Succeed())
+				Expect(scpCmd.Run()).To(MatchError("exactly one path must refer to the PCF Dev VM using the 'vm:' prefix"))
+
+				Expect(scpCmd.Parse([]string{"vm:some-guest-path", "vm:some-other-guest-path"})).To(Succeed())
+				Expect(scpCmd.Run()).To(MatchError("exactly one path must refer to the PCF Dev VM using the 'vm:' prefix"))
+			})
+		})
+
+		Context("when an old VM is present", func() {
+			It("should return an error", func() {
+				Expect(scpCmd.Parse([]string{"some-host-path", "vm:some-guest-path"})).To(Succeed())
+				mockProvider.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(scpCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
+			})
+		})
+
+		Context("when copying fails", func() {
+			It("should return the error", func() {
+				Expect(scpCmd.Parse([]string{"some-host-path", "vm:some-guest-path"})).To(Succeed())
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Copy(gomock.Any()).Return(errors.New("some-error")),
+				)
+
+				Expect(scpCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+)
+
+type ServicesCmd struct {
+	Provider  Provider
+	VMBuilder VMBuilder
+	Config    *config.Config
+
+	subcommand string
+	service    string
+}
+
+func (s *ServicesCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	if err := flagContext.Parse(args...); err != nil {
+		return err
+	}
+
+	args = flagContext.Args()
+	if len(args) == 0 {
+		s.subcommand = "list"
+		return nil
+	}
+
+	s.subcommand = args[0]
+	switch s.subcommand {
+	case "enable", "disable":
+		if len(args) != 2 {
+			return errors.New("wrong number of arguments")
+		}
+	default:
+		return fmt.Errorf("unknown services command: %s", s.subcommand)
+	}
+
+	switch args[1] {
+	case "redis", "rabbitmq", "spring-cloud-services":
+		s.service = args[1]
+	case "scs":
+		s.service = "spring-cloud-services"
+	case "mysql":
+		return errors.New("mysql is always available and cannot be enabled or disabled")
+	default:
+		return fmt.Errorf("unknown service: %s, use redis, rabbitmq or spring-cloud-services (scs)", args[1])
+	}
+	return nil
+}
+
+func (s *ServicesCmd) Run() error {
+	vm, err := s.getVM()
+	if err != nil {
+		return err
+	}
+
+	switch s.subcommand {
+	case "enable":
+		return vm.EnableService(s.service)
+	case "disable":
+		return vm.DisableService(s.service)
+	default:
+		return vm.Services()
+	}
+}
+
+func (s *ServicesCmd) getVM() (vm vm.VM, err error) {
+	name, err := s.Provider.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = s.Config.DefaultVMName
+	}
+	if name != s.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	return s.VMBuilder.VM(name)
+}
@@ -0,0 +1,150 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
+)
+
+var _ = Describe("ServicesCmd", func() {
+	var (
+		servicesCmd   *cmd.ServicesCmd
+		mockCtrl      *gomock.Controller
+		mockProvider  *mocks.MockProvider
+		mockVM        *vmMocks.MockVM
+		mockVMBuilder *mocks.MockVMBuilder
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockProvider = mocks.NewMockProvider(mockCtrl)
+		mockVM = vmMocks.NewMockVM(mockCtrl)
+		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
+		servicesCmd = &cmd.ServicesCmd{
+			Provider:  mockProvider,
+			VMBuilder: mockVMBuilder,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		It("should accept no subcommand and the enable and disable subcommands", func() {
+			Expect(servicesCmd.Parse([]string{})).To(Succeed())
+			Expect(servicesCmd.Parse([]string{"enable", "redis"})).To(Succeed())
+			Expect(servicesCmd.Parse([]string{"disable", "rabbitmq"})).To(Succeed())
+		})
+
+		Context("when an unknown subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(servicesCmd.Parse([]string{"some-subcommand"})).To(MatchError("unknown services command: some-subcommand"))
+			})
+		})
+
+		Context("when no service is passed", func() {
+			It("should fail", func() {
+				Expect(servicesCmd.Parse([]string{"enable"})).To(MatchError("wrong number of arguments"))
+			})
+		})
+
+		Context("when an unknown service is passed", func() {
+			It("should fail", func() {
+				Expect(servicesCmd.Parse([]string{"enable", "some-service"})).To(MatchError("unknown service: some-service, use redis, rabbitmq or spring-cloud-services (scs)"))
+			})
+		})
+
+		Context("when mysql is passed", func() {
+			It("should fail", func() {
+				Expect(servicesCmd.Parse([]string{"disable", "mysql"})).To(MatchError("mysql is always available and cannot be enabled or disabled"))
+			})
+		})
+
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(servicesCmd.Parse([]string{"--some-bad-flag"})).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		Context("when no subcommand is passed", func() {
+			It("should list the services", func() {
+				Expect(servicesCmd.Parse([]string{})).To(Succeed())
+
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Services(),
+				)
+
+				Expect(servicesCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when enable is passed", func() {
+			It("should enable the service", func() {
+				Expect(servicesCmd.Parse([]string{"enable", "scs"})).To(Succeed())
+
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("pcfdev-custom", nil),
+					mockVMBuilder.EXPECT().VM("pcfdev-custom").Return(mockVM, nil),
+					mockVM.EXPECT().EnableService("spring-cloud-services"),
+				)
+
+				Expect(servicesCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when disable is passed", func() {
+			It("should disable the service", func() {
+				Expect(servicesCmd.Parse([]string{"disable", "redis"})).To(Succeed())
+
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().DisableService("redis").Return(errors.New("some-error")),
+				)
+
+				Expect(servicesCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when there is an old vm present", func() {
+			It("should tell the user to destroy pcfdev", func() {
+				mockProvider.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(servicesCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
+			})
+		})
+
+		Context("when there is an error getting the VM name", func() {
+			It("should return the error", func() {
+				mockProvider.EXPECT().GetVMName().Return("", errors.New("some-error"))
+
+				Expect(servicesCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when it fails to get VM", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(servicesCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/provider"
+)
+
+type SnapshotCmd struct {
+	Provider Provider
+	UI       UI
+	Config   *config.Config
+
+	subcommand   string
+	snapshotName string
+	flagContext  flags.FlagContext
+}
+
+func (s *SnapshotCmd) Parse(args []string) error {
+	s.flagContext = flags.New()
+	s.flagContext.NewBoolFlag("force", "f", "<force>")
+	if err := s.flagContext.Parse(args...); err != nil {
+		return err
+	}
+
+	args = s.flagContext.Args()
+	if len(args) == 0 {
+		return errors.New("wrong number of arguments")
+	}
+
+	s.subcommand = args[0]
+	switch s.subcommand {
+	case "list":
+		if len(args) != 1 {
+			return errors.New("wrong number of arguments")
+		}
+	case "save", "restore", "delete":
+		if len(args) != 2 {
+			return errors.New("wrong number of arguments")
+		}
+		s.snapshotName = args[1]
+	default:
+		return fmt.Errorf("unknown snapshot command: %s", s.subcommand)
+	}
+	return nil
+}
+
+func (s *SnapshotCmd) Run() error {
+	name, err := s.Provider.GetVMName()
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return errors.New("PCF Dev VM has not been created")
+	}
+	if name != s.Config.DefaultVMName && name != "pcfdev-custom" {
+		return &OldVMError{}
+	}
+
+	switch s.subcommand {
+	case "list":
+		return s.list(name)
+	case "save":
+		return s.save(name)
+	case "restore":
+		return s.restore(name)
+	case "delete":
+		return s.delete(name)
+	}
+	return nil
+}
+
+func (s *SnapshotCmd) list(name string) error {
+	snapshots, err := s.Provider.Snapshots(name)
+	if err != nil {
+		return err
+	}
+
+	if len(snapshots) == 0 {
+		s.UI.Say("No snapshots found.")
+		return nil
+	}
+
+	for _, snapshot := range snapshots {
+		s.UI.Say(snapshot)
+	}
+	return nil
+}
+
+func (s *SnapshotCmd) save(name string) error {
+	s.UI.Say(fmt.Sprintf("Saving snapshot %s...", s.snapshotName))
+	if err := s.Provider.TakeSnapshot(name, s.snapshotName); err != nil {
+		return err
+	}
+	s.UI.Say(fmt.Sprintf("Snapshot %s saved.", s.snapshotName))
+	return nil
+}
+
+func (s *SnapshotCmd) restore(name string) error {
+	status, err := s.Provider.VMStatus(name)
+	if err != nil {
+		return err
+	}
+
+	if status == provider.StatusRunning || status == provider.StatusPaused {
+		if !s.flagContext.Bool("force") {
+			return &SnapshotVMRunningError{}
+		}
+
+		s.UI.Say("Powering off PCF Dev VM...")
+		if err := s.Provider.PowerOffVM(&config.VMConfig{Name: name}); err != nil {
+			return err
+		}
+	}
+
+	s.UI.Say(fmt.Sprintf("Restoring snapshot %s...", s.snapshotName))
+	if err := s.Provider.RestoreSnapshot(name, s.snapshotName); err != nil {
+		return err
+	}
+	s.UI.Say(fmt.Sprintf("Snapshot %s restored. Run `cf dev start` to start PCF Dev.", s.snapshotName))
+	return nil
+}
+
+func (s *SnapshotCmd) delete(name string) error {
+	if err := s.Provider.DeleteSnapshot(name, s.snapshotName); err != nil {
+		return err
+	}
+	s.UI.Say(fmt.Sprintf("Snapshot %s deleted.", s.snapshotName))
+	return nil
+}
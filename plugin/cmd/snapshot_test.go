@@ -0,0 +1,250 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+)
+
+var _ = Describe("SnapshotCmd", func() {
+	var (
+		snapshotCmd  *cmd.SnapshotCmd
+		mockCtrl     *gomock.Controller
+		mockProvider *mocks.MockProvider
+		mockUI       *mocks.MockUI
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockProvider = mocks.NewMockProvider(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		snapshotCmd = &cmd.SnapshotCmd{
+			Provider: mockProvider,
+			UI:       mockUI,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when the correct number of arguments are passed", func() {
+			It("should succeed", func() {
+				Expect(snapshotCmd.Parse([]string{"list"})).To(Succeed())
+				Expect(snapshotCmd.Parse([]string{"save", "some-snapshot"})).To(Succeed())
+				Expect(snapshotCmd.Parse([]string{"restore", "some-snapshot", "--force"})).To(Succeed())
+				Expect(snapshotCmd.Parse([]string{"delete", "some-snapshot"})).To(Succeed())
+			})
+		})
+
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(snapshotCmd.Parse([]string{})).To(MatchError("wrong number of arguments"))
+				Expect(snapshotCmd.Parse([]string{"list", "some-bad-arg"})).To(MatchError("wrong number of arguments"))
+				Expect(snapshotCmd.Parse([]string{"save"})).To(MatchError("wrong number of arguments"))
+			})
+		})
+
+		Context("when an unknown subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(snapshotCmd.Parse([]string{"some-bad-subcommand"})).To(MatchError("unknown snapshot command: some-bad-subcommand"))
+			})
+		})
+
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(snapshotCmd.Parse([]string{"list", "--some-bad-flag"})).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		Context("when the VM has not been created", func() {
+			It("should return an error", func() {
+				Expect(snapshotCmd.Parse([]string{"list"})).To(Succeed())
+				mockProvider.EXPECT().GetVMName().Return("", nil)
+
+				Expect(snapshotCmd.Run()).To(MatchError("PCF Dev VM has not been created"))
+			})
+		})
+
+		Context("when an old VM is present", func() {
+			It("should return an error", func() {
+				Expect(snapshotCmd.Parse([]string{"list"})).To(Succeed())
+				mockProvider.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(snapshotCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
+			})
+		})
+
+		Context("when listing snapshots", func() {
+			BeforeEach(func() {
+				Expect(snapshotCmd.Parse([]string{"list"})).To(Succeed())
+			})
+
+			It("should print the snapshots", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().Snapshots("some-default-vm-name").Return([]string{"some-snapshot", "some-other-snapshot"}, nil),
+					mockUI.EXPECT().Say("some-snapshot"),
+					mockUI.EXPECT().Say("some-other-snapshot"),
+				)
+
+				Expect(snapshotCmd.Run()).To(Succeed())
+			})
+
+			Context("when there are no snapshots", func() {
+				It("should say so", func() {
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+						mockProvider.EXPECT().Snapshots("some-default-vm-name").Return([]string{}, nil),
+						mockUI.EXPECT().Say("No snapshots found."),
+					)
+
+					Expect(snapshotCmd.Run()).To(Succeed())
+				})
+			})
+
+			Context("when listing the snapshots fails", func() {
+				It("should return the error", func() {
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+						mockProvider.EXPECT().Snapshots("some-default-vm-name").Return(nil, errors.New("some-error")),
+					)
+
+					Expect(snapshotCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+		})
+
+		Context("when saving a snapshot", func() {
+			It("should take a snapshot", func() {
+				Expect(snapshotCmd.Parse([]string{"save", "some-snapshot"})).To(Succeed())
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("pcfdev-custom", nil),
+					mockUI.EXPECT().Say("Saving snapshot some-snapshot..."),
+					mockProvider.EXPECT().TakeSnapshot("pcfdev-custom", "some-snapshot"),
+					mockUI.EXPECT().Say("Snapshot some-snapshot saved."),
+				)
+
+				Expect(snapshotCmd.Run()).To(Succeed())
+			})
+
+			Context("when taking the snapshot fails", func() {
+				It("should return the error", func() {
+					Expect(snapshotCmd.Parse([]string{"save", "some-snapshot"})).To(Succeed())
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+						mockUI.EXPECT().Say("Saving snapshot some-snapshot..."),
+						mockProvider.EXPECT().TakeSnapshot("some-default-vm-name", "some-snapshot").Return(errors.New("some-error")),
+					)
+
+					Expect(snapshotCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+		})
+
+		Context("when restoring a snapshot", func() {
+			Context("when the VM is not running", func() {
+				It("should restore the snapshot", func() {
+					Expect(snapshotCmd.Parse([]string{"restore", "some-snapshot"})).To(Succeed())
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+						mockProvider.EXPECT().VMStatus("some-default-vm-name").Return("Stopped", nil),
+						mockUI.EXPECT().Say("Restoring snapshot some-snapshot..."),
+						mockProvider.EXPECT().RestoreSnapshot("some-default-vm-name", "some-snapshot"),
+						mockUI.EXPECT().Say("Snapshot some-snapshot restored. Run `cf dev start` to start PCF Dev."),
+					)
+
+					Expect(snapshotCmd.Run()).To(Succeed())
+				})
+			})
+
+			Context("when the VM is running", func() {
+				It("should refuse to restore the snapshot", func() {
+					Expect(snapshotCmd.Parse([]string{"restore", "some-snapshot"})).To(Succeed())
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+						mockProvider.EXPECT().VMStatus("some-default-vm-name").Return("Running", nil),
+					)
+
+					Expect(snapshotCmd.Run()).To(MatchError("cannot restore a snapshot while PCF Dev is running, use `cf dev snapshot restore --force` to power off PCF Dev first"))
+				})
+
+				Context("when the force flag is passed", func() {
+					It("should power off the VM and restore the snapshot", func() {
+						Expect(snapshotCmd.Parse([]string{"restore", "some-snapshot", "-f"})).To(Succeed())
+						gomock.InOrder(
+							mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+							mockProvider.EXPECT().VMStatus("some-default-vm-name").Return("Running", nil),
+							mockUI.EXPECT().Say("Powering off PCF Dev VM..."),
+							mockProvider.EXPECT().PowerOffVM(&config.VMConfig{Name: "some-default-vm-name"}),
+							mockUI.EXPECT().Say("Restoring snapshot some-snapshot..."),
+							mockProvider.EXPECT().RestoreSnapshot("some-default-vm-name", "some-snapshot"),
+							mockUI.EXPECT().Say("Snapshot some-snapshot restored. Run `cf dev start` to start PCF Dev."),
+						)
+
+						Expect(snapshotCmd.Run()).To(Succeed())
+					})
+
+					Context("when powering off the VM fails", func() {
+						It("should return the error", func() {
+							Expect(snapshotCmd.Parse([]string{"restore", "some-snapshot", "--force"})).To(Succeed())
+							gomock.InOrder(
+								mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+								mockProvider.EXPECT().VMStatus("some-default-vm-name").Return("Paused", nil),
+								mockUI.EXPECT().Say("Powering off PCF Dev VM..."),
+								mockProvider.EXPECT().PowerOffVM(&config.VMConfig{Name: "some-default-vm-name"}).Return(errors.New("some-error")),
+							)
+
+							Expect(snapshotCmd.Run()).To(MatchError("some-error"))
+						})
+					})
+				})
+			})
+
+			Context("when getting the VM status fails", func() {
+				It("should return the error", func() {
+					Expect(snapshotCmd.Parse([]string{"restore", "some-snapshot"})).To(Succeed())
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+						mockProvider.EXPECT().VMStatus("some-default-vm-name").Return("", errors.New("some-error")),
+					)
+
+					Expect(snapshotCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+		})
+
+		Context("when deleting a snapshot", func() {
+			It("should delete the snapshot", func() {
+				Expect(snapshotCmd.Parse([]string{"delete", "some-snapshot"})).To(Succeed())
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().DeleteSnapshot("some-default-vm-name", "some-snapshot"),
+					mockUI.EXPECT().Say("Snapshot some-snapshot deleted."),
+				)
+
+				Expect(snapshotCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when getting the VM name fails", func() {
+			It("should return the error", func() {
+				Expect(snapshotCmd.Parse([]string{"list"})).To(Succeed())
+				mockProvider.EXPECT().GetVMName().Return("", errors.New("some-error"))
+
+				Expect(snapshotCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
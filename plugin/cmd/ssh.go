@@ -10,12 +10,16 @@ const SSH_ARGS = 0
 
 type SSHCmd struct {
 	VMBuilder VMBuilder
-	VBox      VBox
+	Provider  Provider
 	Config    *config.Config
+
+	flagContext flags.FlagContext
 }
 
 func (s *SSHCmd) Parse(args []string) error {
-	return parse(flags.New(), args, SSH_ARGS)
+	s.flagContext = flags.New()
+	s.flagContext.NewStringFlag("c", "", "<command>")
+	return parse(s.flagContext, args, SSH_ARGS)
 }
 
 func (s *SSHCmd) Run() error {
@@ -23,11 +27,15 @@ func (s *SSHCmd) Run() error {
 	if err != nil {
 		return err
 	}
+
+	if command := s.flagContext.String("c"); command != "" {
+		return vm.RunCommand(command)
+	}
 	return vm.SSH()
 }
 
 func (s *SSHCmd) getVM() (vm vm.VM, err error) {
-	name, err := s.VBox.GetVMName()
+	name, err := s.Provider.GetVMName()
 	if err != nil {
 		return nil, err
 	}
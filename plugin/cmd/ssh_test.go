@@ -16,18 +16,18 @@ var _ = Describe("SSHCmd", func() {
 	var (
 		sshCmd        *cmd.SSHCmd
 		mockCtrl      *gomock.Controller
-		mockVBox      *mocks.MockVBox
+		mockProvider  *mocks.MockProvider
 		mockVMBuilder *mocks.MockVMBuilder
 		mockVM        *vmMocks.MockVM
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
 		mockVM = vmMocks.NewMockVM(mockCtrl)
 		sshCmd = &cmd.SSHCmd{
-			VBox:      mockVBox,
+			Provider:  mockProvider,
 			VMBuilder: mockVMBuilder,
 			Config: &config.Config{
 				DefaultVMName: "some-default-vm-name",
@@ -43,11 +43,13 @@ var _ = Describe("SSHCmd", func() {
 		Context("when the correct number of arguments are passed", func() {
 			It("should succeed", func() {
 				Expect(sshCmd.Parse([]string{})).To(Succeed())
+				Expect(sshCmd.Parse([]string{"-c", "some-command"})).To(Succeed())
 			})
 		})
 		Context("when the wrong number of arguments are passed", func() {
 			It("should fail", func() {
 				Expect(sshCmd.Parse([]string{"some-bad-arg"})).NotTo(Succeed())
+				Expect(sshCmd.Parse([]string{"-c"})).NotTo(Succeed())
 			})
 		})
 		Context("when an unknown flag is passed", func() {
@@ -58,9 +60,13 @@ var _ = Describe("SSHCmd", func() {
 	})
 
 	Describe("Run", func() {
+		BeforeEach(func() {
+			Expect(sshCmd.Parse([]string{})).To(Succeed())
+		})
+
 		It("should call SSH on the VM", func() {
 			gomock.InOrder(
-				mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 				mockVM.EXPECT().SSH(),
 			)
@@ -70,7 +76,7 @@ var _ = Describe("SSHCmd", func() {
 
 		Context("when there is an error getting the VM name", func() {
 			It("should return the error", func() {
-				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+				mockProvider.EXPECT().GetVMName().Return("", errors.New("some-error"))
 
 				Expect(sshCmd.Run()).To(MatchError("some-error"))
 			})
@@ -79,7 +85,7 @@ var _ = Describe("SSHCmd", func() {
 		Context("when there is an error building the VM", func() {
 			It("should return the error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
 				)
 
@@ -90,7 +96,7 @@ var _ = Describe("SSHCmd", func() {
 		Context("when there is an error SSHing to PCF Dev", func() {
 			It("should return the error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().SSH().Return(errors.New("some-error")),
 				)
@@ -98,5 +104,33 @@ var _ = Describe("SSHCmd", func() {
 				Expect(sshCmd.Run()).To(MatchError("some-error"))
 			})
 		})
+
+		Context("when a command is passed", func() {
+			BeforeEach(func() {
+				Expect(sshCmd.Parse([]string{"-c", "some-command"})).To(Succeed())
+			})
+
+			It("should run the command on the VM", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().RunCommand("some-command"),
+				)
+
+				Expect(sshCmd.Run()).To(Succeed())
+			})
+
+			Context("when running the command fails", func() {
+				It("should return the error", func() {
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+						mockVM.EXPECT().RunCommand("some-command").Return(errors.New("some-error")),
+					)
+
+					Expect(sshCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+		})
 	})
 })
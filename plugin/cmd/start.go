@@ -13,7 +13,7 @@ const START_ARGS = 0
 
 type StartCmd struct {
 	Opts         *vm.StartOpts
-	VBox         VBox
+	Provider     Provider
 	VMBuilder    VMBuilder
 	Config       *config.Config
 	AutoTrustCmd AutoCmd
@@ -32,6 +32,8 @@ func (s *StartCmd) Parse(args []string) error {
 	s.flagContext.NewIntFlag("c", "", "<number of cpus>")
 	s.flagContext.NewIntFlag("m", "", "<memory in MB>")
 	s.flagContext.NewStringFlag("o", "", "<path to custom ova>")
+	s.flagContext.NewStringFlag("ova-source", "", "<ova source>")
+	s.flagContext.NewBoolFlag("accept-eula", "", "<accept the EULA>")
 	s.flagContext.NewStringFlag("r", "", "<docker registries>")
 	s.flagContext.NewStringFlag("s", "", "<services to start with>")
 	s.flagContext.NewStringFlag("d", "", "<domain>")
@@ -66,12 +68,15 @@ func (s *StartCmd) Parse(args []string) error {
 }
 
 func (s *StartCmd) Run() error {
-	version, err := s.VBox.Version()
+	version, err := s.Provider.Version()
 	if err != nil {
 		return err
 	}
 
 	if version.Major < 5 {
+		if s.Config.Provider == config.ProviderQEMU {
+			return &OldQEMUError{}
+		}
 		return &OldDriverError{}
 	}
 
@@ -83,7 +88,7 @@ func (s *StartCmd) Run() error {
 		name = s.Config.DefaultVMName
 	}
 
-	existingVMName, err := s.VBox.GetVMName()
+	existingVMName, err := s.Provider.GetVMName()
 	if err != nil {
 		return err
 	}
@@ -103,6 +108,10 @@ func (s *StartCmd) Run() error {
 		name = "pcfdev-custom"
 	}
 
+	if existingVMName == "" {
+		s.applyUserConfig()
+	}
+
 	v, err := s.VMBuilder.VM(name)
 	if err != nil {
 		return err
@@ -115,6 +124,12 @@ func (s *StartCmd) Run() error {
 			return err
 		}
 		if s.Opts.OVAPath == "" && existingVMName != "pcfdev-custom" {
+			if ovaSource := s.flagContext.String("ova-source"); ovaSource != "" {
+				s.Config.OVASource = ovaSource
+			}
+			if s.flagContext.Bool("accept-eula") {
+				s.Config.AcceptEULA = true
+			}
 			if err := s.DownloadCmd.Run(); err != nil {
 				return err
 			}
@@ -138,6 +153,32 @@ func (s *StartCmd) Run() error {
 	}
 }
 
+func (s *StartCmd) applyUserConfig() {
+	if s.Config.UserConfig == nil {
+		return
+	}
+
+	defaults := startOptsFromUserConfig(s.Config.UserConfig)
+	if s.Opts.CPUs == 0 {
+		s.Opts.CPUs = defaults.CPUs
+	}
+	if s.Opts.Memory == uint64(0) {
+		s.Opts.Memory = defaults.Memory
+	}
+	if s.Opts.Services == "" {
+		s.Opts.Services = defaults.Services
+	}
+	if s.Opts.Registries == "" {
+		s.Opts.Registries = defaults.Registries
+	}
+	if s.Opts.Domain == "" {
+		s.Opts.Domain = defaults.Domain
+	}
+	if s.Opts.IP == "" {
+		s.Opts.IP = defaults.IP
+	}
+}
+
 func (s *StartCmd) getPCFDevPassword() (string, error) {
 	if os.Getenv("PCFDEV_PASSWORD") != "" {
 		return os.Getenv("PCFDEV_PASSWORD"), nil
@@ -9,7 +9,7 @@ import (
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
-	"github.com/pivotal-cf/pcfdev-cli/vboxdriver"
+	"github.com/pivotal-cf/pcfdev-cli/provider"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
 	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
 	"os"
@@ -20,7 +20,7 @@ var _ = Describe("StartCmd", func() {
 		startCmd         *cmd.StartCmd
 		mockCtrl         *gomock.Controller
 		mockVMBuilder    *mocks.MockVMBuilder
-		mockVBox         *mocks.MockVBox
+		mockProvider     *mocks.MockProvider
 		mockUI           *mocks.MockUI
 		mockVM           *vmMocks.MockVM
 		mockStartedVM    *vmMocks.MockVM
@@ -32,7 +32,7 @@ var _ = Describe("StartCmd", func() {
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockVM = vmMocks.NewMockVM(mockCtrl)
 		mockStartedVM = vmMocks.NewMockVM(mockCtrl)
 		mockDownloadCmd = mocks.NewMockCmd(mockCtrl)
@@ -40,7 +40,7 @@ var _ = Describe("StartCmd", func() {
 		mockTargetCmd = mocks.NewMockCmd(mockCtrl)
 		mockUI = mocks.NewMockUI(mockCtrl)
 		startCmd = &cmd.StartCmd{
-			VBox:      mockVBox,
+			Provider:  mockProvider,
 			VMBuilder: mockVMBuilder,
 			Config: &config.Config{
 				DefaultVMName: "some-default-vm-name",
@@ -186,8 +186,8 @@ var _ = Describe("StartCmd", func() {
 				}
 				startCmd.Opts = startOpts
 				gomock.InOrder(
-					mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().VerifyStartOpts(startOpts),
 					mockDownloadCmd.EXPECT().Run(),
@@ -197,13 +197,97 @@ var _ = Describe("StartCmd", func() {
 				Expect(startCmd.Run()).To(Succeed())
 			})
 
+			Context("when a config file sets start options", func() {
+				BeforeEach(func() {
+					userConfig, err := config.ParseUserConfig([]byte("cpus: 4\nmemory: 8192\nservices: all\nregistries: some-registry:5000\ndomain: some-domain\n"))
+					Expect(err).NotTo(HaveOccurred())
+					startCmd.Config.UserConfig = userConfig
+				})
+
+				It("should use them below the command line flags", func() {
+					startCmd.Parse([]string{"-m", "6144", "-i", "some-ip"})
+					startOpts := &vm.StartOpts{
+						CPUs:       4,
+						Memory:     uint64(6144),
+						Services:   "all",
+						Registries: "some-registry:5000",
+						Domain:     "some-domain",
+						IP:         "some-ip",
+					}
+
+					gomock.InOrder(
+						mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
+						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+						mockVM.EXPECT().VerifyStartOpts(startOpts),
+						mockDownloadCmd.EXPECT().Run(),
+						mockVM.EXPECT().Start(startOpts),
+					)
+
+					Expect(startCmd.Run()).To(Succeed())
+				})
+
+				Context("when the VM has already been created", func() {
+					It("should ignore them", func() {
+						gomock.InOrder(
+							mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+							mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+							mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+							mockVM.EXPECT().VerifyStartOpts(&vm.StartOpts{}),
+							mockDownloadCmd.EXPECT().Run(),
+							mockVM.EXPECT().Start(&vm.StartOpts{}),
+						)
+
+						Expect(startCmd.Run()).To(Succeed())
+					})
+				})
+			})
+
+			Context("when an OVA source is passed", func() {
+				It("should download the OVA from that source", func() {
+					startCmd.Parse([]string{"--ova-source", "/some/mirror"})
+
+					gomock.InOrder(
+						mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
+						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+						mockVM.EXPECT().VerifyStartOpts(&vm.StartOpts{}),
+						mockDownloadCmd.EXPECT().Run().Do(func() {
+							Expect(startCmd.Config.OVASource).To(Equal("/some/mirror"))
+						}),
+						mockVM.EXPECT().Start(&vm.StartOpts{}),
+					)
+
+					Expect(startCmd.Run()).To(Succeed())
+				})
+			})
+
+			Context("when --accept-eula is passed", func() {
+				It("should accept the EULA when downloading the OVA", func() {
+					startCmd.Parse([]string{"--accept-eula"})
+
+					gomock.InOrder(
+						mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
+						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+						mockVM.EXPECT().VerifyStartOpts(&vm.StartOpts{}),
+						mockDownloadCmd.EXPECT().Run().Do(func() {
+							Expect(startCmd.Config.AcceptEULA).To(BeTrue())
+						}),
+						mockVM.EXPECT().Start(&vm.StartOpts{}),
+					)
+
+					Expect(startCmd.Run()).To(Succeed())
+				})
+			})
+
 			Context("when the trust option is passed", func() {
 				It("should trust the VM certificate after starting", func() {
 					startCmd.Parse([]string{"-k"})
 
 					gomock.InOrder(
-						mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 						mockVM.EXPECT().VerifyStartOpts(&vm.StartOpts{}),
 						mockDownloadCmd.EXPECT().Run(),
@@ -220,8 +304,8 @@ var _ = Describe("StartCmd", func() {
 					startCmd.Parse([]string{"-t"})
 
 					gomock.InOrder(
-						mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 						mockVM.EXPECT().VerifyStartOpts(&vm.StartOpts{Target: true}),
 						mockDownloadCmd.EXPECT().Run(),
@@ -238,8 +322,8 @@ var _ = Describe("StartCmd", func() {
 					startCmd.Parse([]string{"-t"})
 
 					gomock.InOrder(
-						mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 						mockVM.EXPECT().VerifyStartOpts(&vm.StartOpts{Target: true}),
 						mockDownloadCmd.EXPECT().Run(),
@@ -256,8 +340,8 @@ var _ = Describe("StartCmd", func() {
 					startCmd.Parse([]string{"-t", "-k"})
 
 					gomock.InOrder(
-						mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 						mockVM.EXPECT().VerifyStartOpts(&vm.StartOpts{Target: true}),
 						mockDownloadCmd.EXPECT().Run(),
@@ -272,15 +356,24 @@ var _ = Describe("StartCmd", func() {
 
 			Context("when virtualbox version is too old", func() {
 				It("should tell the user to upgrade virtualbox", func() {
-					mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 4}, nil)
+					mockProvider.EXPECT().Version().Return(&provider.Version{Major: 4}, nil)
 
 					Expect(startCmd.Run()).To(MatchError("please install Virtualbox version 5 or greater"))
 				})
 			})
 
+			Context("when the qemu provider is selected and qemu version is too old", func() {
+				It("should tell the user to upgrade qemu", func() {
+					startCmd.Config.Provider = "qemu"
+					mockProvider.EXPECT().Version().Return(&provider.Version{Major: 4}, nil)
+
+					Expect(startCmd.Run()).To(MatchError("please install QEMU version 5 or greater"))
+				})
+			})
+
 			Context("when there is an error retrieving the virtualbox version", func() {
 				It("should return the error", func() {
-					mockVBox.EXPECT().Version().Return(nil, errors.New("some-error"))
+					mockProvider.EXPECT().Version().Return(nil, errors.New("some-error"))
 
 					Expect(startCmd.Run()).To(MatchError("some-error"))
 				})
@@ -288,8 +381,8 @@ var _ = Describe("StartCmd", func() {
 
 			Context("when there is an old vm present", func() {
 				It("should tell the user to destroy pcfdev", func() {
-					mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil)
-					mockVBox.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+					mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil)
+					mockProvider.EXPECT().GetVMName().Return("some-old-vm-name", nil)
 
 					Expect(startCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
 				})
@@ -297,8 +390,8 @@ var _ = Describe("StartCmd", func() {
 
 			Context("when there is an error getting the VM name", func() {
 				It("should return the error", func() {
-					mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil)
-					mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+					mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil)
+					mockProvider.EXPECT().GetVMName().Return("", errors.New("some-error"))
 
 					Expect(startCmd.Run()).To(MatchError("some-error"))
 				})
@@ -307,8 +400,8 @@ var _ = Describe("StartCmd", func() {
 			Context("when it fails to get VM", func() {
 				It("should return an error", func() {
 					gomock.InOrder(
-						mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
 					)
 					Expect(startCmd.Run()).To(MatchError("some-error"))
@@ -318,8 +411,8 @@ var _ = Describe("StartCmd", func() {
 			Context("when verifying start options fails", func() {
 				It("should return an error", func() {
 					gomock.InOrder(
-						mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 						mockVM.EXPECT().VerifyStartOpts(&vm.StartOpts{}).Return(errors.New("some-error")),
 					)
@@ -331,8 +424,8 @@ var _ = Describe("StartCmd", func() {
 			Context("when the OVA fails to download", func() {
 				It("should print an error message", func() {
 					gomock.InOrder(
-						mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 						mockVM.EXPECT().VerifyStartOpts(&vm.StartOpts{}),
 						mockDownloadCmd.EXPECT().Run().Return(errors.New("some-error")),
@@ -345,8 +438,8 @@ var _ = Describe("StartCmd", func() {
 			Context("when it fails to start VM", func() {
 				It("should return an error", func() {
 					gomock.InOrder(
-						mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 						mockVM.EXPECT().VerifyStartOpts(&vm.StartOpts{}),
 						mockDownloadCmd.EXPECT().Run(),
@@ -362,8 +455,8 @@ var _ = Describe("StartCmd", func() {
 					startCmd.Parse([]string{"-k"})
 
 					gomock.InOrder(
-						mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
-						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+						mockProvider.EXPECT().GetVMName().Return("", nil),
 						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 						mockVM.EXPECT().VerifyStartOpts(&vm.StartOpts{}),
 						mockDownloadCmd.EXPECT().Run(),
@@ -383,8 +476,8 @@ var _ = Describe("StartCmd", func() {
 				}
 				startCmd.Opts = startOpts
 				gomock.InOrder(
-					mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockVMBuilder.EXPECT().VM("pcfdev-custom").Return(mockVM, nil),
 					mockVM.EXPECT().VerifyStartOpts(startOpts),
 					mockVM.EXPECT().Start(startOpts),
@@ -396,8 +489,8 @@ var _ = Describe("StartCmd", func() {
 			Context("when the custom VM is already present and OVAPath is not set", func() {
 				It("should start the custom VM", func() {
 					gomock.InOrder(
-						mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
-						mockVBox.EXPECT().GetVMName().Return("pcfdev-custom", nil),
+						mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+						mockProvider.EXPECT().GetVMName().Return("pcfdev-custom", nil),
 						mockVMBuilder.EXPECT().VM("pcfdev-custom").Return(mockVM, nil),
 						mockVM.EXPECT().VerifyStartOpts(&vm.StartOpts{}),
 						mockVM.EXPECT().Start(&vm.StartOpts{}),
@@ -413,8 +506,8 @@ var _ = Describe("StartCmd", func() {
 					}
 					startCmd.Opts = startOpts
 					gomock.InOrder(
-						mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
-						mockVBox.EXPECT().GetVMName().Return("pcfdev-custom", nil),
+						mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+						mockProvider.EXPECT().GetVMName().Return("pcfdev-custom", nil),
 						mockVMBuilder.EXPECT().VM("pcfdev-custom").Return(mockVM, nil),
 						mockVM.EXPECT().VerifyStartOpts(startOpts),
 						mockVM.EXPECT().Start(startOpts),
@@ -429,8 +522,8 @@ var _ = Describe("StartCmd", func() {
 						OVAPath: "some-custom-ova",
 					}
 					startCmd.Opts = startOpts
-					mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil)
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil)
+					mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil)
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil)
 					Expect(startCmd.Run()).To(MatchError("you must destroy your existing VM to use a custom OVA"))
 				})
 			})
@@ -441,8 +534,8 @@ var _ = Describe("StartCmd", func() {
 						OVAPath: "some-custom-ova",
 					}
 					startCmd.Opts = startOpts
-					mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil)
-					mockVBox.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+					mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil)
+					mockProvider.EXPECT().GetVMName().Return("some-old-vm-name", nil)
 					Expect(startCmd.Run()).To(MatchError("you must destroy your existing VM to use a custom OVA"))
 				})
 			})
@@ -453,8 +546,8 @@ var _ = Describe("StartCmd", func() {
 				startCmd.Parse([]string{"-p"})
 
 				gomock.InOrder(
-					mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Provision(&vm.StartOpts{}),
 				)
@@ -468,8 +561,8 @@ var _ = Describe("StartCmd", func() {
 				startCmd.Parse([]string{"-p"})
 
 				gomock.InOrder(
-					mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().Version().Return(&provider.Version{Major: 5}, nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Provision(&vm.StartOpts{}).Return(errors.New("some-error")),
 				)
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"encoding/json"
+
 	"github.com/cloudfoundry/cli/cf/flags"
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
@@ -9,14 +11,23 @@ import (
 const STATUS_ARGS = 0
 
 type StatusCmd struct {
-	VBox      VBox
-	VMBuilder VMBuilder
-	Config    *config.Config
-	UI        UI
+	Provider    Provider
+	VMBuilder   VMBuilder
+	Config      *config.Config
+	UI          UI
+	JSON        bool
+	flagContext flags.FlagContext
 }
 
 func (s *StatusCmd) Parse(args []string) error {
-	return parse(flags.New(), args, STATUS_ARGS)
+	s.flagContext = flags.New()
+	s.flagContext.NewBoolFlag("json", "", "<json>")
+	if err := parse(s.flagContext, args, STATUS_ARGS); err != nil {
+		return err
+	}
+
+	s.JSON = s.flagContext.Bool("json")
+	return nil
 }
 
 func (s *StatusCmd) Run() error {
@@ -24,12 +35,26 @@ func (s *StatusCmd) Run() error {
 	if err != nil {
 		return err
 	}
-	s.UI.Say(vm.Status())
+
+	if !s.JSON {
+		s.UI.Say(vm.Status())
+		return nil
+	}
+
+	status, err := vm.StatusDetails()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	s.UI.Say(string(data))
 	return nil
 }
 
 func (s *StatusCmd) getVM() (vm vm.VM, err error) {
-	name, err := s.VBox.GetVMName()
+	name, err := s.Provider.GetVMName()
 	if err != nil {
 		return nil, err
 	}
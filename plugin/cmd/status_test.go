@@ -9,6 +9,7 @@ import (
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
 	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
 )
 
@@ -17,7 +18,7 @@ var _ = Describe("StatusCmd", func() {
 		statusCmd     *cmd.StatusCmd
 		mockCtrl      *gomock.Controller
 		mockVMBuilder *mocks.MockVMBuilder
-		mockVBox      *mocks.MockVBox
+		mockProvider  *mocks.MockProvider
 		mockVM        *vmMocks.MockVM
 		mockUI        *mocks.MockUI
 	)
@@ -25,12 +26,12 @@ var _ = Describe("StatusCmd", func() {
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockVM = vmMocks.NewMockVM(mockCtrl)
 		mockUI = mocks.NewMockUI(mockCtrl)
 		statusCmd = &cmd.StatusCmd{
 			UI:        mockUI,
-			VBox:      mockVBox,
+			Provider:  mockProvider,
 			VMBuilder: mockVMBuilder,
 			Config: &config.Config{
 				DefaultVMName: "some-default-vm-name",
@@ -58,13 +59,19 @@ var _ = Describe("StatusCmd", func() {
 				Expect(statusCmd.Parse([]string{"--some-bad-flag"})).NotTo(Succeed())
 			})
 		})
+		Context("when the json flag is passed", func() {
+			It("should set JSON", func() {
+				Expect(statusCmd.Parse([]string{"--json"})).To(Succeed())
+				Expect(statusCmd.JSON).To(BeTrue())
+			})
+		})
 	})
 
 	Describe("Run", func() {
 		Context("when the default vm is present", func() {
 			It("should return the status", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Status().Return("some-status"),
 					mockUI.EXPECT().Say("some-status"),
@@ -77,7 +84,7 @@ var _ = Describe("StatusCmd", func() {
 		Context("when the custom vm is present", func() {
 			It("should return the status", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("pcfdev-custom", nil),
+					mockProvider.EXPECT().GetVMName().Return("pcfdev-custom", nil),
 					mockVMBuilder.EXPECT().VM("pcfdev-custom").Return(mockVM, nil),
 					mockVM.EXPECT().Status().Return("some-status"),
 					mockUI.EXPECT().Say("some-status"),
@@ -90,7 +97,7 @@ var _ = Describe("StatusCmd", func() {
 		Context("when there is no vm present", func() {
 			It("should return the status of the default VM", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Status().Return("some-status"),
 					mockUI.EXPECT().Say("some-status"),
@@ -100,9 +107,48 @@ var _ = Describe("StatusCmd", func() {
 			})
 		})
 
+		Context("when the json flag is set", func() {
+			BeforeEach(func() {
+				statusCmd.JSON = true
+			})
+
+			It("should return the status as JSON", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().StatusDetails().Return(&vm.Status{
+						State:    "running",
+						VMName:   "some-default-vm-name",
+						IP:       "192.168.11.11",
+						Domain:   "local.pcfdev.io",
+						Memory:   uint64(4096),
+						CPUs:     2,
+						SSHPort:  "some-port",
+						APIURL:   "https://api.local.pcfdev.io",
+						Services: []string{"rabbitmq", "redis"},
+					}, nil),
+					mockUI.EXPECT().Say(`{"state":"running","vm_name":"some-default-vm-name","ip":"192.168.11.11","domain":"local.pcfdev.io","memory":4096,"cpus":2,"ssh_port":"some-port","api_url":"https://api.local.pcfdev.io","services":["rabbitmq","redis"]}`),
+				)
+
+				Expect(statusCmd.Run()).To(Succeed())
+			})
+
+			Context("when getting the status details fails", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+						mockVM.EXPECT().StatusDetails().Return(nil, errors.New("some-error")),
+					)
+
+					Expect(statusCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+		})
+
 		Context("when there is an old vm present", func() {
 			It("should tell the user to destroy pcfdev", func() {
-				mockVBox.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+				mockProvider.EXPECT().GetVMName().Return("some-old-vm-name", nil)
 
 				Expect(statusCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
 			})
@@ -110,7 +156,7 @@ var _ = Describe("StatusCmd", func() {
 
 		Context("when there is an error getting the VM name", func() {
 			It("should return the error", func() {
-				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+				mockProvider.EXPECT().GetVMName().Return("", errors.New("some-error"))
 
 				Expect(statusCmd.Run()).To(MatchError("some-error"))
 			})
@@ -119,7 +165,7 @@ var _ = Describe("StatusCmd", func() {
 		Context("when it fails to get VM", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
 				)
 
@@ -9,7 +9,7 @@ import (
 const STOP_ARGS = 0
 
 type StopCmd struct {
-	VBox      VBox
+	Provider  Provider
 	VMBuilder VMBuilder
 	Config    *config.Config
 }
@@ -27,7 +27,7 @@ func (s *StopCmd) Run() error {
 }
 
 func (s *StopCmd) getVM() (vm vm.VM, err error) {
-	name, err := s.VBox.GetVMName()
+	name, err := s.Provider.GetVMName()
 	if err != nil {
 		return nil, err
 	}
@@ -17,17 +17,17 @@ var _ = Describe("StopCmd", func() {
 		stopCmd       *cmd.StopCmd
 		mockCtrl      *gomock.Controller
 		mockVMBuilder *mocks.MockVMBuilder
-		mockVBox      *mocks.MockVBox
+		mockProvider  *mocks.MockProvider
 		mockVM        *vmMocks.MockVM
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockVM = vmMocks.NewMockVM(mockCtrl)
 		stopCmd = &cmd.StopCmd{
-			VBox:      mockVBox,
+			Provider:  mockProvider,
 			VMBuilder: mockVMBuilder,
 			Config: &config.Config{
 				DefaultVMName: "some-default-vm-name",
@@ -59,7 +59,7 @@ var _ = Describe("StopCmd", func() {
 		Context("when the default vm is present", func() {
 			It("should stop the VM", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Stop(),
 				)
@@ -71,7 +71,7 @@ var _ = Describe("StopCmd", func() {
 		Context("when the custom vm is present", func() {
 			It("should stop the VM", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("pcfdev-custom", nil),
+					mockProvider.EXPECT().GetVMName().Return("pcfdev-custom", nil),
 					mockVMBuilder.EXPECT().VM("pcfdev-custom").Return(mockVM, nil),
 					mockVM.EXPECT().Stop(),
 				)
@@ -83,7 +83,7 @@ var _ = Describe("StopCmd", func() {
 		Context("when there is no vm present", func() {
 			It("should stop the default VM", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Stop(),
 				)
@@ -94,7 +94,7 @@ var _ = Describe("StopCmd", func() {
 
 		Context("when there is an old vm present", func() {
 			It("should tell the user to destroy pcfdev", func() {
-				mockVBox.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+				mockProvider.EXPECT().GetVMName().Return("some-old-vm-name", nil)
 
 				Expect(stopCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
 			})
@@ -102,7 +102,7 @@ var _ = Describe("StopCmd", func() {
 
 		Context("when there is an getting the VM name", func() {
 			It("should return the error", func() {
-				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+				mockProvider.EXPECT().GetVMName().Return("", errors.New("some-error"))
 
 				Expect(stopCmd.Run()).To(MatchError("some-error"))
 			})
@@ -111,7 +111,7 @@ var _ = Describe("StopCmd", func() {
 		Context("when it fails to get VM", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
 				)
 
@@ -122,7 +122,7 @@ var _ = Describe("StopCmd", func() {
 		Context("when it fails to stop VM", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Stop().Return(errors.New("some-error")),
 				)
@@ -9,7 +9,7 @@ import (
 const SUSPEND_ARGS = 0
 
 type SuspendCmd struct {
-	VBox      VBox
+	Provider  Provider
 	VMBuilder VMBuilder
 	Config    *config.Config
 }
@@ -27,7 +27,7 @@ func (s *SuspendCmd) Run() error {
 }
 
 func (s *SuspendCmd) getVM() (vm vm.VM, err error) {
-	name, err := s.VBox.GetVMName()
+	name, err := s.Provider.GetVMName()
 	if err != nil {
 		return nil, err
 	}
@@ -17,17 +17,17 @@ var _ = Describe("SuspendCmd", func() {
 		suspendCmd    *cmd.SuspendCmd
 		mockCtrl      *gomock.Controller
 		mockVMBuilder *mocks.MockVMBuilder
-		mockVBox      *mocks.MockVBox
+		mockProvider  *mocks.MockProvider
 		mockVM        *vmMocks.MockVM
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockVM = vmMocks.NewMockVM(mockCtrl)
 		suspendCmd = &cmd.SuspendCmd{
-			VBox:      mockVBox,
+			Provider:  mockProvider,
 			VMBuilder: mockVMBuilder,
 			Config: &config.Config{
 				DefaultVMName: "some-default-vm-name",
@@ -60,7 +60,7 @@ var _ = Describe("SuspendCmd", func() {
 		Context("when the default VM is present", func() {
 			It("should suspend the VM", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Suspend(),
 				)
@@ -72,7 +72,7 @@ var _ = Describe("SuspendCmd", func() {
 		Context("when the custom vm is present", func() {
 			It("should suspend the VM", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("pcfdev-custom", nil),
+					mockProvider.EXPECT().GetVMName().Return("pcfdev-custom", nil),
 					mockVMBuilder.EXPECT().VM("pcfdev-custom").Return(mockVM, nil),
 					mockVM.EXPECT().Suspend(),
 				)
@@ -84,7 +84,7 @@ var _ = Describe("SuspendCmd", func() {
 		Context("when there is no vm present", func() {
 			It("should suspend the default VM", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Suspend(),
 				)
@@ -95,7 +95,7 @@ var _ = Describe("SuspendCmd", func() {
 
 		Context("when there is an old vm present", func() {
 			It("should tell the user to destroy pcfdev", func() {
-				mockVBox.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+				mockProvider.EXPECT().GetVMName().Return("some-old-vm-name", nil)
 
 				Expect(suspendCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
 			})
@@ -103,7 +103,7 @@ var _ = Describe("SuspendCmd", func() {
 
 		Context("when there is an error checking for an old vm present", func() {
 			It("should return the error", func() {
-				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+				mockProvider.EXPECT().GetVMName().Return("", errors.New("some-error"))
 
 				Expect(suspendCmd.Run()).To(MatchError("some-error"))
 			})
@@ -112,7 +112,7 @@ var _ = Describe("SuspendCmd", func() {
 		Context("when it fails to get VM", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
 				)
 
@@ -123,7 +123,7 @@ var _ = Describe("SuspendCmd", func() {
 		Context("when it fails to suspend VM", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockProvider.EXPECT().GetVMName().Return("", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Suspend().Return(errors.New("some-error")),
 				)
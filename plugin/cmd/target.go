@@ -10,7 +10,7 @@ const TARGET_ARGS = 0
 
 type TargetCmd struct {
 	VMBuilder  VMBuilder
-	VBox       VBox
+	Provider   Provider
 	Config     *config.Config
 	AutoTarget bool
 }
@@ -28,7 +28,7 @@ func (t *TargetCmd) Run() error {
 }
 
 func (t *TargetCmd) getVM() (vm vm.VM, err error) {
-	name, err := t.VBox.GetVMName()
+	name, err := t.Provider.GetVMName()
 	if err != nil {
 		return nil, err
 	}
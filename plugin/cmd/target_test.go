@@ -16,18 +16,18 @@ var _ = Describe("TargetCmd", func() {
 	var (
 		targetCmd     *cmd.TargetCmd
 		mockCtrl      *gomock.Controller
-		mockVBox      *mocks.MockVBox
+		mockProvider  *mocks.MockProvider
 		mockVMBuilder *mocks.MockVMBuilder
 		mockVM        *vmMocks.MockVM
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
 		mockVM = vmMocks.NewMockVM(mockCtrl)
 		targetCmd = &cmd.TargetCmd{
-			VBox:      mockVBox,
+			Provider:  mockProvider,
 			VMBuilder: mockVMBuilder,
 			Config: &config.Config{
 				DefaultVMName: "some-default-vm-name",
@@ -60,7 +60,7 @@ var _ = Describe("TargetCmd", func() {
 	Describe("Run", func() {
 		It("should call Target on the VM", func() {
 			gomock.InOrder(
-				mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 				mockVM.EXPECT().Target(false),
 			)
@@ -72,7 +72,7 @@ var _ = Describe("TargetCmd", func() {
 			It("should pass the autoTarget flag to Target", func() {
 				targetCmd.AutoTarget = true
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Target(true),
 				)
@@ -83,7 +83,7 @@ var _ = Describe("TargetCmd", func() {
 
 		Context("when there is an error getting the VM name", func() {
 			It("should return the error", func() {
-				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+				mockProvider.EXPECT().GetVMName().Return("", errors.New("some-error"))
 
 				Expect(targetCmd.Run()).To(MatchError("some-error"))
 			})
@@ -92,7 +92,7 @@ var _ = Describe("TargetCmd", func() {
 		Context("when there is an error building the VM", func() {
 			It("should return the error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
 				)
 
@@ -103,7 +103,7 @@ var _ = Describe("TargetCmd", func() {
 		Context("when there is an error targeting PCF Dev", func() {
 			It("should return the error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Target(false).Return(errors.New("some-error")),
 				)
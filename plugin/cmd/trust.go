@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/cert"
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
 )
@@ -11,7 +15,7 @@ const TRUST_ARGS = 0
 type TrustCmd struct {
 	Opts        *vm.StartOpts
 	VMBuilder   VMBuilder
-	VBox        VBox
+	Provider    Provider
 	Config      *config.Config
 	flagContext flags.FlagContext
 }
@@ -19,17 +23,49 @@ type TrustCmd struct {
 func (t *TrustCmd) Parse(args []string) error {
 	t.flagContext = flags.New()
 	t.flagContext.NewBoolFlag("p", "", "<trust>")
+	t.flagContext.NewStringFlag("stores", "", "<stores>")
 	if err := parse(t.flagContext, args, TRUST_ARGS); err != nil {
 		return err
 	}
 
+	stores, err := parseStores(t.flagContext.String("stores"))
+	if err != nil {
+		return err
+	}
+
 	t.Opts = &vm.StartOpts{
-		PrintCA: t.flagContext.Bool("p"),
+		PrintCA:     t.flagContext.Bool("p"),
+		TrustStores: stores,
 	}
 
 	return nil
 }
 
+func parseStores(value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	stores := []string{}
+	for _, store := range strings.Split(value, ",") {
+		store = strings.TrimSpace(store)
+		if !isKnownStore(store) {
+			return nil, fmt.Errorf("unknown certificate store: %s, use %s", store, strings.Join(cert.Stores, ", "))
+		}
+		stores = append(stores, store)
+	}
+	return stores, nil
+}
+
+func isKnownStore(store string) bool {
+	for _, known := range cert.Stores {
+		if store == known {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *TrustCmd) Run() error {
 	vm, err := t.getVM()
 	if err != nil {
@@ -39,7 +75,7 @@ func (t *TrustCmd) Run() error {
 }
 
 func (t *TrustCmd) getVM() (vm vm.VM, err error) {
-	name, err := t.VBox.GetVMName()
+	name, err := t.Provider.GetVMName()
 	if err != nil {
 		return nil, err
 	}
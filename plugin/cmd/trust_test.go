@@ -17,18 +17,18 @@ var _ = Describe("TrustCmd", func() {
 	var (
 		trustCmd      *cmd.TrustCmd
 		mockCtrl      *gomock.Controller
-		mockVBox      *mocks.MockVBox
+		mockProvider  *mocks.MockProvider
 		mockVMBuilder *mocks.MockVMBuilder
 		mockVM        *vmMocks.MockVM
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
 		mockVM = vmMocks.NewMockVM(mockCtrl)
 		trustCmd = &cmd.TrustCmd{
-			VBox:      mockVBox,
+			Provider:  mockProvider,
 			VMBuilder: mockVMBuilder,
 			Config: &config.Config{
 				DefaultVMName: "some-default-vm-name",
@@ -45,9 +45,17 @@ var _ = Describe("TrustCmd", func() {
 			It("should set start options", func() {
 				Expect(trustCmd.Parse([]string{
 					"-p",
+					"--stores", "system,nss,java",
 				})).To(Succeed())
 
 				Expect(trustCmd.Opts.PrintCA).To(BeTrue())
+				Expect(trustCmd.Opts.TrustStores).To(Equal([]string{"system", "nss", "java"}))
+			})
+		})
+
+		Context("when an unknown certificate store is passed", func() {
+			It("should fail", func() {
+				Expect(trustCmd.Parse([]string{"--stores", "system,some-store"})).To(MatchError("unknown certificate store: some-store, use system, nss, java"))
 			})
 		})
 
@@ -56,6 +64,7 @@ var _ = Describe("TrustCmd", func() {
 				Expect(trustCmd.Parse([]string{})).To(Succeed())
 
 				Expect(trustCmd.Opts.PrintCA).To(BeFalse())
+				Expect(trustCmd.Opts.TrustStores).To(BeNil())
 			})
 		})
 
@@ -76,7 +85,7 @@ var _ = Describe("TrustCmd", func() {
 			trustOpts := &vm.StartOpts{}
 			trustCmd.Opts = trustOpts
 			gomock.InOrder(
-				mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 				mockVM.EXPECT().Trust(trustOpts),
 			)
@@ -92,7 +101,7 @@ var _ = Describe("TrustCmd", func() {
 				trustCmd.Opts = trustOpts
 
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Trust(trustOpts),
 				)
@@ -103,7 +112,7 @@ var _ = Describe("TrustCmd", func() {
 
 		Context("when there is an error getting the VM name", func() {
 			It("should return the error", func() {
-				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+				mockProvider.EXPECT().GetVMName().Return("", errors.New("some-error"))
 
 				Expect(trustCmd.Run()).To(MatchError("some-error"))
 			})
@@ -112,7 +121,7 @@ var _ = Describe("TrustCmd", func() {
 		Context("when there is an error building the VM", func() {
 			It("should return the error", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
 				)
 
@@ -125,7 +134,7 @@ var _ = Describe("TrustCmd", func() {
 				trustOpts := &vm.StartOpts{}
 				trustCmd.Opts = trustOpts
 				gomock.InOrder(
-					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
 					mockVM.EXPECT().Trust(trustOpts).Return(errors.New("some-error")),
 				)
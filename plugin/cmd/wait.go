@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+)
+
+const WAIT_ARGS = 0
+
+type WaitCmd struct {
+	Provider  Provider
+	VMBuilder VMBuilder
+	Config    *config.Config
+
+	timeout time.Duration
+}
+
+func (w *WaitCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	flagContext.NewIntFlagWithDefault("timeout", "", "<minutes>", int(vm.ReadinessTimeout/time.Minute))
+	if err := parse(flagContext, args, WAIT_ARGS); err != nil {
+		return err
+	}
+
+	if flagContext.Int("timeout") <= 0 {
+		return errors.New("--timeout must be a positive number of minutes")
+	}
+	w.timeout = time.Duration(flagContext.Int("timeout")) * time.Minute
+	return nil
+}
+
+func (w *WaitCmd) Run() error {
+	vm, err := w.getVM()
+	if err != nil {
+		return err
+	}
+	return vm.Wait(w.timeout)
+}
+
+func (w *WaitCmd) getVM() (vm vm.VM, err error) {
+	name, err := w.Provider.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = w.Config.DefaultVMName
+	}
+	if name != w.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	return w.VMBuilder.VM(name)
+}
@@ -0,0 +1,137 @@
+package cmd_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
+)
+
+var _ = Describe("WaitCmd", func() {
+	var (
+		waitCmd       *cmd.WaitCmd
+		mockCtrl      *gomock.Controller
+		mockProvider  *mocks.MockProvider
+		mockVM        *vmMocks.MockVM
+		mockVMBuilder *mocks.MockVMBuilder
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockProvider = mocks.NewMockProvider(mockCtrl)
+		mockVM = vmMocks.NewMockVM(mockCtrl)
+		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
+		waitCmd = &cmd.WaitCmd{
+			Provider:  mockProvider,
+			VMBuilder: mockVMBuilder,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when the correct number of arguments are passed", func() {
+			It("should succeed", func() {
+				Expect(waitCmd.Parse([]string{})).To(Succeed())
+				Expect(waitCmd.Parse([]string{"--timeout", "5"})).To(Succeed())
+			})
+		})
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(waitCmd.Parse([]string{"some-bad-arg"})).NotTo(Succeed())
+			})
+		})
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(waitCmd.Parse([]string{"--some-bad-flag"})).NotTo(Succeed())
+			})
+		})
+		Context("when the timeout is not positive", func() {
+			It("should fail", func() {
+				Expect(waitCmd.Parse([]string{"--timeout", "0"})).To(MatchError("--timeout must be a positive number of minutes"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		Context("when no timeout is passed", func() {
+			It("should wait for the VM for ten minutes", func() {
+				Expect(waitCmd.Parse([]string{})).To(Succeed())
+
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Wait(10*time.Minute),
+				)
+
+				Expect(waitCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when a timeout is passed", func() {
+			It("should wait for the VM for that many minutes", func() {
+				Expect(waitCmd.Parse([]string{"--timeout", "3"})).To(Succeed())
+
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("pcfdev-custom", nil),
+					mockVMBuilder.EXPECT().VM("pcfdev-custom").Return(mockVM, nil),
+					mockVM.EXPECT().Wait(3*time.Minute),
+				)
+
+				Expect(waitCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when there is an old vm present", func() {
+			It("should tell the user to destroy pcfdev", func() {
+				mockProvider.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(waitCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
+			})
+		})
+
+		Context("when there is an error getting the VM name", func() {
+			It("should return the error", func() {
+				mockProvider.EXPECT().GetVMName().Return("", errors.New("some-error"))
+
+				Expect(waitCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when it fails to get VM", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(waitCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when PCF Dev does not become ready", func() {
+			It("should return an error", func() {
+				Expect(waitCmd.Parse([]string{})).To(Succeed())
+
+				gomock.InOrder(
+					mockProvider.EXPECT().GetVMName().Return("", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Wait(10*time.Minute).Return(errors.New("some-error")),
+				)
+
+				Expect(waitCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
@@ -32,6 +32,15 @@ type CmdBuilder interface {
 //go:generate mockgen -package mocks -destination mocks/exit.go github.com/pivotal-cf/pcfdev-cli/plugin Exit
 type Exit interface {
 	Exit()
+	ExitWithStatus(status int)
+}
+
+type exitStatusError interface {
+	ExitStatus() int
+}
+
+type cfCLICmd interface {
+	SetCFCLI(cfCLI cmd.CFCLI)
 }
 
 //go:generate mockgen -package mocks -destination mocks/cmd.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd Cmd
@@ -41,6 +50,15 @@ func (p *Plugin) Run(cliConnection cfplugin.CliConnection, args []string) {
 		return
 	}
 
+	instance, args, found := extractInstance(args)
+	if found {
+		if err := p.Config.SetInstance(instance); err != nil {
+			p.UI.Failed(getErrorText(err))
+			p.Exit.Exit()
+			return
+		}
+	}
+
 	var subcommand string
 	var cmdArgs []string
 
@@ -58,12 +76,37 @@ func (p *Plugin) Run(cliConnection cfplugin.CliConnection, args []string) {
 		p.showUsageMessage(cliConnection)
 		return
 	}
+	if cliCmd, ok := cmd.(cfCLICmd); ok {
+		cliCmd.SetCFCLI(cliConnection)
+	}
 	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(exitStatusError); ok {
+			p.Exit.ExitWithStatus(exitErr.ExitStatus())
+			return
+		}
 		p.UI.Failed(getErrorText(err))
 		p.Exit.Exit()
 	}
 }
 
+func extractInstance(args []string) (instance string, remainingArgs []string, found bool) {
+	remainingArgs = []string{}
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--instance" && i+1 < len(args):
+			instance = args[i+1]
+			found = true
+			i++
+		case strings.HasPrefix(args[i], "--instance="):
+			instance = strings.TrimPrefix(args[i], "--instance=")
+			found = true
+		default:
+			remainingArgs = append(remainingArgs, args[i])
+		}
+	}
+	return instance, remainingArgs, found
+}
+
 func (p *Plugin) showUsageMessage(cliConnection cfplugin.CliConnection) {
 	if _, err := cliConnection.CliCommand("help", "dev"); err != nil {
 		p.UI.Failed(getErrorText(err))
@@ -107,15 +150,21 @@ func (p *Plugin) GetMetadata() cfplugin.PluginMetadata {
 				Alias:    "pcfdev",
 				HelpText: "Control PCF Dev VMs running on your workstation",
 				UsageDetails: cfplugin.Usage{
-					Usage: `cf dev SUBCOMMAND
+					Usage: `cf dev SUBCOMMAND [--instance NAME]
+
+OPTIONS:
+   --instance NAME                   Operate on the named PCF Dev instance. Can also be set with PCFDEV_INSTANCE.
 
 SUBCOMMANDS:
    start                             Start the PCF Dev VM. When creating a VM, http proxy env vars are respected.
       [-c number-of-cores]           Number of processor cores used by VM. Default: number of physical cores.
-      [-d domain]                    Specify the domain that the PCF Dev VM will occupy.
-      [-i ip-address]                Specify the IP Address that the PCF Dev VM will occupy.
+      [-d domain]                    Specify the wildcard domain that the PCF Dev VM will occupy.
+      [-i ip-address]                Specify the private IP Address that the PCF Dev VM will occupy.
       [-k]                           Import VM certificates into host's trusted certificate store.
       [-m memory-in-mb]              Memory to allocate for VM. Default: half of total memory, max 4 GB, max 8 GB with SCS.
+      [--ova-source source]          Download the OVA from pivnet, an http(s) URL or a local directory.
+                                        Default: PCFDEV_OVA_SOURCE, or pivnet
+      [--accept-eula]                Accept the PCF Dev EULA without prompting. Can also be set with PCFDEV_ACCEPT_EULA=true.
       [-r registry1,registry2,...]   Docker registries that PCF Dev will use without SSL validation. Specify in 'host:port' format.
       [-s service1,service2]         Specify the services started with PCF Dev.
                                         Options: redis, rabbitmq, spring-cloud-services (scs), default, all, none
@@ -125,14 +174,61 @@ SUBCOMMANDS:
    stop                              Shutdown the PCF Dev VM. All data is preserved.
    suspend                           Save the current state of the PCF Dev VM to disk and then stop the VM.
    resume                            Resume PCF Dev VM from suspended state.
-   destroy                           Delete the PCF Dev VM. All data is destroyed.
+   wait                              Wait until the PCF Dev API, CF API and UAA are responding.
+      [--timeout minutes]            Give up after this many minutes. Default: 10
+   destroy                           Delete the PCF Dev VM and its DNS and hosts file entries. All data is destroyed.
+   resize                            Change the memory or processor cores of the PCF Dev VM.
+      [-m memory-in-mb]              Memory to allocate for VM.
+      [-c number-of-cores]           Number of processor cores used by VM.
+                                        A running VM is stopped and started again.
+   disk resize SIZE-IN-GB            Grow the disk of a running PCF Dev VM. The VM is stopped and started again.
    status                            Query for the status of the PCF Dev VM.
+      [--json]                       Output the status as JSON, including services and disk usage.
+   services                          List the optional services and whether they are enabled.
+   services enable SERVICE           Start a service in a running PCF Dev VM.
+                                        Options: redis, rabbitmq, spring-cloud-services (scs)
+   services disable SERVICE          Stop a service in a running PCF Dev VM.
+   config get [KEY]                  Print the default start options saved in $PCFDEV_HOME/config.yml.
+   config set KEY VALUE              Save a default start option, used when no flag is given to start.
+                                        Keys: cpus, memory, services, registries, domain, ip, provider
+   config unset KEY                  Remove a saved default start option.
+   eula                              Print the PCF Dev EULA from Pivotal Network.
+      [--output file]                Write the EULA to a file instead.
    import /path/to/ova               Import OVA from local filesystem.
+   cache list                        List the OVAs downloaded to $PCFDEV_HOME/ova. The OVA this plugin uses is marked with *.
+   cache verify                      Check the cached OVAs against the digests recorded when they were downloaded.
+   cache prune                       Delete the cached OVAs, except the one this plugin uses.
+      [--older-than days]            Only delete OVAs downloaded more than this many days ago.
+      [--keep count]                 Keep this many of the most recently downloaded OVAs.
+   list                              List all PCF Dev instances.
+   logout                            Remove the saved Pivotal Network API token.
    ssh                               Start an SSH session into a running PCF Dev VM.
+      [-c command]                   Run a command in the VM instead of starting an interactive session.
+                                        Exits with the status of the command.
+   releases                          List the PCF Dev releases on Pivotal Network.
+                                        Download one with: cf dev download --release VERSION
+   scp SOURCE DESTINATION            Copy files or directories to or from a running PCF Dev VM.
+                                        Prefix paths in the VM with 'vm:', e.g. cf dev scp ./app vm:/tmp/app
+   mount HOST-PATH GUEST-PATH        Share a host folder with a running PCF Dev VM and mount it at GUEST-PATH.
+                                        Folders are mounted again each time the VM starts. (VirtualBox only)
+   mounts                            List the host folders mounted in the PCF Dev VM.
+   forward LOCAL:HOST:REMOTE...      Forward local ports to addresses reachable from the PCF Dev VM until Ctrl-C.
+                                        e.g. cf dev forward 5432:10.244.0.5:5432
+   dns start                         Answer DNS queries for the PCF Dev domain on the host until Ctrl-C.
+      [--port port]                  Listen on this port of the host address of the VM network. Default: 10053
+                                        On Linux, systemd-resolved or NetworkManager is configured to use it.
+   hosts sync                        Map the PCF Dev API, UAA, login and app routes to the VM IP in the hosts file.
+   hosts clean                       Remove the PCF Dev entries from the hosts file.
+   snapshot list                     List the saved snapshots of the PCF Dev VM.
+   snapshot save NAME                Save a named snapshot of the PCF Dev VM.
+   snapshot restore NAME             Restore the PCF Dev VM to a named snapshot.
+      [-f]                           Power off a running PCF Dev VM before restoring.
+   snapshot delete NAME              Delete a named snapshot of the PCF Dev VM.
    target                            Perform a CF login to PCF Dev, as the 'user' user.
    trust                             Import VM certificates into host's trusted certificate store.
       [-p]                           Print the PCF Dev Root CA Certificate to stdout.
-   untrust                           Remove VM certificates from host's trusted certificate store.
+      [--stores system,nss,java]     Certificate stores to import into (Default: system).
+   untrust                           Remove VM certificates from all of host's trusted certificate stores.
    version                           Display the release version of the CLI.`,
 				},
 			},
@@ -7,6 +7,7 @@ import (
 	"github.com/cloudfoundry/cli/plugin/pluginfakes"
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/plugin"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/mocks"
 	"github.com/pivotal-cf/pcfdev-cli/user"
 
@@ -65,6 +66,59 @@ var _ = Describe("Plugin", func() {
 			})
 		})
 
+		Context("when the subcommand uses the cf CLI", func() {
+			It("should pass it the cf CLI connection before running it", func() {
+				cliCmd := &cfCLICmd{MockCmd: mockCmd}
+				gomock.InOrder(
+					mockCmdBuilder.EXPECT().Cmd("some-command").Return(cliCmd, nil),
+					mockCmd.EXPECT().Parse([]string{}),
+					mockCmd.EXPECT().Run(),
+				)
+
+				pcfdev.Run(fakeCliConnection, []string{"dev", "some-command"})
+				Expect(cliCmd.cfCLI).To(BeIdenticalTo(fakeCliConnection))
+			})
+		})
+
+		Context("when an instance is specified", func() {
+			BeforeEach(func() {
+				pcfdev.Config = &config.Config{VMDir: "some-vm-dir"}
+			})
+
+			It("should select the instance and run the subcommand without the instance flag", func() {
+				gomock.InOrder(
+					mockCmdBuilder.EXPECT().Cmd("some-command").Return(mockCmd, nil),
+					mockCmd.EXPECT().Parse([]string{"some-arg"}),
+					mockCmd.EXPECT().Run(),
+				)
+
+				pcfdev.Run(fakeCliConnection, []string{"dev", "--instance", "some-instance", "some-command", "some-arg"})
+				Expect(pcfdev.Config.Instance).To(Equal("some-instance"))
+			})
+
+			It("should accept the instance flag after the subcommand", func() {
+				gomock.InOrder(
+					mockCmdBuilder.EXPECT().Cmd("some-command").Return(mockCmd, nil),
+					mockCmd.EXPECT().Parse([]string{"some-arg"}),
+					mockCmd.EXPECT().Run(),
+				)
+
+				pcfdev.Run(fakeCliConnection, []string{"dev", "some-command", "--instance=some-instance", "some-arg"})
+				Expect(pcfdev.Config.Instance).To(Equal("some-instance"))
+			})
+
+			Context("when the instance name is invalid", func() {
+				It("should print the error", func() {
+					gomock.InOrder(
+						mockUI.EXPECT().Failed("Error: Some_Instance is not a valid instance name, use lowercase letters, numbers and single dashes."),
+						mockExit.EXPECT().Exit(),
+					)
+
+					pcfdev.Run(fakeCliConnection, []string{"dev", "some-command", "--instance", "Some_Instance"})
+				})
+			})
+		})
+
 		Context("when parsing arguments fails", func() {
 			It("should print the usage message", func() {
 				gomock.InOrder(
@@ -91,6 +145,19 @@ var _ = Describe("Plugin", func() {
 
 				pcfdev.Run(fakeCliConnection, []string{"dev", "some-command"})
 			})
+
+			Context("when the error carries an exit status", func() {
+				It("should exit with that status", func() {
+					gomock.InOrder(
+						mockCmdBuilder.EXPECT().Cmd("some-command").Return(mockCmd, nil),
+						mockCmd.EXPECT().Parse([]string{}),
+						mockCmd.EXPECT().Run().Return(&exitStatusError{status: 42}),
+						mockExit.EXPECT().ExitWithStatus(42),
+					)
+
+					pcfdev.Run(fakeCliConnection, []string{"dev", "some-command"})
+				})
+			})
 		})
 
 		Context("when it is called with no subcommand", func() {
@@ -172,3 +239,24 @@ var _ = Describe("Plugin", func() {
 		})
 	})
 })
+
+type exitStatusError struct {
+	status int
+}
+
+func (e *exitStatusError) Error() string {
+	return "some-error"
+}
+
+func (e *exitStatusError) ExitStatus() int {
+	return e.status
+}
+
+type cfCLICmd struct {
+	*mocks.MockCmd
+	cfCLI cmd.CFCLI
+}
+
+func (c *cfCLICmd) SetCFCLI(cfCLI cmd.CFCLI) {
+	c.cfCLI = cfCLI
+}
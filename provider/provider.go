@@ -0,0 +1,36 @@
+package provider
+
+type Instance struct {
+	Name   string
+	VMName string
+	Status string
+}
+
+type Version struct {
+	Major, Minor, Build int
+}
+
+type ProxyTypes struct {
+	HTTPProxy  string
+	HTTPSProxy string
+	NOProxy    string
+}
+
+const (
+	StatusRunning    = "Running"
+	StatusSaved      = "Saved"
+	StatusPaused     = "Paused"
+	StatusStopped    = "Stopped"
+	StatusNotCreated = "Not created"
+	StatusUnknown    = "Unknown"
+)
+
+// ProxyTemplate renders ProxyTypes as the /etc/environment of a VM.
+const ProxyTemplate = `
+PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin:/usr/games:/usr/local/games
+{{if .HTTPProxy}}HTTP_PROXY={{.HTTPProxy}}{{end}}
+{{if .HTTPSProxy}}HTTPS_PROXY={{.HTTPSProxy}}{{end}}
+NO_PROXY={{.NOProxy}}
+{{if .HTTPProxy}}http_proxy={{.HTTPProxy}}{{end}}
+{{if .HTTPSProxy}}https_proxy={{.HTTPSProxy}}{{end}}
+no_proxy={{.NOProxy}}`
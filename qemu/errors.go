@@ -0,0 +1,12 @@
+package qemu
+
+import "fmt"
+
+type PortUnavailableError struct {
+	Address string
+	Err     error
+}
+
+func (e *PortUnavailableError) Error() string {
+	return fmt.Sprintf("cannot forward %s to the VM: %s. PCF Dev serves HTTP and HTTPS on ports 80 and 443 only, so stop any other QEMU instance of PCF Dev or allow binding privileged ports, for example with 'sudo sysctl net.ipv4.ip_unprivileged_port_start=80'", e.Address, e.Err)
+}
@@ -0,0 +1,527 @@
+package qemu
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pivotal-cf/pcfdev-cli/address"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	. "github.com/pivotal-cf/pcfdev-cli/helpers"
+	"github.com/pivotal-cf/pcfdev-cli/provider"
+	"github.com/pivotal-cf/pcfdev-cli/qemudriver"
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
+)
+
+//go:generate mockgen -package mocks -destination mocks/driver.go github.com/pivotal-cf/pcfdev-cli/qemu Driver
+type Driver interface {
+	StartVM(vmName string) error
+	VMExists(vmName string) (exists bool, err error)
+	PowerOffVM(vmName string) error
+	StopVM(vmName string) error
+	SuspendVM(vmName string) error
+	ResumeVM(vmName string) error
+	DestroyVM(vmName string) error
+	VMs() (vms []string, err error)
+	CreateVM(vmName string) error
+	ConvertDisk(src string, dest string) error
+	DeleteDisk(diskPath string) error
+	ResizeDisk(diskPath string, sizeInMB uint64) error
+	DiskSize(diskPath string) (sizeInMB uint64, err error)
+	AttachDisk(vmName string, diskPath string) error
+	ForwardPort(vmName string, ruleName string, hostPort string, guestPort string) error
+	GetHostForwardPort(vmName string, ruleName string) (port string, err error)
+	SetCPUs(vmName string, cpuNumber int) error
+	SetMemory(vmName string, memory uint64) error
+	GetMemory(vmName string) (uint64, error)
+	GetCPUs(vmName string) (int, error)
+	VMState(vmName string) (string, error)
+	TakeSnapshot(vmName string, snapshotName string) error
+	RestoreSnapshot(vmName string, snapshotName string) error
+	DeleteSnapshot(vmName string, snapshotName string) error
+	Snapshots(vmName string) (snapshots []string, err error)
+	Version() (version *provider.Version, err error)
+}
+
+//go:generate mockgen -package mocks -destination mocks/fs.go github.com/pivotal-cf/pcfdev-cli/qemu FS
+type FS interface {
+	Exists(path string) (exists bool, err error)
+	Extract(archivePath string, destinationPath string, filename string) error
+	Write(path string, contents io.Reader, append bool) error
+	Read(path string) (contents []byte, err error)
+	Chmod(path string, mode os.FileMode) error
+}
+
+//go:generate mockgen -package mocks -destination mocks/ssh.go github.com/pivotal-cf/pcfdev-cli/qemu SSH
+type SSH interface {
+	GenerateAddress() (host string, port string, err error)
+	GenerateKeypair() (privateKey []byte, publicKey []byte, err error)
+	RunSSHCommand(command string, addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration, stdout io.Writer, stderr io.Writer) error
+}
+
+//go:generate mockgen -package mocks -destination mocks/network.go github.com/pivotal-cf/pcfdev-cli/qemu Network
+type Network interface {
+	CheckListen(address string) error
+}
+
+type Qemu struct {
+	Config  *config.Config
+	Driver  Driver
+	FS      FS
+	Network Network
+	SSH     SSH
+}
+
+const (
+	GuestIP   = "10.0.2.15"
+	GatewayIP = "10.0.2.2"
+	HostIP    = "127.0.0.1"
+	HTTPPort  = "80"
+	HTTPSPort = "443"
+)
+
+var (
+	networkTemplate = `
+auto lo
+iface lo inet loopback
+
+auto eth0
+iface eth0 inet dhcp`
+)
+
+func (q *Qemu) StartVM(vmConfig *config.VMConfig) error {
+	if err := q.checkForwardedPorts(); err != nil {
+		return err
+	}
+
+	vmName := q.Config.InstanceVMName(vmConfig.Name)
+	if err := q.Driver.StartVM(vmName); err != nil {
+		return err
+	}
+
+	if err := q.insertSecureKeypair(vmConfig); err != nil {
+		return err
+	}
+
+	if err := q.configureNetwork(vmConfig); err != nil {
+		return err
+	}
+
+	if err := q.configureEnvironment(vmConfig); err != nil {
+		return err
+	}
+
+	if err := q.Driver.StopVM(vmName); err != nil {
+		return err
+	}
+
+	return q.Driver.StartVM(vmName)
+}
+
+func (q *Qemu) insertSecureKeypair(vmConfig *config.VMConfig) error {
+	exists, err := q.FS.Exists(q.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return nil
+	}
+
+	privateKey, publicKey, err := q.SSH.GenerateKeypair()
+	if err != nil {
+		return err
+	}
+
+	if err := q.SSH.RunSSHCommand(
+		fmt.Sprintf(`echo -n "%s" > /home/vcap/.ssh/authorized_keys`, publicKey),
+		q.addresses(vmConfig),
+		q.Config.InsecurePrivateKey,
+		5*time.Minute,
+		ioutil.Discard,
+		ioutil.Discard,
+	); err != nil {
+		return err
+	}
+
+	if err := q.FS.Write(q.Config.PrivateKeyPath, bytes.NewReader(privateKey), false); err != nil {
+		return err
+	}
+	return q.FS.Chmod(q.Config.PrivateKeyPath, 0600)
+}
+
+func (q *Qemu) configureNetwork(vmConfig *config.VMConfig) error {
+	privateKeyBytes, err := q.FS.Read(q.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	return q.SSH.RunSSHCommand(
+		fmt.Sprintf("echo -e '%s' | sudo tee /etc/network/interfaces", networkTemplate),
+		q.addresses(vmConfig),
+		privateKeyBytes,
+		5*time.Minute,
+		ioutil.Discard,
+		ioutil.Discard,
+	)
+}
+
+func (q *Qemu) configureEnvironment(vmConfig *config.VMConfig) error {
+	proxySettings, err := q.proxySettings(vmConfig)
+	if err != nil {
+		return err
+	}
+
+	privateKeyBytes, err := q.FS.Read(q.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	return q.SSH.RunSSHCommand(
+		fmt.Sprintf("echo -e '%s' | sudo tee /etc/environment", proxySettings),
+		q.addresses(vmConfig),
+		privateKeyBytes,
+		5*time.Minute,
+		ioutil.Discard,
+		ioutil.Discard,
+	)
+}
+
+func (q *Qemu) proxySettings(vmConfig *config.VMConfig) (settings string, err error) {
+	httpProxy := strings.Replace(q.Config.HTTPProxy, "127.0.0.1", GatewayIP, -1)
+	httpsProxy := strings.Replace(q.Config.HTTPSProxy, "127.0.0.1", GatewayIP, -1)
+	noProxy := strings.Join([]string{
+		"localhost",
+		"127.0.0.1",
+		GatewayIP,
+		vmConfig.IP,
+		vmConfig.Domain,
+		"." + vmConfig.Domain,
+	}, ",")
+	if q.Config.NoProxy != "" {
+		noProxy = strings.Join([]string{noProxy, q.Config.NoProxy}, ",")
+	}
+
+	t, err := template.New("proxy template").Parse(provider.ProxyTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	var proxySettings bytes.Buffer
+	if err = t.Execute(&proxySettings, provider.ProxyTypes{HTTPProxy: httpProxy, HTTPSProxy: httpsProxy, NOProxy: noProxy}); err != nil {
+		return "", err
+	}
+
+	return proxySettings.String(), nil
+}
+
+func (q *Qemu) ImportVM(vmConfig *config.VMConfig) error {
+	vmName := q.Config.InstanceVMName(vmConfig.Name)
+	if err := q.Driver.CreateVM(vmName); err != nil {
+		return err
+	}
+
+	compressedDisk := filepath.Join(q.Config.VMDir, vmName+"-disk1.vmdk") + ".compressed"
+	disk := q.diskPath(vmName)
+	if err := q.FS.Extract(vmConfig.OVAPath, compressedDisk, `\w+\.vmdk`); err != nil {
+		return err
+	}
+
+	if err := q.Driver.ConvertDisk(compressedDisk, disk); err != nil {
+		return err
+	}
+
+	if err := q.Driver.DeleteDisk(compressedDisk); err != nil {
+		return err
+	}
+
+	if err := q.Driver.AttachDisk(vmName, disk); err != nil {
+		return err
+	}
+
+	domain := vmConfig.Domain
+	if domain == "" {
+		domain = address.LocalDomain
+	}
+
+	if err := q.SaveVMConfig(&config.VMConfig{IP: GuestIP, Domain: domain}); err != nil {
+		return err
+	}
+
+	_, sshPort, err := q.SSH.GenerateAddress()
+	if err != nil {
+		return err
+	}
+
+	if err := q.Driver.ForwardPort(vmName, "ssh", sshPort, "22"); err != nil {
+		return err
+	}
+
+	if err := q.Driver.ForwardPort(vmName, "http", HTTPPort, HTTPPort); err != nil {
+		return err
+	}
+
+	if err := q.Driver.ForwardPort(vmName, "https", HTTPSPort, HTTPSPort); err != nil {
+		return err
+	}
+
+	if err := q.Driver.SetCPUs(vmName, vmConfig.CPUs); err != nil {
+		return err
+	}
+
+	return q.Driver.SetMemory(vmName, vmConfig.Memory)
+}
+
+// SaveVMConfig saves the VM config to Config.VMConfigPath.
+func (q *Qemu) SaveVMConfig(vmConfig *config.VMConfig) error {
+	return config.SaveVMConfig(q.FS, q.Config.VMConfigPath, vmConfig)
+}
+
+func (q *Qemu) ShareFolder(*config.VMConfig, *config.Mount) error {
+	return errors.New("shared folders are not supported by the QEMU provider")
+}
+
+func (q *Qemu) DestroyVM(vmConfig *config.VMConfig) error {
+	return q.Driver.DestroyVM(q.Config.InstanceVMName(vmConfig.Name))
+}
+
+func (q *Qemu) PowerOffVM(vmConfig *config.VMConfig) error {
+	return q.Driver.PowerOffVM(q.Config.InstanceVMName(vmConfig.Name))
+}
+
+func (q *Qemu) GetVMName() (name string, err error) {
+	vms, err := q.Driver.VMs()
+	if err != nil {
+		return "", err
+	}
+	for _, vm := range vms {
+		if q.isInstanceVM(vm) {
+			if name == "" {
+				name, _ = config.InstanceOfVMName(vm)
+			} else {
+				return "", errors.New("multiple PCF Dev VMs found")
+			}
+		}
+	}
+	return name, nil
+}
+
+func (q *Qemu) StopVM(vmConfig *config.VMConfig) error {
+	return q.Driver.StopVM(q.Config.InstanceVMName(vmConfig.Name))
+}
+
+// ResizeVM applies the memory and CPUs in vmConfig to a powered off VM.
+func (q *Qemu) ResizeVM(vmConfig *config.VMConfig) error {
+	vmName := q.Config.InstanceVMName(vmConfig.Name)
+	if err := q.Driver.SetCPUs(vmName, vmConfig.CPUs); err != nil {
+		return err
+	}
+	return q.Driver.SetMemory(vmName, vmConfig.Memory)
+}
+
+// DiskSize returns the capacity of the VM disk in MB.
+func (q *Qemu) DiskSize(vmConfig *config.VMConfig) (uint64, error) {
+	return q.Driver.DiskSize(q.diskPath(q.Config.InstanceVMName(vmConfig.Name)))
+}
+
+// ResizeDisk grows the disk of a powered off VM to sizeInMB.
+func (q *Qemu) ResizeDisk(vmConfig *config.VMConfig, sizeInMB uint64) error {
+	return q.Driver.ResizeDisk(q.diskPath(q.Config.InstanceVMName(vmConfig.Name)), sizeInMB)
+}
+
+func (q *Qemu) diskPath(vmName string) string {
+	return filepath.Join(q.Config.VMDir, vmName, vmName+"-disk1.qcow2")
+}
+
+func (q *Qemu) SuspendVM(vmConfig *config.VMConfig) error {
+	return q.Driver.SuspendVM(q.Config.InstanceVMName(vmConfig.Name))
+}
+
+func (q *Qemu) ResumePausedVM(vmConfig *config.VMConfig) error {
+	return q.Driver.ResumeVM(q.Config.InstanceVMName(vmConfig.Name))
+}
+
+func (q *Qemu) ResumeSavedVM(vmConfig *config.VMConfig) error {
+	if err := q.checkForwardedPorts(); err != nil {
+		return err
+	}
+	return q.Driver.StartVM(q.Config.InstanceVMName(vmConfig.Name))
+}
+
+// checkForwardedPorts fails early when QEMU would not be able to forward
+// HTTP and HTTPS. The ports cannot be moved, since PCF Dev advertises its
+// API, UAA and app routes on 80 and 443.
+func (q *Qemu) checkForwardedPorts() error {
+	for _, port := range []string{HTTPPort, HTTPSPort} {
+		if err := q.Network.CheckListen(HostIP + ":" + port); err != nil {
+			return &PortUnavailableError{Address: HostIP + ":" + port, Err: err}
+		}
+	}
+	return nil
+}
+
+func (q *Qemu) DestroyPCFDevVMs() error {
+	vms, err := q.Driver.VMs()
+	if err != nil {
+		return err
+	}
+
+	for _, vm := range vms {
+		if q.isInstanceVM(vm) {
+			IgnoreErrorFrom(q.Driver.PowerOffVM(vm))
+			IgnoreErrorFrom(q.Driver.DestroyVM(vm))
+		}
+	}
+
+	vms, err = q.Driver.VMs()
+	if err != nil {
+		return err
+	}
+
+	for _, vm := range vms {
+		if q.isInstanceVM(vm) {
+			return errors.New("failed to destroy all pcfdev vms")
+		}
+	}
+	return nil
+}
+
+func (q *Qemu) VMConfig(vmName string) (*config.VMConfig, error) {
+	instanceVMName := q.Config.InstanceVMName(vmName)
+
+	memory, err := q.Driver.GetMemory(instanceVMName)
+	if err != nil {
+		return nil, err
+	}
+	cpus, err := q.Driver.GetCPUs(instanceVMName)
+	if err != nil {
+		return nil, err
+	}
+	port, err := q.Driver.GetHostForwardPort(instanceVMName, "ssh")
+	if err != nil {
+		return nil, err
+	}
+	vmConfigBytes, err := q.FS.Read(q.Config.VMConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	vmConfig := &config.VMConfig{
+		Memory:   memory,
+		CPUs:     cpus,
+		Name:     vmName,
+		SSHPort:  port,
+		Provider: config.ProviderQEMU,
+	}
+	if err := json.Unmarshal(vmConfigBytes, &vmConfig); err != nil {
+		return nil, err
+	}
+
+	return vmConfig, nil
+}
+
+func (q *Qemu) VMStatus(vmName string) (status string, err error) {
+	return q.vmStatus(q.Config.InstanceVMName(vmName))
+}
+
+func (q *Qemu) vmStatus(vmName string) (status string, err error) {
+	exists, err := q.Driver.VMExists(vmName)
+	if err != nil {
+		return "", err
+	}
+
+	if !exists {
+		return provider.StatusNotCreated, nil
+	}
+
+	state, err := q.Driver.VMState(vmName)
+	if err != nil {
+		return "", err
+	}
+
+	switch state {
+	case qemudriver.StateRunning:
+		return provider.StatusRunning, nil
+	case qemudriver.StateStopped:
+		return provider.StatusStopped, nil
+	case qemudriver.StateSaved:
+		return provider.StatusSaved, nil
+	case qemudriver.StatePaused:
+		return provider.StatusPaused, nil
+	default:
+		return provider.StatusUnknown, nil
+	}
+}
+
+func (q *Qemu) TakeSnapshot(vmName string, snapshotName string) error {
+	return q.Driver.TakeSnapshot(q.Config.InstanceVMName(vmName), snapshotName)
+}
+
+func (q *Qemu) RestoreSnapshot(vmName string, snapshotName string) error {
+	return q.Driver.RestoreSnapshot(q.Config.InstanceVMName(vmName), snapshotName)
+}
+
+func (q *Qemu) DeleteSnapshot(vmName string, snapshotName string) error {
+	return q.Driver.DeleteSnapshot(q.Config.InstanceVMName(vmName), snapshotName)
+}
+
+func (q *Qemu) Snapshots(vmName string) (snapshots []string, err error) {
+	return q.Driver.Snapshots(q.Config.InstanceVMName(vmName))
+}
+
+func (q *Qemu) Version() (version *provider.Version, err error) {
+	return q.Driver.Version()
+}
+
+func (q *Qemu) Instances() ([]*provider.Instance, error) {
+	vms, err := q.Driver.VMs()
+	if err != nil {
+		return nil, err
+	}
+
+	instances := []*provider.Instance{}
+	for _, vm := range vms {
+		if !strings.HasPrefix(vm, "pcfdev-") {
+			continue
+		}
+
+		status, err := q.vmStatus(vm)
+		if err != nil {
+			return nil, err
+		}
+
+		vmName, instance := config.InstanceOfVMName(vm)
+		instances = append(instances, &provider.Instance{
+			Name:   instance,
+			VMName: vmName,
+			Status: status,
+		})
+	}
+	return instances, nil
+}
+
+func (q *Qemu) isInstanceVM(vm string) bool {
+	_, instance := config.InstanceOfVMName(vm)
+	return strings.HasPrefix(vm, "pcfdev-") && instance == q.Config.Instance
+}
+
+func (q *Qemu) addresses(vmConfig *config.VMConfig) []ssh.SSHAddress {
+	return []ssh.SSHAddress{
+		{
+			IP:   "127.0.0.1",
+			Port: vmConfig.SSHPort,
+		},
+		{
+			IP:   vmConfig.IP,
+			Port: "22",
+		},
+	}
+}
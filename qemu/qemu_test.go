@@ -0,0 +1,436 @@
+package qemu_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/provider"
+	"github.com/pivotal-cf/pcfdev-cli/qemu"
+	"github.com/pivotal-cf/pcfdev-cli/qemu/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/qemudriver"
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
+)
+
+var _ = Describe("Qemu", func() {
+	var (
+		mockCtrl    *gomock.Controller
+		mockDriver  *mocks.MockDriver
+		mockSSH     *mocks.MockSSH
+		mockFS      *mocks.MockFS
+		mockNetwork *mocks.MockNetwork
+		q           *qemu.Qemu
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockDriver = mocks.NewMockDriver(mockCtrl)
+		mockSSH = mocks.NewMockSSH(mockCtrl)
+		mockFS = mocks.NewMockFS(mockCtrl)
+		mockNetwork = mocks.NewMockNetwork(mockCtrl)
+
+		q = &qemu.Qemu{
+			Driver:  mockDriver,
+			SSH:     mockSSH,
+			FS:      mockFS,
+			Network: mockNetwork,
+			Config: &config.Config{
+				VMDir:              "some-vm-dir",
+				HTTPProxy:          "http://127.0.0.1:8080",
+				NoProxy:            "some-no-proxy",
+				InsecurePrivateKey: []byte("some-insecure-private-key"),
+				PrivateKeyPath:     "some-private-key-path",
+				VMConfigPath:       filepath.Join("some-vm-dir", "vm_config"),
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("#ImportVM", func() {
+		It("should convert the disk to qcow2 and forward ports", func() {
+			gomock.InOrder(
+				mockDriver.EXPECT().CreateVM("some-vm"),
+				mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
+				mockDriver.EXPECT().ConvertDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.qcow2")),
+				mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
+				mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.qcow2")),
+				mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "vm_config"), strings.NewReader(`{"ip":"10.0.2.15","domain":"local.pcfdev.test"}`), false),
+				mockSSH.EXPECT().GenerateAddress().Return("", "some-port", nil),
+				mockDriver.EXPECT().ForwardPort("some-vm", "ssh", "some-port", "22"),
+				mockDriver.EXPECT().ForwardPort("some-vm", "http", "80", "80"),
+				mockDriver.EXPECT().ForwardPort("some-vm", "https", "443", "443"),
+				mockDriver.EXPECT().SetCPUs("some-vm", 2),
+				mockDriver.EXPECT().SetMemory("some-vm", uint64(3000)),
+			)
+
+			Expect(q.ImportVM(&config.VMConfig{
+				Name:    "some-vm",
+				OVAPath: "some-ova-path",
+				CPUs:    2,
+				Memory:  uint64(3000),
+			})).To(Succeed())
+		})
+
+		Context("when a domain is given", func() {
+			It("should use the given domain", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().CreateVM("some-vm"),
+					mockFS.EXPECT().Extract(gomock.Any(), gomock.Any(), gomock.Any()),
+					mockDriver.EXPECT().ConvertDisk(gomock.Any(), gomock.Any()),
+					mockDriver.EXPECT().DeleteDisk(gomock.Any()),
+					mockDriver.EXPECT().AttachDisk(gomock.Any(), gomock.Any()),
+					mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "vm_config"), strings.NewReader(`{"ip":"10.0.2.15","domain":"some-domain"}`), false),
+					mockSSH.EXPECT().GenerateAddress().Return("", "some-port", nil),
+					mockDriver.EXPECT().ForwardPort("some-vm", gomock.Any(), gomock.Any(), gomock.Any()).Times(3),
+					mockDriver.EXPECT().SetCPUs("some-vm", 2),
+					mockDriver.EXPECT().SetMemory("some-vm", uint64(3000)),
+				)
+
+				Expect(q.ImportVM(&config.VMConfig{
+					Name:    "some-vm",
+					OVAPath: "some-ova-path",
+					Domain:  "some-domain",
+					CPUs:    2,
+					Memory:  uint64(3000),
+				})).To(Succeed())
+			})
+		})
+
+		Context("when converting the disk fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().CreateVM("some-vm"),
+					mockFS.EXPECT().Extract(gomock.Any(), gomock.Any(), gomock.Any()),
+					mockDriver.EXPECT().ConvertDisk(gomock.Any(), gomock.Any()).Return(errors.New("some-error")),
+				)
+
+				Expect(q.ImportVM(&config.VMConfig{Name: "some-vm"})).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#StartVM", func() {
+		It("should start the VM and configure the guest", func() {
+			addresses := []ssh.SSHAddress{
+				{
+					IP:   "127.0.0.1",
+					Port: "some-port",
+				},
+				{
+					IP:   "10.0.2.15",
+					Port: "22",
+				},
+			}
+
+			gomock.InOrder(
+				mockNetwork.EXPECT().CheckListen("127.0.0.1:80"),
+				mockNetwork.EXPECT().CheckListen("127.0.0.1:443"),
+				mockDriver.EXPECT().StartVM("some-vm"),
+				mockFS.EXPECT().Exists("some-private-key-path").Return(false, nil),
+				mockSSH.EXPECT().GenerateKeypair().Return([]byte("some-private-key"), []byte("some-public-key"), nil),
+				mockSSH.EXPECT().RunSSHCommand(`echo -n "some-public-key" > /home/vcap/.ssh/authorized_keys`, addresses, []byte("some-insecure-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+				mockFS.EXPECT().Write("some-private-key-path", bytes.NewReader([]byte("some-private-key")), false),
+				mockFS.EXPECT().Chmod("some-private-key-path", os.FileMode(0600)),
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockSSH.EXPECT().RunSSHCommand(`echo -e '
+auto lo
+iface lo inet loopback
+
+auto eth0
+iface eth0 inet dhcp' | sudo tee /etc/network/interfaces`, addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockSSH.EXPECT().RunSSHCommand(`echo -e '
+PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin:/usr/games:/usr/local/games
+HTTP_PROXY=http://10.0.2.2:8080
+
+NO_PROXY=localhost,127.0.0.1,10.0.2.2,10.0.2.15,some-domain,.some-domain,some-no-proxy
+http_proxy=http://10.0.2.2:8080
+
+no_proxy=localhost,127.0.0.1,10.0.2.2,10.0.2.15,some-domain,.some-domain,some-no-proxy' | sudo tee /etc/environment`, addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+				mockDriver.EXPECT().StopVM("some-vm"),
+				mockDriver.EXPECT().StartVM("some-vm"),
+			)
+
+			Expect(q.StartVM(&config.VMConfig{
+				Name:    "some-vm",
+				IP:      "10.0.2.15",
+				SSHPort: "some-port",
+				Domain:  "some-domain",
+			})).To(Succeed())
+		})
+
+		Context("when a forwarded port is unavailable", func() {
+			It("should return an error without starting the VM", func() {
+				gomock.InOrder(
+					mockNetwork.EXPECT().CheckListen("127.0.0.1:80"),
+					mockNetwork.EXPECT().CheckListen("127.0.0.1:443").Return(errors.New("some-error")),
+				)
+
+				err := q.StartVM(&config.VMConfig{Name: "some-vm"})
+				Expect(err).To(BeAssignableToTypeOf(&qemu.PortUnavailableError{}))
+				Expect(err.Error()).To(HavePrefix("cannot forward 127.0.0.1:443 to the VM: some-error."))
+			})
+		})
+
+		Context("when starting the VM fails", func() {
+			It("should return the error", func() {
+				mockNetwork.EXPECT().CheckListen(gomock.Any()).Times(2)
+				mockDriver.EXPECT().StartVM("some-vm").Return(errors.New("some-error"))
+
+				Expect(q.StartVM(&config.VMConfig{Name: "some-vm"})).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#ResumeSavedVM", func() {
+		It("should start the VM", func() {
+			gomock.InOrder(
+				mockNetwork.EXPECT().CheckListen("127.0.0.1:80"),
+				mockNetwork.EXPECT().CheckListen("127.0.0.1:443"),
+				mockDriver.EXPECT().StartVM("some-vm"),
+			)
+
+			Expect(q.ResumeSavedVM(&config.VMConfig{Name: "some-vm"})).To(Succeed())
+		})
+
+		Context("when a forwarded port is unavailable", func() {
+			It("should return an error without starting the VM", func() {
+				mockNetwork.EXPECT().CheckListen("127.0.0.1:80").Return(errors.New("some-error"))
+
+				Expect(q.ResumeSavedVM(&config.VMConfig{Name: "some-vm"})).To(BeAssignableToTypeOf(&qemu.PortUnavailableError{}))
+			})
+		})
+	})
+
+	Describe("#VMConfig", func() {
+		It("should return the VM config", func() {
+			gomock.InOrder(
+				mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(3000), nil),
+				mockDriver.EXPECT().GetCPUs("some-vm").Return(2, nil),
+				mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("some-port", nil),
+				mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return([]byte(`{"ip":"10.0.2.15","domain":"some-domain"}`), nil),
+			)
+
+			Expect(q.VMConfig("some-vm")).To(Equal(&config.VMConfig{
+				Name:     "some-vm",
+				Memory:   uint64(3000),
+				CPUs:     2,
+				SSHPort:  "some-port",
+				IP:       "10.0.2.15",
+				Domain:   "some-domain",
+				Provider: "qemu",
+			}))
+		})
+
+		Context("when getting the forwarded port fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(3000), nil),
+					mockDriver.EXPECT().GetCPUs("some-vm").Return(2, nil),
+					mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("", errors.New("some-error")),
+				)
+
+				_, err := q.VMConfig("some-vm")
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#VMStatus", func() {
+		It("should map the driver state to a status", func() {
+			for state, status := range map[string]string{
+				"running":      "Running",
+				"poweroff":     "Stopped",
+				"saved":        "Saved",
+				"paused":       "Paused",
+				"some-unknown": "Unknown",
+			} {
+				gomock.InOrder(
+					mockDriver.EXPECT().VMExists("some-vm").Return(true, nil),
+					mockDriver.EXPECT().VMState("some-vm").Return(state, nil),
+				)
+
+				Expect(q.VMStatus("some-vm")).To(Equal(status))
+			}
+		})
+
+		Context("when the VM does not exist", func() {
+			It("should return not created", func() {
+				mockDriver.EXPECT().VMExists("some-vm").Return(false, nil)
+
+				Expect(q.VMStatus("some-vm")).To(Equal("Not created"))
+			})
+		})
+
+		Context("when getting the state fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().VMExists("some-vm").Return(true, nil),
+					mockDriver.EXPECT().VMState("some-vm").Return("", errors.New("some-error")),
+				)
+
+				_, err := q.VMStatus("some-vm")
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#GetVMName", func() {
+		It("should return the PCF Dev VM", func() {
+			mockDriver.EXPECT().VMs().Return([]string{"some-other-vm", "pcfdev-some-version"}, nil)
+
+			Expect(q.GetVMName()).To(Equal("pcfdev-some-version"))
+		})
+
+		Context("when there are multiple PCF Dev VMs", func() {
+			It("should return an error", func() {
+				mockDriver.EXPECT().VMs().Return([]string{"pcfdev-some-version", "pcfdev-some-other-version"}, nil)
+
+				_, err := q.GetVMName()
+				Expect(err).To(MatchError("multiple PCF Dev VMs found"))
+			})
+		})
+
+		Context("when an instance is selected", func() {
+			It("should return the PCF Dev VM of that instance", func() {
+				q.Config.Instance = "some-instance"
+				mockDriver.EXPECT().VMs().Return([]string{"pcfdev-some-version", "pcfdev-some-version--some-instance"}, nil)
+
+				Expect(q.GetVMName()).To(Equal("pcfdev-some-version"))
+			})
+		})
+	})
+
+	Describe("#Instances", func() {
+		It("should return every PCF Dev instance with its status", func() {
+			gomock.InOrder(
+				mockDriver.EXPECT().VMs().Return([]string{"some-other-vm", "pcfdev-some-version--some-instance"}, nil),
+				mockDriver.EXPECT().VMExists("pcfdev-some-version--some-instance").Return(true, nil),
+				mockDriver.EXPECT().VMState("pcfdev-some-version--some-instance").Return(qemudriver.StateSaved, nil),
+			)
+
+			Expect(q.Instances()).To(Equal([]*provider.Instance{
+				{Name: "some-instance", VMName: "pcfdev-some-version", Status: provider.StatusSaved},
+			}))
+		})
+	})
+
+	Describe("#DestroyPCFDevVMs", func() {
+		It("should destroy all PCF Dev VMs", func() {
+			gomock.InOrder(
+				mockDriver.EXPECT().VMs().Return([]string{"some-other-vm", "pcfdev-some-version"}, nil),
+				mockDriver.EXPECT().PowerOffVM("pcfdev-some-version"),
+				mockDriver.EXPECT().DestroyVM("pcfdev-some-version"),
+				mockDriver.EXPECT().VMs().Return([]string{"some-other-vm"}, nil),
+			)
+
+			Expect(q.DestroyPCFDevVMs()).To(Succeed())
+		})
+
+		Context("when a PCF Dev VM remains", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().VMs().Return([]string{"pcfdev-some-version"}, nil),
+					mockDriver.EXPECT().PowerOffVM("pcfdev-some-version"),
+					mockDriver.EXPECT().DestroyVM("pcfdev-some-version").Return(errors.New("some-error")),
+					mockDriver.EXPECT().VMs().Return([]string{"pcfdev-some-version"}, nil),
+				)
+
+				Expect(q.DestroyPCFDevVMs()).To(MatchError("failed to destroy all pcfdev vms"))
+			})
+		})
+	})
+
+	Context("when an instance is selected", func() {
+		It("should operate on the VM belonging to that instance", func() {
+			q.Config.Instance = "some-instance"
+			mockDriver.EXPECT().SuspendVM("some-vm--some-instance")
+
+			Expect(q.SuspendVM(&config.VMConfig{Name: "some-vm"})).To(Succeed())
+		})
+	})
+
+	Describe("#ResizeVM", func() {
+		It("should set the CPUs and memory of the VM", func() {
+			gomock.InOrder(
+				mockDriver.EXPECT().SetCPUs("some-vm", 4),
+				mockDriver.EXPECT().SetMemory("some-vm", uint64(6144)),
+			)
+
+			Expect(q.ResizeVM(&config.VMConfig{Name: "some-vm", CPUs: 4, Memory: 6144})).To(Succeed())
+		})
+
+		Context("when setting the CPUs fails", func() {
+			It("should return the error", func() {
+				mockDriver.EXPECT().SetCPUs("some-vm", 4).Return(errors.New("some-error"))
+
+				Expect(q.ResizeVM(&config.VMConfig{Name: "some-vm", CPUs: 4, Memory: 6144})).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#ShareFolder", func() {
+		It("should return an error", func() {
+			Expect(q.ShareFolder(&config.VMConfig{Name: "some-vm"}, &config.Mount{HostPath: "/some-host-path"})).To(MatchError("shared folders are not supported by the QEMU provider"))
+		})
+	})
+
+	Describe("#DiskSize", func() {
+		It("should return the size of the VM disk", func() {
+			mockDriver.EXPECT().DiskSize(filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.qcow2")).Return(uint64(51200), nil)
+
+			Expect(q.DiskSize(&config.VMConfig{Name: "some-vm"})).To(Equal(uint64(51200)))
+		})
+	})
+
+	Describe("#ResizeDisk", func() {
+		It("should resize the VM disk", func() {
+			mockDriver.EXPECT().ResizeDisk(filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.qcow2"), uint64(102400))
+
+			Expect(q.ResizeDisk(&config.VMConfig{Name: "some-vm"}, 102400)).To(Succeed())
+		})
+
+		Context("when resizing the disk fails", func() {
+			It("should return the error", func() {
+				mockDriver.EXPECT().ResizeDisk(filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.qcow2"), uint64(102400)).Return(errors.New("some-error"))
+
+				Expect(q.ResizeDisk(&config.VMConfig{Name: "some-vm"}, 102400)).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#RestoreSnapshot", func() {
+		It("should restore the snapshot of the VM", func() {
+			mockDriver.EXPECT().RestoreSnapshot("some-vm", "some-snapshot")
+
+			Expect(q.RestoreSnapshot("some-vm", "some-snapshot")).To(Succeed())
+		})
+	})
+
+	Describe("#Snapshots", func() {
+		It("should return the snapshots of the VM", func() {
+			mockDriver.EXPECT().Snapshots("some-vm").Return([]string{"some-snapshot"}, nil)
+
+			Expect(q.Snapshots("some-vm")).To(Equal([]string{"some-snapshot"}))
+		})
+	})
+
+	Describe("#Version", func() {
+		It("should return the QEMU version", func() {
+			mockDriver.EXPECT().Version().Return(&provider.Version{Major: 5, Minor: 2, Build: 0}, nil)
+
+			Expect(q.Version()).To(Equal(&provider.Version{Major: 5, Minor: 2, Build: 0}))
+		})
+	})
+})
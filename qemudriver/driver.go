@@ -0,0 +1,643 @@
+package qemudriver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pivotal-cf/pcfdev-cli/fs"
+	"github.com/pivotal-cf/pcfdev-cli/helpers"
+	"github.com/pivotal-cf/pcfdev-cli/provider"
+	"github.com/pivotal-cf/pcfdev-cli/runner"
+)
+
+type QemuDriver struct {
+	FS        *fs.FS
+	CmdRunner *runner.CmdRunner
+	VMDir     string
+}
+
+type Definition struct {
+	Name     string     `json:"name"`
+	Memory   uint64     `json:"memory"`
+	CPUs     int        `json:"cpus"`
+	Disk     string     `json:"disk"`
+	Forwards []*Forward `json:"forwards"`
+
+	LiveSnapshots []string `json:"live_snapshots,omitempty"`
+}
+
+type Forward struct {
+	Name      string `json:"name"`
+	HostPort  string `json:"host_port"`
+	GuestPort string `json:"guest_port"`
+}
+
+const (
+	StateRunning = "running"
+	StateSaved   = "saved"
+	StateStopped = "poweroff"
+	StatePaused  = "paused"
+)
+
+const (
+	qemuBinary    = "qemu-system-x86_64"
+	qemuImgBinary = "qemu-img"
+)
+
+func (d *QemuDriver) CreateVM(vmName string) error {
+	if err := d.FS.CreateDir(d.vmPath(vmName)); err != nil {
+		return err
+	}
+
+	return d.writeDefinition(&Definition{
+		Name:     vmName,
+		CPUs:     1,
+		Forwards: []*Forward{},
+	})
+}
+
+func (d *QemuDriver) ConvertDisk(src string, dst string) error {
+	_, err := d.CmdRunner.Run(qemuImgBinary, "convert", "-O", "qcow2", src, dst)
+	return err
+}
+
+func (d *QemuDriver) ResizeDisk(diskPath string, sizeInMB uint64) error {
+	_, err := d.CmdRunner.Run(qemuImgBinary, "resize", diskPath, fmt.Sprintf("%dM", sizeInMB))
+	return err
+}
+
+func (d *QemuDriver) DiskSize(diskPath string) (uint64, error) {
+	output, err := d.CmdRunner.Run(qemuImgBinary, "info", "-U", "--output=json", diskPath)
+	if err != nil {
+		return uint64(0), err
+	}
+
+	return ParseDiskSize(output)
+}
+
+// ParseDiskSize returns the virtual size in MB from the JSON output of qemu-img info.
+func ParseDiskSize(output []byte) (uint64, error) {
+	info := struct {
+		VirtualSize uint64 `json:"virtual-size"`
+	}{}
+	if err := json.Unmarshal(output, &info); err != nil {
+		return uint64(0), fmt.Errorf("failed to determine disk size: %s", err)
+	}
+	return info.VirtualSize / 1024 / 1024, nil
+}
+
+func (d *QemuDriver) DeleteDisk(diskPath string) error {
+	return d.FS.Remove(diskPath)
+}
+
+func (d *QemuDriver) AttachDisk(vmName string, diskPath string) error {
+	return d.updateDefinition(vmName, func(definition *Definition) {
+		definition.Disk = diskPath
+	})
+}
+
+func (d *QemuDriver) SetMemory(vmName string, memory uint64) error {
+	return d.updateDefinition(vmName, func(definition *Definition) {
+		definition.Memory = memory
+	})
+}
+
+func (d *QemuDriver) SetCPUs(vmName string, cpus int) error {
+	return d.updateDefinition(vmName, func(definition *Definition) {
+		definition.CPUs = cpus
+	})
+}
+
+func (d *QemuDriver) GetMemory(vmName string) (uint64, error) {
+	definition, err := d.readDefinition(vmName)
+	if err != nil {
+		return uint64(0), fmt.Errorf("failed to determine VM memory for '%s': %s", vmName, err)
+	}
+	return definition.Memory, nil
+}
+
+func (d *QemuDriver) GetCPUs(vmName string) (int, error) {
+	definition, err := d.readDefinition(vmName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to determine VM cpus for '%s': %s", vmName, err)
+	}
+	return definition.CPUs, nil
+}
+
+func (d *QemuDriver) ForwardPort(vmName string, ruleName string, hostPort string, guestPort string) error {
+	return d.updateDefinition(vmName, func(definition *Definition) {
+		for _, forward := range definition.Forwards {
+			if forward.Name == ruleName {
+				forward.HostPort = hostPort
+				forward.GuestPort = guestPort
+				return
+			}
+		}
+		definition.Forwards = append(definition.Forwards, &Forward{
+			Name:      ruleName,
+			HostPort:  hostPort,
+			GuestPort: guestPort,
+		})
+	})
+}
+
+func (d *QemuDriver) GetHostForwardPort(vmName string, ruleName string) (port string, err error) {
+	definition, err := d.readDefinition(vmName)
+	if err != nil {
+		return "", err
+	}
+
+	for _, forward := range definition.Forwards {
+		if forward.Name == ruleName {
+			return forward.HostPort, nil
+		}
+	}
+	return "", errors.New("could not find forwarded port")
+}
+
+func (d *QemuDriver) StartVM(vmName string) error {
+	definition, err := d.readDefinition(vmName)
+	if err != nil {
+		return err
+	}
+
+	restoring, err := d.FS.Exists(d.statePath(vmName))
+	if err != nil {
+		return err
+	}
+
+	args := []string{
+		"-name", vmName,
+		"-machine", "accel=kvm",
+		"-cpu", "host",
+		"-smp", strconv.Itoa(definition.CPUs),
+		"-m", strconv.FormatUint(definition.Memory, 10),
+		"-drive", fmt.Sprintf("file=%s,if=virtio,format=qcow2", definition.Disk),
+		"-netdev", d.netdev(definition),
+		"-device", "virtio-net-pci,netdev=net0",
+		"-qmp", fmt.Sprintf("unix:%s,server,nowait", d.qmpPath(vmName)),
+		"-pidfile", filepath.Join(d.vmPath(vmName), "qemu.pid"),
+		"-display", "none",
+		"-daemonize",
+	}
+	if restoring {
+		args = append(args, "-incoming", fmt.Sprintf("exec:cat %s", d.statePath(vmName)))
+	}
+
+	snapshot, err := d.pendingSnapshot(vmName)
+	if err != nil {
+		return err
+	}
+	if snapshot != "" {
+		args = append(args, "-loadvm", snapshot)
+	}
+
+	if _, err := d.CmdRunner.Run(qemuBinary, args...); err != nil {
+		return err
+	}
+
+	if snapshot != "" {
+		if err := d.FS.Remove(d.loadVMPath(vmName)); err != nil {
+			return err
+		}
+	}
+
+	if !restoring {
+		return nil
+	}
+
+	if err := helpers.ExecuteWithTimeout(func() error {
+		status, err := d.queryStatus(vmName)
+		if err != nil {
+			return fmt.Errorf("timed out waiting for vm to restore: %s", err)
+		}
+		if status == "inmigrate" {
+			return errors.New("timed out waiting for vm to restore")
+		}
+		return nil
+	},
+		2*time.Minute,
+		time.Second,
+	); err != nil {
+		return err
+	}
+
+	if err := d.execute(vmName, "cont", nil); err != nil {
+		return err
+	}
+
+	return d.FS.Remove(d.statePath(vmName))
+}
+
+func (d *QemuDriver) StopVM(vmName string) error {
+	if err := d.execute(vmName, "system_powerdown", nil); err != nil {
+		return err
+	}
+
+	return helpers.ExecuteWithTimeout(func() error {
+		state, err := d.VMState(vmName)
+		if err != nil {
+			return fmt.Errorf("timed out waiting for vm to stop: %s", err)
+		}
+		if state != StateStopped {
+			return fmt.Errorf("timed out waiting for vm to stop")
+		}
+		return nil
+	},
+		time.Minute,
+		time.Second,
+	)
+}
+
+func (d *QemuDriver) SuspendVM(vmName string) error {
+	if err := d.execute(vmName, "stop", nil); err != nil {
+		return err
+	}
+
+	if err := d.execute(vmName, "migrate", map[string]string{
+		"uri": fmt.Sprintf("exec:cat > %s", d.statePath(vmName)),
+	}); err != nil {
+		return err
+	}
+
+	if err := helpers.ExecuteWithTimeout(func() error {
+		output, err := d.executeWithOutput(vmName, "query-migrate", nil)
+		if err != nil {
+			return fmt.Errorf("timed out waiting for vm to suspend: %s", err)
+		}
+		migration := struct {
+			Status string `json:"status"`
+		}{}
+		if err := json.Unmarshal(output, &migration); err != nil {
+			return err
+		}
+		switch migration.Status {
+		case "completed":
+			return nil
+		case "failed", "cancelled":
+			return fmt.Errorf("failed to save vm state: migration %s", migration.Status)
+		default:
+			return errors.New("timed out waiting for vm to suspend")
+		}
+	},
+		2*time.Minute,
+		time.Second,
+	); err != nil {
+		return err
+	}
+
+	return d.PowerOffVM(vmName)
+}
+
+func (d *QemuDriver) ResumeVM(vmName string) error {
+	return d.execute(vmName, "cont", nil)
+}
+
+func (d *QemuDriver) PowerOffVM(vmName string) error {
+	if !d.isRunning(vmName) {
+		return nil
+	}
+
+	helpers.IgnoreErrorFrom(d.execute(vmName, "quit", nil))
+
+	return helpers.ExecuteWithTimeout(func() error {
+		if d.isRunning(vmName) {
+			return errors.New("timed out waiting for vm to power off")
+		}
+		return nil
+	},
+		30*time.Second,
+		time.Second,
+	)
+}
+
+func (d *QemuDriver) DestroyVM(vmName string) error {
+	return d.FS.Remove(d.vmPath(vmName))
+}
+
+func (d *QemuDriver) VMExists(vmName string) (exists bool, err error) {
+	return d.FS.Exists(d.definitionPath(vmName))
+}
+
+func (d *QemuDriver) VMState(vmName string) (string, error) {
+	if d.isRunning(vmName) {
+		status, err := d.queryStatus(vmName)
+		if err != nil {
+			return "", err
+		}
+
+		switch status {
+		case "running":
+			return StateRunning, nil
+		case "paused":
+			return StatePaused, nil
+		default:
+			return status, nil
+		}
+	}
+
+	saved, err := d.FS.Exists(d.statePath(vmName))
+	if err != nil {
+		return "", err
+	}
+	if saved {
+		return StateSaved, nil
+	}
+
+	snapshot, err := d.pendingSnapshot(vmName)
+	if err != nil {
+		return "", err
+	}
+	if snapshot != "" {
+		return StateSaved, nil
+	}
+
+	return StateStopped, nil
+}
+
+func (d *QemuDriver) VMs() ([]string, error) {
+	exists, err := d.FS.Exists(d.VMDir)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return []string{}, nil
+	}
+
+	files, err := ioutil.ReadDir(d.VMDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vms: %s", err)
+	}
+
+	vms := []string{}
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+		exists, err := d.VMExists(file.Name())
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			vms = append(vms, file.Name())
+		}
+	}
+	return vms, nil
+}
+
+func (d *QemuDriver) TakeSnapshot(vmName string, snapshotName string) error {
+	definition, err := d.readDefinition(vmName)
+	if err != nil {
+		return err
+	}
+
+	if !d.isRunning(vmName) {
+		_, err := d.CmdRunner.Run(qemuImgBinary, "snapshot", "-c", snapshotName, definition.Disk)
+		return err
+	}
+
+	if err := d.monitor(vmName, "savevm "+snapshotName); err != nil {
+		return err
+	}
+
+	definition.LiveSnapshots = append(definition.LiveSnapshots, snapshotName)
+	return d.writeDefinition(definition)
+}
+
+func (d *QemuDriver) RestoreSnapshot(vmName string, snapshotName string) error {
+	definition, err := d.readDefinition(vmName)
+	if err != nil {
+		return err
+	}
+
+	if err := d.FS.Remove(d.statePath(vmName)); err != nil {
+		return err
+	}
+
+	for _, liveSnapshot := range definition.LiveSnapshots {
+		if liveSnapshot == snapshotName {
+			return d.FS.Write(d.loadVMPath(vmName), strings.NewReader(snapshotName), false)
+		}
+	}
+
+	if err := d.FS.Remove(d.loadVMPath(vmName)); err != nil {
+		return err
+	}
+
+	_, err = d.CmdRunner.Run(qemuImgBinary, "snapshot", "-a", snapshotName, definition.Disk)
+	return err
+}
+
+func (d *QemuDriver) DeleteSnapshot(vmName string, snapshotName string) error {
+	definition, err := d.readDefinition(vmName)
+	if err != nil {
+		return err
+	}
+
+	if d.isRunning(vmName) {
+		err = d.monitor(vmName, "delvm "+snapshotName)
+	} else {
+		_, err = d.CmdRunner.Run(qemuImgBinary, "snapshot", "-d", snapshotName, definition.Disk)
+	}
+	if err != nil {
+		return err
+	}
+
+	liveSnapshots := []string{}
+	for _, liveSnapshot := range definition.LiveSnapshots {
+		if liveSnapshot != snapshotName {
+			liveSnapshots = append(liveSnapshots, liveSnapshot)
+		}
+	}
+	definition.LiveSnapshots = liveSnapshots
+	return d.writeDefinition(definition)
+}
+
+func (d *QemuDriver) Snapshots(vmName string) ([]string, error) {
+	definition, err := d.readDefinition(vmName)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := d.CmdRunner.Run(qemuImgBinary, "snapshot", "-l", "-U", definition.Disk)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseSnapshots(string(output)), nil
+}
+
+func ParseSnapshots(output string) []string {
+	snapshots := []string{}
+	regex := regexp.MustCompile(`^\d+\s+(\S+)\s`)
+	for _, line := range strings.Split(output, "\n") {
+		if matches := regex.FindStringSubmatch(strings.TrimSpace(line) + " "); len(matches) > 1 {
+			snapshots = append(snapshots, matches[1])
+		}
+	}
+	return snapshots
+}
+
+func (d *QemuDriver) Version() (*provider.Version, error) {
+	output, err := d.CmdRunner.Run(qemuBinary, "--version")
+	if err != nil {
+		return nil, err
+	}
+
+	regex := regexp.MustCompile(`QEMU emulator version (\d+)\.(\d+)\.(\d+)`)
+	matches := regex.FindStringSubmatch(string(output))
+	if len(matches) != 4 {
+		return nil, fmt.Errorf("failed to parse version from '%s --version': %s", qemuBinary, string(output))
+	}
+
+	majorVersion, errMajor := strconv.Atoi(matches[1])
+	minorVersion, errMinor := strconv.Atoi(matches[2])
+	buildVersion, errBuild := strconv.Atoi(matches[3])
+	if errMajor != nil || errMinor != nil || errBuild != nil {
+		return nil, fmt.Errorf("failed to parse version from '%s --version': %s", qemuBinary, string(output))
+	}
+
+	return &provider.Version{
+		Major: majorVersion,
+		Minor: minorVersion,
+		Build: buildVersion,
+	}, nil
+}
+
+func (d *QemuDriver) netdev(definition *Definition) string {
+	options := []string{"user", "id=net0"}
+	for _, forward := range definition.Forwards {
+		options = append(options, fmt.Sprintf("hostfwd=tcp:127.0.0.1:%s-:%s", forward.HostPort, forward.GuestPort))
+	}
+	return strings.Join(options, ",")
+}
+
+func (d *QemuDriver) isRunning(vmName string) bool {
+	qmp, err := DialQMP(d.qmpPath(vmName), 5*time.Second)
+	if err != nil {
+		return false
+	}
+	qmp.Close()
+	return true
+}
+
+func (d *QemuDriver) monitor(vmName string, commandLine string) error {
+	output, err := d.executeWithOutput(vmName, "human-monitor-command", map[string]string{
+		"command-line": commandLine,
+	})
+	if err != nil {
+		return err
+	}
+
+	var message string
+	if err := json.Unmarshal(output, &message); err != nil {
+		return err
+	}
+	if message = strings.TrimSpace(message); message != "" {
+		return fmt.Errorf("failed to execute '%s': %s", commandLine, message)
+	}
+	return nil
+}
+
+func (d *QemuDriver) pendingSnapshot(vmName string) (string, error) {
+	exists, err := d.FS.Exists(d.loadVMPath(vmName))
+	if err != nil || !exists {
+		return "", err
+	}
+
+	snapshot, err := d.FS.Read(d.loadVMPath(vmName))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(snapshot)), nil
+}
+
+func (d *QemuDriver) queryStatus(vmName string) (string, error) {
+	output, err := d.executeWithOutput(vmName, "query-status", nil)
+	if err != nil {
+		return "", err
+	}
+
+	status := struct {
+		Status string `json:"status"`
+	}{}
+	if err := json.Unmarshal(output, &status); err != nil {
+		return "", err
+	}
+	return status.Status, nil
+}
+
+func (d *QemuDriver) execute(vmName string, command string, arguments interface{}) error {
+	_, err := d.executeWithOutput(vmName, command, arguments)
+	return err
+}
+
+func (d *QemuDriver) executeWithOutput(vmName string, command string, arguments interface{}) (json.RawMessage, error) {
+	qmp, err := DialQMP(d.qmpPath(vmName), 5*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to vm '%s': %s", vmName, err)
+	}
+	defer qmp.Close()
+
+	return qmp.Execute(command, arguments)
+}
+
+func (d *QemuDriver) readDefinition(vmName string) (*Definition, error) {
+	data, err := d.FS.Read(d.definitionPath(vmName))
+	if err != nil {
+		return nil, err
+	}
+
+	definition := &Definition{}
+	if err := json.Unmarshal(data, definition); err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+func (d *QemuDriver) writeDefinition(definition *Definition) error {
+	data, err := json.Marshal(definition)
+	if err != nil {
+		return err
+	}
+
+	return d.FS.Write(d.definitionPath(definition.Name), bytes.NewReader(data), false)
+}
+
+func (d *QemuDriver) updateDefinition(vmName string, update func(definition *Definition)) error {
+	definition, err := d.readDefinition(vmName)
+	if err != nil {
+		return err
+	}
+
+	update(definition)
+	return d.writeDefinition(definition)
+}
+
+func (d *QemuDriver) vmPath(vmName string) string {
+	return filepath.Join(d.VMDir, vmName)
+}
+
+func (d *QemuDriver) definitionPath(vmName string) string {
+	return filepath.Join(d.vmPath(vmName), "qemu.json")
+}
+
+func (d *QemuDriver) qmpPath(vmName string) string {
+	return filepath.Join(d.vmPath(vmName), "qmp.sock")
+}
+
+func (d *QemuDriver) statePath(vmName string) string {
+	return filepath.Join(d.vmPath(vmName), "qemu.state")
+}
+
+func (d *QemuDriver) loadVMPath(vmName string) string {
+	return filepath.Join(d.vmPath(vmName), "qemu.loadvm")
+}
@@ -0,0 +1,188 @@
+package qemudriver_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/fs"
+	"github.com/pivotal-cf/pcfdev-cli/qemudriver"
+	"github.com/pivotal-cf/pcfdev-cli/runner"
+)
+
+var _ = Describe("QemuDriver", func() {
+	var (
+		driver *qemudriver.QemuDriver
+		vmDir  string
+	)
+
+	BeforeEach(func() {
+		var err error
+		vmDir, err = ioutil.TempDir("", "pcfdev-qemu")
+		Expect(err).NotTo(HaveOccurred())
+
+		driver = &qemudriver.QemuDriver{
+			FS:        &fs.FS{},
+			CmdRunner: &runner.CmdRunner{},
+			VMDir:     vmDir,
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(vmDir)
+	})
+
+	Describe("#CreateVM", func() {
+		It("should register the VM", func() {
+			Expect(driver.VMExists("some-vm")).To(BeFalse())
+
+			Expect(driver.CreateVM("some-vm")).To(Succeed())
+
+			Expect(driver.VMExists("some-vm")).To(BeTrue())
+			Expect(driver.VMs()).To(Equal([]string{"some-vm"}))
+			Expect(driver.VMState("some-vm")).To(Equal(qemudriver.StateStopped))
+		})
+	})
+
+	Describe("#DestroyVM", func() {
+		It("should remove the VM", func() {
+			Expect(driver.CreateVM("some-vm")).To(Succeed())
+
+			Expect(driver.DestroyVM("some-vm")).To(Succeed())
+
+			Expect(driver.VMExists("some-vm")).To(BeFalse())
+			Expect(driver.VMs()).To(BeEmpty())
+		})
+	})
+
+	Describe("#VMs", func() {
+		It("should only return directories with a VM definition", func() {
+			Expect(os.MkdirAll(filepath.Join(vmDir, "some-other-dir"), 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(vmDir, "vm_config"), []byte("{}"), 0644)).To(Succeed())
+			Expect(driver.CreateVM("some-vm")).To(Succeed())
+
+			Expect(driver.VMs()).To(Equal([]string{"some-vm"}))
+		})
+
+		Context("when the VM directory does not exist", func() {
+			It("should return no VMs", func() {
+				driver.VMDir = filepath.Join(vmDir, "some-missing-dir")
+
+				Expect(driver.VMs()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("#SetMemory", func() {
+		It("should set the memory of the VM", func() {
+			Expect(driver.CreateVM("some-vm")).To(Succeed())
+
+			Expect(driver.SetMemory("some-vm", uint64(3456))).To(Succeed())
+
+			Expect(driver.GetMemory("some-vm")).To(Equal(uint64(3456)))
+		})
+
+		Context("when the VM does not exist", func() {
+			It("should return an error", func() {
+				Expect(driver.SetMemory("some-bad-vm", uint64(3456))).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("#SetCPUs", func() {
+		It("should set the cpus of the VM", func() {
+			Expect(driver.CreateVM("some-vm")).To(Succeed())
+			Expect(driver.GetCPUs("some-vm")).To(Equal(1))
+
+			Expect(driver.SetCPUs("some-vm", 3)).To(Succeed())
+
+			Expect(driver.GetCPUs("some-vm")).To(Equal(3))
+		})
+
+		Context("when the VM does not exist", func() {
+			It("should return an error", func() {
+				_, err := driver.GetCPUs("some-bad-vm")
+				Expect(err.Error()).To(ContainSubstring("failed to determine VM cpus for 'some-bad-vm'"))
+			})
+		})
+	})
+
+	Describe("#ForwardPort", func() {
+		It("should record the forwarded port", func() {
+			Expect(driver.CreateVM("some-vm")).To(Succeed())
+
+			Expect(driver.ForwardPort("some-vm", "some-rule", "some-host-port", "some-guest-port")).To(Succeed())
+			Expect(driver.GetHostForwardPort("some-vm", "some-rule")).To(Equal("some-host-port"))
+
+			Expect(driver.ForwardPort("some-vm", "some-rule", "some-other-host-port", "some-guest-port")).To(Succeed())
+			Expect(driver.GetHostForwardPort("some-vm", "some-rule")).To(Equal("some-other-host-port"))
+		})
+
+		Context("when the rule does not exist", func() {
+			It("should return an error", func() {
+				Expect(driver.CreateVM("some-vm")).To(Succeed())
+
+				_, err := driver.GetHostForwardPort("some-vm", "some-bad-rule")
+				Expect(err).To(MatchError("could not find forwarded port"))
+			})
+		})
+	})
+
+	Describe("#ParseSnapshots", func() {
+		It("should return the snapshot tags", func() {
+			output := `Snapshot list:
+ID        TAG                 VM SIZE                DATE       VM CLOCK
+1         some-snapshot       0 B 2020-01-01 00:00:00   00:00:00.000
+2         some-other-snapshot 1.2 GiB 2020-01-02 00:00:00   00:10:00.000
+`
+			Expect(qemudriver.ParseSnapshots(output)).To(Equal([]string{"some-snapshot", "some-other-snapshot"}))
+		})
+
+		Context("when there are no snapshots", func() {
+			It("should return no snapshots", func() {
+				Expect(qemudriver.ParseSnapshots("")).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("#ParseDiskSize", func() {
+		It("should return the virtual size in MB", func() {
+			output := `{"virtual-size": 53687091200, "filename": "some-disk.qcow2", "format": "qcow2", "actual-size": 4294967296}`
+			Expect(qemudriver.ParseDiskSize([]byte(output))).To(Equal(uint64(51200)))
+		})
+
+		Context("when the output is not valid JSON", func() {
+			It("should return an error", func() {
+				_, err := qemudriver.ParseDiskSize([]byte("some-bad-output"))
+				Expect(err).To(MatchError(ContainSubstring("failed to determine disk size:")))
+			})
+		})
+	})
+
+	Describe("#RestoreSnapshot", func() {
+		Context("when the snapshot was taken while the VM was running", func() {
+			It("should load the snapshot the next time the VM starts", func() {
+				Expect(driver.CreateVM("some-vm")).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(vmDir, "some-vm", "qemu.json"), []byte(`{"name":"some-vm","live_snapshots":["some-snapshot"]}`), 0644)).To(Succeed())
+
+				Expect(driver.RestoreSnapshot("some-vm", "some-snapshot")).To(Succeed())
+
+				Expect(driver.VMState("some-vm")).To(Equal(qemudriver.StateSaved))
+			})
+		})
+	})
+
+	Describe("#AttachDisk", func() {
+		It("should record the disk in the VM definition", func() {
+			Expect(driver.CreateVM("some-vm")).To(Succeed())
+
+			Expect(driver.AttachDisk("some-vm", "some-disk.qcow2")).To(Succeed())
+
+			data, err := ioutil.ReadFile(filepath.Join(vmDir, "some-vm", "qemu.json"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"disk":"some-disk.qcow2"`))
+		})
+	})
+})
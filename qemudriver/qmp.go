@@ -0,0 +1,88 @@
+package qemudriver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+)
+
+type QMP struct {
+	conn    net.Conn
+	decoder *json.Decoder
+	encoder *json.Encoder
+}
+
+type QMPError struct {
+	Class       string `json:"class"`
+	Description string `json:"desc"`
+}
+
+func (e *QMPError) Error() string {
+	return fmt.Sprintf("qmp command failed: %s: %s", e.Class, e.Description)
+}
+
+type qmpCommand struct {
+	Execute   string      `json:"execute"`
+	Arguments interface{} `json:"arguments,omitempty"`
+}
+
+type qmpResponse struct {
+	Return json.RawMessage `json:"return"`
+	Error  *QMPError       `json:"error"`
+	Event  string          `json:"event"`
+	QMP    json.RawMessage `json:"QMP"`
+}
+
+func DialQMP(socketPath string, timeout time.Duration) (*QMP, error) {
+	conn, err := net.DialTimeout("unix", socketPath, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	qmp := &QMP{
+		conn:    conn,
+		decoder: json.NewDecoder(conn),
+		encoder: json.NewEncoder(conn),
+	}
+
+	greeting := &qmpResponse{}
+	if err := qmp.decoder.Decode(greeting); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read qmp greeting: %s", err)
+	}
+
+	if _, err := qmp.Execute("qmp_capabilities", nil); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return qmp, nil
+}
+
+func (q *QMP) Execute(command string, arguments interface{}) (json.RawMessage, error) {
+	if err := q.encoder.Encode(&qmpCommand{Execute: command, Arguments: arguments}); err != nil {
+		return nil, err
+	}
+
+	for {
+		response := &qmpResponse{}
+		if err := q.decoder.Decode(response); err != nil {
+			return nil, fmt.Errorf("failed to read qmp response: %s", err)
+		}
+
+		if response.Event != "" {
+			continue
+		}
+
+		if response.Error != nil {
+			return nil, response.Error
+		}
+
+		return response.Return, nil
+	}
+}
+
+func (q *QMP) Close() error {
+	return q.conn.Close()
+}
@@ -0,0 +1,149 @@
+package qemudriver_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/qemudriver"
+)
+
+var _ = Describe("QMP", func() {
+	var (
+		tmpDir     string
+		socketPath string
+		listener   net.Listener
+		commands   chan string
+	)
+
+	serve := func(responses map[string]string) {
+		go func() {
+			defer GinkgoRecover()
+			conn, err := listener.Accept()
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			conn.Write([]byte(`{"QMP": {"version": {}, "capabilities": []}}` + "\n"))
+			scanner := bufio.NewScanner(conn)
+			for scanner.Scan() {
+				command := struct {
+					Execute string `json:"execute"`
+				}{}
+				Expect(json.Unmarshal(scanner.Bytes(), &command)).To(Succeed())
+				commands <- scanner.Text()
+				response, ok := responses[command.Execute]
+				if !ok {
+					response = `{"return": {}}`
+				}
+				conn.Write([]byte(response + "\n"))
+			}
+		}()
+	}
+
+	BeforeEach(func() {
+		if runtime.GOOS == "windows" {
+			Skip("This test is not appropriate for the windows OS")
+		}
+
+		var err error
+		tmpDir, err = ioutil.TempDir("", "pcfdev-qmp")
+		Expect(err).NotTo(HaveOccurred())
+		socketPath = filepath.Join(tmpDir, "qmp.sock")
+		listener, err = net.Listen("unix", socketPath)
+		Expect(err).NotTo(HaveOccurred())
+		commands = make(chan string, 10)
+	})
+
+	AfterEach(func() {
+		listener.Close()
+		os.RemoveAll(tmpDir)
+	})
+
+	Describe("#DialQMP", func() {
+		It("should negotiate capabilities", func() {
+			serve(map[string]string{})
+
+			qmp, err := qemudriver.DialQMP(socketPath, time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			defer qmp.Close()
+
+			Eventually(commands).Should(Receive(MatchJSON(`{"execute": "qmp_capabilities"}`)))
+		})
+
+		Context("when there is no socket", func() {
+			It("should return an error", func() {
+				_, err := qemudriver.DialQMP(filepath.Join(tmpDir, "some-bad-socket"), time.Second)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("#Execute", func() {
+		It("should send the command and return the result", func() {
+			serve(map[string]string{
+				"query-status": `{"return": {"status": "running", "running": true}}`,
+			})
+
+			qmp, err := qemudriver.DialQMP(socketPath, time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			defer qmp.Close()
+			Eventually(commands).Should(Receive())
+
+			output, err := qmp.Execute("query-status", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(MatchJSON(`{"status": "running", "running": true}`))
+			Eventually(commands).Should(Receive(MatchJSON(`{"execute": "query-status"}`)))
+		})
+
+		It("should send arguments", func() {
+			serve(map[string]string{})
+
+			qmp, err := qemudriver.DialQMP(socketPath, time.Second)
+			Expect(err).NotTo(HaveOccurred())
+			defer qmp.Close()
+			Eventually(commands).Should(Receive())
+
+			_, err = qmp.Execute("migrate", map[string]string{"uri": "some-uri"})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(commands).Should(Receive(MatchJSON(`{"execute": "migrate", "arguments": {"uri": "some-uri"}}`)))
+		})
+
+		Context("when events are received before the response", func() {
+			It("should skip the events", func() {
+				serve(map[string]string{
+					"stop": `{"event": "STOP", "timestamp": {}}` + "\n" + `{"return": {}}`,
+				})
+
+				qmp, err := qemudriver.DialQMP(socketPath, time.Second)
+				Expect(err).NotTo(HaveOccurred())
+				defer qmp.Close()
+
+				output, err := qmp.Execute("stop", nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output).To(MatchJSON(`{}`))
+			})
+		})
+
+		Context("when the command fails", func() {
+			It("should return the error", func() {
+				serve(map[string]string{
+					"some-command": `{"error": {"class": "CommandNotFound", "desc": "some-description"}}`,
+				})
+
+				qmp, err := qemudriver.DialQMP(socketPath, time.Second)
+				Expect(err).NotTo(HaveOccurred())
+				defer qmp.Close()
+
+				_, err = qmp.Execute("some-command", nil)
+				Expect(err).To(MatchError("qmp command failed: CommandNotFound: some-description"))
+			})
+		})
+	})
+})
@@ -0,0 +1,16 @@
+package readiness
+
+import (
+	"fmt"
+	"time"
+)
+
+type NotReadyError struct {
+	Probe   string
+	Timeout time.Duration
+	Err     error
+}
+
+func (e *NotReadyError) Error() string {
+	return fmt.Sprintf("PCF Dev is not ready after %s, %s is not responding: %s", e.Timeout, e.Probe, e.Err)
+}
@@ -0,0 +1,81 @@
+package readiness
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
+)
+
+//go:generate mockgen -package mocks -destination mocks/client.go github.com/pivotal-cf/pcfdev-cli/readiness Client
+type Client interface {
+	ProbeHTTPS(sshAddresses []ssh.SSHAddress, privateKey []byte, address string, host string, path string) error
+	Status(sshAddresses []ssh.SSHAddress, privateKey []byte) (status string, err error)
+}
+
+// Checker waits for PCF Dev to serve requests after the VM is started or resumed.
+type Checker struct {
+	Client   Client
+	Interval time.Duration
+}
+
+type probe struct {
+	name  string
+	check func() error
+}
+
+// Wait runs each probe in turn until it passes, and fails with the probe
+// that was still failing once timeout has passed.
+func (c *Checker) Wait(vmConfig *config.VMConfig, privateKey []byte, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for _, probe := range c.probes(vmConfig, privateKey) {
+		for {
+			err := probe.check()
+			if err == nil {
+				break
+			}
+			if !time.Now().Before(deadline) {
+				return &NotReadyError{Probe: probe.name, Timeout: timeout, Err: err}
+			}
+			time.Sleep(c.Interval)
+		}
+	}
+	return nil
+}
+
+func (c *Checker) probes(vmConfig *config.VMConfig, privateKey []byte) []probe {
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: vmConfig.SSHPort},
+		{IP: vmConfig.IP, Port: "22"},
+	}
+	routerAddress := vmConfig.IP + ":443"
+
+	return []probe{
+		{
+			name: "the PCF Dev API",
+			check: func() error {
+				status, err := c.Client.Status(addresses, privateKey)
+				if err != nil {
+					return err
+				}
+				if status != "Running" {
+					return fmt.Errorf("status is %s", status)
+				}
+				return nil
+			},
+		},
+		{
+			name: "the CF API",
+			check: func() error {
+				return c.Client.ProbeHTTPS(addresses, privateKey, routerAddress, "api."+vmConfig.Domain, "/v2/info")
+			},
+		},
+		{
+			name: "UAA",
+			check: func() error {
+				return c.Client.ProbeHTTPS(addresses, privateKey, routerAddress, "uaa."+vmConfig.Domain, "/healthz")
+			},
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package readiness_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/readiness"
+	"github.com/pivotal-cf/pcfdev-cli/readiness/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checker", func() {
+	var (
+		mockCtrl   *gomock.Controller
+		mockClient *mocks.MockClient
+		checker    *readiness.Checker
+		vmConfig   *config.VMConfig
+		addresses  []ssh.SSHAddress
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockClient = mocks.NewMockClient(mockCtrl)
+		checker = &readiness.Checker{
+			Client:   mockClient,
+			Interval: time.Millisecond,
+		}
+		vmConfig = &config.VMConfig{
+			IP:      "some-ip",
+			SSHPort: "some-port",
+			Domain:  "some-domain",
+		}
+		addresses = []ssh.SSHAddress{
+			{IP: "127.0.0.1", Port: "some-port"},
+			{IP: "some-ip", Port: "22"},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("#Wait", func() {
+		It("should wait for the PCF Dev API, the CF API and UAA in turn", func() {
+			gomock.InOrder(
+				mockClient.EXPECT().Status(addresses, []byte("some-private-key")).Return("", errors.New("some-error")),
+				mockClient.EXPECT().Status(addresses, []byte("some-private-key")).Return("Unprovisioned", nil),
+				mockClient.EXPECT().Status(addresses, []byte("some-private-key")).Return("Running", nil),
+				mockClient.EXPECT().ProbeHTTPS(addresses, []byte("some-private-key"), "some-ip:443", "api.some-domain", "/v2/info").Return(errors.New("some-error")),
+				mockClient.EXPECT().ProbeHTTPS(addresses, []byte("some-private-key"), "some-ip:443", "api.some-domain", "/v2/info"),
+				mockClient.EXPECT().ProbeHTTPS(addresses, []byte("some-private-key"), "some-ip:443", "uaa.some-domain", "/healthz"),
+			)
+
+			Expect(checker.Wait(vmConfig, []byte("some-private-key"), time.Minute)).To(Succeed())
+		})
+
+		Context("when a probe does not pass before the timeout", func() {
+			It("should return an error", func() {
+				mockClient.EXPECT().Status(addresses, []byte("some-private-key")).Return("Running", nil)
+				mockClient.EXPECT().ProbeHTTPS(addresses, []byte("some-private-key"), "some-ip:443", "api.some-domain", "/v2/info").Return(errors.New("some-error")).AnyTimes()
+
+				Expect(checker.Wait(vmConfig, []byte("some-private-key"), 20*time.Millisecond)).To(MatchError("PCF Dev is not ready after 20ms, the CF API is not responding: some-error"))
+			})
+		})
+	})
+})
@@ -2,17 +2,54 @@ package runner
 
 import (
 	"fmt"
+	"io"
 	"os/exec"
 	"strings"
+	"syscall"
 )
 
 type CmdRunner struct{}
 
+// CmdError is returned when a command fails. Status is the exit status of the
+// command, or -1 when it did not exit, e.g. because it could not be found.
+type CmdError struct {
+	Command string
+	Args    []string
+	Status  int
+	Output  []byte
+	Err     error
+}
+
+func (e *CmdError) Error() string {
+	return fmt.Sprintf("failed to execute '%s %s': %s: %s", e.Command, strings.Join(e.Args, " "), e.Err, e.Output)
+}
+
 func (c *CmdRunner) Run(command string, args ...string) ([]byte, error) {
-	output, err := exec.Command(command, args...).CombinedOutput()
+	return c.RunWithInput(nil, command, args...)
+}
+
+func (c *CmdRunner) RunWithInput(input io.Reader, command string, args ...string) ([]byte, error) {
+	cmd := exec.Command(command, args...)
+	cmd.Stdin = input
+	output, err := cmd.CombinedOutput()
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute '%s %s': %s: %s", command, strings.Join(args, " "), err, output)
+		return nil, &CmdError{
+			Command: command,
+			Args:    args,
+			Status:  exitStatus(err),
+			Output:  output,
+			Err:     err,
+		}
 	}
 
 	return output, nil
 }
+
+func exitStatus(err error) int {
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return status.ExitStatus()
+		}
+	}
+	return -1
+}
@@ -2,6 +2,7 @@ package runner_test
 
 import (
 	"runtime"
+	"strings"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -32,6 +33,24 @@ var _ = Describe("CmdRunner", func() {
 				_, err := runner.Run("bash", "-c", "echo -n some-error && exit 1")
 				Expect(err).To(MatchError("failed to execute 'bash -c echo -n some-error && exit 1': exit status 1: some-error"))
 			})
+
+			It("should return the exit status of the command", func() {
+				_, err := runner.Run("bash", "-c", "exit 3")
+				Expect(err.(*rnr.CmdError).Status).To(Equal(3))
+			})
+
+			Context("when the command cannot be run", func() {
+				It("should return no exit status", func() {
+					_, err := runner.Run("some-non-existent-command")
+					Expect(err.(*rnr.CmdError).Status).To(Equal(-1))
+				})
+			})
+		})
+	})
+
+	Describe("#RunWithInput", func() {
+		It("should pass the input to the command", func() {
+			Expect(runner.RunWithInput(strings.NewReader("some-input"), "cat")).To(Equal([]byte("some-input")))
 		})
 	})
 })
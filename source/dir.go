@@ -0,0 +1,82 @@
+package source
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pivotal-cf/pcfdev-cli/pivnet"
+)
+
+type Dir struct {
+	Path     string
+	FileName string
+}
+
+type limitedFile struct {
+	io.Reader
+	file *os.File
+}
+
+func (l *limitedFile) Close() error {
+	return l.file.Close()
+}
+
+func (d *Dir) DownloadOVA(startAtByte int64) (*pivnet.DownloadReader, error) {
+	file, size, err := d.open(startAtByte)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pivnet.DownloadReader{ReadCloser: file, Writer: os.Stdout, ContentLength: size - startAtByte, ExistingLength: startAtByte}, nil
+}
+
+func (d *Dir) DownloadOVARange(startAtByte int64, endAtByte int64) (io.ReadCloser, error) {
+	file, _, err := d.open(startAtByte)
+	if err != nil {
+		return nil, err
+	}
+
+	return &limitedFile{Reader: io.LimitReader(file, endAtByte-startAtByte+1), file: file}, nil
+}
+
+func (d *Dir) OVASize() (int64, error) {
+	info, err := os.Stat(d.ovaPath())
+	if err != nil {
+		return 0, d.statError(err)
+	}
+	return info.Size(), nil
+}
+
+func (d *Dir) open(startAtByte int64) (*os.File, int64, error) {
+	file, err := os.Open(d.ovaPath())
+	if err != nil {
+		return nil, 0, d.statError(err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+
+	if _, err := file.Seek(startAtByte, io.SeekStart); err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	return file, info.Size(), nil
+}
+
+func (d *Dir) ovaPath() string {
+	if info, err := os.Stat(d.Path); err == nil && !info.IsDir() {
+		return d.Path
+	}
+	return filepath.Join(d.Path, d.FileName)
+}
+
+func (d *Dir) statError(err error) error {
+	if os.IsNotExist(err) {
+		return &OVANotFoundError{d.ovaPath()}
+	}
+	return err
+}
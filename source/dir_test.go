@@ -0,0 +1,93 @@
+package source_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pivotal-cf/pcfdev-cli/source"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Dir", func() {
+	var (
+		dir string
+		src *source.Dir
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "pcfdev-source")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ioutil.WriteFile(filepath.Join(dir, "some-vm-name.ova"), []byte("some-ova-contents"), 0644)).To(Succeed())
+
+		src = &source.Dir{Path: dir, FileName: "some-vm-name.ova"}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("#DownloadOVA", func() {
+		It("should read the OVA from the directory", func() {
+			ova, err := src.DownloadOVA(int64(5))
+			Expect(err).NotTo(HaveOccurred())
+			defer ova.Close()
+			Expect(ova.ExistingLength).To(Equal(int64(5)))
+			Expect(ova.ContentLength).To(Equal(int64(12)))
+			buf, err := ioutil.ReadAll(ova.ReadCloser)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(buf)).To(Equal("ova-contents"))
+		})
+
+		Context("when the path is the OVA itself", func() {
+			It("should read the OVA", func() {
+				src.Path = filepath.Join(dir, "some-vm-name.ova")
+
+				ova, err := src.DownloadOVA(int64(0))
+				Expect(err).NotTo(HaveOccurred())
+				defer ova.Close()
+				buf, err := ioutil.ReadAll(ova.ReadCloser)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(buf)).To(Equal("some-ova-contents"))
+			})
+		})
+
+		Context("when the OVA is not in the directory", func() {
+			It("should return an error", func() {
+				src.FileName = "some-other-vm-name.ova"
+
+				_, err := src.DownloadOVA(int64(0))
+				Expect(err).To(MatchError("OVA not found at " + filepath.Join(dir, "some-other-vm-name.ova")))
+			})
+		})
+	})
+
+	Describe("#DownloadOVARange", func() {
+		It("should read the given range of the OVA", func() {
+			ova, err := src.DownloadOVARange(int64(5), int64(7))
+			Expect(err).NotTo(HaveOccurred())
+			defer ova.Close()
+			buf, err := ioutil.ReadAll(ova)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(buf)).To(Equal("ova"))
+		})
+	})
+
+	Describe("#OVASize", func() {
+		It("should return the size of the OVA", func() {
+			Expect(src.OVASize()).To(Equal(int64(17)))
+		})
+
+		Context("when the OVA is not in the directory", func() {
+			It("should return an error", func() {
+				src.FileName = "some-other-vm-name.ova"
+
+				_, err := src.OVASize()
+				Expect(err).To(MatchError("OVA not found at " + filepath.Join(dir, "some-other-vm-name.ova")))
+			})
+		})
+	})
+})
@@ -0,0 +1,44 @@
+package source
+
+import "fmt"
+
+type UnsupportedSourceError struct {
+	Source string
+}
+
+func (e *UnsupportedSourceError) Error() string {
+	return fmt.Sprintf("%s is not a supported OVA source, use pivnet, an http(s) URL or an absolute path to a directory", e.Source)
+}
+
+type UnauthorizedError struct {
+	URL string
+}
+
+func (e *UnauthorizedError) Error() string {
+	return fmt.Sprintf("%s rejected the OVA source credentials, check PCFDEV_OVA_SOURCE_USERNAME, PCFDEV_OVA_SOURCE_PASSWORD or PCFDEV_OVA_SOURCE_TOKEN", e.URL)
+}
+
+type OVANotFoundError struct {
+	Location string
+}
+
+func (e *OVANotFoundError) Error() string {
+	return fmt.Sprintf("OVA not found at %s", e.Location)
+}
+
+type UnexpectedResponseError struct {
+	URL    string
+	Status string
+}
+
+func (e *UnexpectedResponseError) Error() string {
+	return fmt.Sprintf("%s returned: %s", e.URL, e.Status)
+}
+
+type UnreachableError struct {
+	Err error
+}
+
+func (e *UnreachableError) Error() string {
+	return fmt.Sprintf("failed to reach OVA source: %s", e.Err)
+}
@@ -0,0 +1,170 @@
+package source
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pivotal-cf/pcfdev-cli/pivnet"
+)
+
+type HTTP struct {
+	URL         string
+	FileName    string
+	Username    string
+	Password    string
+	BearerToken string
+	HTTPClient  *http.Client
+}
+
+func (h *HTTP) DownloadOVA(startAtByte int64) (*pivnet.DownloadReader, error) {
+	byteRange := ""
+	if startAtByte > 0 {
+		byteRange = fmt.Sprintf("bytes=%d-", startAtByte)
+	}
+
+	resp, err := h.request(byteRange)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		return &pivnet.DownloadReader{ReadCloser: resp.Body, Writer: os.Stdout, ContentLength: h.remainingLength(resp, startAtByte), ExistingLength: startAtByte}, nil
+	case http.StatusOK:
+		if _, err := io.CopyN(ioutil.Discard, resp.Body, startAtByte); err != nil {
+			resp.Body.Close()
+			return nil, &UnreachableError{err}
+		}
+		return &pivnet.DownloadReader{ReadCloser: resp.Body, Writer: os.Stdout, ContentLength: h.remainingLength(resp, startAtByte), ExistingLength: startAtByte}, nil
+	default:
+		defer resp.Body.Close()
+		return nil, h.responseError(resp)
+	}
+}
+
+func (h *HTTP) DownloadOVARange(startAtByte int64, endAtByte int64) (io.ReadCloser, error) {
+	resp, err := h.request(fmt.Sprintf("bytes=%d-%d", startAtByte, endAtByte))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusPartialContent {
+		return resp.Body, nil
+	}
+	defer resp.Body.Close()
+	return nil, h.responseError(resp)
+}
+
+func (h *HTTP) OVASize() (int64, error) {
+	resp, err := h.request("bytes=0-0")
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		contentRange := resp.Header.Get("Content-Range")
+		size, err := strconv.ParseInt(contentRange[strings.LastIndex(contentRange, "/")+1:], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%s returned an invalid Content-Range: %q", h.location(), contentRange)
+		}
+		return size, nil
+	case http.StatusOK:
+		if resp.ContentLength < 0 {
+			return 0, fmt.Errorf("%s did not return the size of the OVA", h.location())
+		}
+		return resp.ContentLength, nil
+	default:
+		return 0, h.responseError(resp)
+	}
+}
+
+// remainingLength is the length of the OVA after startAtByte, or -1 when
+// neither the response nor the server tell the size of the OVA.
+func (h *HTTP) remainingLength(resp *http.Response, startAtByte int64) int64 {
+	if resp.ContentLength >= 0 {
+		if resp.StatusCode == http.StatusPartialContent {
+			return resp.ContentLength
+		}
+		return resp.ContentLength - startAtByte
+	}
+
+	size, err := h.OVASize()
+	if err != nil {
+		return -1
+	}
+	return size - startAtByte
+}
+
+func (h *HTTP) request(byteRange string) (*http.Response, error) {
+	ovaURL, err := h.ovaURL()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", ovaURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if byteRange != "" {
+		req.Header.Set("Range", byteRange)
+	}
+	switch {
+	case h.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+h.BearerToken)
+	case h.Username != "":
+		req.SetBasicAuth(h.Username, h.Password)
+	}
+
+	client := h.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, &UnreachableError{err}
+	}
+	return resp, nil
+}
+
+func (h *HTTP) ovaURL() (*url.URL, error) {
+	ovaURL, err := url.Parse(h.URL)
+	if err != nil {
+		return nil, &UnsupportedSourceError{h.URL}
+	}
+
+	if strings.HasSuffix(ovaURL.Path, "/") {
+		ovaURL.Path += h.FileName
+	}
+	return ovaURL, nil
+}
+
+func (h *HTTP) location() string {
+	ovaURL, err := h.ovaURL()
+	if err != nil {
+		return h.URL
+	}
+
+	ovaURL.User = nil
+	return ovaURL.String()
+}
+
+func (h *HTTP) responseError(resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &UnauthorizedError{h.location()}
+	case http.StatusNotFound:
+		return &OVANotFoundError{h.location()}
+	default:
+		return &UnexpectedResponseError{URL: h.location(), Status: resp.Status}
+	}
+}
@@ -0,0 +1,258 @@
+package source_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/pivotal-cf/pcfdev-cli/source"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HTTP", func() {
+	var (
+		server   *httptest.Server
+		handler  http.HandlerFunc
+		requests []*http.Request
+		src      *source.HTTP
+	)
+
+	BeforeEach(func() {
+		requests = []*http.Request{}
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			http.ServeContent(w, r, "some-vm-name.ova", time.Time{}, strings.NewReader("some-ova-contents"))
+		}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer GinkgoRecover()
+
+			requests = append(requests, r)
+			Expect(r.URL.Path).To(Equal("/pcfdev/some-vm-name.ova"))
+			handler(w, r)
+		}))
+		src = &source.HTTP{
+			URL:      server.URL + "/pcfdev/",
+			FileName: "some-vm-name.ova",
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("#DownloadOVA", func() {
+		It("should download the OVA from the given URL", func() {
+			ova, err := src.DownloadOVA(int64(0))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ova.ExistingLength).To(Equal(int64(0)))
+			Expect(ova.ContentLength).To(Equal(int64(17)))
+			buf, err := ioutil.ReadAll(ova.ReadCloser)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(buf)).To(Equal("some-ova-contents"))
+			Expect(requests[0].Header.Get("Range")).To(BeEmpty())
+		})
+
+		Context("when the URL is the OVA itself", func() {
+			It("should download the OVA from the URL as given", func() {
+				src.URL = server.URL + "/pcfdev/some-vm-name.ova"
+
+				ova, err := src.DownloadOVA(int64(0))
+				Expect(err).NotTo(HaveOccurred())
+				buf, err := ioutil.ReadAll(ova.ReadCloser)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(buf)).To(Equal("some-ova-contents"))
+			})
+		})
+
+		Context("when resuming a download", func() {
+			It("should request the remaining bytes", func() {
+				ova, err := src.DownloadOVA(int64(5))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ova.ExistingLength).To(Equal(int64(5)))
+				Expect(ova.ContentLength).To(Equal(int64(12)))
+				buf, err := ioutil.ReadAll(ova.ReadCloser)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(buf)).To(Equal("ova-contents"))
+				Expect(requests[0].Header.Get("Range")).To(Equal("bytes=5-"))
+			})
+
+			Context("when the server does not support ranges", func() {
+				It("should skip the bytes that were already downloaded", func() {
+					handler = func(w http.ResponseWriter, r *http.Request) {
+						w.Write([]byte("some-ova-contents"))
+					}
+
+					ova, err := src.DownloadOVA(int64(5))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ova.ExistingLength).To(Equal(int64(5)))
+					Expect(ova.ContentLength).To(Equal(int64(12)))
+					buf, err := ioutil.ReadAll(ova.ReadCloser)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(buf)).To(Equal("ova-contents"))
+				})
+			})
+		})
+
+		Context("when the response is chunked", func() {
+			BeforeEach(func() {
+				handler = func(w http.ResponseWriter, r *http.Request) {
+					if r.Header.Get("Range") == "bytes=0-0" {
+						http.ServeContent(w, r, "some-vm-name.ova", time.Time{}, strings.NewReader("some-ova-contents"))
+						return
+					}
+					w.Write([]byte("some-ova-contents"))
+					w.(http.Flusher).Flush()
+				}
+			})
+
+			It("should take the length from the size of the OVA", func() {
+				ova, err := src.DownloadOVA(int64(5))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ova.ExistingLength).To(Equal(int64(5)))
+				Expect(ova.ContentLength).To(Equal(int64(12)))
+				buf, err := ioutil.ReadAll(ova.ReadCloser)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(buf)).To(Equal("ova-contents"))
+				Expect(requests[1].Header.Get("Range")).To(Equal("bytes=0-0"))
+			})
+
+			Context("when the server does not tell the size of the OVA", func() {
+				BeforeEach(func() {
+					handler = func(w http.ResponseWriter, r *http.Request) {
+						w.Write([]byte("some-ova-contents"))
+						w.(http.Flusher).Flush()
+					}
+				})
+
+				It("should leave the length unknown", func() {
+					ova, err := src.DownloadOVA(int64(0))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ova.ContentLength).To(Equal(int64(-1)))
+					ova.Writer = ioutil.Discard
+					buf, err := ioutil.ReadAll(ova)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(buf)).To(Equal("some-ova-contents"))
+				})
+			})
+		})
+
+		Context("when a bearer token is given", func() {
+			It("should authenticate with the token", func() {
+				src.BearerToken = "some-token"
+				src.Username = "some-username"
+
+				_, err := src.DownloadOVA(int64(0))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requests[0].Header.Get("Authorization")).To(Equal("Bearer some-token"))
+			})
+		})
+
+		Context("when a username and password are given", func() {
+			It("should authenticate with basic auth", func() {
+				src.Username = "some-username"
+				src.Password = "some-password"
+
+				_, err := src.DownloadOVA(int64(0))
+				Expect(err).NotTo(HaveOccurred())
+				username, password, ok := requests[0].BasicAuth()
+				Expect(ok).To(BeTrue())
+				Expect(username).To(Equal("some-username"))
+				Expect(password).To(Equal("some-password"))
+			})
+		})
+
+		Context("when the credentials are rejected", func() {
+			It("should return an error", func() {
+				handler = func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusUnauthorized)
+				}
+
+				_, err := src.DownloadOVA(int64(0))
+				Expect(err).To(MatchError(server.URL + "/pcfdev/some-vm-name.ova rejected the OVA source credentials, check PCFDEV_OVA_SOURCE_USERNAME, PCFDEV_OVA_SOURCE_PASSWORD or PCFDEV_OVA_SOURCE_TOKEN"))
+			})
+		})
+
+		Context("when the OVA is not found", func() {
+			It("should return an error", func() {
+				handler = func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+				}
+
+				_, err := src.DownloadOVA(int64(0))
+				Expect(err).To(MatchError("OVA not found at " + server.URL + "/pcfdev/some-vm-name.ova"))
+			})
+		})
+
+		Context("when the server returns an unexpected status", func() {
+			It("should return an error", func() {
+				handler = func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+
+				_, err := src.DownloadOVA(int64(0))
+				Expect(err).To(MatchError(server.URL + "/pcfdev/some-vm-name.ova returned: 500 Internal Server Error"))
+			})
+		})
+
+		Context("when the server is unreachable", func() {
+			It("should return an error", func() {
+				src.URL = "http://some-bad-host.invalid/pcfdev/"
+
+				_, err := src.DownloadOVA(int64(0))
+				Expect(err).To(MatchError(ContainSubstring("failed to reach OVA source:")))
+			})
+		})
+	})
+
+	Describe("#DownloadOVARange", func() {
+		It("should download the given range of the OVA", func() {
+			ova, err := src.DownloadOVARange(int64(5), int64(7))
+			Expect(err).NotTo(HaveOccurred())
+			buf, err := ioutil.ReadAll(ova)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(buf)).To(Equal("ova"))
+			Expect(requests[0].Header.Get("Range")).To(Equal("bytes=5-7"))
+		})
+
+		Context("when the server does not support ranges", func() {
+			It("should return an error", func() {
+				handler = func(w http.ResponseWriter, r *http.Request) {
+					w.Write([]byte("some-ova-contents"))
+				}
+
+				_, err := src.DownloadOVARange(int64(5), int64(7))
+				Expect(err).To(MatchError(server.URL + "/pcfdev/some-vm-name.ova returned: 200 OK"))
+			})
+		})
+	})
+
+	Describe("#OVASize", func() {
+		It("should return the size of the OVA", func() {
+			Expect(src.OVASize()).To(Equal(int64(17)))
+		})
+
+		Context("when the server does not support ranges", func() {
+			It("should return the content length", func() {
+				handler = func(w http.ResponseWriter, r *http.Request) {
+					w.Write([]byte("some-ova-contents"))
+				}
+
+				Expect(src.OVASize()).To(Equal(int64(17)))
+			})
+		})
+
+		Context("when the OVA is not found", func() {
+			It("should return an error", func() {
+				handler = func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusNotFound)
+				}
+
+				_, err := src.OVASize()
+				Expect(err).To(MatchError("OVA not found at " + server.URL + "/pcfdev/some-vm-name.ova"))
+			})
+		})
+	})
+})
@@ -0,0 +1,94 @@
+package ssh
+
+import (
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	. "github.com/pivotal-cf/pcfdev-cli/helpers"
+)
+
+type Forward struct {
+	LocalAddress  string
+	RemoteAddress string
+}
+
+func (s *SSH) ForwardPorts(forwards []Forward, sshAddresses []SSHAddress, privateKey []byte, timeout time.Duration, onError func(forward Forward, err error), block func()) error {
+	client, err := s.waitForSSH(sshAddresses, privateKey, timeout)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var listeners []net.Listener
+	defer func() {
+		for _, listener := range listeners {
+			IgnoreErrorFrom(listener.Close())
+		}
+	}()
+
+	for _, forward := range forwards {
+		listener, err := net.Listen("tcp", forward.LocalAddress)
+		if err != nil {
+			return err
+		}
+		listeners = append(listeners, listener)
+	}
+
+	for i, forward := range forwards {
+		go func(listener net.Listener, forward Forward) {
+			tunnel(listener, client, forward.RemoteAddress, func(err error) {
+				onError(forward, err)
+			})
+		}(listeners[i], forward)
+	}
+
+	block()
+	return nil
+}
+
+func tunnel(listener net.Listener, client *ssh.Client, remoteAddress string, onError func(err error)) {
+	for {
+		localConn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go func(conn net.Conn) {
+			defer conn.Close()
+
+			sshTunnel, err := client.Dial("tcp", remoteAddress)
+			if err != nil {
+				onError(err)
+				return
+			}
+			defer sshTunnel.Close()
+
+			go func() {
+				IgnoreErrorFrom(io.Copy(conn, sshTunnel))
+			}()
+
+			IgnoreErrorFrom(io.Copy(sshTunnel, conn))
+		}(localConn)
+	}
+}
+
+type tunnelErrors struct {
+	sync.Mutex
+	err error
+}
+
+func (t *tunnelErrors) record(err error) {
+	t.Lock()
+	defer t.Unlock()
+	t.err = err
+}
+
+func (t *tunnelErrors) last() error {
+	t.Lock()
+	defer t.Unlock()
+	return t.err
+}
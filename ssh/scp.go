@@ -0,0 +1,315 @@
+package ssh
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/pivotal-cf/pcfdev-cli/helpers"
+)
+
+type SCPError struct {
+	Message string
+}
+
+func (e *SCPError) Error() string {
+	return fmt.Sprintf("scp failed: %s", e.Message)
+}
+
+func (s *SSH) CopyToGuest(hostPath string, guestPath string, addresses []SSHAddress, privateKey []byte, timeout time.Duration, progress io.Writer) error {
+	info, err := os.Stat(hostPath)
+	if err != nil {
+		return err
+	}
+
+	return s.runSCP("scp -r -t "+shellQuote(guestPath), addresses, privateKey, timeout, func(w io.Writer, r *bufio.Reader) error {
+		if err := readSCPAck(r); err != nil {
+			return err
+		}
+		return sendSCPEntry(w, r, hostPath, info, progress)
+	})
+}
+
+func (s *SSH) CopyFromGuest(guestPath string, hostPath string, addresses []SSHAddress, privateKey []byte, timeout time.Duration, progress io.Writer) error {
+	return s.runSCP("scp -r -f "+shellQuote(guestPath), addresses, privateKey, timeout, func(w io.Writer, r *bufio.Reader) error {
+		if err := writeSCPAck(w); err != nil {
+			return err
+		}
+		return receiveSCPEntries(w, r, hostPath, progress)
+	})
+}
+
+func (s *SSH) runSCP(command string, addresses []SSHAddress, privateKey []byte, timeout time.Duration, transfer func(w io.Writer, r *bufio.Reader) error) error {
+	client, session, err := s.newSession(addresses, privateKey, timeout)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	defer session.Close()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr := &bytes.Buffer{}
+	session.Stderr = stderr
+
+	if err := session.Start(command); err != nil {
+		return err
+	}
+
+	transferErr := transfer(stdin, bufio.NewReader(stdout))
+	IgnoreErrorFrom(stdin.Close())
+	waitErr := session.Wait()
+
+	if transferErr != nil {
+		return transferErr
+	}
+	if waitErr != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return &SCPError{Message: message}
+		}
+		return waitErr
+	}
+	return nil
+}
+
+func sendSCPEntry(w io.Writer, r *bufio.Reader, path string, info os.FileInfo, progress io.Writer) error {
+	if info.IsDir() {
+		if _, err := fmt.Fprintf(w, "D%04o 0 %s\n", info.Mode().Perm(), info.Name()); err != nil {
+			return err
+		}
+		if err := readSCPAck(r); err != nil {
+			return err
+		}
+
+		entries, err := ioutil.ReadDir(path)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			entryPath := filepath.Join(path, entry.Name())
+			entryInfo, err := os.Stat(entryPath)
+			if err != nil {
+				return err
+			}
+			if err := sendSCPEntry(w, r, entryPath, entryInfo, progress); err != nil {
+				return err
+			}
+		}
+
+		if _, err := fmt.Fprint(w, "E\n"); err != nil {
+			return err
+		}
+		return readSCPAck(r)
+	}
+
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("%s is not a regular file", path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := fmt.Fprintf(w, "C%04o %d %s\n", info.Mode().Perm(), info.Size(), info.Name()); err != nil {
+		return err
+	}
+	if err := readSCPAck(r); err != nil {
+		return err
+	}
+
+	progressWriter := newProgressWriter(progress, path, info.Size())
+	if _, err := io.Copy(io.MultiWriter(w, progressWriter), file); err != nil {
+		return err
+	}
+	progressWriter.Done()
+
+	if err := writeSCPAck(w); err != nil {
+		return err
+	}
+	return readSCPAck(r)
+}
+
+func receiveSCPEntries(w io.Writer, r *bufio.Reader, hostPath string, progress io.Writer) error {
+	var dirs []string
+	for {
+		line, err := r.ReadString('\n')
+		if err == io.EOF && line == "" {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch line[0] {
+		case 1, 2:
+			return &SCPError{Message: strings.TrimSpace(line[1:])}
+		case 'T':
+		case 'E':
+			if len(dirs) == 0 {
+				return fmt.Errorf("unexpected scp message: %q", line)
+			}
+			dirs = dirs[:len(dirs)-1]
+		case 'D':
+			mode, _, name, err := parseSCPHeader(line)
+			if err != nil {
+				return err
+			}
+
+			target := scpTarget(hostPath, dirs, name)
+			if err := os.MkdirAll(target, mode|0700); err != nil {
+				return err
+			}
+			dirs = append(dirs, target)
+		case 'C':
+			mode, size, name, err := parseSCPHeader(line)
+			if err != nil {
+				return err
+			}
+
+			if err := writeSCPAck(w); err != nil {
+				return err
+			}
+			if err := receiveSCPFile(r, scpTarget(hostPath, dirs, name), mode, size, progress); err != nil {
+				return err
+			}
+			if err := readSCPAck(r); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unexpected scp message: %q", line)
+		}
+
+		if err := writeSCPAck(w); err != nil {
+			return err
+		}
+	}
+}
+
+func receiveSCPFile(r io.Reader, path string, mode os.FileMode, size int64, progress io.Writer) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	progressWriter := newProgressWriter(progress, path, size)
+	if _, err := io.CopyN(io.MultiWriter(file, progressWriter), r, size); err != nil {
+		return err
+	}
+	progressWriter.Done()
+	return nil
+}
+
+func parseSCPHeader(line string) (mode os.FileMode, size int64, name string, err error) {
+	parts := strings.SplitN(strings.TrimSuffix(line[1:], "\n"), " ", 3)
+	if len(parts) != 3 {
+		return 0, 0, "", fmt.Errorf("unexpected scp message: %q", line)
+	}
+
+	parsedMode, err := strconv.ParseUint(parts[0], 8, 32)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("unexpected scp message: %q", line)
+	}
+	size, err = strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("unexpected scp message: %q", line)
+	}
+	if parts[2] == "" || parts[2] == "." || parts[2] == ".." || strings.ContainsAny(parts[2], `/\`) {
+		return 0, 0, "", fmt.Errorf("unsafe file name in scp message: %q", parts[2])
+	}
+
+	return os.FileMode(parsedMode), size, parts[2], nil
+}
+
+func scpTarget(hostPath string, dirs []string, name string) string {
+	if len(dirs) > 0 {
+		return filepath.Join(dirs[len(dirs)-1], name)
+	}
+	if info, err := os.Stat(hostPath); err == nil && info.IsDir() {
+		return filepath.Join(hostPath, name)
+	}
+	return hostPath
+}
+
+func readSCPAck(r *bufio.Reader) error {
+	code, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if code == 0 {
+		return nil
+	}
+
+	message, err := r.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+	return &SCPError{Message: strings.TrimSpace(message)}
+}
+
+func writeSCPAck(w io.Writer) error {
+	_, err := w.Write([]byte{0})
+	return err
+}
+
+func shellQuote(s string) string {
+	if s == "" {
+		return "."
+	}
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+type progressWriter struct {
+	out         io.Writer
+	name        string
+	total       int64
+	written     int64
+	lastPercent int64
+}
+
+func newProgressWriter(out io.Writer, name string, total int64) *progressWriter {
+	return &progressWriter{
+		out:         out,
+		name:        name,
+		total:       total,
+		lastPercent: -1,
+	}
+}
+
+func (p *progressWriter) Write(data []byte) (int, error) {
+	p.written += int64(len(data))
+	if percent := p.percent(); percent != p.lastPercent {
+		p.lastPercent = percent
+		fmt.Fprintf(p.out, "\r%s %3d%% (%d bytes)", p.name, percent, p.total)
+	}
+	return len(data), nil
+}
+
+func (p *progressWriter) Done() {
+	if p.lastPercent != 100 {
+		fmt.Fprintf(p.out, "\r%s %3d%% (%d bytes)", p.name, 100, p.total)
+	}
+	fmt.Fprintln(p.out)
+}
+
+func (p *progressWriter) percent() int64 {
+	if p.total == 0 {
+		return 100
+	}
+	return p.written * 100 / p.total
+}
@@ -160,36 +160,11 @@ func (s *SSH) WithSSHTunnel(remoteAddress string, sshAddresses []SSHAddress, pri
 	}
 	defer localListener.Close()
 
-	var tunnelError error
-	go func() {
-		for {
-			localConn, err := localListener.Accept()
-			if err != nil {
-				tunnelError = err
-				return
-			}
-
-			go func(conn net.Conn) {
-				defer conn.Close()
-
-				sshTunnel, err := client.Dial("tcp", remoteAddress)
-				if err != nil {
-					tunnelError = err
-					return
-				}
-				defer sshTunnel.Close()
-
-				go func() {
-					IgnoreErrorFrom(io.Copy(conn, sshTunnel))
-				}()
-
-				IgnoreErrorFrom(io.Copy(sshTunnel, conn))
-			}(localConn)
-		}
-	}()
+	errs := &tunnelErrors{}
+	go tunnel(localListener, client, remoteAddress, errs.record)
 
 	block("http://" + localListener.Addr().String())
-	return tunnelError
+	return errs.last()
 }
 
 func (s *SSH) newSession(addresses []SSHAddress, privateKey []byte, timeout time.Duration) (*ssh.Client, *ssh.Session, error) {
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"time"
@@ -282,6 +284,69 @@ var _ = Describe("ssh", func() {
 		})
 	})
 
+	Describe("#CopyToGuest and #CopyFromGuest", func() {
+		var (
+			hostDir  string
+			progress *gbytes.Buffer
+		)
+
+		BeforeEach(func() {
+			var err error
+			hostDir, err = ioutil.TempDir("", "pcfdev-scp")
+			Expect(err).NotTo(HaveOccurred())
+			progress = gbytes.NewBuffer()
+
+			Expect(os.MkdirAll(filepath.Join(hostDir, "some-dir", "some-sub-dir"), 0755)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(hostDir, "some-dir", "some-file"), []byte("some-contents"), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(hostDir, "some-dir", "some-sub-dir", "some-other-file"), []byte("some-other-contents"), 0600)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(hostDir)
+		})
+
+		It("should copy directories to and from the guest recursively", func() {
+			Expect(s.CopyToGuest(filepath.Join(hostDir, "some-dir"), "/tmp/some-guest-dir", []ssh.SSHAddress{{IP: ip, Port: port}}, privateKeyBytes, timeToConnect, progress)).To(Succeed())
+			Expect(progress).To(gbytes.Say("some-file 100% \\(13 bytes\\)"))
+			Expect(s.GetSSHOutput("cat /tmp/some-guest-dir/some-sub-dir/some-other-file", []ssh.SSHAddress{{IP: ip, Port: port}}, privateKeyBytes, timeToConnect)).To(Equal("some-other-contents"))
+
+			Expect(s.CopyFromGuest("/tmp/some-guest-dir", filepath.Join(hostDir, "some-copied-dir"), []ssh.SSHAddress{{IP: ip, Port: port}}, privateKeyBytes, timeToConnect, progress)).To(Succeed())
+			contents, err := ioutil.ReadFile(filepath.Join(hostDir, "some-copied-dir", "some-sub-dir", "some-other-file"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("some-other-contents"))
+		})
+
+		It("should copy a single file to and from the guest", func() {
+			Expect(s.CopyToGuest(filepath.Join(hostDir, "some-dir", "some-file"), "/tmp/some-guest-file", []ssh.SSHAddress{{IP: "some-bad-ip", Port: "some-bad-port"}, {IP: ip, Port: port}}, privateKeyBytes, timeToConnect, progress)).To(Succeed())
+
+			Expect(s.CopyFromGuest("/tmp/some-guest-file", hostDir, []ssh.SSHAddress{{IP: ip, Port: port}}, privateKeyBytes, timeToConnect, progress)).To(Succeed())
+			contents, err := ioutil.ReadFile(filepath.Join(hostDir, "some-guest-file"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("some-contents"))
+		})
+
+		Context("when the guest path does not exist", func() {
+			It("should return an error", func() {
+				err := s.CopyFromGuest("/some-bad-path", hostDir, []ssh.SSHAddress{{IP: ip, Port: port}}, privateKeyBytes, timeToConnect, progress)
+				Expect(err).To(MatchError(ContainSubstring("scp failed:")))
+			})
+		})
+
+		Context("when the host path does not exist", func() {
+			It("should return an error", func() {
+				err := s.CopyToGuest(filepath.Join(hostDir, "some-bad-path"), "/tmp", []ssh.SSHAddress{{IP: ip, Port: port}}, privateKeyBytes, timeToConnect, progress)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Context("when SSH connection times out", func() {
+			It("should return an error", func() {
+				err := s.CopyToGuest(filepath.Join(hostDir, "some-dir"), "/tmp", []ssh.SSHAddress{{IP: ip, Port: "some-bad-port"}}, privateKeyBytes, timeToFail, progress)
+				Expect(err).To(MatchError(ContainSubstring("ssh connection timed out:")))
+			})
+		})
+	})
+
 	Describe("#WithSSHTunnel", func() {
 		Context("when SSH is available", func() {
 			It("should execute a command after creating an SSH tunnel", func() {
@@ -338,6 +403,73 @@ var _ = Describe("ssh", func() {
 			})
 		})
 	})
+
+	Describe("#ForwardPorts", func() {
+		var localAddress string
+
+		BeforeEach(func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			localAddress = listener.Addr().String()
+			Expect(listener.Close()).To(Succeed())
+		})
+
+		Context("when SSH is available", func() {
+			It("should forward the local address to the remote address", func() {
+				go func() {
+					defer GinkgoRecover()
+					s.RunSSHCommand("/home/vcap/snappy_server", []ssh.SSHAddress{{IP: ip, Port: port}}, privateKeyBytes, timeToConnect, GinkgoWriter, GinkgoWriter)
+				}()
+
+				Eventually(func() (string, error) {
+					return s.GetSSHOutput("nc -z localhost 8080 && echo -n success", []ssh.SSHAddress{{IP: ip, Port: port}}, privateKeyBytes, timeToConnect)
+				}, "30s", "1s").Should(Equal("success"))
+
+				var responseBody string
+				forwards := []ssh.Forward{{LocalAddress: localAddress, RemoteAddress: "127.0.0.1:8080"}}
+				err := s.ForwardPorts(forwards, []ssh.SSHAddress{{IP: ip, Port: port}}, privateKeyBytes, timeToConnect, func(_ ssh.Forward, err error) {
+					Fail(err.Error())
+				}, func() {
+					httpResponse, err := http.DefaultClient.Get("http://" + localAddress)
+					Expect(err).NotTo(HaveOccurred())
+					defer httpResponse.Body.Close()
+
+					rawResponseBody, err := ioutil.ReadAll(httpResponse.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					responseBody = string(rawResponseBody)
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(responseBody).To(Equal("Response from Snappy server"))
+			})
+
+			Context("when a connection to the remote address fails", func() {
+				It("should report the error for that forward", func() {
+					errorChan := make(chan error, 1)
+					forwards := []ssh.Forward{{LocalAddress: localAddress, RemoteAddress: "some-address-without-port"}}
+					err := s.ForwardPorts(forwards, []ssh.SSHAddress{{IP: ip, Port: port}}, privateKeyBytes, timeToConnect, func(forward ssh.Forward, err error) {
+						Expect(forward).To(Equal(forwards[0]))
+						errorChan <- err
+					}, func() {
+						conn, err := net.Dial("tcp", localAddress)
+						Expect(err).NotTo(HaveOccurred())
+						defer conn.Close()
+
+						Eventually(errorChan).Should(Receive(MatchError(ContainSubstring("missing port in address"))))
+					})
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		Context("when SSHing fails", func() {
+			It("should return an error", func() {
+				forwards := []ssh.Forward{{LocalAddress: localAddress, RemoteAddress: "some-correct-address"}}
+				err := s.ForwardPorts(forwards, []ssh.SSHAddress{{IP: "some-bad-ip", Port: "some-bad-port"}}, privateKeyBytes, timeToFail, func(ssh.Forward, error) {}, func() {})
+				Expect(err).To(MatchError(ContainSubstring("ssh connection timed out")))
+			})
+		})
+	})
 })
 
 func setupSnappyWithSSHAccess(sshTools *ssh.SSH, vBoxManagePath string) (string, string, string, string, string) {
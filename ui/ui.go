@@ -5,6 +5,7 @@ import (
 	"os/exec"
 	"runtime"
 
+	"github.com/docker/docker/pkg/term"
 	"github.com/gizak/termui"
 )
 
@@ -44,6 +45,12 @@ func (u *UI) Init() error {
 	return nil
 }
 
+// Interactive reports whether both stdin and stdout are attached to a terminal
+// that the full-screen EULA viewer can take over.
+func (u *UI) Interactive() bool {
+	return term.IsTerminal(os.Stdin.Fd()) && term.IsTerminal(os.Stdout.Fd())
+}
+
 func (u *UI) Close() error {
 	termui.Close()
 	if runtime.GOOS == "windows" {
@@ -4,9 +4,9 @@
 package mocks
 
 import (
-	"github.com/golang/mock/gomock"
-	"github.com/pivotal-cf/pcfdev-cli/network"
-	"github.com/pivotal-cf/pcfdev-cli/vboxdriver"
+	gomock "github.com/golang/mock/gomock"
+	network "github.com/pivotal-cf/pcfdev-cli/network"
+	vboxdriver "github.com/pivotal-cf/pcfdev-cli/vboxdriver"
 )
 
 // Mock of Driver interface
@@ -30,6 +30,16 @@ func (_m *MockDriver) EXPECT() *_MockDriverRecorder {
 	return _m.recorder
 }
 
+func (_m *MockDriver) AddSharedFolder(_param0 string, _param1 string, _param2 string) error {
+	ret := _m.ctrl.Call(_m, "AddSharedFolder", _param0, _param1, _param2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDriverRecorder) AddSharedFolder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AddSharedFolder", arg0, arg1, arg2)
+}
+
 func (_m *MockDriver) AttachDisk(_param0 string, _param1 string) error {
 	ret := _m.ctrl.Call(_m, "AttachDisk", _param0, _param1)
 	ret0, _ := ret[0].(error)
@@ -101,6 +111,16 @@ func (_mr *_MockDriverRecorder) DeleteDisk(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DeleteDisk", arg0)
 }
 
+func (_m *MockDriver) DeleteSnapshot(_param0 string, _param1 string) error {
+	ret := _m.ctrl.Call(_m, "DeleteSnapshot", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDriverRecorder) DeleteSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DeleteSnapshot", arg0, arg1)
+}
+
 func (_m *MockDriver) DestroyVM(_param0 string) error {
 	ret := _m.ctrl.Call(_m, "DestroyVM", _param0)
 	ret0, _ := ret[0].(error)
@@ -111,6 +131,17 @@ func (_mr *_MockDriverRecorder) DestroyVM(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DestroyVM", arg0)
 }
 
+func (_m *MockDriver) DiskSize(_param0 string) (uint64, error) {
+	ret := _m.ctrl.Call(_m, "DiskSize", _param0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDriverRecorder) DiskSize(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DiskSize", arg0)
+}
+
 func (_m *MockDriver) Disks() ([]string, error) {
 	ret := _m.ctrl.Call(_m, "Disks")
 	ret0, _ := ret[0].([]string)
@@ -132,6 +163,17 @@ func (_mr *_MockDriverRecorder) ForwardPort(arg0, arg1, arg2, arg3 interface{})
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "ForwardPort", arg0, arg1, arg2, arg3)
 }
 
+func (_m *MockDriver) GetCPUs(_param0 string) (int, error) {
+	ret := _m.ctrl.Call(_m, "GetCPUs", _param0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDriverRecorder) GetCPUs(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCPUs", arg0)
+}
+
 func (_m *MockDriver) GetHostForwardPort(_param0 string, _param1 string) (string, error) {
 	ret := _m.ctrl.Call(_m, "GetHostForwardPort", _param0, _param1)
 	ret0, _ := ret[0].(string)
@@ -186,6 +228,26 @@ func (_mr *_MockDriverRecorder) PowerOffVM(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "PowerOffVM", arg0)
 }
 
+func (_m *MockDriver) ResizeDisk(_param0 string, _param1 uint64) error {
+	ret := _m.ctrl.Call(_m, "ResizeDisk", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDriverRecorder) ResizeDisk(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ResizeDisk", arg0, arg1)
+}
+
+func (_m *MockDriver) RestoreSnapshot(_param0 string, _param1 string) error {
+	ret := _m.ctrl.Call(_m, "RestoreSnapshot", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDriverRecorder) RestoreSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RestoreSnapshot", arg0, arg1)
+}
+
 func (_m *MockDriver) ResumeVM(_param0 string) error {
 	ret := _m.ctrl.Call(_m, "ResumeVM", _param0)
 	ret0, _ := ret[0].(error)
@@ -227,6 +289,17 @@ func (_mr *_MockDriverRecorder) SetMemory(arg0, arg1 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetMemory", arg0, arg1)
 }
 
+func (_m *MockDriver) Snapshots(_param0 string) ([]string, error) {
+	ret := _m.ctrl.Call(_m, "Snapshots", _param0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDriverRecorder) Snapshots(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Snapshots", arg0)
+}
+
 func (_m *MockDriver) StartVM(_param0 string) error {
 	ret := _m.ctrl.Call(_m, "StartVM", _param0)
 	ret0, _ := ret[0].(error)
@@ -257,6 +330,16 @@ func (_mr *_MockDriverRecorder) SuspendVM(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SuspendVM", arg0)
 }
 
+func (_m *MockDriver) TakeSnapshot(_param0 string, _param1 string) error {
+	ret := _m.ctrl.Call(_m, "TakeSnapshot", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDriverRecorder) TakeSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "TakeSnapshot", arg0, arg1)
+}
+
 func (_m *MockDriver) UseDNSProxy(_param0 string) error {
 	ret := _m.ctrl.Call(_m, "UseDNSProxy", _param0)
 	ret0, _ := ret[0].(error)
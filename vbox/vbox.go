@@ -16,6 +16,7 @@ import (
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	. "github.com/pivotal-cf/pcfdev-cli/helpers"
 	"github.com/pivotal-cf/pcfdev-cli/network"
+	"github.com/pivotal-cf/pcfdev-cli/provider"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
 	"github.com/pivotal-cf/pcfdev-cli/vboxdriver"
 	"os"
@@ -47,9 +48,17 @@ type Driver interface {
 	AttachDisk(vmName string, diskPath string) error
 	CloneDisk(src string, dest string) error
 	DeleteDisk(diskPath string) error
+	ResizeDisk(diskPath string, sizeInMB uint64) error
+	DiskSize(diskPath string) (sizeInMB uint64, err error)
 	UseDNSProxy(vmName string) error
+	AddSharedFolder(vmName string, name string, hostPath string) error
 	GetMemory(vmName string) (uint64, error)
+	GetCPUs(vmName string) (int, error)
 	VMState(vmName string) (string, error)
+	TakeSnapshot(vmName string, snapshotName string) error
+	RestoreSnapshot(vmName string, snapshotName string) error
+	DeleteSnapshot(vmName string, snapshotName string) error
+	Snapshots(vmName string) (snapshots []string, err error)
 	Version() (version *vboxdriver.VBoxDriverVersion, err error)
 }
 
@@ -87,21 +96,6 @@ type VMProperties struct {
 	IPAddress string
 }
 
-type ProxyTypes struct {
-	HTTPProxy  string
-	HTTPSProxy string
-	NOProxy    string
-}
-
-const (
-	StatusRunning    = "Running"
-	StatusSaved      = "Saved"
-	StatusPaused     = "Paused"
-	StatusStopped    = "Stopped"
-	StatusNotCreated = "Not created"
-	StatusUnknown    = "Unknown"
-)
-
 var (
 	networkTemplate = `
 auto lo
@@ -114,19 +108,12 @@ auto eth1
 iface eth1 inet static
 address {{.IPAddress}}
 netmask 255.255.255.0`
-
-	proxyTemplate = `
-PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin:/usr/games:/usr/local/games
-{{if .HTTPProxy}}HTTP_PROXY={{.HTTPProxy}}{{end}}
-{{if .HTTPSProxy}}HTTPS_PROXY={{.HTTPSProxy}}{{end}}
-NO_PROXY={{.NOProxy}}
-{{if .HTTPProxy}}http_proxy={{.HTTPProxy}}{{end}}
-{{if .HTTPSProxy}}https_proxy={{.HTTPSProxy}}{{end}}
-no_proxy={{.NOProxy}}`
 )
 
 func (v *VBox) StartVM(vmConfig *config.VMConfig) error {
-	if err := v.Driver.StartVM(vmConfig.Name); err != nil {
+	vmName := v.Config.InstanceVMName(vmConfig.Name)
+
+	if err := v.Driver.StartVM(vmName); err != nil {
 		return err
 	}
 
@@ -141,11 +128,11 @@ func (v *VBox) StartVM(vmConfig *config.VMConfig) error {
 		return err
 	}
 
-	if err := v.Driver.StopVM(vmConfig.Name); err != nil {
+	if err := v.Driver.StopVM(vmName); err != nil {
 		return err
 	}
 
-	return v.Driver.StartVM(vmConfig.Name)
+	return v.Driver.StartVM(vmName)
 }
 
 func (v *VBox) insertSecureKeypair(vmConfig *config.VMConfig) error {
@@ -278,13 +265,13 @@ func (v *VBox) proxySettings(vmConfig *config.VMConfig) (settings string, err er
 		noProxy = strings.Join([]string{noProxy, v.Config.NoProxy}, ",")
 	}
 
-	t, err := template.New("proxy template").Parse(proxyTemplate)
+	t, err := template.New("proxy template").Parse(provider.ProxyTemplate)
 	if err != nil {
 		return "", err
 	}
 
 	var proxySettings bytes.Buffer
-	if err = t.Execute(&proxySettings, ProxyTypes{HTTPProxy: httpProxy, HTTPSProxy: httpsProxy, NOProxy: noProxy}); err != nil {
+	if err = t.Execute(&proxySettings, provider.ProxyTypes{HTTPProxy: httpProxy, HTTPSProxy: httpsProxy, NOProxy: noProxy}); err != nil {
 		return "", err
 	}
 
@@ -292,12 +279,14 @@ func (v *VBox) proxySettings(vmConfig *config.VMConfig) (settings string, err er
 }
 
 func (v *VBox) ImportVM(vmConfig *config.VMConfig) error {
-	if err := v.Driver.CreateVM(vmConfig.Name, v.Config.VMDir); err != nil {
+	vmName := v.Config.InstanceVMName(vmConfig.Name)
+
+	if err := v.Driver.CreateVM(vmName, v.Config.VMDir); err != nil {
 		return err
 	}
 
-	compressedDisk := filepath.Join(v.Config.VMDir, vmConfig.Name+"-disk1.vmdk") + ".compressed"
-	uncompressedDisk := filepath.Join(v.Config.VMDir, vmConfig.Name, vmConfig.Name+"-disk1.vmdk")
+	compressedDisk := filepath.Join(v.Config.VMDir, vmName+"-disk1.vmdk") + ".compressed"
+	uncompressedDisk := v.diskPath(vmName)
 	if err := v.FS.Extract(vmConfig.OVAPath, compressedDisk, `\w+\.vmdk`); err != nil {
 		return err
 	}
@@ -310,7 +299,7 @@ func (v *VBox) ImportVM(vmConfig *config.VMConfig) error {
 		return err
 	}
 
-	if err := v.Driver.AttachDisk(vmConfig.Name, uncompressedDisk); err != nil {
+	if err := v.Driver.AttachDisk(vmName, uncompressedDisk); err != nil {
 		return err
 	}
 
@@ -336,19 +325,15 @@ func (v *VBox) ImportVM(vmConfig *config.VMConfig) error {
 		networkConfig.Interface.Name = interfaceName
 	}
 
-	if err := v.Driver.AttachNetworkInterface(networkConfig.Interface.Name, vmConfig.Name); err != nil {
+	if err := v.Driver.AttachNetworkInterface(networkConfig.Interface.Name, vmName); err != nil {
 		return err
 	}
 
-	if err := v.FS.Write(
-		filepath.Join(v.Config.VMDir, "vm_config"),
-		strings.NewReader(fmt.Sprintf(`{"ip":"%s","domain":"%s"}`, networkConfig.VMIP, networkConfig.VMDomain)),
-		false,
-	); err != nil {
+	if err := v.SaveVMConfig(&config.VMConfig{IP: networkConfig.VMIP, Domain: networkConfig.VMDomain}); err != nil {
 		return err
 	}
 
-	if err := v.Driver.UseDNSProxy(vmConfig.Name); err != nil {
+	if err := v.Driver.UseDNSProxy(vmName); err != nil {
 		return err
 	}
 
@@ -357,27 +342,37 @@ func (v *VBox) ImportVM(vmConfig *config.VMConfig) error {
 		return err
 	}
 
-	if err := v.Driver.ForwardPort(vmConfig.Name, "ssh", sshPort, "22"); err != nil {
+	if err := v.Driver.ForwardPort(vmName, "ssh", sshPort, "22"); err != nil {
 		return err
 	}
 
-	if err := v.Driver.SetCPUs(vmConfig.Name, vmConfig.CPUs); err != nil {
+	if err := v.Driver.SetCPUs(vmName, vmConfig.CPUs); err != nil {
 		return err
 	}
 
-	if err := v.Driver.SetMemory(vmConfig.Name, vmConfig.Memory); err != nil {
+	if err := v.Driver.SetMemory(vmName, vmConfig.Memory); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// SaveVMConfig saves the VM config to Config.VMConfigPath.
+func (v *VBox) SaveVMConfig(vmConfig *config.VMConfig) error {
+	return config.SaveVMConfig(v.FS, v.Config.VMConfigPath, vmConfig)
+}
+
+// ShareFolder shares the host folder of mount with the running VM.
+func (v *VBox) ShareFolder(vmConfig *config.VMConfig, mount *config.Mount) error {
+	return v.Driver.AddSharedFolder(v.Config.InstanceVMName(vmConfig.Name), mount.Name, mount.HostPath)
+}
+
 func (v *VBox) DestroyVM(vmConfig *config.VMConfig) error {
-	return v.Driver.DestroyVM(vmConfig.Name)
+	return v.Driver.DestroyVM(v.Config.InstanceVMName(vmConfig.Name))
 }
 
 func (v *VBox) PowerOffVM(vmConfig *config.VMConfig) error {
-	return v.Driver.PowerOffVM(vmConfig.Name)
+	return v.Driver.PowerOffVM(v.Config.InstanceVMName(vmConfig.Name))
 }
 
 func (v *VBox) GetVMName() (name string, err error) {
@@ -386,9 +381,9 @@ func (v *VBox) GetVMName() (name string, err error) {
 		return "", err
 	}
 	for _, vm := range vms {
-		if strings.HasPrefix(vm, "pcfdev-") {
+		if v.isInstanceVM(vm) {
 			if name == "" {
-				name = vm
+				name, _ = config.InstanceOfVMName(vm)
 			} else {
 				return "", errors.New("multiple PCF Dev VMs found")
 			}
@@ -398,19 +393,62 @@ func (v *VBox) GetVMName() (name string, err error) {
 }
 
 func (v *VBox) StopVM(vmConfig *config.VMConfig) error {
-	return v.Driver.StopVM(vmConfig.Name)
+	return v.Driver.StopVM(v.Config.InstanceVMName(vmConfig.Name))
+}
+
+// ResizeVM applies the memory and CPUs in vmConfig to a powered off VM.
+func (v *VBox) ResizeVM(vmConfig *config.VMConfig) error {
+	vmName := v.Config.InstanceVMName(vmConfig.Name)
+	if err := v.Driver.SetCPUs(vmName, vmConfig.CPUs); err != nil {
+		return err
+	}
+	return v.Driver.SetMemory(vmName, vmConfig.Memory)
+}
+
+// DiskSize returns the capacity of the VM disk in MB.
+func (v *VBox) DiskSize(vmConfig *config.VMConfig) (uint64, error) {
+	diskPath, err := v.resizableDiskPath(vmConfig)
+	if err != nil {
+		return uint64(0), err
+	}
+	return v.Driver.DiskSize(diskPath)
+}
+
+// ResizeDisk grows the disk of a powered off VM to sizeInMB.
+func (v *VBox) ResizeDisk(vmConfig *config.VMConfig, sizeInMB uint64) error {
+	diskPath, err := v.resizableDiskPath(vmConfig)
+	if err != nil {
+		return err
+	}
+	return v.Driver.ResizeDisk(diskPath, sizeInMB)
+}
+
+func (v *VBox) resizableDiskPath(vmConfig *config.VMConfig) (string, error) {
+	diskPath := v.diskPath(v.Config.InstanceVMName(vmConfig.Name))
+	exists, err := v.FS.Exists(diskPath)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "", errors.New("the PCF Dev disk was imported as a VMDK and cannot be resized, run `cf dev destroy` and `cf dev start` to recreate it")
+	}
+	return diskPath, nil
+}
+
+func (v *VBox) diskPath(vmName string) string {
+	return filepath.Join(v.Config.VMDir, vmName, vmName+"-disk1.vdi")
 }
 
 func (v *VBox) SuspendVM(vmConfig *config.VMConfig) error {
-	return v.Driver.SuspendVM(vmConfig.Name)
+	return v.Driver.SuspendVM(v.Config.InstanceVMName(vmConfig.Name))
 }
 
 func (v *VBox) ResumePausedVM(vmConfig *config.VMConfig) error {
-	return v.Driver.ResumeVM(vmConfig.Name)
+	return v.Driver.ResumeVM(v.Config.InstanceVMName(vmConfig.Name))
 }
 
 func (v *VBox) ResumeSavedVM(vmConfig *config.VMConfig) error {
-	return v.Driver.StartVM(vmConfig.Name)
+	return v.Driver.StartVM(v.Config.InstanceVMName(vmConfig.Name))
 }
 
 func (v *VBox) DestroyPCFDevVMs() error {
@@ -420,7 +458,7 @@ func (v *VBox) DestroyPCFDevVMs() error {
 	}
 
 	for _, vm := range vms {
-		if strings.HasPrefix(vm, "pcfdev-") {
+		if v.isInstanceVM(vm) {
 			IgnoreErrorFrom(v.Driver.PowerOffVM(vm))
 			IgnoreErrorFrom(v.Driver.DestroyVM(vm))
 		}
@@ -432,7 +470,7 @@ func (v *VBox) DestroyPCFDevVMs() error {
 	}
 
 	for _, vm := range vms {
-		if strings.HasPrefix(vm, "pcfdev-") {
+		if v.isInstanceVM(vm) {
 			return errors.New("failed to destroy all pcfdev vms")
 		}
 	}
@@ -443,8 +481,7 @@ func (v *VBox) DestroyPCFDevVMs() error {
 	}
 
 	for _, disk := range disks {
-		filename := filepath.Base(disk)
-		if strings.HasPrefix(filename, "pcfdev-") {
+		if v.isInstanceDisk(disk) {
 			IgnoreErrorFrom(v.Driver.DeleteDisk(disk))
 		}
 	}
@@ -455,8 +492,7 @@ func (v *VBox) DestroyPCFDevVMs() error {
 	}
 
 	for _, disk := range disks {
-		filename := filepath.Base(disk)
-		if strings.HasPrefix(filename, "pcfdev-") {
+		if v.isInstanceDisk(disk) {
 			return errors.New("failed to destroy all pcfdev disks")
 		}
 	}
@@ -464,24 +500,31 @@ func (v *VBox) DestroyPCFDevVMs() error {
 }
 
 func (v *VBox) VMConfig(vmName string) (*config.VMConfig, error) {
-	memory, err := v.Driver.GetMemory(vmName)
+	instanceVMName := v.Config.InstanceVMName(vmName)
+
+	memory, err := v.Driver.GetMemory(instanceVMName)
+	if err != nil {
+		return nil, err
+	}
+	cpus, err := v.Driver.GetCPUs(instanceVMName)
 	if err != nil {
 		return nil, err
 	}
-	port, err := v.Driver.GetHostForwardPort(vmName, "ssh")
+	port, err := v.Driver.GetHostForwardPort(instanceVMName, "ssh")
 	if err != nil {
 		return nil, err
 	}
-	vmConfigBytes, err := v.FS.Read(filepath.Join(v.Config.VMDir, "vm_config"))
+	vmConfigBytes, err := v.FS.Read(v.Config.VMConfigPath)
 	if err != nil {
 		return nil, err
 	}
 
 	vmConfig := &config.VMConfig{
 		Memory:   memory,
+		CPUs:     cpus,
 		Name:     vmName,
 		SSHPort:  port,
-		Provider: "virtualbox",
+		Provider: config.ProviderVirtualBox,
 	}
 	if err := json.Unmarshal(vmConfigBytes, &vmConfig); err != nil {
 		return nil, err
@@ -491,13 +534,17 @@ func (v *VBox) VMConfig(vmName string) (*config.VMConfig, error) {
 }
 
 func (v *VBox) VMStatus(vmName string) (status string, err error) {
+	return v.vmStatus(v.Config.InstanceVMName(vmName))
+}
+
+func (v *VBox) vmStatus(vmName string) (status string, err error) {
 	exists, err := v.Driver.VMExists(vmName)
 	if err != nil {
 		return "", err
 	}
 
 	if !exists {
-		return StatusNotCreated, nil
+		return provider.StatusNotCreated, nil
 	}
 
 	state, err := v.Driver.VMState(vmName)
@@ -507,18 +554,88 @@ func (v *VBox) VMStatus(vmName string) (status string, err error) {
 
 	switch state {
 	case vboxdriver.StateRunning:
-		return StatusRunning, nil
+		return provider.StatusRunning, nil
 	case vboxdriver.StateStopped, vboxdriver.StateAborted:
-		return StatusStopped, nil
+		return provider.StatusStopped, nil
 	case vboxdriver.StateSaved:
-		return StatusSaved, nil
+		return provider.StatusSaved, nil
 	case vboxdriver.StatePaused:
-		return StatusPaused, nil
+		return provider.StatusPaused, nil
 	default:
-		return StatusUnknown, nil
+		return provider.StatusUnknown, nil
+	}
+}
+
+func (v *VBox) TakeSnapshot(vmName string, snapshotName string) error {
+	return v.Driver.TakeSnapshot(v.Config.InstanceVMName(vmName), snapshotName)
+}
+
+func (v *VBox) RestoreSnapshot(vmName string, snapshotName string) error {
+	return v.Driver.RestoreSnapshot(v.Config.InstanceVMName(vmName), snapshotName)
+}
+
+func (v *VBox) DeleteSnapshot(vmName string, snapshotName string) error {
+	return v.Driver.DeleteSnapshot(v.Config.InstanceVMName(vmName), snapshotName)
+}
+
+func (v *VBox) Snapshots(vmName string) (snapshots []string, err error) {
+	return v.Driver.Snapshots(v.Config.InstanceVMName(vmName))
+}
+
+func (v *VBox) Instances() ([]*provider.Instance, error) {
+	vms, err := v.Driver.VMs()
+	if err != nil {
+		return nil, err
 	}
+
+	instances := []*provider.Instance{}
+	for _, vm := range vms {
+		if !strings.HasPrefix(vm, "pcfdev-") {
+			continue
+		}
+
+		status, err := v.vmStatus(vm)
+		if err != nil {
+			return nil, err
+		}
+
+		vmName, instance := config.InstanceOfVMName(vm)
+		instances = append(instances, &provider.Instance{
+			Name:   instance,
+			VMName: vmName,
+			Status: status,
+		})
+	}
+	return instances, nil
+}
+
+func (v *VBox) isInstanceVM(vm string) bool {
+	_, instance := config.InstanceOfVMName(vm)
+	return strings.HasPrefix(vm, "pcfdev-") && instance == v.Config.Instance
 }
 
-func (v *VBox) Version() (version *vboxdriver.VBoxDriverVersion, err error) {
-	return v.Driver.Version()
+func (v *VBox) isInstanceDisk(disk string) bool {
+	filename := filepath.Base(disk)
+	if !strings.HasPrefix(filename, "pcfdev-") {
+		return false
+	}
+
+	filename = strings.TrimSuffix(filename, ".compressed")
+	for _, suffix := range []string{"-disk1.vmdk", "-disk1.vdi"} {
+		filename = strings.TrimSuffix(filename, suffix)
+	}
+	_, instance := config.InstanceOfVMName(filename)
+	return instance == v.Config.Instance
+}
+
+func (v *VBox) Version() (*provider.Version, error) {
+	version, err := v.Driver.Version()
+	if err != nil {
+		return nil, err
+	}
+	return &provider.Version{
+		Major: version.Major,
+		Minor: version.Minor,
+		Build: version.Build,
+	}, nil
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/network"
+	"github.com/pivotal-cf/pcfdev-cli/provider"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
 	"github.com/pivotal-cf/pcfdev-cli/vbox"
 	"github.com/pivotal-cf/pcfdev-cli/vbox/mocks"
@@ -49,6 +50,7 @@ var _ = Describe("vbox", func() {
 			NoProxy:            "some-no-proxy",
 			InsecurePrivateKey: []byte("some-insecure-private-key"),
 			PrivateKeyPath:     "some-private-key-path",
+			VMConfigPath:       filepath.Join("some-vm-dir", "vm_config"),
 
 			MinMemory: uint64(1000),
 			MaxMemory: uint64(2000),
@@ -99,9 +101,9 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
-					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(newInterface, nil),
 					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
@@ -145,9 +147,9 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
-					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(unusedVBoxInterface, nil),
 					mockDriver.EXPECT().ConfigureHostOnlyInterface("some-unused-vbox-interface", "some-unused-ip"),
@@ -191,9 +193,9 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
-					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(unusedVBoxInterface, nil),
 					mockDriver.EXPECT().ConfigureHostOnlyInterface("some-unused-vbox-interface", "some-unused-ip"),
@@ -228,7 +230,7 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")).Return(errors.New("some-error")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")).Return(errors.New("some-error")),
 				)
 				Expect(vbx.ImportVM(
 					&config.VMConfig{
@@ -243,7 +245,7 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")).Return(errors.New("some-error")),
 				)
 				Expect(vbx.ImportVM(
@@ -259,9 +261,9 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
-					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")).Return(errors.New("some-error")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")).Return(errors.New("some-error")),
 				)
 				Expect(vbx.ImportVM(
 					&config.VMConfig{
@@ -276,9 +278,9 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
-					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().GetHostOnlyInterfaces().Return([]*network.Interface{}, errors.New("some-error")),
 				)
 				Expect(vbx.ImportVM(&config.VMConfig{
@@ -308,9 +310,9 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
-					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(nil, errors.New("some-error")),
 				)
@@ -336,9 +338,9 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
-					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(&config.NetworkConfig{VMIP: "some-vm-ip", VMDomain: "some-vm-domain", Interface: &network.Interface{IP: "some-unused-ip", Exists: false}}, nil),
 					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("", errors.New("some-error")),
@@ -370,9 +372,9 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
-					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(unusedVBoxInterface, nil),
 					mockDriver.EXPECT().ConfigureHostOnlyInterface("some-unused-vbox-interface", "some-unused-ip").Return(errors.New("some-error")),
@@ -399,9 +401,9 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
-					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(&config.NetworkConfig{VMIP: "some-vm-ip", VMDomain: "some-vm-domain", Interface: &network.Interface{IP: "some-unused-ip", Exists: false}}, nil),
 					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
@@ -429,9 +431,9 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
-					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(&config.NetworkConfig{VMIP: "some-vm-ip", VMDomain: "some-vm-domain", Interface: &network.Interface{IP: "some-unused-ip", Exists: false}}, nil),
 					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
@@ -461,9 +463,9 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
-					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(&config.NetworkConfig{VMIP: "some-vm-ip", VMDomain: "some-vm-domain", Interface: &network.Interface{IP: "some-unused-ip", Exists: false}}, nil),
 					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
@@ -494,9 +496,9 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
-					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(&config.NetworkConfig{VMIP: "some-vm-ip", VMDomain: "some-vm-domain", Interface: &network.Interface{IP: "some-unused-ip", Exists: false}}, nil),
 					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
@@ -528,9 +530,9 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
-					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(&config.NetworkConfig{VMIP: "some-vm-ip", VMDomain: "some-vm-domain", Interface: &network.Interface{IP: "some-unused-ip", Exists: false}}, nil),
 					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
@@ -562,9 +564,9 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
-					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(&config.NetworkConfig{VMIP: "some-vm-ip", VMDomain: "some-vm-domain", Interface: &network.Interface{IP: "some-unused-ip", Exists: false}}, nil),
 					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
@@ -597,9 +599,9 @@ var _ = Describe("vbox", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
-					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
-					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")),
 					mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(&config.NetworkConfig{VMIP: "some-vm-ip", VMDomain: "some-vm-domain", Interface: &network.Interface{IP: "some-unused-ip", Exists: false}}, nil),
 					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
@@ -1341,6 +1343,115 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 		})
 	})
 
+	Describe("#ResizeVM", func() {
+		It("should set the CPUs and memory of the VM", func() {
+			gomock.InOrder(
+				mockDriver.EXPECT().SetCPUs("some-vm", 4),
+				mockDriver.EXPECT().SetMemory("some-vm", uint64(6144)),
+			)
+
+			Expect(vbx.ResizeVM(&config.VMConfig{Name: "some-vm", CPUs: 4, Memory: 6144})).To(Succeed())
+		})
+
+		Context("when setting the CPUs fails", func() {
+			It("should return the error", func() {
+				mockDriver.EXPECT().SetCPUs("some-vm", 4).Return(errors.New("some-error"))
+
+				Expect(vbx.ResizeVM(&config.VMConfig{Name: "some-vm", CPUs: 4, Memory: 6144})).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when setting the memory fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().SetCPUs("some-vm", 4),
+					mockDriver.EXPECT().SetMemory("some-vm", uint64(6144)).Return(errors.New("some-error")),
+				)
+
+				Expect(vbx.ResizeVM(&config.VMConfig{Name: "some-vm", CPUs: 4, Memory: 6144})).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#SaveVMConfig", func() {
+		It("should save the IP, domain and mounts of the VM", func() {
+			mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "vm_config"), strings.NewReader(`{"ip":"some-ip","domain":"some-domain","mounts":[{"name":"pcfdev-mount-1","host_path":"/some-host-path","guest_path":"/some-guest-path"}]}`), false)
+
+			Expect(vbx.SaveVMConfig(&config.VMConfig{
+				Name:   "some-vm",
+				IP:     "some-ip",
+				Domain: "some-domain",
+				Mounts: []*config.Mount{{Name: "pcfdev-mount-1", HostPath: "/some-host-path", GuestPath: "/some-guest-path"}},
+			})).To(Succeed())
+		})
+	})
+
+	Describe("#ShareFolder", func() {
+		It("should add a shared folder to the VM", func() {
+			mockDriver.EXPECT().AddSharedFolder("some-vm", "pcfdev-mount-1", "/some-host-path")
+
+			Expect(vbx.ShareFolder(&config.VMConfig{Name: "some-vm"}, &config.Mount{Name: "pcfdev-mount-1", HostPath: "/some-host-path", GuestPath: "/some-guest-path"})).To(Succeed())
+		})
+
+		Context("when adding the shared folder fails", func() {
+			It("should return the error", func() {
+				mockDriver.EXPECT().AddSharedFolder("some-vm", "pcfdev-mount-1", "/some-host-path").Return(errors.New("some-error"))
+
+				Expect(vbx.ShareFolder(&config.VMConfig{Name: "some-vm"}, &config.Mount{Name: "pcfdev-mount-1", HostPath: "/some-host-path"})).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#DiskSize", func() {
+		It("should return the size of the VM disk", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")).Return(true, nil),
+				mockDriver.EXPECT().DiskSize(filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")).Return(uint64(51200), nil),
+			)
+
+			Expect(vbx.DiskSize(&config.VMConfig{Name: "some-vm"})).To(Equal(uint64(51200)))
+		})
+
+		Context("when the VM was imported with a VMDK disk", func() {
+			It("should return an error", func() {
+				mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")).Return(false, nil)
+
+				_, err := vbx.DiskSize(&config.VMConfig{Name: "some-vm"})
+				Expect(err).To(MatchError("the PCF Dev disk was imported as a VMDK and cannot be resized, run `cf dev destroy` and `cf dev start` to recreate it"))
+			})
+		})
+	})
+
+	Describe("#ResizeDisk", func() {
+		It("should resize the VM disk", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")).Return(true, nil),
+				mockDriver.EXPECT().ResizeDisk(filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi"), uint64(102400)),
+			)
+
+			Expect(vbx.ResizeDisk(&config.VMConfig{Name: "some-vm"}, 102400)).To(Succeed())
+		})
+
+		Context("when checking for the disk fails", func() {
+			It("should return the error", func() {
+				mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")).Return(false, errors.New("some-error"))
+
+				Expect(vbx.ResizeDisk(&config.VMConfig{Name: "some-vm"}, 102400)).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when resizing the disk fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")).Return(true, nil),
+					mockDriver.EXPECT().ResizeDisk(filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi"), uint64(102400)).Return(errors.New("some-error")),
+				)
+
+				Expect(vbx.ResizeDisk(&config.VMConfig{Name: "some-vm"}, 102400)).To(MatchError("some-error"))
+			})
+		})
+	})
+
 	Describe("#SuspendVM", func() {
 		It("should suspend the VM", func() {
 			mockDriver.EXPECT().SuspendVM("some-vm")
@@ -1365,7 +1476,7 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 					mockDriver.EXPECT().VMExists("some-vm").Return(true, nil),
 					mockDriver.EXPECT().VMState("some-vm").Return(vboxdriver.StateRunning, nil),
 				)
-				Expect(vbx.VMStatus("some-vm")).To(Equal(vbox.StatusRunning))
+				Expect(vbx.VMStatus("some-vm")).To(Equal(provider.StatusRunning))
 			})
 
 		})
@@ -1377,7 +1488,7 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 					mockDriver.EXPECT().VMState("some-vm").Return(vboxdriver.StateStopped, nil),
 				)
 
-				Expect(vbx.VMStatus("some-vm")).To(Equal(vbox.StatusStopped))
+				Expect(vbx.VMStatus("some-vm")).To(Equal(provider.StatusStopped))
 			})
 		})
 
@@ -1388,7 +1499,7 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 					mockDriver.EXPECT().VMState("some-vm").Return(vboxdriver.StateAborted, nil),
 				)
 
-				Expect(vbx.VMStatus("some-vm")).To(Equal(vbox.StatusStopped))
+				Expect(vbx.VMStatus("some-vm")).To(Equal(provider.StatusStopped))
 			})
 		})
 
@@ -1399,7 +1510,7 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 					mockDriver.EXPECT().VMState("some-vm").Return(vboxdriver.StateSaved, nil),
 				)
 
-				Expect(vbx.VMStatus("some-vm")).To(Equal(vbox.StatusSaved))
+				Expect(vbx.VMStatus("some-vm")).To(Equal(provider.StatusSaved))
 			})
 		})
 
@@ -1410,7 +1521,7 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 					mockDriver.EXPECT().VMState("some-vm").Return(vboxdriver.StatePaused, nil),
 				)
 
-				Expect(vbx.VMStatus("some-vm")).To(Equal(vbox.StatusPaused))
+				Expect(vbx.VMStatus("some-vm")).To(Equal(provider.StatusPaused))
 			})
 		})
 
@@ -1421,7 +1532,7 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 					mockDriver.EXPECT().VMState("some-vm").Return("some-unknown-status", nil),
 				)
 
-				Expect(vbx.VMStatus("some-vm")).To(Equal(vbox.StatusUnknown))
+				Expect(vbx.VMStatus("some-vm")).To(Equal(provider.StatusUnknown))
 			})
 		})
 
@@ -1429,7 +1540,7 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 			It("should return a not created status", func() {
 				mockDriver.EXPECT().VMExists("some-vm").Return(false, nil)
 
-				Expect(vbx.VMStatus("some-vm")).To(Equal(vbox.StatusNotCreated))
+				Expect(vbx.VMStatus("some-vm")).To(Equal(provider.StatusNotCreated))
 			})
 		})
 
@@ -1461,6 +1572,7 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 		It("should get the vm config", func() {
 			gomock.InOrder(
 				mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
+				mockDriver.EXPECT().GetCPUs("some-vm").Return(2, nil),
 				mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("some-port", nil),
 				mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return([]byte(`{"ip":"192.168.22.11","domain":"local2.pcfdev.io"}`), nil),
 			)
@@ -1469,12 +1581,28 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 				Domain:   "local2.pcfdev.io",
 				IP:       "192.168.22.11",
 				Memory:   uint64(4000),
+				CPUs:     2,
 				Name:     "some-vm",
 				SSHPort:  "some-port",
 				Provider: "virtualbox",
 			}))
 		})
 
+		Context("when folders are mounted", func() {
+			It("should return the mounts", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
+					mockDriver.EXPECT().GetCPUs("some-vm").Return(2, nil),
+					mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("some-port", nil),
+					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return([]byte(`{"ip":"192.168.22.11","domain":"local2.pcfdev.io","mounts":[{"name":"pcfdev-mount-1","host_path":"/some-host-path","guest_path":"/some-guest-path"}]}`), nil),
+				)
+
+				vmConfig, err := vbx.VMConfig("some-vm")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vmConfig.Mounts).To(Equal([]*config.Mount{{Name: "pcfdev-mount-1", HostPath: "/some-host-path", GuestPath: "/some-guest-path"}}))
+			})
+		})
+
 		Context("when the driver fails to get the memory", func() {
 			It("should return an error", func() {
 				mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(0), errors.New("some-error"))
@@ -1484,10 +1612,23 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 			})
 		})
 
+		Context("when the driver fails to get the cpus", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
+					mockDriver.EXPECT().GetCPUs("some-vm").Return(0, errors.New("some-error")),
+				)
+
+				_, err := vbx.VMConfig("some-vm")
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+
 		Context("when the driver fails to get the SSHPort", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
+					mockDriver.EXPECT().GetCPUs("some-vm").Return(2, nil),
 					mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("", errors.New("some-error")),
 				)
 
@@ -1500,6 +1641,7 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 			It("should return an error", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
+					mockDriver.EXPECT().GetCPUs("some-vm").Return(2, nil),
 					mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("some-port", nil),
 					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return(nil, errors.New("some-error")),
 				)
@@ -1513,6 +1655,7 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 			It("should return an error", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
+					mockDriver.EXPECT().GetCPUs("some-vm").Return(2, nil),
 					mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("some-port", nil),
 					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return([]byte(`some-invalid-json`), nil),
 				)
@@ -1586,6 +1729,21 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 				Expect(vbx.GetVMName()).To(Equal(""))
 			})
 		})
+
+		Context("when an instance is selected", func() {
+			It("should return the name of the VM belonging to that instance", func() {
+				conf.Instance = "some-instance"
+				mockDriver.EXPECT().VMs().Return([]string{"pcfdev-our-vm", "pcfdev-our-vm--some-instance", "pcfdev-our-vm--some-other-instance"}, nil)
+				Expect(vbx.GetVMName()).To(Equal("pcfdev-our-vm"))
+			})
+		})
+
+		Context("when instances other than the default instance are present", func() {
+			It("should ignore them", func() {
+				mockDriver.EXPECT().VMs().Return([]string{"pcfdev-our-vm", "pcfdev-our-vm--some-instance"}, nil)
+				Expect(vbx.GetVMName()).To(Equal("pcfdev-our-vm"))
+			})
+		})
 	})
 
 	Describe("#Destroy", func() {
@@ -1606,10 +1764,9 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 
 	Describe("#Version", func() {
 		It("return the VBoxDriver version", func() {
-			driverVersion := &vboxdriver.VBoxDriverVersion{Major: 1, Minor: 0, Build: 0}
-			mockDriver.EXPECT().Version().Return(driverVersion, nil)
+			mockDriver.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 1, Minor: 0, Build: 0}, nil)
 
-			Expect(vbx.Version()).To(Equal(driverVersion))
+			Expect(vbx.Version()).To(Equal(&provider.Version{Major: 1, Minor: 0, Build: 0}))
 		})
 
 		Context("when there is an error retrieving the version", func() {
@@ -1639,6 +1796,141 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 		})
 	})
 
+	Describe("#TakeSnapshot", func() {
+		It("should take a snapshot of the VM", func() {
+			mockDriver.EXPECT().TakeSnapshot("some-vm", "some-snapshot")
+
+			Expect(vbx.TakeSnapshot("some-vm", "some-snapshot")).To(Succeed())
+		})
+
+		Context("when the driver fails to take the snapshot", func() {
+			It("should return the error", func() {
+				mockDriver.EXPECT().TakeSnapshot("some-vm", "some-snapshot").Return(errors.New("some-error"))
+
+				Expect(vbx.TakeSnapshot("some-vm", "some-snapshot")).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#RestoreSnapshot", func() {
+		It("should restore the snapshot of the VM", func() {
+			mockDriver.EXPECT().RestoreSnapshot("some-vm", "some-snapshot")
+
+			Expect(vbx.RestoreSnapshot("some-vm", "some-snapshot")).To(Succeed())
+		})
+
+		Context("when the driver fails to restore the snapshot", func() {
+			It("should return the error", func() {
+				mockDriver.EXPECT().RestoreSnapshot("some-vm", "some-snapshot").Return(errors.New("some-error"))
+
+				Expect(vbx.RestoreSnapshot("some-vm", "some-snapshot")).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#DeleteSnapshot", func() {
+		It("should delete the snapshot of the VM", func() {
+			mockDriver.EXPECT().DeleteSnapshot("some-vm", "some-snapshot")
+
+			Expect(vbx.DeleteSnapshot("some-vm", "some-snapshot")).To(Succeed())
+		})
+
+		Context("when the driver fails to delete the snapshot", func() {
+			It("should return the error", func() {
+				mockDriver.EXPECT().DeleteSnapshot("some-vm", "some-snapshot").Return(errors.New("some-error"))
+
+				Expect(vbx.DeleteSnapshot("some-vm", "some-snapshot")).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#Snapshots", func() {
+		It("should return the snapshots of the VM", func() {
+			mockDriver.EXPECT().Snapshots("some-vm").Return([]string{"some-snapshot", "some-other-snapshot"}, nil)
+
+			Expect(vbx.Snapshots("some-vm")).To(Equal([]string{"some-snapshot", "some-other-snapshot"}))
+		})
+
+		Context("when the driver fails to list the snapshots", func() {
+			It("should return the error", func() {
+				mockDriver.EXPECT().Snapshots("some-vm").Return(nil, errors.New("some-error"))
+
+				_, err := vbx.Snapshots("some-vm")
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#Instances", func() {
+		It("should return every PCF Dev instance with its status", func() {
+			gomock.InOrder(
+				mockDriver.EXPECT().VMs().Return([]string{"pcfdev-our-vm", "some-vm-name", "pcfdev-our-vm--some-instance"}, nil),
+				mockDriver.EXPECT().VMExists("pcfdev-our-vm").Return(true, nil),
+				mockDriver.EXPECT().VMState("pcfdev-our-vm").Return(vboxdriver.StateRunning, nil),
+				mockDriver.EXPECT().VMExists("pcfdev-our-vm--some-instance").Return(true, nil),
+				mockDriver.EXPECT().VMState("pcfdev-our-vm--some-instance").Return(vboxdriver.StateStopped, nil),
+			)
+
+			Expect(vbx.Instances()).To(Equal([]*provider.Instance{
+				{Name: "", VMName: "pcfdev-our-vm", Status: provider.StatusRunning},
+				{Name: "some-instance", VMName: "pcfdev-our-vm", Status: provider.StatusStopped},
+			}))
+		})
+
+		Context("when Driver.VMs() returns an error", func() {
+			It("should return an error", func() {
+				mockDriver.EXPECT().VMs().Return(nil, errors.New("some-error"))
+				_, err := vbx.Instances()
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when the status of an instance cannot be determined", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().VMs().Return([]string{"pcfdev-our-vm"}, nil),
+					mockDriver.EXPECT().VMExists("pcfdev-our-vm").Return(false, errors.New("some-error")),
+				)
+				_, err := vbx.Instances()
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Context("when an instance is selected", func() {
+		BeforeEach(func() {
+			conf.Instance = "some-instance"
+		})
+
+		It("should operate on the VM belonging to that instance", func() {
+			gomock.InOrder(
+				mockDriver.EXPECT().StopVM("some-vm--some-instance"),
+				mockDriver.EXPECT().VMExists("some-vm--some-instance").Return(true, nil),
+				mockDriver.EXPECT().VMState("some-vm--some-instance").Return(vboxdriver.StateStopped, nil),
+			)
+
+			Expect(vbx.StopVM(&config.VMConfig{Name: "some-vm"})).To(Succeed())
+			Expect(vbx.VMStatus("some-vm")).To(Equal(provider.StatusStopped))
+		})
+
+		It("should only destroy the VMs and disks of that instance", func() {
+			gomock.InOrder(
+				mockDriver.EXPECT().VMs().Return([]string{"pcfdev-0.0.0", "pcfdev-0.0.0--some-instance"}, nil),
+				mockDriver.EXPECT().PowerOffVM("pcfdev-0.0.0--some-instance"),
+				mockDriver.EXPECT().DestroyVM("pcfdev-0.0.0--some-instance"),
+				mockDriver.EXPECT().VMs().Return([]string{"pcfdev-0.0.0"}, nil),
+				mockDriver.EXPECT().Disks().Return([]string{
+					filepath.Join("some-dir", "pcfdev-0.0.0-disk1.vmdk"),
+					filepath.Join("some-other-dir", "pcfdev-0.0.0--some-instance-disk1.vdi"),
+				}, nil),
+				mockDriver.EXPECT().DeleteDisk(filepath.Join("some-other-dir", "pcfdev-0.0.0--some-instance-disk1.vdi")),
+				mockDriver.EXPECT().Disks().Return([]string{filepath.Join("some-dir", "pcfdev-0.0.0-disk1.vmdk")}, nil),
+			)
+
+			Expect(vbx.DestroyPCFDevVMs()).To(Succeed())
+		})
+	})
+
 	Describe("#DestroyPCFDevVMs", func() {
 		It("should destroy VMs and Disks that begin with pcfdev-", func() {
 			gomock.InOrder(
@@ -61,6 +61,12 @@ func (d *VBoxDriver) UseDNSProxy(vmName string) error {
 	return err
 }
 
+// AddSharedFolder shares hostPath with a running VM until it is powered off.
+func (d *VBoxDriver) AddSharedFolder(vmName string, name string, hostPath string) error {
+	_, err := d.VBoxManage("sharedfolder", "add", vmName, "--name", name, "--hostpath", hostPath, "--transient")
+	return err
+}
+
 func (d *VBoxDriver) AttachDisk(vmName string, diskPath string) error {
 	if _, err := d.VBoxManage("storagectl", vmName, "--name", "SATA", "--add", "sata"); err != nil {
 		return err
@@ -241,6 +247,49 @@ func (d *VBoxDriver) GetMemory(vmName string) (uint64, error) {
 	return uint64(0), fmt.Errorf("failed to determine VM memory for '%s'", vmName)
 }
 
+func (d *VBoxDriver) GetCPUs(vmName string) (int, error) {
+	output, err := d.VBoxManage("showvminfo", vmName, "--machinereadable")
+	if err != nil {
+		return 0, err
+	}
+
+	regex := regexp.MustCompile(`cpus=(\d+)`)
+	if matches := regex.FindStringSubmatch(string(output)); len(matches) > 1 {
+		return strconv.Atoi(matches[1])
+	}
+
+	return 0, fmt.Errorf("failed to determine VM cpus for '%s'", vmName)
+}
+
+func (d *VBoxDriver) TakeSnapshot(vmName string, snapshotName string) error {
+	_, err := d.VBoxManage("snapshot", vmName, "take", snapshotName)
+	return err
+}
+
+func (d *VBoxDriver) RestoreSnapshot(vmName string, snapshotName string) error {
+	_, err := d.VBoxManage("snapshot", vmName, "restore", snapshotName)
+	return err
+}
+
+func (d *VBoxDriver) DeleteSnapshot(vmName string, snapshotName string) error {
+	_, err := d.VBoxManage("snapshot", vmName, "delete", snapshotName)
+	return err
+}
+
+func (d *VBoxDriver) Snapshots(vmName string) ([]string, error) {
+	output, err := d.VBoxManage("showvminfo", vmName, "--machinereadable")
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := []string{}
+	regex := regexp.MustCompile(`(?m)^SnapshotName(?:-[\d-]+)?="(.*)"\s*$`)
+	for _, matches := range regex.FindAllStringSubmatch(string(output), -1) {
+		snapshots = append(snapshots, matches[1])
+	}
+	return snapshots, nil
+}
+
 func (d *VBoxDriver) SetMemory(vmName string, memory uint64) error {
 	_, err := d.VBoxManage("modifyvm", vmName, "--memory", strconv.Itoa(int(memory)))
 	return err
@@ -337,8 +386,9 @@ func (d *VBoxDriver) IsInterfaceInUse(interfaceName string) (bool, error) {
 	return false, nil
 }
 
+// CloneDisk copies src to dst in the VDI format, which unlike VMDK can be resized.
 func (d *VBoxDriver) CloneDisk(src, dst string) error {
-	if _, err := d.VBoxManage("clonemedium", "disk", src, dst); err != nil {
+	if _, err := d.VBoxManage("clonemedium", "disk", src, dst, "--format", "VDI"); err != nil {
 		return err
 	}
 	if _, err := d.VBoxManage("closemedium", "disk", src); err != nil {
@@ -367,6 +417,25 @@ func (d *VBoxDriver) DeleteDisk(diskPath string) error {
 	return nil
 }
 
+func (d *VBoxDriver) ResizeDisk(diskPath string, sizeInMB uint64) error {
+	_, err := d.VBoxManage("modifymedium", "disk", diskPath, "--resize", strconv.FormatUint(sizeInMB, 10))
+	return err
+}
+
+func (d *VBoxDriver) DiskSize(diskPath string) (uint64, error) {
+	output, err := d.VBoxManage("showmediuminfo", "disk", diskPath)
+	if err != nil {
+		return uint64(0), err
+	}
+
+	regex := regexp.MustCompile(`(?m)^Capacity:\s+(\d+) MBytes`)
+	if matches := regex.FindStringSubmatch(string(output)); len(matches) > 1 {
+		return strconv.ParseUint(matches[1], 10, 64)
+	}
+
+	return uint64(0), fmt.Errorf("failed to determine the size of '%s'", diskPath)
+}
+
 func (d *VBoxDriver) Disks() ([]string, error) {
 	output, err := d.VBoxManage("list", "hdds")
 	if err != nil {
@@ -99,6 +99,14 @@ var _ = Describe("driver", func() {
 		})
 	})
 
+	Describe("#AddSharedFolder", func() {
+		Context("when the VM is not running", func() {
+			It("should return an error", func() {
+				Expect(driver.AddSharedFolder(vmName, "some-share", os.TempDir())).To(MatchError(MatchRegexp("failed to execute '.* sharedfolder add " + vmName + " --name some-share --hostpath .* --transient':")))
+			})
+		})
+	})
+
 	Describe("#GetMemory", func() {
 		BeforeEach(func() {
 			Expect(exec.Command(vBoxManagePath, "modifyvm", vmName, "--memory", "4567").Run()).To(Succeed())
@@ -117,6 +125,46 @@ var _ = Describe("driver", func() {
 		})
 	})
 
+	Describe("#GetCPUs", func() {
+		BeforeEach(func() {
+			Expect(exec.Command(vBoxManagePath, "modifyvm", vmName, "--cpus", "2").Run()).To(Succeed())
+		})
+
+		It("should return the number of vm cpus", func() {
+			Expect(driver.GetCPUs(vmName)).To(Equal(2))
+		})
+
+		Context("when VBoxManage command fails", func() {
+			It("should return the output of the failed command", func() {
+				_, err := driver.GetCPUs("some-bad-vm-name")
+				Expect(err).To(MatchError(MatchRegexp("failed to execute '.* showvminfo some-bad-vm-name --machinereadable': exit status 1")))
+				Expect(err).To(MatchError(ContainSubstring("Could not find a registered machine named 'some-bad-vm-name'")))
+			})
+		})
+	})
+
+	Describe("snapshots", func() {
+		It("should take, list, restore and delete snapshots", func() {
+			Expect(driver.Snapshots(vmName)).To(BeEmpty())
+
+			Expect(driver.TakeSnapshot(vmName, "some-snapshot")).To(Succeed())
+			Expect(driver.TakeSnapshot(vmName, "some-other-snapshot")).To(Succeed())
+			Expect(driver.Snapshots(vmName)).To(ConsistOf("some-snapshot", "some-other-snapshot"))
+
+			Expect(driver.RestoreSnapshot(vmName, "some-snapshot")).To(Succeed())
+
+			Expect(driver.DeleteSnapshot(vmName, "some-other-snapshot")).To(Succeed())
+			Expect(driver.Snapshots(vmName)).To(ConsistOf("some-snapshot"))
+		})
+
+		Context("when the snapshot does not exist", func() {
+			It("should return an error", func() {
+				err := driver.RestoreSnapshot(vmName, "some-bad-snapshot")
+				Expect(err).To(MatchError(MatchRegexp("failed to execute '.* snapshot .* restore some-bad-snapshot': exit status 1")))
+			})
+		})
+	})
+
 	Describe("when starting and stopping and suspending and resuming and destroying the VM", func() {
 		It("should start, stop, suspend, start, pause, resume and then destroy a VBox VM", func() {
 			sshClient := &ssh.SSH{}
@@ -731,18 +779,18 @@ var _ = Describe("driver", func() {
 		})
 
 		AfterEach(func() {
-			exec.Command(vBoxManagePath, "closemedium", "disk", filepath.Join(tmpDir, "cloned-Snappy-disk1.vmdk")).Run()
+			exec.Command(vBoxManagePath, "closemedium", "disk", filepath.Join(tmpDir, "cloned-Snappy-disk1.vdi")).Run()
 			os.RemoveAll(tmpDir)
 		})
 
-		It("should clone a disk", func() {
-			Expect(driver.CloneDisk(filepath.Join(tmpDir, "compressed-Snappy-disk1.vmdk"), filepath.Join(tmpDir, "cloned-Snappy-disk1.vmdk"))).To(Succeed())
+		It("should clone a disk to a VDI", func() {
+			Expect(driver.CloneDisk(filepath.Join(tmpDir, "compressed-Snappy-disk1.vmdk"), filepath.Join(tmpDir, "cloned-Snappy-disk1.vdi"))).To(Succeed())
 
-			command := exec.Command(vBoxManagePath, "showmediuminfo", "disk", filepath.Join(tmpDir, "cloned-Snappy-disk1.vmdk"))
+			command := exec.Command(vBoxManagePath, "showmediuminfo", "disk", filepath.Join(tmpDir, "cloned-Snappy-disk1.vdi"))
 			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
 			Expect(err).NotTo(HaveOccurred())
 			Eventually(session, 10*time.Second).Should(gexec.Exit(0))
-			Expect(session).To(gbytes.Say(`Storage format: VMDK`))
+			Expect(session).To(gbytes.Say(`Storage format: VDI`))
 
 			command = exec.Command(vBoxManagePath, "list", "hdds")
 			session, err = gexec.Start(command, GinkgoWriter, GinkgoWriter)
@@ -753,8 +801,42 @@ var _ = Describe("driver", func() {
 
 		Context("when cloning fails", func() {
 			It("should return an error", func() {
-				Expect(driver.CloneDisk("some-bad-src", "cloned-Snappy-disk1.vmdk")).To(
-					MatchError(MatchRegexp("failed to execute '.* clonemedium disk some-bad-src cloned-Snappy-disk1.vmdk':")))
+				Expect(driver.CloneDisk("some-bad-src", "cloned-Snappy-disk1.vdi")).To(
+					MatchError(MatchRegexp("failed to execute '.* clonemedium disk some-bad-src cloned-Snappy-disk1.vdi --format VDI':")))
+			})
+		})
+	})
+
+	Describe("#ResizeDisk", func() {
+		var diskPath string
+
+		BeforeEach(func() {
+			diskPath = filepath.Join(os.TempDir(), "some-disk.vdi")
+			_, err := exec.Command(vBoxManagePath, "createmedium", "--filename", diskPath, "--size", "1024", "--format", "VDI").CombinedOutput()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			exec.Command(vBoxManagePath, "closemedium", diskPath, "--delete").Run()
+		})
+
+		It("should grow the disk", func() {
+			Expect(driver.ResizeDisk(diskPath, 2048)).To(Succeed())
+			Expect(driver.DiskSize(diskPath)).To(Equal(uint64(2048)))
+		})
+
+		Context("when VBoxManage command fails", func() {
+			It("should return an error", func() {
+				Expect(driver.ResizeDisk("some-bad-disk.vdi", 2048)).To(MatchError(MatchRegexp("failed to execute '.* modifymedium disk some-bad-disk.vdi --resize 2048':")))
+			})
+		})
+	})
+
+	Describe("#DiskSize", func() {
+		Context("when VBoxManage command fails", func() {
+			It("should return an error", func() {
+				_, err := driver.DiskSize("some-bad-disk.vdi")
+				Expect(err).To(MatchError(MatchRegexp("failed to execute '.* showmediuminfo disk some-bad-disk.vdi':")))
 			})
 		})
 	})
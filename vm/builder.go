@@ -4,28 +4,33 @@ import (
 	"errors"
 	"github.com/cloudfoundry/cli/cf/terminal"
 	"github.com/cloudfoundry/cli/cf/trace"
+	"github.com/docker/docker/pkg/term"
 	"github.com/pivotal-cf/pcfdev-cli/cert"
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/debug"
 	"github.com/pivotal-cf/pcfdev-cli/fs"
 	"github.com/pivotal-cf/pcfdev-cli/network"
+	"github.com/pivotal-cf/pcfdev-cli/provider"
 	"github.com/pivotal-cf/pcfdev-cli/runner"
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
 	"github.com/pivotal-cf/pcfdev-cli/ui"
-	"github.com/pivotal-cf/pcfdev-cli/vbox"
 	"github.com/pivotal-cf/pcfdev-cli/vboxdriver"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 )
 
-type VBoxBuilder struct {
-	Config *config.Config
-	VBox   VBox
-	FS     FS
-	SSH    SSH
-	Client Client
+type ProviderBuilder struct {
+	Config    *config.Config
+	Provider  Provider
+	FS        FS
+	SSH       SSH
+	Client    Client
+	Readiness Readiness
 }
 
-func (b *VBoxBuilder) VM(vmName string) (VM, error) {
+func (b *ProviderBuilder) VM(vmName string) (VM, error) {
 	termUI := terminal.NewUI(
 		os.Stdin,
 		os.Stdout,
@@ -33,7 +38,7 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 		trace.NewLogger(os.Stdout, false, "", ""),
 	)
 
-	status, err := b.VBox.VMStatus(vmName)
+	status, err := b.Provider.VMStatus(vmName)
 	if err != nil {
 		return nil, err
 	}
@@ -49,13 +54,15 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 		VMConfig:  vmConfig,
 		Config:    b.Config,
 		UI:        termUI,
-		VBox:      b.VBox,
+		Provider:  b.Provider,
 		FS:        b.FS,
 		SSHClient: b.SSH,
 		HelpText: &ui.HelpText{
 			UI: termUI,
 		},
-		Client: b.Client,
+		Client:    b.Client,
+		Readiness: b.Readiness,
+		Spinner:   spinner(),
 		LogFetcher: &debug.LogFetcher{
 			VMConfig: vmConfig,
 			Config:   b.Config,
@@ -72,10 +79,12 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 		VMConfig:  vmConfig,
 		FS:        b.FS,
 		UI:        termUI,
-		VBox:      b.VBox,
+		Provider:  b.Provider,
 		SSHClient: b.SSH,
 		Builder:   b,
 		CmdRunner: &runner.CmdRunner{},
+		Readiness: b.Readiness,
+		Client:    b.Client,
 		HelpText: &ui.HelpText{
 			UI: termUI,
 		},
@@ -85,6 +94,15 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 				FS:        b.FS,
 				CmdRunner: &runner.CmdRunner{},
 			},
+			NSSStore: &cert.NSSStore{
+				CmdRunner: &runner.CmdRunner{},
+			},
+			JavaStore: &cert.JavaStore{
+				FS:        b.FS,
+				CmdRunner: &runner.CmdRunner{},
+				JavaHome:  os.Getenv("JAVA_HOME"),
+			},
+			StoresPath: b.Config.TrustedStoresPath,
 		},
 		LogFetcher: &debug.LogFetcher{
 			VMConfig: vmConfig,
@@ -99,8 +117,8 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 	}
 
 	switch status {
-	case vbox.StatusNotCreated:
-		dirExists, err := b.FS.Exists(filepath.Join(b.Config.VMDir, vmName))
+	case provider.StatusNotCreated:
+		dirExists, err := b.FS.Exists(filepath.Join(b.Config.VMDir, b.Config.InstanceVMName(vmName)))
 		if err != nil {
 			return nil, err
 		}
@@ -112,7 +130,7 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 		}
 
 		return &NotCreated{
-			VBox:     b.VBox,
+			Provider: b.Provider,
 			UI:       termUI,
 			Builder:  b,
 			Config:   b.Config,
@@ -120,7 +138,7 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 			VMConfig: vmConfig,
 			Network:  &network.Network{},
 		}, nil
-	case vbox.StatusRunning:
+	case provider.StatusRunning:
 		key, err := b.FS.Read(b.Config.PrivateKeyPath)
 		if err != nil {
 			return &Invalid{
@@ -128,7 +146,11 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 			}, nil
 		}
 
-		output, err := b.Client.Status(vmConfig.IP, key)
+		addresses := []ssh.SSHAddress{
+			{IP: "127.0.0.1", Port: vmConfig.SSHPort},
+			{IP: vmConfig.IP, Port: "22"},
+		}
+		output, err := b.Client.Status(addresses, key)
 
 		if output == "Unprovisioned" || err != nil {
 			return unprovisionedVm, nil
@@ -140,7 +162,7 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 			}, nil
 		}
 
-	case vbox.StatusStopped:
+	case provider.StatusStopped:
 		return &Stopped{
 			VMConfig: vmConfig,
 			Config:   b.Config,
@@ -148,26 +170,28 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 			FS:        b.FS,
 			UI:        termUI,
 			SSHClient: b.SSH,
-			VBox:      b.VBox,
+			Provider:  b.Provider,
 			Builder:   b,
 		}, nil
-	case vbox.StatusPaused:
+	case provider.StatusPaused:
 		return &Paused{
 			VMConfig:  vmConfig,
 			SSHClient: b.SSH,
 			UI:        termUI,
-			VBox:      b.VBox,
+			Provider:  b.Provider,
 			Config:    b.Config,
 			FS:        b.FS,
+			Readiness: b.Readiness,
 		}, nil
-	case vbox.StatusSaved:
+	case provider.StatusSaved:
 		return &Saved{
 			VMConfig:  vmConfig,
 			SSHClient: b.SSH,
 			UI:        termUI,
-			VBox:      b.VBox,
+			Provider:  b.Provider,
 			Config:    b.Config,
 			FS:        b.FS,
+			Readiness: b.Readiness,
 		}, nil
 	default:
 		return &Invalid{
@@ -176,11 +200,20 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 	}
 }
 
-func (b *VBoxBuilder) getVMConfig(vmName string, status string) (*config.VMConfig, error) {
-	if status == vbox.StatusNotCreated {
+func (b *ProviderBuilder) getVMConfig(vmName string, status string) (*config.VMConfig, error) {
+	if status == provider.StatusNotCreated {
 		return &config.VMConfig{
 			Name: vmName,
 		}, nil
 	}
-	return b.VBox.VMConfig(vmName)
+	return b.Provider.VMConfig(vmName)
+}
+
+// spinner returns where provisioning progress is redrawn in place, or nil
+// when stdout is not a terminal that understands ANSI escape codes.
+func spinner() io.Writer {
+	if runtime.GOOS == "windows" || !term.IsTerminal(os.Stdout.Fd()) {
+		return nil
+	}
+	return os.Stdout
 }
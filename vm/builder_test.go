@@ -4,7 +4,8 @@ import (
 	"errors"
 	"github.com/golang/mock/gomock"
 	"github.com/pivotal-cf/pcfdev-cli/config"
-	"github.com/pivotal-cf/pcfdev-cli/vbox"
+	"github.com/pivotal-cf/pcfdev-cli/provider"
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
 	"github.com/pivotal-cf/pcfdev-cli/vm/mocks"
 	"path/filepath"
@@ -16,21 +17,23 @@ import (
 var _ = Describe("Builder", func() {
 	Describe("#VM", func() {
 		var (
-			mockCtrl   *gomock.Controller
-			mockVBox   *mocks.MockVBox
-			mockFS     *mocks.MockFS
-			mockSSH    *mocks.MockSSH
-			mockClient *mocks.MockClient
-			builder    *vm.VBoxBuilder
-			conf       *config.Config
+			mockCtrl      *gomock.Controller
+			mockProvider  *mocks.MockProvider
+			mockFS        *mocks.MockFS
+			mockSSH       *mocks.MockSSH
+			mockClient    *mocks.MockClient
+			mockReadiness *mocks.MockReadiness
+			builder       *vm.ProviderBuilder
+			conf          *config.Config
 		)
 
 		BeforeEach(func() {
 			mockCtrl = gomock.NewController(GinkgoT())
-			mockVBox = mocks.NewMockVBox(mockCtrl)
+			mockProvider = mocks.NewMockProvider(mockCtrl)
 			mockFS = mocks.NewMockFS(mockCtrl)
 			mockSSH = mocks.NewMockSSH(mockCtrl)
 			mockClient = mocks.NewMockClient(mockCtrl)
+			mockReadiness = mocks.NewMockReadiness(mockCtrl)
 			conf = &config.Config{
 				MinMemory:      100,
 				MaxMemory:      200,
@@ -38,12 +41,13 @@ var _ = Describe("Builder", func() {
 				PrivateKeyPath: "some-private-key-path",
 			}
 
-			builder = &vm.VBoxBuilder{
-				VBox:   mockVBox,
-				FS:     mockFS,
-				SSH:    mockSSH,
-				Client: mockClient,
-				Config: conf,
+			builder = &vm.ProviderBuilder{
+				Provider:  mockProvider,
+				FS:        mockFS,
+				SSH:       mockSSH,
+				Client:    mockClient,
+				Readiness: mockReadiness,
+				Config:    conf,
 			}
 		})
 
@@ -54,7 +58,7 @@ var _ = Describe("Builder", func() {
 		Context("when vm is not created", func() {
 			It("should return a not created VM", func() {
 				gomock.InOrder(
-					mockVBox.EXPECT().VMStatus("some-vm").Return(vbox.StatusNotCreated, nil),
+					mockProvider.EXPECT().VMStatus("some-vm").Return(provider.StatusNotCreated, nil),
 					mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "some-vm")).Return(false, nil),
 				)
 
@@ -72,7 +76,7 @@ var _ = Describe("Builder", func() {
 			Context("when the disk exists", func() {
 				It("should return an invalid vm", func() {
 					gomock.InOrder(
-						mockVBox.EXPECT().VMStatus("some-vm").Return(vbox.StatusNotCreated, nil),
+						mockProvider.EXPECT().VMStatus("some-vm").Return(provider.StatusNotCreated, nil),
 						mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "some-vm")).Return(true, nil),
 					)
 
@@ -91,7 +95,7 @@ var _ = Describe("Builder", func() {
 			Context("when the disk does not exist", func() {
 				It("should return an invalid vm", func() {
 					gomock.InOrder(
-						mockVBox.EXPECT().VMStatus("some-vm").Return(vbox.StatusNotCreated, nil),
+						mockProvider.EXPECT().VMStatus("some-vm").Return(provider.StatusNotCreated, nil),
 						mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "some-vm")).Return(false, errors.New("some-error")),
 					)
 
@@ -106,8 +110,8 @@ var _ = Describe("Builder", func() {
 				It("should return a stopped vm", func() {
 					expectedVMConfig := &config.VMConfig{}
 					gomock.InOrder(
-						mockVBox.EXPECT().VMStatus("some-vm").Return(vbox.StatusStopped, nil),
-						mockVBox.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
+						mockProvider.EXPECT().VMStatus("some-vm").Return(provider.StatusStopped, nil),
+						mockProvider.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
 					)
 
 					stoppedVM, err := builder.VM("some-vm")
@@ -117,7 +121,7 @@ var _ = Describe("Builder", func() {
 					case *vm.Stopped:
 						Expect(u.VMConfig).To(BeIdenticalTo(expectedVMConfig))
 						Expect(u.SSHClient).NotTo(BeNil())
-						Expect(u.VBox).NotTo(BeNil())
+						Expect(u.Provider).NotTo(BeNil())
 						Expect(u.UI).NotTo(BeNil())
 					default:
 						Fail("wrong type")
@@ -128,8 +132,8 @@ var _ = Describe("Builder", func() {
 			Context("when there is an error getting the vm config", func() {
 				It("should return an invalid vm", func() {
 					gomock.InOrder(
-						mockVBox.EXPECT().VMStatus("some-vm").Return(vbox.StatusStopped, nil),
-						mockVBox.EXPECT().VMConfig("some-vm").Return(nil, errors.New("some-error")),
+						mockProvider.EXPECT().VMStatus("some-vm").Return(provider.StatusStopped, nil),
+						mockProvider.EXPECT().VMConfig("some-vm").Return(nil, errors.New("some-error")),
 					)
 
 					invalidVM, err := builder.VM("some-vm")
@@ -154,10 +158,10 @@ var _ = Describe("Builder", func() {
 					}
 
 					gomock.InOrder(
-						mockVBox.EXPECT().VMStatus("some-vm").Return(vbox.StatusRunning, nil),
-						mockVBox.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
+						mockProvider.EXPECT().VMStatus("some-vm").Return(provider.StatusRunning, nil),
+						mockProvider.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
 						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
-						mockClient.EXPECT().Status("192.168.11.11", []byte("some-private-key")).Return("Running", nil),
+						mockClient.EXPECT().Status([]ssh.SSHAddress{{IP: "127.0.0.1", Port: "some-port"}, {IP: "192.168.11.11", Port: "22"}}, []byte("some-private-key")).Return("Running", nil),
 					)
 
 					runningVM, err := builder.VM("some-vm")
@@ -165,9 +169,11 @@ var _ = Describe("Builder", func() {
 
 					switch u := runningVM.(type) {
 					case *vm.Running:
+						Expect(u.Readiness).To(BeIdenticalTo(mockReadiness))
+						Expect(u.Client).To(BeIdenticalTo(mockClient))
 						Expect(u.Config).To(BeIdenticalTo(conf))
 						Expect(u.VMConfig).To(BeIdenticalTo(expectedVMConfig))
-						Expect(u.VBox).NotTo(BeNil())
+						Expect(u.Provider).NotTo(BeNil())
 						Expect(u.UI).NotTo(BeNil())
 						Expect(u.SSHClient).NotTo(BeNil())
 						Expect(u.FS).NotTo(BeNil())
@@ -192,10 +198,10 @@ var _ = Describe("Builder", func() {
 					}
 
 					gomock.InOrder(
-						mockVBox.EXPECT().VMStatus("some-vm").Return(vbox.StatusRunning, nil),
-						mockVBox.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
+						mockProvider.EXPECT().VMStatus("some-vm").Return(provider.StatusRunning, nil),
+						mockProvider.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
 						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
-						mockClient.EXPECT().Status("192.168.11.11", []byte("some-private-key")).Return("Unprovisioned", nil),
+						mockClient.EXPECT().Status([]ssh.SSHAddress{{IP: "127.0.0.1", Port: "some-port"}, {IP: "192.168.11.11", Port: "22"}}, []byte("some-private-key")).Return("Unprovisioned", nil),
 					)
 
 					unprovisionedVM, err := builder.VM("some-vm")
@@ -203,8 +209,9 @@ var _ = Describe("Builder", func() {
 
 					switch u := unprovisionedVM.(type) {
 					case *vm.Unprovisioned:
+						Expect(u.Readiness).To(BeIdenticalTo(mockReadiness))
 						Expect(u.UI).NotTo(BeNil())
-						Expect(u.VBox).NotTo(BeNil())
+						Expect(u.Provider).NotTo(BeNil())
 						Expect(u.LogFetcher).NotTo(BeNil())
 						Expect(u.VMConfig).To(BeIdenticalTo(expectedVMConfig))
 						Expect(u.Client).To(BeIdenticalTo(builder.Client))
@@ -224,10 +231,10 @@ var _ = Describe("Builder", func() {
 					}
 
 					gomock.InOrder(
-						mockVBox.EXPECT().VMStatus("some-vm").Return(vbox.StatusRunning, nil),
-						mockVBox.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
+						mockProvider.EXPECT().VMStatus("some-vm").Return(provider.StatusRunning, nil),
+						mockProvider.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
 						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
-						mockClient.EXPECT().Status("192.168.11.11", []byte("some-private-key")).Return("some-unexpected-status", nil),
+						mockClient.EXPECT().Status([]ssh.SSHAddress{{IP: "127.0.0.1", Port: "some-port"}, {IP: "192.168.11.11", Port: "22"}}, []byte("some-private-key")).Return("some-unexpected-status", nil),
 					)
 
 					invalidVM, err := builder.VM("some-vm")
@@ -252,8 +259,8 @@ var _ = Describe("Builder", func() {
 					}
 
 					gomock.InOrder(
-						mockVBox.EXPECT().VMStatus("some-vm").Return(vbox.StatusRunning, nil),
-						mockVBox.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
+						mockProvider.EXPECT().VMStatus("some-vm").Return(provider.StatusRunning, nil),
+						mockProvider.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
 						mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error")),
 					)
 
@@ -279,10 +286,10 @@ var _ = Describe("Builder", func() {
 					}
 
 					gomock.InOrder(
-						mockVBox.EXPECT().VMStatus("some-vm").Return(vbox.StatusRunning, nil),
-						mockVBox.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
+						mockProvider.EXPECT().VMStatus("some-vm").Return(provider.StatusRunning, nil),
+						mockProvider.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
 						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
-						mockClient.EXPECT().Status("192.168.11.11", []byte("some-private-key")).Return("", errors.New("some-error")),
+						mockClient.EXPECT().Status([]ssh.SSHAddress{{IP: "127.0.0.1", Port: "some-port"}, {IP: "192.168.11.11", Port: "22"}}, []byte("some-private-key")).Return("", errors.New("some-error")),
 					)
 
 					unprovisionedVM, err := builder.VM("some-vm")
@@ -290,8 +297,9 @@ var _ = Describe("Builder", func() {
 
 					switch u := unprovisionedVM.(type) {
 					case *vm.Unprovisioned:
+						Expect(u.Readiness).To(BeIdenticalTo(mockReadiness))
 						Expect(u.UI).NotTo(BeNil())
-						Expect(u.VBox).NotTo(BeNil())
+						Expect(u.Provider).NotTo(BeNil())
 						Expect(u.LogFetcher).NotTo(BeNil())
 						Expect(u.VMConfig).To(BeIdenticalTo(expectedVMConfig))
 						Expect(u.Client).To(BeIdenticalTo(builder.Client))
@@ -310,8 +318,8 @@ var _ = Describe("Builder", func() {
 						Domain:  "local.pcfdev.io",
 					}
 					gomock.InOrder(
-						mockVBox.EXPECT().VMStatus("some-vm").Return(vbox.StatusPaused, nil),
-						mockVBox.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
+						mockProvider.EXPECT().VMStatus("some-vm").Return(provider.StatusPaused, nil),
+						mockProvider.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
 					)
 
 					pausedVM, err := builder.VM("some-vm")
@@ -319,9 +327,10 @@ var _ = Describe("Builder", func() {
 
 					switch u := pausedVM.(type) {
 					case *vm.Paused:
+						Expect(u.Readiness).To(BeIdenticalTo(mockReadiness))
 						Expect(u.VMConfig).To(BeIdenticalTo(expectedVMConfig))
 						Expect(u.Config).To(BeIdenticalTo(conf))
-						Expect(u.VBox).NotTo(BeNil())
+						Expect(u.Provider).NotTo(BeNil())
 						Expect(u.UI).NotTo(BeNil())
 						Expect(u.FS).NotTo(BeNil())
 					default:
@@ -339,8 +348,8 @@ var _ = Describe("Builder", func() {
 						Domain:  "local.pcfdev.io",
 					}
 					gomock.InOrder(
-						mockVBox.EXPECT().VMStatus("some-vm").Return(vbox.StatusSaved, nil),
-						mockVBox.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
+						mockProvider.EXPECT().VMStatus("some-vm").Return(provider.StatusSaved, nil),
+						mockProvider.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
 					)
 
 					savedVM, err := builder.VM("some-vm")
@@ -348,8 +357,9 @@ var _ = Describe("Builder", func() {
 
 					switch u := savedVM.(type) {
 					case *vm.Saved:
+						Expect(u.Readiness).To(BeIdenticalTo(mockReadiness))
 						Expect(u.VMConfig).To(BeIdenticalTo(expectedVMConfig))
-						Expect(u.VBox).NotTo(BeNil())
+						Expect(u.Provider).NotTo(BeNil())
 						Expect(u.UI).NotTo(BeNil())
 						Expect(u.Config).To(BeIdenticalTo(conf))
 						Expect(u.FS).NotTo(BeNil())
@@ -368,8 +378,8 @@ var _ = Describe("Builder", func() {
 						Domain:  "local.pcfdev.io",
 					}
 					gomock.InOrder(
-						mockVBox.EXPECT().VMStatus("some-vm").Return(vbox.StatusUnknown, nil),
-						mockVBox.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
+						mockProvider.EXPECT().VMStatus("some-vm").Return(provider.StatusUnknown, nil),
+						mockProvider.EXPECT().VMConfig("some-vm").Return(expectedVMConfig, nil),
 					)
 
 					invalidVM, err := builder.VM("some-vm")
@@ -386,7 +396,7 @@ var _ = Describe("Builder", func() {
 
 			Context("when there is an error retrieving vm status", func() {
 				It("should return an error", func() {
-					mockVBox.EXPECT().VMStatus("some-vm").Return("", errors.New("some-error"))
+					mockProvider.EXPECT().VMStatus("some-vm").Return("", errors.New("some-error"))
 
 					_, err := builder.VM("some-vm")
 					Expect(err).To(MatchError("some-error"))
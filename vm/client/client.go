@@ -1,7 +1,9 @@
 package client
 
 import (
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -28,12 +30,12 @@ type StatusResponse struct {
 
 const APIPort = 8090
 
-func (c *Client) Status(sshIP string, privateKey []byte) (string, error) {
+func (c *Client) Status(sshAddresses []ssh.SSHAddress, privateKey []byte) (string, error) {
 	var resp *http.Response
 	var errorInTunnel error
 	errorWithTunnel := c.SSHClient.WithSSHTunnel(
 		fmt.Sprintf("127.0.0.1:%d", APIPort),
-		[]ssh.SSHAddress{{IP: sshIP, Port: "22"}},
+		sshAddresses,
 		privateKey,
 		time.Minute,
 		func(host string) {
@@ -73,12 +75,12 @@ func (c *Client) Status(sshIP string, privateKey []byte) (string, error) {
 	}
 }
 
-func (c *Client) ReplaceSecrets(sshIP, password string, privateKey []byte) error {
+func (c *Client) ReplaceSecrets(sshAddresses []ssh.SSHAddress, password string, privateKey []byte) error {
 	var resp *http.Response
 	var errorInTunnel error
 	errorWithTunnel := c.SSHClient.WithSSHTunnel(
 		fmt.Sprintf("127.0.0.1:%d", APIPort),
-		[]ssh.SSHAddress{{IP: sshIP, Port: "22"}},
+		sshAddresses,
 		privateKey,
 		time.Minute,
 		func(host string) {
@@ -115,6 +117,157 @@ func (c *Client) ReplaceSecrets(sshIP, password string, privateKey []byte) error
 	}
 }
 
+type ServicesResponse struct {
+	Services map[string]bool `json:"services"`
+}
+
+// Services returns whether each optional service in the VM is enabled.
+func (c *Client) Services(sshAddresses []ssh.SSHAddress, privateKey []byte) (map[string]bool, error) {
+	var resp *http.Response
+	var errorInTunnel error
+	errorWithTunnel := c.SSHClient.WithSSHTunnel(
+		fmt.Sprintf("127.0.0.1:%d", APIPort),
+		sshAddresses,
+		privateKey,
+		time.Minute,
+		func(host string) {
+			var err error
+			resp, err = c.HttpClient.Get(fmt.Sprintf("%s/services", host))
+			if err != nil {
+				errorInTunnel = &PCFDevVmUnreachableError{err}
+			}
+		},
+	)
+
+	if errorWithTunnel != nil {
+		return nil, errorWithTunnel
+	}
+
+	if errorInTunnel != nil {
+		return nil, errorInTunnel
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		servicesResponse := &ServicesResponse{}
+		if err := json.Unmarshal(data, servicesResponse); err != nil {
+			return nil, &InvalidJSONError{err}
+		}
+
+		return servicesResponse.Services, nil
+	default:
+		return nil, &ServicesRetrievalError{fmt.Errorf("PCF Dev API returned: %d", resp.StatusCode)}
+	}
+}
+
+func (c *Client) EnableService(sshAddresses []ssh.SSHAddress, privateKey []byte, service string) error {
+	return c.changeService(sshAddresses, privateKey, service, "enable")
+}
+
+func (c *Client) DisableService(sshAddresses []ssh.SSHAddress, privateKey []byte, service string) error {
+	return c.changeService(sshAddresses, privateKey, service, "disable")
+}
+
+// changeService waits for the VM to start or stop the service, so the
+// request is not subject to the client timeout.
+func (c *Client) changeService(sshAddresses []ssh.SSHAddress, privateKey []byte, service string, action string) error {
+	var resp *http.Response
+	var errorInTunnel error
+	errorWithTunnel := c.SSHClient.WithSSHTunnel(
+		fmt.Sprintf("127.0.0.1:%d", APIPort),
+		sshAddresses,
+		privateKey,
+		time.Minute,
+		func(host string) {
+			uri := fmt.Sprintf("%s/services/%s/%s", host, service, action)
+
+			req, err := http.NewRequest("PUT", uri, nil)
+			if err != nil {
+				errorInTunnel = err
+				return
+			}
+
+			resp, err = http.DefaultClient.Do(req)
+			if err != nil {
+				errorInTunnel = &PCFDevVmUnreachableError{err}
+			}
+		},
+	)
+
+	if errorWithTunnel != nil {
+		return errorWithTunnel
+	}
+
+	if errorInTunnel != nil {
+		return errorInTunnel
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return &ChangeServiceError{Action: action, Service: service, Err: errors.New("unknown service")}
+	default:
+		return &ChangeServiceError{Action: action, Service: service, Err: fmt.Errorf("PCF Dev API returned: %d", resp.StatusCode)}
+	}
+}
+
+// ProbeHTTPS requests path from host through the router at address in the VM,
+// and fails unless the response is 200 OK. The router certificate is not verified.
+func (c *Client) ProbeHTTPS(sshAddresses []ssh.SSHAddress, privateKey []byte, address string, host string, path string) error {
+	var resp *http.Response
+	var errorInTunnel error
+	errorWithTunnel := c.SSHClient.WithSSHTunnel(
+		address,
+		sshAddresses,
+		privateKey,
+		time.Minute,
+		func(forwardingAddress string) {
+			forwardingAddress = strings.TrimPrefix(strings.TrimPrefix(forwardingAddress, "http://"), "https://")
+			req, err := http.NewRequest("GET", fmt.Sprintf("https://%s%s", forwardingAddress, path), nil)
+			if err != nil {
+				errorInTunnel = err
+				return
+			}
+			req.Host = host
+
+			httpsClient := &http.Client{
+				Timeout: c.Timeout,
+				Transport: &http.Transport{
+					Proxy:           nil,
+					TLSClientConfig: &tls.Config{ServerName: host, InsecureSkipVerify: true},
+				},
+			}
+			resp, err = httpsClient.Do(req)
+			if err != nil {
+				errorInTunnel = &PCFDevVmUnreachableError{err}
+			}
+		},
+	)
+
+	if errorWithTunnel != nil {
+		return errorWithTunnel
+	}
+
+	if errorInTunnel != nil {
+		return errorInTunnel
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("https://%s%s returned: %d", host, path, resp.StatusCode)
+	}
+	return nil
+}
+
 type PCFDevVmUnreachableError struct {
 	Err error
 }
@@ -146,3 +299,21 @@ type ReplaceMasterPasswordError struct {
 func (e *ReplaceMasterPasswordError) Error() string {
 	return fmt.Sprintf("failed to replace master password: %+v", e.Err)
 }
+
+type ServicesRetrievalError struct {
+	Err error
+}
+
+func (e *ServicesRetrievalError) Error() string {
+	return fmt.Sprintf("failed to retrieve services: %+v", e.Err)
+}
+
+type ChangeServiceError struct {
+	Action  string
+	Service string
+	Err     error
+}
+
+func (e *ChangeServiceError) Error() string {
+	return fmt.Sprintf("failed to %s %s: %+v", e.Action, e.Service, e.Err)
+}
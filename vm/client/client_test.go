@@ -67,7 +67,7 @@ var _ = Describe("PCF Dev Client", func() {
 				block(host)
 			})
 
-			status, err := client.Status("some-ip", []byte("some-private-key"))
+			status, err := client.Status([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"))
 			Expect(err).NotTo(HaveOccurred())
 			Expect(status).To(Equal("some-status"))
 		})
@@ -86,7 +86,7 @@ var _ = Describe("PCF Dev Client", func() {
 					block(host)
 				})
 
-				_, err := client.Status("some-ip", []byte("some-private-key"))
+				_, err := client.Status([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"))
 				errorMessage := err.Error()
 				Expect(errorMessage).To(ContainSubstring("failed to talk to PCF Dev VM"))
 				Expect(errorMessage).To(MatchRegexp(`[nN]o such host`))
@@ -121,7 +121,7 @@ var _ = Describe("PCF Dev Client", func() {
 					block(host)
 				})
 
-				_, err := client.Status("some-ip", []byte("some-private-key"))
+				_, err := client.Status([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"))
 				Expect(err).To(MatchError(ContainSubstring("failed to parse JSON response:")))
 
 			})
@@ -154,7 +154,7 @@ var _ = Describe("PCF Dev Client", func() {
 					block(host)
 				})
 
-				_, err := client.Status("some-ip", []byte("some-private-key"))
+				_, err := client.Status([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"))
 				Expect(err).To(MatchError("failed to retrieve status: PCF Dev API returned: 500"))
 			})
 		})
@@ -169,7 +169,7 @@ var _ = Describe("PCF Dev Client", func() {
 					gomock.Any(),
 				).Return(errors.New("some-error"))
 
-				_, err := client.Status("some-ip", []byte("some-private-key"))
+				_, err := client.Status([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"))
 				Expect(err).To(MatchError("some-error"))
 			})
 		})
@@ -204,7 +204,7 @@ var _ = Describe("PCF Dev Client", func() {
 				block(host)
 			})
 
-			Expect(client.ReplaceSecrets("some-ip", "some-master-password", []byte("some-private-key"))).To(Succeed())
+			Expect(client.ReplaceSecrets([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, "some-master-password", []byte("some-private-key"))).To(Succeed())
 		})
 
 		Context("when there is a bad response from the api", func() {
@@ -221,7 +221,7 @@ var _ = Describe("PCF Dev Client", func() {
 					block(host)
 				})
 
-				Expect(client.ReplaceSecrets("some-ip", "some-master-password", []byte("some-private-key"))).To(MatchError(ContainSubstring("failed to talk to PCF Dev VM:")))
+				Expect(client.ReplaceSecrets([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, "some-master-password", []byte("some-private-key"))).To(MatchError(ContainSubstring("failed to talk to PCF Dev VM:")))
 			})
 		})
 
@@ -248,7 +248,7 @@ var _ = Describe("PCF Dev Client", func() {
 					block(host)
 				})
 
-				Expect(client.ReplaceSecrets("some-ip", "some-master-password", []byte("some-private-key"))).To(MatchError("failed to replace master password: PCF Dev API returned: 500"))
+				Expect(client.ReplaceSecrets([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, "some-master-password", []byte("some-private-key"))).To(MatchError("failed to replace master password: PCF Dev API returned: 500"))
 			})
 		})
 
@@ -262,7 +262,251 @@ var _ = Describe("PCF Dev Client", func() {
 					gomock.Any(),
 				).Return(errors.New("some-error"))
 
-				Expect(client.ReplaceSecrets("some-ip", "some-master-password", []byte("some-private-key"))).To(MatchError("some-error"))
+				Expect(client.ReplaceSecrets([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, "some-master-password", []byte("some-private-key"))).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#Services", func() {
+		It("should return whether each service is enabled", func() {
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				switch r.URL.Path {
+				case "/services":
+					Expect(r.Method).To(Equal("GET"))
+					w.WriteHeader(200)
+					w.Write([]byte(`{"services":{"rabbitmq":true,"redis":false}}`))
+				default:
+					Fail("unexpected server request")
+				}
+			}
+
+			host := httptest.NewServer(http.HandlerFunc(handler)).URL
+
+			mockSSH.EXPECT().WithSSHTunnel(
+				fmt.Sprintf("127.0.0.1:%d", c.APIPort),
+				[]ssh.SSHAddress{{IP: "some-ip", Port: "22"}},
+				[]byte("some-private-key"),
+				time.Minute,
+				gomock.Any(),
+			).Do(func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, block func(string)) {
+				block(host)
+			})
+
+			services, err := client.Services([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(services).To(Equal(map[string]bool{"rabbitmq": true, "redis": false}))
+		})
+
+		Context("when there is invalid JSON", func() {
+			It("should return an error", func() {
+				handler := func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(200)
+					w.Write([]byte(`some-bad-json`))
+				}
+
+				host := httptest.NewServer(http.HandlerFunc(handler)).URL
+
+				mockSSH.EXPECT().WithSSHTunnel(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, block func(string)) {
+					block(host)
+				})
+
+				_, err := client.Services([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"))
+				Expect(err).To(MatchError(ContainSubstring("failed to parse JSON response:")))
+			})
+		})
+
+		Context("when the api returns an error", func() {
+			It("should return an error", func() {
+				handler := func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(500)
+				}
+
+				host := httptest.NewServer(http.HandlerFunc(handler)).URL
+
+				mockSSH.EXPECT().WithSSHTunnel(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, block func(string)) {
+					block(host)
+				})
+
+				_, err := client.Services([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"))
+				Expect(err).To(MatchError("failed to retrieve services: PCF Dev API returned: 500"))
+			})
+		})
+
+		Context("when there is an error establishing the SSH tunnel", func() {
+			It("should return the error", func() {
+				mockSSH.EXPECT().WithSSHTunnel(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some-error"))
+
+				_, err := client.Services([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"))
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#EnableService", func() {
+		It("should enable the service on the VM", func() {
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				switch r.URL.Path {
+				case "/services/redis/enable":
+					Expect(r.Method).To(Equal("PUT"))
+					w.WriteHeader(200)
+				default:
+					Fail("unexpected server request")
+				}
+			}
+
+			host := httptest.NewServer(http.HandlerFunc(handler)).URL
+
+			mockSSH.EXPECT().WithSSHTunnel(
+				fmt.Sprintf("127.0.0.1:%d", c.APIPort),
+				[]ssh.SSHAddress{{IP: "some-ip", Port: "22"}},
+				[]byte("some-private-key"),
+				time.Minute,
+				gomock.Any(),
+			).Do(func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, block func(string)) {
+				block(host)
+			})
+
+			Expect(client.EnableService([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"), "redis")).To(Succeed())
+		})
+
+		Context("when the service is unknown", func() {
+			It("should return an error", func() {
+				handler := func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(404)
+				}
+
+				host := httptest.NewServer(http.HandlerFunc(handler)).URL
+
+				mockSSH.EXPECT().WithSSHTunnel(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, block func(string)) {
+					block(host)
+				})
+
+				Expect(client.EnableService([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"), "some-service")).To(MatchError("failed to enable some-service: unknown service"))
+			})
+		})
+
+		Context("when there is no response from the api", func() {
+			It("should return an error", func() {
+				mockSSH.EXPECT().WithSSHTunnel(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, block func(string)) {
+					block("http://some-bad-host")
+				})
+
+				Expect(client.EnableService([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"), "redis")).To(MatchError(ContainSubstring("failed to talk to PCF Dev VM:")))
+			})
+		})
+	})
+
+	Describe("#DisableService", func() {
+		It("should disable the service on the VM", func() {
+			handler := func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				switch r.URL.Path {
+				case "/services/redis/disable":
+					Expect(r.Method).To(Equal("PUT"))
+					w.WriteHeader(200)
+				default:
+					Fail("unexpected server request")
+				}
+			}
+
+			host := httptest.NewServer(http.HandlerFunc(handler)).URL
+
+			mockSSH.EXPECT().WithSSHTunnel(
+				fmt.Sprintf("127.0.0.1:%d", c.APIPort),
+				[]ssh.SSHAddress{{IP: "some-ip", Port: "22"}},
+				[]byte("some-private-key"),
+				time.Minute,
+				gomock.Any(),
+			).Do(func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, block func(string)) {
+				block(host)
+			})
+
+			Expect(client.DisableService([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"), "redis")).To(Succeed())
+		})
+
+		Context("when the api returns an error", func() {
+			It("should return an error", func() {
+				handler := func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(500)
+				}
+
+				host := httptest.NewServer(http.HandlerFunc(handler)).URL
+
+				mockSSH.EXPECT().WithSSHTunnel(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, block func(string)) {
+					block(host)
+				})
+
+				Expect(client.DisableService([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"), "redis")).To(MatchError("failed to disable redis: PCF Dev API returned: 500"))
+			})
+		})
+
+		Context("when there is an error establishing the SSH tunnel", func() {
+			It("should return the error", func() {
+				mockSSH.EXPECT().WithSSHTunnel(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some-error"))
+
+				Expect(client.DisableService([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"), "redis")).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#ProbeHTTPS", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			client.Timeout = 5 * time.Second
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		expectTunnel := func() {
+			mockSSH.EXPECT().WithSSHTunnel(
+				"some-vm-ip:443",
+				[]ssh.SSHAddress{{IP: "some-ip", Port: "22"}},
+				[]byte("some-private-key"),
+				time.Minute,
+				gomock.Any(),
+			).Do(func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, block func(string)) {
+				block(server.URL)
+			})
+		}
+
+		It("should request the path from the host over https", func() {
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+
+				Expect(r.Host).To(Equal("api.some-domain"))
+				Expect(r.URL.Path).To(Equal("/v2/info"))
+				w.WriteHeader(200)
+			}))
+			expectTunnel()
+
+			Expect(client.ProbeHTTPS([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"), "some-vm-ip:443", "api.some-domain", "/v2/info")).To(Succeed())
+		})
+
+		Context("when the response is not OK", func() {
+			It("should return an error", func() {
+				server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(404)
+				}))
+				expectTunnel()
+
+				Expect(client.ProbeHTTPS([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"), "some-vm-ip:443", "api.some-domain", "/v2/info")).To(MatchError("https://api.some-domain/v2/info returned: 404"))
+			})
+		})
+
+		Context("when the tunnel fails", func() {
+			It("should return an error", func() {
+				server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+				mockSSH.EXPECT().WithSSHTunnel("some-vm-ip:443", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some-error"))
+
+				Expect(client.ProbeHTTPS([]ssh.SSHAddress{{IP: "some-ip", Port: "22"}}, []byte("some-private-key"), "some-vm-ip:443", "api.some-domain", "/v2/info")).To(MatchError("some-error"))
 			})
 		})
 	})
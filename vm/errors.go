@@ -1,6 +1,9 @@
 package vm
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type StartVMError struct {
 	Err error
@@ -42,6 +45,14 @@ func (e *ProvisionVMError) Error() string {
 	return fmt.Sprintf("failed to provision VM: %s", e.Err)
 }
 
+type ProvisionTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e *ProvisionTimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s, set PCFDEV_PROVISION_TIMEOUT to wait longer", e.Timeout)
+}
+
 type StopVMError struct {
 	Err error
 }
@@ -50,6 +61,32 @@ func (e *StopVMError) Error() string {
 	return fmt.Sprintf("failed to stop VM: %s", e.Err)
 }
 
+type ResizeVMError struct {
+	Err error
+}
+
+func (e *ResizeVMError) Error() string {
+	return fmt.Sprintf("failed to resize VM: %s", e.Err)
+}
+
+type ResizeDiskError struct {
+	Err error
+}
+
+func (e *ResizeDiskError) Error() string {
+	return fmt.Sprintf("failed to resize disk: %s", e.Err)
+}
+
+type MountError struct {
+	HostPath  string
+	GuestPath string
+	Err       error
+}
+
+func (e *MountError) Error() string {
+	return fmt.Sprintf("failed to mount %s at %s: %s", e.HostPath, e.GuestPath, e.Err)
+}
+
 type DestroyVMError struct {
 	Err error
 }
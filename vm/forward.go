@@ -0,0 +1,35 @@
+package vm
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
+)
+
+func forwardPorts(sshClient SSH, ui UI, vmConfig *config.VMConfig, privateKey []byte, forwards []ssh.Forward) error {
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: vmConfig.SSHPort},
+		{IP: vmConfig.IP, Port: "22"},
+	}
+
+	return sshClient.ForwardPorts(forwards, addresses, privateKey, 5*time.Minute, func(forward ssh.Forward, err error) {
+		ui.Say(fmt.Sprintf("Error forwarding %s to %s: %s", forward.LocalAddress, forward.RemoteAddress, err))
+	}, func() {
+		for _, forward := range forwards {
+			ui.Say(fmt.Sprintf("Forwarding %s to %s", forward.LocalAddress, forward.RemoteAddress))
+		}
+		ui.Say("Press Ctrl-C to stop.")
+		waitForInterrupt()
+	})
+}
+
+func waitForInterrupt() {
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+	<-interrupts
+}
@@ -1,6 +1,11 @@
 package vm
 
-import "errors"
+import (
+	"errors"
+	"time"
+
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
+)
 
 type Invalid struct {
 	Err error
@@ -26,6 +31,12 @@ func (i *Invalid) Status() string {
 	return i.message()
 }
 
+func (i *Invalid) StatusDetails() (*Status, error) {
+	status := newStatus(StateInvalid, nil)
+	status.Message = i.message()
+	return status, nil
+}
+
 func (i *Invalid) Suspend() error {
 	return i.err()
 }
@@ -50,6 +61,50 @@ func (i *Invalid) SSH() error {
 	return i.err()
 }
 
+func (i *Invalid) RunCommand(string) error {
+	return i.err()
+}
+
+func (i *Invalid) Forward([]ssh.Forward) error {
+	return i.err()
+}
+
+func (i *Invalid) Wait(time.Duration) error {
+	return i.err()
+}
+
+func (i *Invalid) Services() error {
+	return i.err()
+}
+
+func (i *Invalid) EnableService(string) error {
+	return i.err()
+}
+
+func (i *Invalid) DisableService(string) error {
+	return i.err()
+}
+
+func (i *Invalid) Resize(*ResizeOpts) error {
+	return i.err()
+}
+
+func (i *Invalid) ResizeDisk(uint64) error {
+	return i.err()
+}
+
+func (i *Invalid) Mount(string, string) error {
+	return i.err()
+}
+
+func (i *Invalid) Mounts() error {
+	return i.err()
+}
+
+func (i *Invalid) Copy(*CopyOpts) error {
+	return i.err()
+}
+
 func (i *Invalid) message() string {
 	return "PCF Dev is in an invalid state. Please run 'cf dev destroy'"
 }
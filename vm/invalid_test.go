@@ -2,7 +2,9 @@ package vm_test
 
 import (
 	"errors"
+	"time"
 
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
 
 	. "github.com/onsi/ginkgo"
@@ -44,6 +46,15 @@ var _ = Describe("Invalid", func() {
 		})
 	})
 
+	Describe("StatusDetails", func() {
+		It("should return the invalid status with a message", func() {
+			Expect(invalid.StatusDetails()).To(Equal(&vm.Status{
+				State:   "invalid",
+				Message: "PCF Dev is in an invalid state. Please run 'cf dev destroy'",
+			}))
+		})
+	})
+
 	Describe("Suspend", func() {
 		It("should say a message", func() {
 			Expect(invalid.Suspend()).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
@@ -79,4 +90,70 @@ var _ = Describe("Invalid", func() {
 			Expect(invalid.SSH()).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
 		})
 	})
+
+	Describe("Wait", func() {
+		It("should return an error", func() {
+			Expect(invalid.Wait(time.Minute)).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("Services", func() {
+		It("should return an error", func() {
+			Expect(invalid.Services()).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("EnableService", func() {
+		It("should return an error", func() {
+			Expect(invalid.EnableService("redis")).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("DisableService", func() {
+		It("should return an error", func() {
+			Expect(invalid.DisableService("redis")).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("Resize", func() {
+		It("should return an error", func() {
+			Expect(invalid.Resize(&vm.ResizeOpts{Memory: 6144})).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("ResizeDisk", func() {
+		It("should return an error", func() {
+			Expect(invalid.ResizeDisk(100)).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("Mount", func() {
+		It("should return an error", func() {
+			Expect(invalid.Mount("/some-host-path", "/some-guest-path")).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("Mounts", func() {
+		It("should return an error", func() {
+			Expect(invalid.Mounts()).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("RunCommand", func() {
+		It("should return an error", func() {
+			Expect(invalid.RunCommand("some-command")).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("Forward", func() {
+		It("should return an error", func() {
+			Expect(invalid.Forward([]ssh.Forward{})).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("Copy", func() {
+		It("should return an error", func() {
+			Expect(invalid.Copy(&vm.CopyOpts{})).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
 })
@@ -28,12 +28,12 @@ func (_m *MockCertStore) EXPECT() *_MockCertStoreRecorder {
 	return _m.recorder
 }
 
-func (_m *MockCertStore) Store(_param0 string) error {
-	ret := _m.ctrl.Call(_m, "Store", _param0)
+func (_m *MockCertStore) Store(_param0 string, _param1 []string) error {
+	ret := _m.ctrl.Call(_m, "Store", _param0, _param1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-func (_mr *_MockCertStoreRecorder) Store(arg0 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "Store", arg0)
+func (_mr *_MockCertStoreRecorder) Store(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Store", arg0, arg1)
 }
@@ -5,6 +5,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	ssh "github.com/pivotal-cf/pcfdev-cli/ssh"
 )
 
 // Mock of Client interface
@@ -28,7 +29,27 @@ func (_m *MockClient) EXPECT() *_MockClientRecorder {
 	return _m.recorder
 }
 
-func (_m *MockClient) ReplaceSecrets(_param0 string, _param1 string, _param2 []byte) error {
+func (_m *MockClient) DisableService(_param0 []ssh.SSHAddress, _param1 []byte, _param2 string) error {
+	ret := _m.ctrl.Call(_m, "DisableService", _param0, _param1, _param2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockClientRecorder) DisableService(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DisableService", arg0, arg1, arg2)
+}
+
+func (_m *MockClient) EnableService(_param0 []ssh.SSHAddress, _param1 []byte, _param2 string) error {
+	ret := _m.ctrl.Call(_m, "EnableService", _param0, _param1, _param2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockClientRecorder) EnableService(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "EnableService", arg0, arg1, arg2)
+}
+
+func (_m *MockClient) ReplaceSecrets(_param0 []ssh.SSHAddress, _param1 string, _param2 []byte) error {
 	ret := _m.ctrl.Call(_m, "ReplaceSecrets", _param0, _param1, _param2)
 	ret0, _ := ret[0].(error)
 	return ret0
@@ -38,7 +59,18 @@ func (_mr *_MockClientRecorder) ReplaceSecrets(arg0, arg1, arg2 interface{}) *go
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "ReplaceSecrets", arg0, arg1, arg2)
 }
 
-func (_m *MockClient) Status(_param0 string, _param1 []byte) (string, error) {
+func (_m *MockClient) Services(_param0 []ssh.SSHAddress, _param1 []byte) (map[string]bool, error) {
+	ret := _m.ctrl.Call(_m, "Services", _param0, _param1)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockClientRecorder) Services(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Services", arg0, arg1)
+}
+
+func (_m *MockClient) Status(_param0 []ssh.SSHAddress, _param1 []byte) (string, error) {
 	ret := _m.ctrl.Call(_m, "Status", _param0, _param1)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
@@ -31,6 +31,36 @@ func (_m *MockSSH) EXPECT() *_MockSSHRecorder {
 	return _m.recorder
 }
 
+func (_m *MockSSH) CopyFromGuest(_param0 string, _param1 string, _param2 []ssh.SSHAddress, _param3 []byte, _param4 time.Duration, _param5 io.Writer) error {
+	ret := _m.ctrl.Call(_m, "CopyFromGuest", _param0, _param1, _param2, _param3, _param4, _param5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockSSHRecorder) CopyFromGuest(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CopyFromGuest", arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+func (_m *MockSSH) CopyToGuest(_param0 string, _param1 string, _param2 []ssh.SSHAddress, _param3 []byte, _param4 time.Duration, _param5 io.Writer) error {
+	ret := _m.ctrl.Call(_m, "CopyToGuest", _param0, _param1, _param2, _param3, _param4, _param5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockSSHRecorder) CopyToGuest(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CopyToGuest", arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+func (_m *MockSSH) ForwardPorts(_param0 []ssh.Forward, _param1 []ssh.SSHAddress, _param2 []byte, _param3 time.Duration, _param4 func(forward ssh.Forward, err error), _param5 func()) error {
+	ret := _m.ctrl.Call(_m, "ForwardPorts", _param0, _param1, _param2, _param3, _param4, _param5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockSSHRecorder) ForwardPorts(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ForwardPorts", arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 func (_m *MockSSH) GenerateAddress() (string, string, error) {
 	ret := _m.ctrl.Call(_m, "GenerateAddress")
 	ret0, _ := ret[0].(string)
@@ -5,7 +5,9 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	ssh "github.com/pivotal-cf/pcfdev-cli/ssh"
 	vm "github.com/pivotal-cf/pcfdev-cli/vm"
+	time "time"
 )
 
 // Mock of VM interface
@@ -29,6 +31,46 @@ func (_m *MockVM) EXPECT() *_MockVMRecorder {
 	return _m.recorder
 }
 
+func (_m *MockVM) Copy(_param0 *vm.CopyOpts) error {
+	ret := _m.ctrl.Call(_m, "Copy", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) Copy(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Copy", arg0)
+}
+
+func (_m *MockVM) DisableService(_param0 string) error {
+	ret := _m.ctrl.Call(_m, "DisableService", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) DisableService(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DisableService", arg0)
+}
+
+func (_m *MockVM) EnableService(_param0 string) error {
+	ret := _m.ctrl.Call(_m, "EnableService", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) EnableService(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "EnableService", arg0)
+}
+
+func (_m *MockVM) Forward(_param0 []ssh.Forward) error {
+	ret := _m.ctrl.Call(_m, "Forward", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) Forward(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Forward", arg0)
+}
+
 func (_m *MockVM) GetDebugLogs() error {
 	ret := _m.ctrl.Call(_m, "GetDebugLogs")
 	ret0, _ := ret[0].(error)
@@ -39,6 +81,26 @@ func (_mr *_MockVMRecorder) GetDebugLogs() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetDebugLogs")
 }
 
+func (_m *MockVM) Mount(_param0 string, _param1 string) error {
+	ret := _m.ctrl.Call(_m, "Mount", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) Mount(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Mount", arg0, arg1)
+}
+
+func (_m *MockVM) Mounts() error {
+	ret := _m.ctrl.Call(_m, "Mounts")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) Mounts() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Mounts")
+}
+
 func (_m *MockVM) Provision(_param0 *vm.StartOpts) error {
 	ret := _m.ctrl.Call(_m, "Provision", _param0)
 	ret0, _ := ret[0].(error)
@@ -49,6 +111,26 @@ func (_mr *_MockVMRecorder) Provision(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Provision", arg0)
 }
 
+func (_m *MockVM) Resize(_param0 *vm.ResizeOpts) error {
+	ret := _m.ctrl.Call(_m, "Resize", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) Resize(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Resize", arg0)
+}
+
+func (_m *MockVM) ResizeDisk(_param0 uint64) error {
+	ret := _m.ctrl.Call(_m, "ResizeDisk", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) ResizeDisk(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ResizeDisk", arg0)
+}
+
 func (_m *MockVM) Resume() error {
 	ret := _m.ctrl.Call(_m, "Resume")
 	ret0, _ := ret[0].(error)
@@ -59,6 +141,26 @@ func (_mr *_MockVMRecorder) Resume() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Resume")
 }
 
+func (_m *MockVM) RunCommand(_param0 string) error {
+	ret := _m.ctrl.Call(_m, "RunCommand", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) RunCommand(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RunCommand", arg0)
+}
+
+func (_m *MockVM) Services() error {
+	ret := _m.ctrl.Call(_m, "Services")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) Services() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Services")
+}
+
 func (_m *MockVM) SSH() error {
 	ret := _m.ctrl.Call(_m, "SSH")
 	ret0, _ := ret[0].(error)
@@ -89,6 +191,17 @@ func (_mr *_MockVMRecorder) Status() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Status")
 }
 
+func (_m *MockVM) StatusDetails() (*vm.Status, error) {
+	ret := _m.ctrl.Call(_m, "StatusDetails")
+	ret0, _ := ret[0].(*vm.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVMRecorder) StatusDetails() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "StatusDetails")
+}
+
 func (_m *MockVM) Stop() error {
 	ret := _m.ctrl.Call(_m, "Stop")
 	ret0, _ := ret[0].(error)
@@ -138,3 +251,13 @@ func (_m *MockVM) VerifyStartOpts(_param0 *vm.StartOpts) error {
 func (_mr *_MockVMRecorder) VerifyStartOpts(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VerifyStartOpts", arg0)
 }
+
+func (_m *MockVM) Wait(_param0 time.Duration) error {
+	ret := _m.ctrl.Call(_m, "Wait", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) Wait(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Wait", arg0)
+}
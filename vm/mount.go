@@ -0,0 +1,40 @@
+package vm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
+)
+
+// mountFolder shares the host folder of mount with the running VM and
+// mounts it at its guest path.
+func mountFolder(provider Provider, sshClient SSH, vmConfig *config.VMConfig, privateKey []byte, mount *config.Mount) error {
+	if err := provider.ShareFolder(vmConfig, mount); err != nil {
+		return &MountError{mount.HostPath, mount.GuestPath, err}
+	}
+
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: vmConfig.SSHPort},
+		{IP: vmConfig.IP, Port: "22"},
+	}
+
+	command := fmt.Sprintf("sudo mkdir -p '%s' && sudo mount -t vboxsf -o uid=$(id -u),gid=$(id -g) %s '%s'", mount.GuestPath, mount.Name, mount.GuestPath)
+	if _, err := sshClient.GetSSHOutput(command, addresses, privateKey, 30*time.Second); err != nil {
+		return &MountError{mount.HostPath, mount.GuestPath, err}
+	}
+	return nil
+}
+
+func listMounts(ui UI, vmConfig *config.VMConfig) error {
+	if len(vmConfig.Mounts) == 0 {
+		ui.Say("No folders are mounted.")
+		return nil
+	}
+
+	for _, mount := range vmConfig.Mounts {
+		ui.Say(fmt.Sprintf("%s => %s", mount.HostPath, mount.GuestPath))
+	}
+	return nil
+}
@@ -5,13 +5,15 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/pivotal-cf/pcfdev-cli/address"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
 )
 
 type NotCreated struct {
-	VBox     VBox
+	Provider Provider
 	UI       UI
 	Builder  Builder
 	Config   *config.Config
@@ -39,32 +41,8 @@ func (n *NotCreated) VerifyStartOpts(opts *StartOpts) error {
 			return fmt.Errorf("no file found at %s", opts.OVAPath)
 		}
 	}
-	if opts.CPUs < 0 {
-		return errors.New("cannot start with less than one core")
-	}
-
-	if len(opts.Services) != 0 {
-		var disallowedServices []string
-
-		for _, service := range strings.Split(opts.Services, ",") {
-			switch service {
-			case "all", "none", "default", "redis", "rabbitmq", "mysql", "spring-cloud-services", "scs":
-			default:
-				disallowedServices = append(disallowedServices, service)
-			}
-		}
-
-		if len(disallowedServices) > 0 {
-			return fmt.Errorf("invalid services specified: %s", strings.Join(disallowedServices, ", "))
-		}
-	}
-
-	if opts.Registries != "" && strings.Count(opts.Registries, ":") != 1 {
-		return fmt.Errorf("docker registries must be passed in 'host:port' format")
-	}
-
-	if opts.IP == "" && opts.Domain != "" && !address.IsDomainAllowed(opts.Domain) {
-		return errors.New(fmt.Sprintf("%s is not one of the allowed PCF Dev domains", opts.Domain))
+	if err := opts.Verify(); err != nil {
+		return err
 	}
 
 	if opts.IP != "" {
@@ -149,7 +127,7 @@ func (n *NotCreated) Start(opts *StartOpts) error {
 
 	n.UI.Say(fmt.Sprintf("Allocating %d MB out of %d MB total system memory (%d MB free).", memory, n.Config.TotalMemory, n.Config.FreeMemory))
 	n.UI.Say("Importing VM...")
-	if err := n.VBox.ImportVM(&config.VMConfig{
+	if err := n.Provider.ImportVM(&config.VMConfig{
 		Name:    n.VMConfig.Name,
 		Memory:  memory,
 		CPUs:    cpus,
@@ -175,6 +153,10 @@ func (n *NotCreated) Status() string {
 	return "Not Created"
 }
 
+func (n *NotCreated) StatusDetails() (*Status, error) {
+	return newStatus(StateNotCreated, n.VMConfig), nil
+}
+
 func (n *NotCreated) Suspend() error {
 	n.UI.Say("No VM running, cannot suspend.")
 	return nil
@@ -204,3 +186,49 @@ func (n *NotCreated) SSH() error {
 	n.UI.Say("No VM created, cannot SSH to PCF Dev.")
 	return nil
 }
+
+func (n *NotCreated) RunCommand(string) error {
+	return errors.New("no VM created, cannot run commands on PCF Dev")
+}
+
+func (n *NotCreated) Forward([]ssh.Forward) error {
+	n.UI.Say("No VM created, cannot forward ports to PCF Dev.")
+	return nil
+}
+
+func (n *NotCreated) Wait(time.Duration) error {
+	return errors.New("no VM created, cannot wait for PCF Dev")
+}
+
+func (n *NotCreated) Services() error {
+	return errors.New("no VM created, cannot list PCF Dev services")
+}
+
+func (n *NotCreated) EnableService(string) error {
+	return errors.New("no VM created, cannot enable PCF Dev services")
+}
+
+func (n *NotCreated) DisableService(string) error {
+	return errors.New("no VM created, cannot disable PCF Dev services")
+}
+
+func (n *NotCreated) Resize(*ResizeOpts) error {
+	return errors.New("no VM created, use `cf dev start -m` and `-c` to choose the memory and cores of PCF Dev")
+}
+
+func (n *NotCreated) ResizeDisk(uint64) error {
+	return errors.New("no VM created, cannot resize the PCF Dev disk")
+}
+
+func (n *NotCreated) Mount(string, string) error {
+	return errors.New("no VM created, cannot mount folders in PCF Dev")
+}
+
+func (n *NotCreated) Mounts() error {
+	return errors.New("no VM created, cannot list mounted folders")
+}
+
+func (n *NotCreated) Copy(*CopyOpts) error {
+	n.UI.Say("No VM created, cannot copy files to or from PCF Dev.")
+	return nil
+}
@@ -3,9 +3,11 @@ package vm_test
 import (
 	"errors"
 	"path/filepath"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
 	"github.com/pivotal-cf/pcfdev-cli/user"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
 	"github.com/pivotal-cf/pcfdev-cli/vm/mocks"
@@ -18,7 +20,7 @@ var _ = Describe("Not Created", func() {
 	var (
 		mockCtrl     *gomock.Controller
 		mockUI       *mocks.MockUI
-		mockVBox     *mocks.MockVBox
+		mockProvider *mocks.MockProvider
 		mockBuilder  *mocks.MockBuilder
 		mockStopped  *mocks.MockVM
 		mockFS       *mocks.MockFS
@@ -30,7 +32,7 @@ var _ = Describe("Not Created", func() {
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockUI = mocks.NewMockUI(mockCtrl)
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockBuilder = mocks.NewMockBuilder(mockCtrl)
 		mockStopped = mocks.NewMockVM(mockCtrl)
 		mockFS = mocks.NewMockFS(mockCtrl)
@@ -44,12 +46,12 @@ var _ = Describe("Not Created", func() {
 				Name: "some-vm",
 			},
 
-			VBox:    mockVBox,
-			UI:      mockUI,
-			Builder: mockBuilder,
-			FS:      mockFS,
-			Config:  conf,
-			Network: mockNetwork,
+			Provider: mockProvider,
+			UI:       mockUI,
+			Builder:  mockBuilder,
+			FS:       mockFS,
+			Config:   conf,
+			Network:  mockNetwork,
 		}
 	})
 
@@ -139,25 +141,41 @@ var _ = Describe("Not Created", func() {
 				})
 			})
 
-			Context("when non-standard domain and no IP is passed", func() {
+			Context("when an invalid domain is passed", func() {
 				It("should return an error", func() {
 					Expect(notCreatedVM.VerifyStartOpts(&vm.StartOpts{
 						Domain: "some-bad-domain",
-					})).To(MatchError("some-bad-domain is not one of the allowed PCF Dev domains"))
+					})).To(MatchError("some-bad-domain is not a valid domain"))
+				})
+			})
+
+			Context("when non-standard domain and no IP is passed", func() {
+				It("should succeed", func() {
+					Expect(notCreatedVM.VerifyStartOpts(&vm.StartOpts{
+						Domain: "pcfdev.example.com",
+					})).To(Succeed())
 				})
 			})
 
 			Context("when non-standard domain and IP is passed", func() {
 				It("should succeed", func() {
-					mockNetwork.EXPECT().HasIPCollision("192.168.11.1").Return(false, nil)
+					mockNetwork.EXPECT().HasIPCollision("10.20.30.1").Return(false, nil)
 
 					Expect(notCreatedVM.VerifyStartOpts(&vm.StartOpts{
-						IP:     "192.168.11.11",
-						Domain: "some-non-standard-domain",
+						IP:     "10.20.30.11",
+						Domain: "pcfdev.example.com",
 					})).To(Succeed())
 				})
 			})
 
+			Context("when a public IP is passed", func() {
+				It("should return an error", func() {
+					Expect(notCreatedVM.VerifyStartOpts(&vm.StartOpts{
+						IP: "8.8.8.8",
+					})).To(MatchError("8.8.8.8 is not a private IP address, use one in 10.0.0.0/8, 172.16.0.0/12 or 192.168.0.0/16"))
+				})
+			})
+
 			Context("when a valid domain is passed", func() {
 				It("should succeed", func() {
 					Expect(notCreatedVM.VerifyStartOpts(&vm.StartOpts{
@@ -406,7 +424,7 @@ var _ = Describe("Not Created", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Allocating 4000 MB out of 8000 MB total system memory (5000 MB free)."),
 					mockUI.EXPECT().Say("Importing VM..."),
-					mockVBox.EXPECT().ImportVM(&config.VMConfig{
+					mockProvider.EXPECT().ImportVM(&config.VMConfig{
 						Name:    "some-vm",
 						Memory:  uint64(4000),
 						CPUs:    3,
@@ -434,7 +452,7 @@ var _ = Describe("Not Created", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Allocating 6000 MB out of 8000 MB total system memory (7000 MB free)."),
 					mockUI.EXPECT().Say("Importing VM..."),
-					mockVBox.EXPECT().ImportVM(&config.VMConfig{
+					mockProvider.EXPECT().ImportVM(&config.VMConfig{
 						Name:    "some-vm",
 						Memory:  uint64(6000),
 						CPUs:    3,
@@ -461,7 +479,7 @@ var _ = Describe("Not Created", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Allocating 6000 MB out of 8000 MB total system memory (7000 MB free)."),
 					mockUI.EXPECT().Say("Importing VM..."),
-					mockVBox.EXPECT().ImportVM(&config.VMConfig{
+					mockProvider.EXPECT().ImportVM(&config.VMConfig{
 						Name:    "some-vm",
 						Memory:  uint64(6000),
 						CPUs:    3,
@@ -488,7 +506,7 @@ var _ = Describe("Not Created", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Allocating 6000 MB out of 8000 MB total system memory (7000 MB free)."),
 					mockUI.EXPECT().Say("Importing VM..."),
-					mockVBox.EXPECT().ImportVM(&config.VMConfig{
+					mockProvider.EXPECT().ImportVM(&config.VMConfig{
 						Name:    "some-vm",
 						Memory:  uint64(6000),
 						CPUs:    3,
@@ -510,7 +528,7 @@ var _ = Describe("Not Created", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Allocating 3500 MB out of 8000 MB total system memory (5000 MB free)."),
 					mockUI.EXPECT().Say("Importing VM..."),
-					mockVBox.EXPECT().ImportVM(&config.VMConfig{
+					mockProvider.EXPECT().ImportVM(&config.VMConfig{
 						Name:    "some-vm",
 						Memory:  uint64(3500),
 						CPUs:    7,
@@ -541,7 +559,7 @@ var _ = Describe("Not Created", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Allocating 3072 MB out of 0 MB total system memory (0 MB free)."),
 					mockUI.EXPECT().Say("Importing VM..."),
-					mockVBox.EXPECT().ImportVM(&config.VMConfig{
+					mockProvider.EXPECT().ImportVM(&config.VMConfig{
 						Name:    "some-vm",
 						Memory:  uint64(3072),
 						OVAPath: filepath.Join("some-ova-dir", "some-vm.ova"),
@@ -560,7 +578,7 @@ var _ = Describe("Not Created", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Allocating 3072 MB out of 0 MB total system memory (0 MB free)."),
 					mockUI.EXPECT().Say("Importing VM..."),
-					mockVBox.EXPECT().ImportVM(&config.VMConfig{
+					mockProvider.EXPECT().ImportVM(&config.VMConfig{
 						Name:    "some-vm",
 						Memory:  uint64(3072),
 						OVAPath: filepath.Join("some-ova-dir", "some-vm.ova"),
@@ -583,7 +601,7 @@ var _ = Describe("Not Created", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Allocating 3072 MB out of 0 MB total system memory (0 MB free)."),
 					mockUI.EXPECT().Say("Importing VM..."),
-					mockVBox.EXPECT().ImportVM(&config.VMConfig{
+					mockProvider.EXPECT().ImportVM(&config.VMConfig{
 						Name:    "some-vm",
 						Memory:  uint64(3072),
 						OVAPath: filepath.Join("some-ova-dir", "some-vm.ova"),
@@ -604,6 +622,15 @@ var _ = Describe("Not Created", func() {
 		})
 	})
 
+	Describe("StatusDetails", func() {
+		It("should return the not created status", func() {
+			Expect(notCreatedVM.StatusDetails()).To(Equal(&vm.Status{
+				State:  "not_created",
+				VMName: "some-vm",
+			}))
+		})
+	})
+
 	Describe("Suspend", func() {
 		It("should say message", func() {
 			mockUI.EXPECT().Say("No VM running, cannot suspend.")
@@ -651,4 +678,73 @@ var _ = Describe("Not Created", func() {
 			Expect(notCreatedVM.SSH()).To(Succeed())
 		})
 	})
+
+	Describe("Wait", func() {
+		It("should return an error", func() {
+			Expect(notCreatedVM.Wait(time.Minute)).To(MatchError("no VM created, cannot wait for PCF Dev"))
+		})
+	})
+
+	Describe("Services", func() {
+		It("should return an error", func() {
+			Expect(notCreatedVM.Services()).To(MatchError("no VM created, cannot list PCF Dev services"))
+		})
+	})
+
+	Describe("EnableService", func() {
+		It("should return an error", func() {
+			Expect(notCreatedVM.EnableService("redis")).To(MatchError("no VM created, cannot enable PCF Dev services"))
+		})
+	})
+
+	Describe("DisableService", func() {
+		It("should return an error", func() {
+			Expect(notCreatedVM.DisableService("redis")).To(MatchError("no VM created, cannot disable PCF Dev services"))
+		})
+	})
+
+	Describe("Resize", func() {
+		It("should return an error", func() {
+			Expect(notCreatedVM.Resize(&vm.ResizeOpts{Memory: 6144})).To(MatchError("no VM created, use `cf dev start -m` and `-c` to choose the memory and cores of PCF Dev"))
+		})
+	})
+
+	Describe("ResizeDisk", func() {
+		It("should return an error", func() {
+			Expect(notCreatedVM.ResizeDisk(100)).To(MatchError("no VM created, cannot resize the PCF Dev disk"))
+		})
+	})
+
+	Describe("Mount", func() {
+		It("should return an error", func() {
+			Expect(notCreatedVM.Mount("/some-host-path", "/some-guest-path")).To(MatchError("no VM created, cannot mount folders in PCF Dev"))
+		})
+	})
+
+	Describe("Mounts", func() {
+		It("should return an error", func() {
+			Expect(notCreatedVM.Mounts()).To(MatchError("no VM created, cannot list mounted folders"))
+		})
+	})
+
+	Describe("RunCommand", func() {
+		It("should return an error", func() {
+			Expect(notCreatedVM.RunCommand("some-command")).To(MatchError("no VM created, cannot run commands on PCF Dev"))
+		})
+	})
+
+	Describe("Forward", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("No VM created, cannot forward ports to PCF Dev.")
+			Expect(notCreatedVM.Forward([]ssh.Forward{})).To(Succeed())
+		})
+	})
+
+	Describe("Copy", func() {
+		It("should say message", func() {
+			mockUI.EXPECT().Say("No VM created, cannot copy files to or from PCF Dev.")
+
+			Expect(notCreatedVM.Copy(&vm.CopyOpts{})).To(Succeed())
+		})
+	})
 })
@@ -13,9 +13,10 @@ type Paused struct {
 	Config   *config.Config
 
 	UI        UI
-	VBox      VBox
+	Provider  Provider
 	SSHClient SSH
 	FS        FS
+	Readiness Readiness
 }
 
 func (p *Paused) Stop() error {
@@ -54,6 +55,10 @@ func (p *Paused) Status() string {
 	return "Suspended - system memory for the VM is still allocated. Resume and suspend to suspend pcfdev VM to the disk."
 }
 
+func (p *Paused) StatusDetails() (*Status, error) {
+	return newStatus(StatePaused, p.VMConfig), nil
+}
+
 func (p *Paused) Suspend() error {
 	p.UI.Say("Your VM is suspended and system memory for the VM is still allocated. Resume and suspend to suspend pcfdev VM to the disk.")
 	return nil
@@ -61,7 +66,7 @@ func (p *Paused) Suspend() error {
 
 func (p *Paused) Resume() error {
 	p.UI.Say("Resuming VM...")
-	if err := p.VBox.ResumePausedVM(p.VMConfig); err != nil {
+	if err := p.Provider.ResumePausedVM(p.VMConfig); err != nil {
 		return &ResumeVMError{err}
 	}
 
@@ -78,6 +83,11 @@ func (p *Paused) Resume() error {
 		return &ResumeVMError{err}
 	}
 
+	p.UI.Say("Waiting for PCF Dev to be ready...")
+	if err := p.Readiness.Wait(p.VMConfig, privateKeyBytes, ReadinessTimeout); err != nil {
+		return &ResumeVMError{err}
+	}
+
 	p.UI.Say("PCF Dev is now running.")
 
 	return nil
@@ -102,3 +112,49 @@ func (p *Paused) SSH() error {
 	p.UI.Say("Your VM is suspended. Resume to SSH to PCF Dev.")
 	return nil
 }
+
+func (p *Paused) RunCommand(string) error {
+	return errors.New("your VM is suspended, resume to run commands on PCF Dev")
+}
+
+func (p *Paused) Forward([]ssh.Forward) error {
+	p.UI.Say("Your VM is suspended. Resume to forward ports to PCF Dev.")
+	return nil
+}
+
+func (p *Paused) Wait(time.Duration) error {
+	return errors.New("your VM is suspended, resume to wait for PCF Dev")
+}
+
+func (p *Paused) Services() error {
+	return errors.New("your VM is suspended, resume to list PCF Dev services")
+}
+
+func (p *Paused) EnableService(string) error {
+	return errors.New("your VM is suspended, resume to enable PCF Dev services")
+}
+
+func (p *Paused) DisableService(string) error {
+	return errors.New("your VM is suspended, resume to disable PCF Dev services")
+}
+
+func (p *Paused) Resize(*ResizeOpts) error {
+	return errors.New("your VM is suspended, resume to resize PCF Dev")
+}
+
+func (p *Paused) ResizeDisk(uint64) error {
+	return errors.New("your VM is suspended, resume to resize the PCF Dev disk")
+}
+
+func (p *Paused) Mount(string, string) error {
+	return errors.New("your VM is suspended, resume to mount folders in PCF Dev")
+}
+
+func (p *Paused) Mounts() error {
+	return listMounts(p.UI, p.VMConfig)
+}
+
+func (p *Paused) Copy(*CopyOpts) error {
+	p.UI.Say("Your VM is suspended. Resume to copy files to or from PCF Dev.")
+	return nil
+}
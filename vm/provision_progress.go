@@ -0,0 +1,189 @@
+package vm
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+type provisionPhase struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// provisionPhases are entered, in order, when /var/pcfdev/provision prints a
+// line matching their pattern. The first phase is entered when provisioning starts.
+var provisionPhases = []provisionPhase{
+	{name: "Configuring network"},
+	{name: "Starting services", pattern: regexp.MustCompile(`^Waiting for services to start`)},
+	{name: "Waiting for CF API", pattern: regexp.MustCompile(`^Services started`)},
+}
+
+var serviceCountRegex = regexp.MustCompile(`^\d+ out of \d+ running`)
+
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+const spinnerInterval = 100 * time.Millisecond
+
+// ProvisionProgress reads the output of /var/pcfdev/provision and reports
+// each phase with its elapsed time. When Spinner is set, the current phase
+// is redrawn there with a spinner instead of printing every status line.
+// Output written after Finish is dropped.
+type ProvisionProgress struct {
+	UI      UI
+	Spinner io.Writer
+	Now     func() time.Time
+
+	mutex      sync.Mutex
+	phase      int
+	detail     string
+	start      time.Time
+	phaseStart time.Time
+	buffer     []byte
+	frame      int
+	stop       chan struct{}
+	stopped    sync.WaitGroup
+	finished   bool
+}
+
+func (p *ProvisionProgress) Start() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.start = p.now()
+	p.enterPhase(0)
+
+	if p.Spinner != nil {
+		p.stop = make(chan struct{})
+		p.stopped.Add(1)
+		go p.spin()
+	}
+}
+
+func (p *ProvisionProgress) Write(data []byte) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.finished {
+		return len(data), nil
+	}
+
+	p.buffer = append(p.buffer, data...)
+	for {
+		index := strings.IndexByte(string(p.buffer), '\n')
+		if index < 0 {
+			break
+		}
+		line := strings.TrimRight(string(p.buffer[:index]), "\r")
+		p.buffer = p.buffer[index+1:]
+		p.handleLine(line)
+	}
+	return len(data), nil
+}
+
+// Finish stops the spinner and reports how the last phase ended.
+func (p *ProvisionProgress) Finish(succeeded bool) {
+	if p.stop != nil {
+		close(p.stop)
+		p.stopped.Wait()
+		p.stop = nil
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.finished = true
+	if len(p.buffer) > 0 {
+		p.handleLine(strings.TrimRight(string(p.buffer), "\r"))
+		p.buffer = nil
+	}
+
+	p.clearSpinner()
+	name := provisionPhases[p.phase].name
+	if !succeeded {
+		p.UI.Say(fmt.Sprintf("%s failed after %s.", name, p.elapsed(p.phaseStart)))
+		return
+	}
+	p.UI.Say(fmt.Sprintf("%s done (%s).", name, p.elapsed(p.phaseStart)))
+	p.UI.Say(fmt.Sprintf("Provisioned in %s.", p.elapsed(p.start)))
+}
+
+func (p *ProvisionProgress) handleLine(line string) {
+	for index := p.phase + 1; index < len(provisionPhases); index++ {
+		if provisionPhases[index].pattern.MatchString(line) {
+			p.clearSpinner()
+			p.UI.Say(fmt.Sprintf("%s done (%s).", provisionPhases[p.phase].name, p.elapsed(p.phaseStart)))
+			p.enterPhase(index)
+			return
+		}
+	}
+
+	if p.Spinner != nil && serviceCountRegex.MatchString(line) {
+		p.detail = strings.TrimSpace(line)
+		return
+	}
+
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+
+	p.clearSpinner()
+	p.UI.Say(line)
+}
+
+func (p *ProvisionProgress) enterPhase(index int) {
+	p.phase = index
+	p.detail = ""
+	p.phaseStart = p.now()
+	if p.Spinner == nil {
+		p.UI.Say(fmt.Sprintf("%s...", provisionPhases[index].name))
+	}
+}
+
+func (p *ProvisionProgress) spin() {
+	defer p.stopped.Done()
+
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	for {
+		p.mutex.Lock()
+		p.drawSpinner()
+		p.mutex.Unlock()
+
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *ProvisionProgress) drawSpinner() {
+	status := provisionPhases[p.phase].name
+	if p.detail != "" {
+		status = fmt.Sprintf("%s, %s", status, p.detail)
+	}
+	fmt.Fprintf(p.Spinner, "\r\033[K%s %s (%s)", spinnerFrames[p.frame%len(spinnerFrames)], status, p.elapsed(p.phaseStart))
+	p.frame++
+}
+
+func (p *ProvisionProgress) clearSpinner() {
+	if p.Spinner != nil {
+		fmt.Fprint(p.Spinner, "\r\033[K")
+	}
+}
+
+func (p *ProvisionProgress) elapsed(since time.Time) time.Duration {
+	return p.now().Sub(since) / time.Second * time.Second
+}
+
+func (p *ProvisionProgress) now() time.Time {
+	if p.Now != nil {
+		return p.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,105 @@
+package vm_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+	"github.com/pivotal-cf/pcfdev-cli/vm/mocks"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("ProvisionProgress", func() {
+	var (
+		mockCtrl *gomock.Controller
+		mockUI   *mocks.MockUI
+		progress *vm.ProvisionProgress
+		now      time.Time
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockUI = mocks.NewMockUI(mockCtrl)
+		now = time.Date(2016, 10, 5, 12, 0, 0, 0, time.UTC)
+		progress = &vm.ProvisionProgress{
+			UI:  mockUI,
+			Now: func() time.Time { return now },
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("should report each phase with its elapsed time", func() {
+		gomock.InOrder(
+			mockUI.EXPECT().Say("Configuring network..."),
+			mockUI.EXPECT().Say("Configuring network done (12s)."),
+			mockUI.EXPECT().Say("Starting services..."),
+			mockUI.EXPECT().Say("1 out of 2 running"),
+			mockUI.EXPECT().Say("2 out of 2 running"),
+			mockUI.EXPECT().Say("Starting services done (1m5s)."),
+			mockUI.EXPECT().Say("Waiting for CF API..."),
+			mockUI.EXPECT().Say("some-banner"),
+			mockUI.EXPECT().Say("Waiting for CF API done (3s)."),
+			mockUI.EXPECT().Say("Provisioned in 1m20s."),
+		)
+
+		progress.Start()
+		now = now.Add(12 * time.Second)
+		fmt.Fprint(progress, "Waiting for services to start...\n1 out of 2 ")
+		fmt.Fprint(progress, "running\n2 out of 2 running\n\n")
+		now = now.Add(65 * time.Second)
+		fmt.Fprint(progress, "Services started\nsome-banner")
+		now = now.Add(3 * time.Second)
+		progress.Finish(true)
+	})
+
+	Context("when provisioning fails", func() {
+		It("should report the phase that failed", func() {
+			gomock.InOrder(
+				mockUI.EXPECT().Say("Configuring network..."),
+				mockUI.EXPECT().Say("Configuring network failed after 30s."),
+			)
+
+			progress.Start()
+			now = now.Add(30 * time.Second)
+			progress.Finish(false)
+		})
+		Context("when the provision command keeps writing after it timed out", func() {
+			It("should drop the output", func() {
+				gomock.InOrder(
+					mockUI.EXPECT().Say("Configuring network..."),
+					mockUI.EXPECT().Say("Configuring network failed after 30s."),
+				)
+
+				progress.Start()
+				now = now.Add(30 * time.Second)
+				progress.Finish(false)
+				fmt.Fprint(progress, "Waiting for services to start...\nsome-late-line\n")
+			})
+		})
+	})
+
+	Context("when a spinner is used", func() {
+		It("should show the phase and service count on the spinner instead of printing them", func() {
+			spinner := gbytes.NewBuffer()
+			progress.Spinner = spinner
+
+			gomock.InOrder(
+				mockUI.EXPECT().Say("Configuring network done (0s)."),
+				mockUI.EXPECT().Say("Starting services done (0s)."),
+				mockUI.EXPECT().Say("Provisioned in 0s."),
+			)
+
+			progress.Start()
+			fmt.Fprint(progress, "Waiting for services to start...\n1 out of 2 running\n")
+			Eventually(spinner).Should(gbytes.Say(`Starting services, 1 out of 2 running \(0s\)`))
+			progress.Finish(true)
+		})
+	})
+})
@@ -0,0 +1,49 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pivotal-cf/pcfdev-cli/config"
+)
+
+// resizedVMConfig returns a copy of vmConfig with the memory and CPUs in opts,
+// keeping the current value of either one that is not set.
+func resizedVMConfig(vmConfig *config.VMConfig, opts *ResizeOpts) *config.VMConfig {
+	resized := *vmConfig
+	if opts.Memory != uint64(0) {
+		resized.Memory = opts.Memory
+	}
+	if opts.CPUs != 0 {
+		resized.CPUs = opts.CPUs
+	}
+	return &resized
+}
+
+// verifyResize checks the resized VM against the minimum memory for its
+// services, which may use the service aliases, and the free memory on the
+// host. inUse is the memory the VM already holds, which is not counted in
+// conf.FreeMemory while it runs.
+func verifyResize(conf *config.Config, ui UI, resized *config.VMConfig, services []string, inUse uint64) error {
+	expanded := expandServices(strings.Join(services, ","))
+	if containsService(expanded, "spring-cloud-services") && resized.Memory < conf.SpringCloudMinMemory {
+		return fmt.Errorf("PCF Dev requires at least %d MB of memory to run Spring Cloud Services", conf.SpringCloudMinMemory)
+	}
+	if resized.Memory < conf.MinMemory {
+		return fmt.Errorf("PCF Dev requires at least %d MB of memory to run", conf.MinMemory)
+	}
+
+	if resized.Memory > conf.FreeMemory+inUse {
+		if !ui.Confirm(fmt.Sprintf("Less than %d MB of free memory detected, continue (y/N): ", resized.Memory)) {
+			return errors.New("user declined to continue, exiting")
+		}
+	}
+	return nil
+}
+
+// growFilesystemCommand grows the root partition and its filesystem
+// to fill a disk that was resized while the VM was stopped. growpart
+// exits 1 when the partition already fills the disk, which is not a
+// failure; any other non-zero exit stops before resize2fs.
+const growFilesystemCommand = "{ sudo growpart /dev/sda 1 || [ $? -eq 1 ]; } && sudo resize2fs /dev/sda1"
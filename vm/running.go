@@ -3,6 +3,9 @@ package vm
 import (
 	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/pkg/term"
@@ -15,7 +18,7 @@ type Running struct {
 	Config   *config.Config
 	VMConfig *config.VMConfig
 
-	VBox       VBox
+	Provider   Provider
 	FS         FS
 	UI         UI
 	SSHClient  SSH
@@ -24,11 +27,13 @@ type Running struct {
 	CertStore  CertStore
 	CmdRunner  CmdRunner
 	HelpText   HelpText
+	Readiness  Readiness
+	Client     Client
 }
 
 func (r *Running) Stop() error {
 	r.UI.Say("Stopping VM...")
-	err := r.VBox.StopVM(r.VMConfig)
+	err := r.Provider.StopVM(r.VMConfig)
 	if err != nil {
 		return &StopVMError{err}
 	}
@@ -65,13 +70,13 @@ func (r *Running) Provision(opts *StartOpts) error {
 
 func (r *Running) VerifyStartOpts(opts *StartOpts) error {
 	if opts.Memory != uint64(0) {
-		return errors.New("memory cannot be changed once the vm has been created")
+		return errors.New("memory cannot be changed with the -m flag once the vm has been created, use `cf dev resize -m`")
 	}
 	if opts.CPUs != 0 {
-		return errors.New("cores cannot be changed once the vm has been created")
+		return errors.New("cores cannot be changed with the -c flag once the vm has been created, use `cf dev resize -c`")
 	}
 	if opts.Services != "" {
-		return errors.New("services cannot be changed once the vm has been created")
+		return errors.New("services cannot be changed with the -s flag once the vm has been created, use `cf dev services enable` or `cf dev services disable`")
 	}
 	if opts.Domain != "" {
 		return errors.New("the -d flag cannot be used if the VM has already been created")
@@ -91,9 +96,42 @@ func (r *Running) Status() string {
 	return fmt.Sprintf("Running\nCLI Login: cf login -a https://api.%s --skip-ssl-validation\nApps Manager URL: https://%s\nAdmin user => Email: admin / Password: admin\nRegular user => Email: user / Password: pass", r.VMConfig.Domain, r.VMConfig.Domain)
 }
 
+// StatusDetails returns the running status. When the services or the disk
+// usage cannot be read from the VM, they are left out and the reason is
+// given in the message instead.
+func (r *Running) StatusDetails() (*Status, error) {
+	status := newStatus(StateRunning, r.VMConfig)
+	if err := r.queryStatusDetails(status); err != nil {
+		status.Message = "failed to query the VM: " + err.Error()
+	}
+	return status, nil
+}
+
+func (r *Running) queryStatusDetails(status *Status) error {
+	privateKeyBytes, err := r.FS.Read(r.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	services, err := provisionedServices(r.SSHClient, r.VMConfig, privateKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	diskSize, diskUsed, err := diskUsage(r.SSHClient, r.VMConfig, privateKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	status.Services = services
+	status.DiskSize = diskSize
+	status.DiskUsed = diskUsed
+	return nil
+}
+
 func (r *Running) Suspend() error {
 	r.UI.Say("Suspending VM...")
-	if err := r.VBox.SuspendVM(r.VMConfig); err != nil {
+	if err := r.Provider.SuspendVM(r.VMConfig); err != nil {
 		return &SuspendVMError{err}
 	}
 
@@ -134,10 +172,14 @@ func (r *Running) Trust(startOpts *StartOpts) error {
 		return nil
 	}
 
-	if err := r.CertStore.Store(output); err != nil {
+	if err := r.CertStore.Store(output, startOpts.TrustStores); err != nil {
 		return &TrustError{err}
 	}
 
+	if len(startOpts.TrustStores) > 0 {
+		r.UI.Say(fmt.Sprintf("***Warning: a self-signed certificate for *.%s has been inserted into these certificate stores: %s. To remove this certificate, run: cf dev untrust***", r.VMConfig.Domain, strings.Join(startOpts.TrustStores, ", ")))
+		return nil
+	}
 	r.UI.Say(fmt.Sprintf("***Warning: a self-signed certificate for *.%s has been inserted into your OS certificate store. To remove this certificate, run: cf dev untrust***", r.VMConfig.Domain))
 
 	return nil
@@ -187,3 +229,320 @@ func (r *Running) SSH() error {
 	stdin, stdout, stderr := term.StdStreams()
 	return r.SSHClient.StartSSHSession(addresses, privateKeyBytes, 5*time.Minute, stdin, stdout, stderr)
 }
+
+func (r *Running) RunCommand(command string) error {
+	privateKeyBytes, err := r.FS.Read(r.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: r.VMConfig.SSHPort},
+		{IP: r.VMConfig.IP, Port: "22"},
+	}
+
+	_, stdout, stderr := term.StdStreams()
+	return r.SSHClient.RunSSHCommand(command, addresses, privateKeyBytes, 5*time.Minute, stdout, stderr)
+}
+
+func (r *Running) Forward(forwards []ssh.Forward) error {
+	privateKeyBytes, err := r.FS.Read(r.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	return forwardPorts(r.SSHClient, r.UI, r.VMConfig, privateKeyBytes, forwards)
+}
+
+func (r *Running) Copy(opts *CopyOpts) error {
+	privateKeyBytes, err := r.FS.Read(r.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: r.VMConfig.SSHPort},
+		{IP: r.VMConfig.IP, Port: "22"},
+	}
+
+	if opts.ToGuest {
+		return r.SSHClient.CopyToGuest(opts.HostPath, opts.GuestPath, addresses, privateKeyBytes, 5*time.Minute, os.Stdout)
+	}
+	return r.SSHClient.CopyFromGuest(opts.GuestPath, opts.HostPath, addresses, privateKeyBytes, 5*time.Minute, os.Stdout)
+}
+
+func (r *Running) Wait(timeout time.Duration) error {
+	privateKeyBytes, err := r.FS.Read(r.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	r.UI.Say("Waiting for PCF Dev to be ready...")
+	if err := r.Readiness.Wait(r.VMConfig, privateKeyBytes, timeout); err != nil {
+		return err
+	}
+
+	r.UI.Say("PCF Dev is ready.")
+	return nil
+}
+
+func (r *Running) Services() error {
+	privateKeyBytes, err := r.FS.Read(r.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: r.VMConfig.SSHPort},
+		{IP: r.VMConfig.IP, Port: "22"},
+	}
+
+	services, err := r.Client.Services(addresses, privateKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	names := []string{}
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		state := "disabled"
+		if services[name] {
+			state = "enabled"
+		}
+		r.UI.Say(fmt.Sprintf("%-24s %s", name, state))
+	}
+	r.UI.Say(fmt.Sprintf("%-24s %s", "mysql", "always enabled"))
+	return nil
+}
+
+func (r *Running) EnableService(name string) error {
+	if name == "spring-cloud-services" && r.VMConfig.Memory < r.Config.SpringCloudMinMemory {
+		return fmt.Errorf("PCF Dev requires at least %d MB of memory to run Spring Cloud Services", r.Config.SpringCloudMinMemory)
+	}
+
+	privateKeyBytes, err := r.FS.Read(r.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: r.VMConfig.SSHPort},
+		{IP: r.VMConfig.IP, Port: "22"},
+	}
+
+	r.UI.Say(fmt.Sprintf("Enabling %s...", name))
+	if err := r.Client.EnableService(addresses, privateKeyBytes, name); err != nil {
+		return err
+	}
+
+	if err := r.saveService(privateKeyBytes, name, true); err != nil {
+		return err
+	}
+
+	r.UI.Say(fmt.Sprintf("%s is now enabled.", name))
+	return nil
+}
+
+func (r *Running) DisableService(name string) error {
+	privateKeyBytes, err := r.FS.Read(r.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: r.VMConfig.SSHPort},
+		{IP: r.VMConfig.IP, Port: "22"},
+	}
+
+	r.UI.Say(fmt.Sprintf("Disabling %s...", name))
+	if err := r.Client.DisableService(addresses, privateKeyBytes, name); err != nil {
+		return err
+	}
+
+	if err := r.saveService(privateKeyBytes, name, false); err != nil {
+		return err
+	}
+
+	r.UI.Say(fmt.Sprintf("%s is now disabled.", name))
+	return nil
+}
+
+// saveService records an enabled or disabled service in the provision
+// options so that the next provision of the VM keeps the change. The saved
+// services and name may use the all, default and scs aliases, which are
+// expanded first.
+func (r *Running) saveService(privateKeyBytes []byte, name string, enabled bool) error {
+	provisionConfig, err := readProvisionConfig(r.SSHClient, r.VMConfig, privateKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	changed := expandServices(name)
+	if !enabled && containsService(changed, "spring-cloud-services") {
+		changed = []string{"spring-cloud-services"}
+	}
+
+	services := []string{}
+	for _, service := range expandServices(provisionConfig.Services) {
+		if !containsService(changed, service) {
+			services = append(services, service)
+		}
+	}
+	if enabled {
+		services = append(services, changed...)
+	}
+	sort.Strings(services)
+	provisionConfig.Services = strings.Join(services, ",")
+
+	r.VMConfig.Services = services
+	if err := r.Provider.SaveVMConfig(r.VMConfig); err != nil {
+		return err
+	}
+
+	return writeProvisionConfig(r.SSHClient, r.VMConfig, privateKeyBytes, provisionConfig)
+}
+
+func (r *Running) Resize(opts *ResizeOpts) error {
+	privateKeyBytes, err := r.FS.Read(r.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	provisionConfig, err := readProvisionConfig(r.SSHClient, r.VMConfig, privateKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	services := []string{}
+	if provisionConfig.Services != "" {
+		services = strings.Split(provisionConfig.Services, ",")
+	}
+
+	resized := resizedVMConfig(r.VMConfig, opts)
+	if err := verifyResize(r.Config, r.UI, resized, services, r.VMConfig.Memory); err != nil {
+		return err
+	}
+
+	if !r.UI.Confirm("PCF Dev must be stopped to resize the VM, continue (y/N): ") {
+		return errors.New("user declined to continue, exiting")
+	}
+
+	if err := r.Stop(); err != nil {
+		return err
+	}
+
+	if err := r.Provider.ResizeVM(resized); err != nil {
+		return &ResizeVMError{err}
+	}
+	r.UI.Say(fmt.Sprintf("PCF Dev VM now has %d MB of memory and %d cores.", resized.Memory, resized.CPUs))
+
+	return r.restart(provisionConfig)
+}
+
+func (r *Running) ResizeDisk(sizeInGB uint64) error {
+	privateKeyBytes, err := r.FS.Read(r.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	currentSize, err := r.Provider.DiskSize(r.VMConfig)
+	if err != nil {
+		return err
+	}
+	if sizeInGB*1024 <= currentSize {
+		return fmt.Errorf("the PCF Dev disk is already %d GB, it can only be grown", currentSize/1024)
+	}
+
+	provisionConfig, err := readProvisionConfig(r.SSHClient, r.VMConfig, privateKeyBytes)
+	if err != nil {
+		return err
+	}
+
+	if !r.UI.Confirm("PCF Dev must be stopped to resize the disk, continue (y/N): ") {
+		return errors.New("user declined to continue, exiting")
+	}
+
+	if err := r.Stop(); err != nil {
+		return err
+	}
+
+	r.UI.Say("Resizing disk...")
+	if err := r.Provider.ResizeDisk(r.VMConfig, sizeInGB*1024); err != nil {
+		return &ResizeDiskError{err}
+	}
+
+	if err := r.restart(provisionConfig); err != nil {
+		return err
+	}
+
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: r.VMConfig.SSHPort},
+		{IP: r.VMConfig.IP, Port: "22"},
+	}
+
+	r.UI.Say("Growing filesystem...")
+	if _, err := r.SSHClient.GetSSHOutput(growFilesystemCommand, addresses, privateKeyBytes, 5*time.Minute); err != nil {
+		return &ResizeDiskError{err}
+	}
+
+	r.UI.Say(fmt.Sprintf("PCF Dev disk is now %d GB.", sizeInGB))
+	return nil
+}
+
+func (r *Running) Mount(hostPath string, guestPath string) error {
+	for _, mount := range r.VMConfig.Mounts {
+		if mount.GuestPath == guestPath {
+			return fmt.Errorf("%s is already mounted", guestPath)
+		}
+	}
+
+	privateKeyBytes, err := r.FS.Read(r.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	mount := &config.Mount{
+		Name:      fmt.Sprintf("pcfdev-mount-%d", len(r.VMConfig.Mounts)+1),
+		HostPath:  hostPath,
+		GuestPath: guestPath,
+	}
+
+	r.UI.Say(fmt.Sprintf("Mounting %s at %s...", hostPath, guestPath))
+	if err := mountFolder(r.Provider, r.SSHClient, r.VMConfig, privateKeyBytes, mount); err != nil {
+		return err
+	}
+
+	r.VMConfig.Mounts = append(r.VMConfig.Mounts, mount)
+	if err := r.Provider.SaveVMConfig(r.VMConfig); err != nil {
+		return err
+	}
+
+	r.UI.Say(fmt.Sprintf("%s is now mounted at %s.", hostPath, guestPath))
+	return nil
+}
+
+func (r *Running) Mounts() error {
+	return listMounts(r.UI, r.VMConfig)
+}
+
+// restart starts the stopped VM again with the services and registries
+// it was provisioned with.
+func (r *Running) restart(provisionConfig *config.ProvisionConfig) error {
+	stoppedVM, err := r.Builder.VM(r.VMConfig.Name)
+	if err != nil {
+		return err
+	}
+
+	startServices := provisionConfig.Services
+	if startServices == "" {
+		startServices = "none"
+	}
+	return stoppedVM.Start(&StartOpts{
+		Services:   startServices,
+		Registries: strings.Join(provisionConfig.Registries, ","),
+	})
+}
@@ -2,6 +2,7 @@ package vm_test
 
 import (
 	"errors"
+	"os"
 	"time"
 
 	"github.com/golang/mock/gomock"
@@ -20,13 +21,15 @@ var _ = Describe("Running", func() {
 		mockCtrl       *gomock.Controller
 		mockFS         *mocks.MockFS
 		mockUI         *mocks.MockUI
-		mockVBox       *mocks.MockVBox
+		mockProvider   *mocks.MockProvider
 		mockBuilder    *mocks.MockBuilder
 		mockSSH        *mocks.MockSSH
 		mockVM         *mocks.MockVM
 		mockLogFetcher *mocks.MockLogFetcher
 		mockCertStore  *mocks.MockCertStore
 		mockCmdRunner  *mocks.MockCmdRunner
+		mockReadiness  *mocks.MockReadiness
+		mockClient     *mocks.MockClient
 
 		runningVM vm.Running
 		config    *conf.VMConfig
@@ -36,13 +39,15 @@ var _ = Describe("Running", func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockFS = mocks.NewMockFS(mockCtrl)
 		mockUI = mocks.NewMockUI(mockCtrl)
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockSSH = mocks.NewMockSSH(mockCtrl)
 		mockVM = mocks.NewMockVM(mockCtrl)
 		mockBuilder = mocks.NewMockBuilder(mockCtrl)
 		mockLogFetcher = mocks.NewMockLogFetcher(mockCtrl)
 		mockCertStore = mocks.NewMockCertStore(mockCtrl)
 		mockCmdRunner = mocks.NewMockCmdRunner(mockCtrl)
+		mockReadiness = mocks.NewMockReadiness(mockCtrl)
+		mockClient = mocks.NewMockClient(mockCtrl)
 		config = &conf.VMConfig{}
 
 		runningVM = vm.Running{
@@ -56,7 +61,7 @@ var _ = Describe("Running", func() {
 				PrivateKeyPath: "some-private-key-path",
 			},
 
-			VBox:       mockVBox,
+			Provider:   mockProvider,
 			FS:         mockFS,
 			UI:         mockUI,
 			Builder:    mockBuilder,
@@ -64,6 +69,8 @@ var _ = Describe("Running", func() {
 			LogFetcher: mockLogFetcher,
 			CertStore:  mockCertStore,
 			CmdRunner:  mockCmdRunner,
+			Readiness:  mockReadiness,
+			Client:     mockClient,
 		}
 	})
 
@@ -75,7 +82,7 @@ var _ = Describe("Running", func() {
 		It("should stop the vm", func() {
 			gomock.InOrder(
 				mockUI.EXPECT().Say("Stopping VM..."),
-				mockVBox.EXPECT().StopVM(runningVM.VMConfig),
+				mockProvider.EXPECT().StopVM(runningVM.VMConfig),
 				mockUI.EXPECT().Say("PCF Dev is now stopped."),
 			)
 
@@ -86,7 +93,7 @@ var _ = Describe("Running", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Stopping VM..."),
-					mockVBox.EXPECT().StopVM(runningVM.VMConfig).Return(errors.New("some-error")),
+					mockProvider.EXPECT().StopVM(runningVM.VMConfig).Return(errors.New("some-error")),
 				)
 
 				Expect(runningVM.Stop()).To(MatchError("failed to stop VM: some-error"))
@@ -99,7 +106,7 @@ var _ = Describe("Running", func() {
 			It("should return an error", func() {
 				Expect(runningVM.VerifyStartOpts(&vm.StartOpts{
 					Memory: 4000,
-				})).To(MatchError("memory cannot be changed once the vm has been created"))
+				})).To(MatchError("memory cannot be changed with the -m flag once the vm has been created, use `cf dev resize -m`"))
 			})
 		})
 
@@ -107,7 +114,7 @@ var _ = Describe("Running", func() {
 			It("should return an error", func() {
 				Expect(runningVM.VerifyStartOpts(&vm.StartOpts{
 					CPUs: 2,
-				})).To(MatchError("cores cannot be changed once the vm has been created"))
+				})).To(MatchError("cores cannot be changed with the -c flag once the vm has been created, use `cf dev resize -c`"))
 			})
 		})
 
@@ -121,7 +128,7 @@ var _ = Describe("Running", func() {
 			It("should return an error", func() {
 				Expect(runningVM.VerifyStartOpts(&vm.StartOpts{
 					Services: "redis",
-				})).To(MatchError("services cannot be changed once the vm has been created"))
+				})).To(MatchError("services cannot be changed with the -s flag once the vm has been created, use `cf dev services enable` or `cf dev services disable`"))
 			})
 		})
 
@@ -230,10 +237,96 @@ var _ = Describe("Running", func() {
 		})
 	})
 
+	Describe("StatusDetails", func() {
+		var sshAddresses []ssh.SSHAddress
+
+		BeforeEach(func() {
+			sshAddresses = []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+		})
+
+		It("should return the running status with provisioned services", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":"rabbitmq,redis"}`, nil),
+				mockSSH.EXPECT().GetSSHOutput("df -m / | tail -n 1", sshAddresses, []byte("some-private-key"), 30*time.Second).Return("/dev/sda1          51175 12288     36325  26% /\n", nil),
+			)
+
+			Expect(runningVM.StatusDetails()).To(Equal(&vm.Status{
+				State:    "running",
+				VMName:   "some-vm",
+				IP:       "some-ip",
+				Domain:   "some-domain",
+				SSHPort:  "some-port",
+				APIURL:   "https://api.some-domain",
+				Services: []string{"rabbitmq", "redis"},
+				DiskSize: 51175,
+				DiskUsed: 12288,
+			}))
+		})
+
+		Context("when reading the private key fails", func() {
+			It("should return the status without the services and disk usage", func() {
+				mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error"))
+
+				Expect(runningVM.StatusDetails()).To(Equal(&vm.Status{
+					State:   "running",
+					VMName:  "some-vm",
+					IP:      "some-ip",
+					Domain:  "some-domain",
+					SSHPort: "some-port",
+					APIURL:  "https://api.some-domain",
+					Message: "failed to query the VM: some-error",
+				}))
+			})
+		})
+
+		Context("when reading the provision options fails", func() {
+			It("should return the status without the services and disk usage", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return("", errors.New("some-error")),
+				)
+
+				Expect(runningVM.StatusDetails()).To(Equal(&vm.Status{
+					State:   "running",
+					VMName:  "some-vm",
+					IP:      "some-ip",
+					Domain:  "some-domain",
+					SSHPort: "some-port",
+					APIURL:  "https://api.some-domain",
+					Message: "failed to query the VM: some-error",
+				}))
+			})
+		})
+
+		Context("when the disk usage cannot be parsed", func() {
+			It("should return the status without the services and disk usage", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":"redis"}`, nil),
+					mockSSH.EXPECT().GetSSHOutput("df -m / | tail -n 1", sshAddresses, []byte("some-private-key"), 30*time.Second).Return("some-bad-output", nil),
+				)
+
+				Expect(runningVM.StatusDetails()).To(Equal(&vm.Status{
+					State:   "running",
+					VMName:  "some-vm",
+					IP:      "some-ip",
+					Domain:  "some-domain",
+					SSHPort: "some-port",
+					APIURL:  "https://api.some-domain",
+					Message: "failed to query the VM: failed to parse disk usage: some-bad-output",
+				}))
+			})
+		})
+	})
+
 	Describe("Suspend", func() {
 		It("should suspend the vm", func() {
 			mockUI.EXPECT().Say("Suspending VM...")
-			mockVBox.EXPECT().SuspendVM(runningVM.VMConfig)
+			mockProvider.EXPECT().SuspendVM(runningVM.VMConfig)
 			mockUI.EXPECT().Say("PCF Dev is now suspended.")
 
 			Expect(runningVM.Suspend()).To(Succeed())
@@ -242,7 +335,7 @@ var _ = Describe("Running", func() {
 		Context("when suspending the vm fails", func() {
 			It("should return an error", func() {
 				mockUI.EXPECT().Say("Suspending VM...")
-				mockVBox.EXPECT().SuspendVM(runningVM.VMConfig).Return(errors.New("some-error"))
+				mockProvider.EXPECT().SuspendVM(runningVM.VMConfig).Return(errors.New("some-error"))
 
 				Expect(runningVM.Suspend()).To(MatchError("failed to suspend VM: some-error"))
 			})
@@ -285,13 +378,30 @@ var _ = Describe("Running", func() {
 			gomock.InOrder(
 				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 				mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/openssl/ca_cert.pem", sshAddresses, []byte("some-private-key"), 5*time.Minute).Return("some-cert", nil),
-				mockCertStore.EXPECT().Store("some-cert"),
+				mockCertStore.EXPECT().Store("some-cert", nil),
 				mockUI.EXPECT().Say("***Warning: a self-signed certificate for *.some-domain has been inserted into your OS certificate store. To remove this certificate, run: cf dev untrust***"),
 			)
 
 			Expect(runningVM.Trust(&vm.StartOpts{})).To(Succeed())
 		})
 
+		Context("when certificate stores are given", func() {
+			It("should trust VM certificates in those stores", func() {
+				sshAddresses := []ssh.SSHAddress{
+					{IP: "127.0.0.1", Port: "some-port"},
+					{IP: "some-ip", Port: "22"},
+				}
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/openssl/ca_cert.pem", sshAddresses, []byte("some-private-key"), 5*time.Minute).Return("some-cert", nil),
+					mockCertStore.EXPECT().Store("some-cert", []string{"system", "nss"}),
+					mockUI.EXPECT().Say("***Warning: a self-signed certificate for *.some-domain has been inserted into these certificate stores: system, nss. To remove this certificate, run: cf dev untrust***"),
+				)
+
+				Expect(runningVM.Trust(&vm.StartOpts{TrustStores: []string{"system", "nss"}})).To(Succeed())
+			})
+		})
+
 		Context("when there is an error reading the private key", func() {
 			It("should return the error", func() {
 				mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error"))
@@ -324,7 +434,7 @@ var _ = Describe("Running", func() {
 				gomock.InOrder(
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/openssl/ca_cert.pem", sshAddresses, []byte("some-private-key"), 5*time.Minute).Return("some-cert", nil),
-					mockCertStore.EXPECT().Store("some-cert").Return(errors.New("some-error")),
+					mockCertStore.EXPECT().Store("some-cert", nil).Return(errors.New("some-error")),
 				)
 
 				Expect(runningVM.Trust(&vm.StartOpts{})).To(MatchError("failed to trust VM certificates: some-error"))
@@ -390,6 +500,772 @@ var _ = Describe("Running", func() {
 		})
 	})
 
+	Describe("Wait", func() {
+		It("should wait until PCF Dev is ready", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockUI.EXPECT().Say("Waiting for PCF Dev to be ready..."),
+				mockReadiness.EXPECT().Wait(runningVM.VMConfig, []byte("some-private-key"), 5*time.Minute),
+				mockUI.EXPECT().Say("PCF Dev is ready."),
+			)
+
+			Expect(runningVM.Wait(5 * time.Minute)).To(Succeed())
+		})
+
+		Context("when PCF Dev does not become ready", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Waiting for PCF Dev to be ready..."),
+					mockReadiness.EXPECT().Wait(runningVM.VMConfig, []byte("some-private-key"), 5*time.Minute).Return(errors.New("some-error")),
+				)
+
+				Expect(runningVM.Wait(5 * time.Minute)).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when reading the private key fails", func() {
+			It("should return the error", func() {
+				mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error"))
+
+				Expect(runningVM.Wait(5 * time.Minute)).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("Services", func() {
+		It("should list whether each service is enabled", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockClient.EXPECT().Services([]ssh.SSHAddress{{IP: "127.0.0.1", Port: "some-port"}, {IP: "some-ip", Port: "22"}}, []byte("some-private-key")).Return(map[string]bool{
+					"redis":                 true,
+					"rabbitmq":              true,
+					"spring-cloud-services": false,
+				}, nil),
+				mockUI.EXPECT().Say("rabbitmq                 enabled"),
+				mockUI.EXPECT().Say("redis                    enabled"),
+				mockUI.EXPECT().Say("spring-cloud-services    disabled"),
+				mockUI.EXPECT().Say("mysql                    always enabled"),
+			)
+
+			Expect(runningVM.Services()).To(Succeed())
+		})
+
+		Context("when listing the services fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockClient.EXPECT().Services(gomock.Any(), []byte("some-private-key")).Return(nil, errors.New("some-error")),
+				)
+
+				Expect(runningVM.Services()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when reading the private key fails", func() {
+			It("should return the error", func() {
+				mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error"))
+
+				Expect(runningVM.Services()).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("EnableService", func() {
+		var sshAddresses []ssh.SSHAddress
+
+		BeforeEach(func() {
+			sshAddresses = []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+		})
+
+		It("should enable the service", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockUI.EXPECT().Say("Enabling redis..."),
+				mockClient.EXPECT().EnableService([]ssh.SSHAddress{{IP: "127.0.0.1", Port: "some-port"}, {IP: "some-ip", Port: "22"}}, []byte("some-private-key"), "redis"),
+				mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"domain":"some-domain","services":"rabbitmq","registries":[]}`, nil),
+				mockProvider.EXPECT().SaveVMConfig(runningVM.VMConfig),
+				mockSSH.EXPECT().RunSSHCommand(`echo '{"domain":"some-domain","ip":"","services":"rabbitmq,redis","registries":[],"provider":""}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`, sshAddresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+				mockUI.EXPECT().Say("redis is now enabled."),
+			)
+
+			Expect(runningVM.EnableService("redis")).To(Succeed())
+		})
+
+		Context("when the service is already in the provision options", func() {
+			It("should not add it twice", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Enabling redis..."),
+					mockClient.EXPECT().EnableService(gomock.Any(), []byte("some-private-key"), "redis"),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":"rabbitmq,redis"}`, nil),
+					mockProvider.EXPECT().SaveVMConfig(runningVM.VMConfig),
+					mockSSH.EXPECT().RunSSHCommand(`echo '{"domain":"","ip":"","services":"rabbitmq,redis","registries":null,"provider":""}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`, sshAddresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockUI.EXPECT().Say("redis is now enabled."),
+				)
+
+				Expect(runningVM.EnableService("redis")).To(Succeed())
+			})
+		})
+
+		Context("when the provision options use a service alias", func() {
+			It("should expand the alias before adding the service", func() {
+				runningVM.Config.SpringCloudMinMemory = 6000
+				runningVM.VMConfig.Memory = 6000
+
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Enabling spring-cloud-services..."),
+					mockClient.EXPECT().EnableService(gomock.Any(), []byte("some-private-key"), "spring-cloud-services"),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":"default"}`, nil),
+					mockProvider.EXPECT().SaveVMConfig(runningVM.VMConfig),
+					mockSSH.EXPECT().RunSSHCommand(`echo '{"domain":"","ip":"","services":"rabbitmq,redis,spring-cloud-services","registries":null,"provider":""}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`, sshAddresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockUI.EXPECT().Say("spring-cloud-services is now enabled."),
+				)
+
+				Expect(runningVM.EnableService("spring-cloud-services")).To(Succeed())
+			})
+		})
+
+		Context("when enabling Spring Cloud Services", func() {
+			BeforeEach(func() {
+				runningVM.Config.SpringCloudMinMemory = 6000
+			})
+
+			It("should enable the service when the VM has enough memory", func() {
+				runningVM.VMConfig.Memory = 6000
+
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Enabling spring-cloud-services..."),
+					mockClient.EXPECT().EnableService(gomock.Any(), []byte("some-private-key"), "spring-cloud-services"),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":"rabbitmq"}`, nil),
+					mockProvider.EXPECT().SaveVMConfig(runningVM.VMConfig),
+					mockSSH.EXPECT().RunSSHCommand(`echo '{"domain":"","ip":"","services":"rabbitmq,spring-cloud-services","registries":null,"provider":""}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`, sshAddresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockUI.EXPECT().Say("spring-cloud-services is now enabled."),
+				)
+
+				Expect(runningVM.EnableService("spring-cloud-services")).To(Succeed())
+			})
+
+			Context("when the VM has less memory than Spring Cloud Services needs", func() {
+				It("should return an error without enabling the service", func() {
+					runningVM.VMConfig.Memory = 4000
+
+					Expect(runningVM.EnableService("spring-cloud-services")).To(MatchError("PCF Dev requires at least 6000 MB of memory to run Spring Cloud Services"))
+				})
+			})
+		})
+
+		Context("when reading the provision options fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Enabling redis..."),
+					mockClient.EXPECT().EnableService(gomock.Any(), []byte("some-private-key"), "redis"),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return("", errors.New("some-error")),
+				)
+
+				Expect(runningVM.EnableService("redis")).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when writing the provision options fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Enabling redis..."),
+					mockClient.EXPECT().EnableService(gomock.Any(), []byte("some-private-key"), "redis"),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":""}`, nil),
+					mockProvider.EXPECT().SaveVMConfig(runningVM.VMConfig),
+					mockSSH.EXPECT().RunSSHCommand(gomock.Any(), sshAddresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr).Return(errors.New("some-error")),
+				)
+
+				Expect(runningVM.EnableService("redis")).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when enabling the service fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Enabling redis..."),
+					mockClient.EXPECT().EnableService(gomock.Any(), []byte("some-private-key"), "redis").Return(errors.New("some-error")),
+				)
+
+				Expect(runningVM.EnableService("redis")).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when reading the private key fails", func() {
+			It("should return the error", func() {
+				mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error"))
+
+				Expect(runningVM.EnableService("redis")).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("DisableService", func() {
+		var sshAddresses []ssh.SSHAddress
+
+		BeforeEach(func() {
+			sshAddresses = []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+		})
+
+		It("should disable the service", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockUI.EXPECT().Say("Disabling redis..."),
+				mockClient.EXPECT().DisableService([]ssh.SSHAddress{{IP: "127.0.0.1", Port: "some-port"}, {IP: "some-ip", Port: "22"}}, []byte("some-private-key"), "redis"),
+				mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"domain":"some-domain","services":"rabbitmq,redis","registries":[]}`, nil),
+				mockProvider.EXPECT().SaveVMConfig(runningVM.VMConfig),
+				mockSSH.EXPECT().RunSSHCommand(`echo '{"domain":"some-domain","ip":"","services":"rabbitmq","registries":[],"provider":""}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`, sshAddresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+				mockUI.EXPECT().Say("redis is now disabled."),
+			)
+
+			Expect(runningVM.DisableService("redis")).To(Succeed())
+		})
+
+		Context("when the provision options use a service alias", func() {
+			It("should expand all before removing the service", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Disabling redis..."),
+					mockClient.EXPECT().DisableService(gomock.Any(), []byte("some-private-key"), "redis"),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":"all"}`, nil),
+					mockProvider.EXPECT().SaveVMConfig(runningVM.VMConfig),
+					mockSSH.EXPECT().RunSSHCommand(`echo '{"domain":"","ip":"","services":"rabbitmq,spring-cloud-services","registries":null,"provider":""}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`, sshAddresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockUI.EXPECT().Say("redis is now disabled."),
+				)
+
+				Expect(runningVM.DisableService("redis")).To(Succeed())
+			})
+
+			It("should expand scs and keep RabbitMQ when removing Spring Cloud Services", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Disabling spring-cloud-services..."),
+					mockClient.EXPECT().DisableService(gomock.Any(), []byte("some-private-key"), "spring-cloud-services"),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":"redis,scs"}`, nil),
+					mockProvider.EXPECT().SaveVMConfig(runningVM.VMConfig),
+					mockSSH.EXPECT().RunSSHCommand(`echo '{"domain":"","ip":"","services":"rabbitmq,redis","registries":null,"provider":""}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`, sshAddresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockUI.EXPECT().Say("spring-cloud-services is now disabled."),
+				)
+
+				Expect(runningVM.DisableService("spring-cloud-services")).To(Succeed())
+			})
+		})
+
+		Context("when disabling the service fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Disabling redis..."),
+					mockClient.EXPECT().DisableService(gomock.Any(), []byte("some-private-key"), "redis").Return(errors.New("some-error")),
+				)
+
+				Expect(runningVM.DisableService("redis")).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("Resize", func() {
+		var sshAddresses []ssh.SSHAddress
+
+		BeforeEach(func() {
+			sshAddresses = []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+			runningVM.VMConfig.Memory = 4096
+			runningVM.VMConfig.CPUs = 2
+			runningVM.Config.MinMemory = 3072
+			runningVM.Config.SpringCloudMinMemory = 6144
+			runningVM.Config.FreeMemory = 4096
+		})
+
+		It("should stop the VM, apply the new memory and cores and start it with the same services", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":"rabbitmq,spring-cloud-services","registries":["some-registry:5000"]}`, nil),
+				mockUI.EXPECT().Confirm("PCF Dev must be stopped to resize the VM, continue (y/N): ").Return(true),
+				mockUI.EXPECT().Say("Stopping VM..."),
+				mockProvider.EXPECT().StopVM(runningVM.VMConfig),
+				mockUI.EXPECT().Say("PCF Dev is now stopped."),
+				mockProvider.EXPECT().ResizeVM(&conf.VMConfig{
+					Name:    "some-vm",
+					Domain:  "some-domain",
+					IP:      "some-ip",
+					SSHPort: "some-port",
+					Memory:  8192,
+					CPUs:    4,
+				}),
+				mockUI.EXPECT().Say("PCF Dev VM now has 8192 MB of memory and 4 cores."),
+				mockBuilder.EXPECT().VM("some-vm").Return(mockVM, nil),
+				mockVM.EXPECT().Start(&vm.StartOpts{
+					Services:   "rabbitmq,spring-cloud-services",
+					Registries: "some-registry:5000",
+				}),
+			)
+
+			Expect(runningVM.Resize(&vm.ResizeOpts{Memory: 8192, CPUs: 4})).To(Succeed())
+		})
+
+		Context("when no services were provisioned", func() {
+			It("should start the VM without services", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":""}`, nil),
+					mockUI.EXPECT().Confirm("PCF Dev must be stopped to resize the VM, continue (y/N): ").Return(true),
+					mockUI.EXPECT().Say("Stopping VM..."),
+					mockProvider.EXPECT().StopVM(runningVM.VMConfig),
+					mockUI.EXPECT().Say("PCF Dev is now stopped."),
+					mockProvider.EXPECT().ResizeVM(gomock.Any()),
+					mockUI.EXPECT().Say("PCF Dev VM now has 4096 MB of memory and 4 cores."),
+					mockBuilder.EXPECT().VM("some-vm").Return(mockVM, nil),
+					mockVM.EXPECT().Start(&vm.StartOpts{Services: "none"}),
+				)
+
+				Expect(runningVM.Resize(&vm.ResizeOpts{CPUs: 4})).To(Succeed())
+			})
+		})
+
+		Context("when Spring Cloud Services is provisioned and the memory is below its minimum", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":"rabbitmq,spring-cloud-services"}`, nil),
+				)
+
+				Expect(runningVM.Resize(&vm.ResizeOpts{Memory: 5120})).To(MatchError("PCF Dev requires at least 6144 MB of memory to run Spring Cloud Services"))
+			})
+
+			It("should expand the scs alias", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":"scs"}`, nil),
+				)
+
+				Expect(runningVM.Resize(&vm.ResizeOpts{Memory: 5120})).To(MatchError("PCF Dev requires at least 6144 MB of memory to run Spring Cloud Services"))
+			})
+		})
+
+		Context("when the added memory is more than the free memory", func() {
+			It("should ask for confirmation", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":"redis"}`, nil),
+					mockUI.EXPECT().Confirm("Less than 10240 MB of free memory detected, continue (y/N): ").Return(false),
+				)
+
+				Expect(runningVM.Resize(&vm.ResizeOpts{Memory: 10240})).To(MatchError("user declined to continue, exiting"))
+			})
+		})
+
+		Context("when the user declines to stop the VM", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":"redis"}`, nil),
+					mockUI.EXPECT().Confirm("PCF Dev must be stopped to resize the VM, continue (y/N): ").Return(false),
+				)
+
+				Expect(runningVM.Resize(&vm.ResizeOpts{Memory: 8192})).To(MatchError("user declined to continue, exiting"))
+			})
+		})
+
+		Context("when resizing the VM fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":"redis"}`, nil),
+					mockUI.EXPECT().Confirm("PCF Dev must be stopped to resize the VM, continue (y/N): ").Return(true),
+					mockUI.EXPECT().Say("Stopping VM..."),
+					mockProvider.EXPECT().StopVM(runningVM.VMConfig),
+					mockUI.EXPECT().Say("PCF Dev is now stopped."),
+					mockProvider.EXPECT().ResizeVM(gomock.Any()).Return(errors.New("some-error")),
+				)
+
+				Expect(runningVM.Resize(&vm.ResizeOpts{Memory: 8192})).To(MatchError("failed to resize VM: some-error"))
+			})
+		})
+
+		Context("when reading the provision options fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return("", errors.New("some-error")),
+				)
+
+				Expect(runningVM.Resize(&vm.ResizeOpts{Memory: 8192})).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("ResizeDisk", func() {
+		var sshAddresses []ssh.SSHAddress
+
+		BeforeEach(func() {
+			sshAddresses = []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+		})
+
+		It("should stop the VM, grow the disk, start the VM with the same services and grow the filesystem", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockProvider.EXPECT().DiskSize(runningVM.VMConfig).Return(uint64(51200), nil),
+				mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":"rabbitmq,redis","registries":["some-registry:5000"]}`, nil),
+				mockUI.EXPECT().Confirm("PCF Dev must be stopped to resize the disk, continue (y/N): ").Return(true),
+				mockUI.EXPECT().Say("Stopping VM..."),
+				mockProvider.EXPECT().StopVM(runningVM.VMConfig),
+				mockUI.EXPECT().Say("PCF Dev is now stopped."),
+				mockUI.EXPECT().Say("Resizing disk..."),
+				mockProvider.EXPECT().ResizeDisk(runningVM.VMConfig, uint64(102400)),
+				mockBuilder.EXPECT().VM("some-vm").Return(mockVM, nil),
+				mockVM.EXPECT().Start(&vm.StartOpts{
+					Services:   "rabbitmq,redis",
+					Registries: "some-registry:5000",
+				}),
+				mockUI.EXPECT().Say("Growing filesystem..."),
+				mockSSH.EXPECT().GetSSHOutput("{ sudo growpart /dev/sda 1 || [ $? -eq 1 ]; } && sudo resize2fs /dev/sda1", sshAddresses, []byte("some-private-key"), 5*time.Minute),
+				mockUI.EXPECT().Say("PCF Dev disk is now 100 GB."),
+			)
+
+			Expect(runningVM.ResizeDisk(100)).To(Succeed())
+		})
+
+		Context("when the new size is not larger than the disk", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockProvider.EXPECT().DiskSize(runningVM.VMConfig).Return(uint64(51200), nil),
+				)
+
+				Expect(runningVM.ResizeDisk(50)).To(MatchError("the PCF Dev disk is already 50 GB, it can only be grown"))
+			})
+		})
+
+		Context("when the disk size cannot be determined", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockProvider.EXPECT().DiskSize(runningVM.VMConfig).Return(uint64(0), errors.New("some-error")),
+				)
+
+				Expect(runningVM.ResizeDisk(100)).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when the user declines to stop the VM", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockProvider.EXPECT().DiskSize(runningVM.VMConfig).Return(uint64(51200), nil),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":"redis"}`, nil),
+					mockUI.EXPECT().Confirm("PCF Dev must be stopped to resize the disk, continue (y/N): ").Return(false),
+				)
+
+				Expect(runningVM.ResizeDisk(100)).To(MatchError("user declined to continue, exiting"))
+			})
+		})
+
+		Context("when resizing the disk fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockProvider.EXPECT().DiskSize(runningVM.VMConfig).Return(uint64(51200), nil),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":"redis"}`, nil),
+					mockUI.EXPECT().Confirm("PCF Dev must be stopped to resize the disk, continue (y/N): ").Return(true),
+					mockUI.EXPECT().Say("Stopping VM..."),
+					mockProvider.EXPECT().StopVM(runningVM.VMConfig),
+					mockUI.EXPECT().Say("PCF Dev is now stopped."),
+					mockUI.EXPECT().Say("Resizing disk..."),
+					mockProvider.EXPECT().ResizeDisk(runningVM.VMConfig, uint64(102400)).Return(errors.New("some-error")),
+				)
+
+				Expect(runningVM.ResizeDisk(100)).To(MatchError("failed to resize disk: some-error"))
+			})
+		})
+
+		Context("when growing the filesystem fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockProvider.EXPECT().DiskSize(runningVM.VMConfig).Return(uint64(51200), nil),
+					mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"services":""}`, nil),
+					mockUI.EXPECT().Confirm("PCF Dev must be stopped to resize the disk, continue (y/N): ").Return(true),
+					mockUI.EXPECT().Say("Stopping VM..."),
+					mockProvider.EXPECT().StopVM(runningVM.VMConfig),
+					mockUI.EXPECT().Say("PCF Dev is now stopped."),
+					mockUI.EXPECT().Say("Resizing disk..."),
+					mockProvider.EXPECT().ResizeDisk(runningVM.VMConfig, uint64(102400)),
+					mockBuilder.EXPECT().VM("some-vm").Return(mockVM, nil),
+					mockVM.EXPECT().Start(&vm.StartOpts{Services: "none"}),
+					mockUI.EXPECT().Say("Growing filesystem..."),
+					mockSSH.EXPECT().GetSSHOutput("{ sudo growpart /dev/sda 1 || [ $? -eq 1 ]; } && sudo resize2fs /dev/sda1", sshAddresses, []byte("some-private-key"), 5*time.Minute).Return("", errors.New("some-error")),
+				)
+
+				Expect(runningVM.ResizeDisk(100)).To(MatchError("failed to resize disk: some-error"))
+			})
+		})
+	})
+
+	Describe("Mount", func() {
+		var sshAddresses []ssh.SSHAddress
+
+		BeforeEach(func() {
+			sshAddresses = []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+		})
+
+		It("should share the folder, mount it in the VM and save it in the VM config", func() {
+			mount := &conf.Mount{Name: "pcfdev-mount-1", HostPath: "/some-host-path", GuestPath: "/some-guest-path"}
+
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockUI.EXPECT().Say("Mounting /some-host-path at /some-guest-path..."),
+				mockProvider.EXPECT().ShareFolder(runningVM.VMConfig, mount),
+				mockSSH.EXPECT().GetSSHOutput("sudo mkdir -p '/some-guest-path' && sudo mount -t vboxsf -o uid=$(id -u),gid=$(id -g) pcfdev-mount-1 '/some-guest-path'", sshAddresses, []byte("some-private-key"), 30*time.Second),
+				mockProvider.EXPECT().SaveVMConfig(runningVM.VMConfig),
+				mockUI.EXPECT().Say("/some-host-path is now mounted at /some-guest-path."),
+			)
+
+			Expect(runningVM.Mount("/some-host-path", "/some-guest-path")).To(Succeed())
+			Expect(runningVM.VMConfig.Mounts).To(Equal([]*conf.Mount{mount}))
+		})
+
+		Context("when the guest path is already mounted", func() {
+			It("should return an error", func() {
+				runningVM.VMConfig.Mounts = []*conf.Mount{{Name: "pcfdev-mount-1", HostPath: "/some-host-path", GuestPath: "/some-guest-path"}}
+
+				Expect(runningVM.Mount("/some-other-host-path", "/some-guest-path")).To(MatchError("/some-guest-path is already mounted"))
+			})
+		})
+
+		Context("when sharing the folder fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Mounting /some-host-path at /some-guest-path..."),
+					mockProvider.EXPECT().ShareFolder(runningVM.VMConfig, gomock.Any()).Return(errors.New("some-error")),
+				)
+
+				Expect(runningVM.Mount("/some-host-path", "/some-guest-path")).To(MatchError("failed to mount /some-host-path at /some-guest-path: some-error"))
+				Expect(runningVM.VMConfig.Mounts).To(BeEmpty())
+			})
+		})
+
+		Context("when mounting the folder in the VM fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Mounting /some-host-path at /some-guest-path..."),
+					mockProvider.EXPECT().ShareFolder(runningVM.VMConfig, gomock.Any()),
+					mockSSH.EXPECT().GetSSHOutput(gomock.Any(), sshAddresses, []byte("some-private-key"), 30*time.Second).Return("", errors.New("some-error")),
+				)
+
+				Expect(runningVM.Mount("/some-host-path", "/some-guest-path")).To(MatchError("failed to mount /some-host-path at /some-guest-path: some-error"))
+			})
+		})
+
+		Context("when saving the VM config fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Mounting /some-host-path at /some-guest-path..."),
+					mockProvider.EXPECT().ShareFolder(runningVM.VMConfig, gomock.Any()),
+					mockSSH.EXPECT().GetSSHOutput(gomock.Any(), sshAddresses, []byte("some-private-key"), 30*time.Second),
+					mockProvider.EXPECT().SaveVMConfig(runningVM.VMConfig).Return(errors.New("some-error")),
+				)
+
+				Expect(runningVM.Mount("/some-host-path", "/some-guest-path")).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("Mounts", func() {
+		It("should list the mounted folders", func() {
+			runningVM.VMConfig.Mounts = []*conf.Mount{{Name: "pcfdev-mount-1", HostPath: "/some-host-path", GuestPath: "/some-guest-path"}}
+
+			mockUI.EXPECT().Say("/some-host-path => /some-guest-path")
+
+			Expect(runningVM.Mounts()).To(Succeed())
+		})
+
+		Context("when no folders are mounted", func() {
+			It("should say so", func() {
+				mockUI.EXPECT().Say("No folders are mounted.")
+
+				Expect(runningVM.Mounts()).To(Succeed())
+			})
+		})
+	})
+
+	Describe("RunCommand", func() {
+		var addresses []ssh.SSHAddress
+
+		BeforeEach(func() {
+			addresses = []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+		})
+
+		It("should run the command over ssh", func() {
+			_, stdout, stderr := term.StdStreams()
+
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockSSH.EXPECT().RunSSHCommand("some-command", addresses, []byte("some-private-key"), 5*time.Minute, stdout, stderr),
+			)
+
+			Expect(runningVM.RunCommand("some-command")).To(Succeed())
+		})
+
+		Context("when running the command fails", func() {
+			It("should return the error", func() {
+				_, stdout, stderr := term.StdStreams()
+
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().RunSSHCommand("some-command", addresses, []byte("some-private-key"), 5*time.Minute, stdout, stderr).Return(errors.New("some-error")),
+				)
+
+				Expect(runningVM.RunCommand("some-command")).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when reading the private key fails", func() {
+			It("should return the error", func() {
+				mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error"))
+
+				Expect(runningVM.RunCommand("some-command")).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("Forward", func() {
+		var (
+			addresses []ssh.SSHAddress
+			forwards  []ssh.Forward
+		)
+
+		BeforeEach(func() {
+			addresses = []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+			forwards = []ssh.Forward{{LocalAddress: "127.0.0.1:5432", RemoteAddress: "10.244.0.5:5432"}}
+		})
+
+		It("should forward the ports over ssh", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockSSH.EXPECT().ForwardPorts(forwards, addresses, []byte("some-private-key"), 5*time.Minute, gomock.Any(), gomock.Any()),
+			)
+
+			Expect(runningVM.Forward(forwards)).To(Succeed())
+		})
+
+		Context("when a forwarded connection fails", func() {
+			It("should report the error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().ForwardPorts(forwards, addresses, []byte("some-private-key"), 5*time.Minute, gomock.Any(), gomock.Any()).Do(
+						func(_ []ssh.Forward, _ []ssh.SSHAddress, _ []byte, _ time.Duration, onError func(ssh.Forward, error), _ func()) {
+							onError(forwards[0], errors.New("some-error"))
+						},
+					),
+					mockUI.EXPECT().Say("Error forwarding 127.0.0.1:5432 to 10.244.0.5:5432: some-error"),
+				)
+
+				Expect(runningVM.Forward(forwards)).To(Succeed())
+			})
+		})
+
+		Context("when forwarding the ports fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().ForwardPorts(forwards, addresses, []byte("some-private-key"), 5*time.Minute, gomock.Any(), gomock.Any()).Return(errors.New("some-error")),
+				)
+
+				Expect(runningVM.Forward(forwards)).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when reading the private key fails", func() {
+			It("should return the error", func() {
+				mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error"))
+
+				Expect(runningVM.Forward(forwards)).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("Copy", func() {
+		var addresses []ssh.SSHAddress
+
+		BeforeEach(func() {
+			addresses = []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+		})
+
+		Context("when copying to the guest", func() {
+			It("should copy the files over ssh", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().CopyToGuest("some-host-path", "some-guest-path", addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout),
+				)
+
+				Expect(runningVM.Copy(&vm.CopyOpts{HostPath: "some-host-path", GuestPath: "some-guest-path", ToGuest: true})).To(Succeed())
+			})
+		})
+
+		Context("when copying from the guest", func() {
+			It("should copy the files over ssh", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().CopyFromGuest("some-guest-path", "some-host-path", addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout),
+				)
+
+				Expect(runningVM.Copy(&vm.CopyOpts{HostPath: "some-host-path", GuestPath: "some-guest-path"})).To(Succeed())
+			})
+		})
+
+		Context("when reading the private key fails", func() {
+			It("should return an error", func() {
+				mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error"))
+
+				Expect(runningVM.Copy(&vm.CopyOpts{})).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when copying fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().CopyToGuest("some-host-path", "some-guest-path", addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout).Return(errors.New("some-error")),
+				)
+
+				Expect(runningVM.Copy(&vm.CopyOpts{HostPath: "some-host-path", GuestPath: "some-guest-path", ToGuest: true})).To(MatchError("some-error"))
+			})
+		})
+	})
+
 	Describe("Target", func() {
 		Context("when autoTarget is set", func() {
 			It("target PCF Dev", func() {
@@ -15,8 +15,9 @@ type Saved struct {
 
 	FS        FS
 	UI        UI
-	VBox      VBox
+	Provider  Provider
 	SSHClient SSH
+	Readiness Readiness
 }
 
 func (s *Saved) VerifyStartOpts(opts *StartOpts) error {
@@ -58,6 +59,10 @@ func (s *Saved) Status() string {
 	return "Suspended"
 }
 
+func (s *Saved) StatusDetails() (*Status, error) {
+	return newStatus(StateSaved, s.VMConfig), nil
+}
+
 func (s *Saved) Suspend() error {
 	s.UI.Say("Your VM is suspended.")
 	return nil
@@ -68,7 +73,7 @@ func (s *Saved) Resume() error {
 		return err
 	}
 	s.UI.Say("Resuming VM...")
-	if err := s.VBox.ResumeSavedVM(s.VMConfig); err != nil {
+	if err := s.Provider.ResumeSavedVM(s.VMConfig); err != nil {
 		return &ResumeVMError{err}
 	}
 
@@ -85,6 +90,11 @@ func (s *Saved) Resume() error {
 		return &ResumeVMError{err}
 	}
 
+	s.UI.Say("Waiting for PCF Dev to be ready...")
+	if err := s.Readiness.Wait(s.VMConfig, privateKeyBytes, ReadinessTimeout); err != nil {
+		return &ResumeVMError{err}
+	}
+
 	s.UI.Say("PCF Dev is now running.")
 
 	return nil
@@ -118,3 +128,49 @@ func (s *Saved) SSH() error {
 	s.UI.Say("Your VM is suspended. Resume to SSH to PCF Dev.")
 	return nil
 }
+
+func (s *Saved) RunCommand(string) error {
+	return errors.New("your VM is suspended, resume to run commands on PCF Dev")
+}
+
+func (s *Saved) Forward([]ssh.Forward) error {
+	s.UI.Say("Your VM is suspended. Resume to forward ports to PCF Dev.")
+	return nil
+}
+
+func (s *Saved) Wait(time.Duration) error {
+	return errors.New("your VM is suspended, resume to wait for PCF Dev")
+}
+
+func (s *Saved) Services() error {
+	return errors.New("your VM is suspended, resume to list PCF Dev services")
+}
+
+func (s *Saved) EnableService(string) error {
+	return errors.New("your VM is suspended, resume to enable PCF Dev services")
+}
+
+func (s *Saved) DisableService(string) error {
+	return errors.New("your VM is suspended, resume to disable PCF Dev services")
+}
+
+func (s *Saved) Resize(*ResizeOpts) error {
+	return errors.New("your VM is suspended, resume to resize PCF Dev")
+}
+
+func (s *Saved) ResizeDisk(uint64) error {
+	return errors.New("your VM is suspended, resume to resize the PCF Dev disk")
+}
+
+func (s *Saved) Mount(string, string) error {
+	return errors.New("your VM is suspended, resume to mount folders in PCF Dev")
+}
+
+func (s *Saved) Mounts() error {
+	return listMounts(s.UI, s.VMConfig)
+}
+
+func (s *Saved) Copy(*CopyOpts) error {
+	s.UI.Say("Your VM is suspended. Resume to copy files to or from PCF Dev.")
+	return nil
+}
@@ -16,20 +16,22 @@ import (
 
 var _ = Describe("Saved", func() {
 	var (
-		mockCtrl *gomock.Controller
-		mockUI   *mocks.MockUI
-		mockVBox *mocks.MockVBox
-		mockSSH  *mocks.MockSSH
-		mockFS   *mocks.MockFS
-		savedVM  vm.Saved
+		mockCtrl      *gomock.Controller
+		mockUI        *mocks.MockUI
+		mockProvider  *mocks.MockProvider
+		mockSSH       *mocks.MockSSH
+		mockFS        *mocks.MockFS
+		mockReadiness *mocks.MockReadiness
+		savedVM       vm.Saved
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockUI = mocks.NewMockUI(mockCtrl)
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockSSH = mocks.NewMockSSH(mockCtrl)
 		mockFS = mocks.NewMockFS(mockCtrl)
+		mockReadiness = mocks.NewMockReadiness(mockCtrl)
 
 		savedVM = vm.Saved{
 			VMConfig: &config.VMConfig{
@@ -38,10 +40,11 @@ var _ = Describe("Saved", func() {
 				IP:      "some-ip",
 				SSHPort: "some-port",
 			},
-			VBox:      mockVBox,
+			Provider:  mockProvider,
 			UI:        mockUI,
 			SSHClient: mockSSH,
 			FS:        mockFS,
+			Readiness: mockReadiness,
 
 			Config: &config.Config{
 				PrivateKeyPath: "some-private-key-path",
@@ -75,9 +78,11 @@ var _ = Describe("Saved", func() {
 			}
 			gomock.InOrder(
 				mockUI.EXPECT().Say("Resuming VM..."),
-				mockVBox.EXPECT().ResumeSavedVM(savedVM.VMConfig),
+				mockProvider.EXPECT().ResumeSavedVM(savedVM.VMConfig),
 				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 				mockSSH.EXPECT().WaitForSSH(addresses, []byte("some-private-key"), 5*time.Minute),
+				mockUI.EXPECT().Say("Waiting for PCF Dev to be ready..."),
+				mockReadiness.EXPECT().Wait(savedVM.VMConfig, []byte("some-private-key"), vm.ReadinessTimeout),
 				mockUI.EXPECT().Say("PCF Dev is now running."),
 			)
 
@@ -88,7 +93,7 @@ var _ = Describe("Saved", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Resuming VM..."),
-					mockVBox.EXPECT().ResumeSavedVM(savedVM.VMConfig).Return(errors.New("some-error")),
+					mockProvider.EXPECT().ResumeSavedVM(savedVM.VMConfig).Return(errors.New("some-error")),
 				)
 
 				Expect(savedVM.Start(&vm.StartOpts{})).To(MatchError("failed to resume VM: some-error"))
@@ -99,7 +104,7 @@ var _ = Describe("Saved", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Resuming VM..."),
-					mockVBox.EXPECT().ResumeSavedVM(savedVM.VMConfig),
+					mockProvider.EXPECT().ResumeSavedVM(savedVM.VMConfig),
 					mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error")),
 				)
 
@@ -114,7 +119,7 @@ var _ = Describe("Saved", func() {
 				}
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Resuming VM..."),
-					mockVBox.EXPECT().ResumeSavedVM(savedVM.VMConfig),
+					mockProvider.EXPECT().ResumeSavedVM(savedVM.VMConfig),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().WaitForSSH(addresses, []byte("some-private-key"), 5*time.Minute).Return(errors.New("some-error")),
 				)
@@ -210,9 +215,11 @@ var _ = Describe("Saved", func() {
 			}
 			gomock.InOrder(
 				mockUI.EXPECT().Say("Resuming VM..."),
-				mockVBox.EXPECT().ResumeSavedVM(savedVM.VMConfig),
+				mockProvider.EXPECT().ResumeSavedVM(savedVM.VMConfig),
 				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 				mockSSH.EXPECT().WaitForSSH(addresses, []byte("some-private-key"), 5*time.Minute),
+				mockUI.EXPECT().Say("Waiting for PCF Dev to be ready..."),
+				mockReadiness.EXPECT().Wait(savedVM.VMConfig, []byte("some-private-key"), vm.ReadinessTimeout),
 				mockUI.EXPECT().Say("PCF Dev is now running."),
 			)
 
@@ -229,7 +236,7 @@ var _ = Describe("Saved", func() {
 				}
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Resuming VM..."),
-					mockVBox.EXPECT().ResumeSavedVM(savedVM.VMConfig),
+					mockProvider.EXPECT().ResumeSavedVM(savedVM.VMConfig),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().WaitForSSH(addresses, []byte("some-private-key"), 5*time.Minute).Return(errors.New("some-error")),
 				)
@@ -238,13 +245,30 @@ var _ = Describe("Saved", func() {
 			})
 		})
 
+		Context("when PCF Dev does not become ready", func() {
+			It("should return an error", func() {
+				savedVM.Config.FreeMemory = uint64(3000)
+				savedVM.VMConfig.Memory = uint64(2000)
+				gomock.InOrder(
+					mockUI.EXPECT().Say("Resuming VM..."),
+					mockProvider.EXPECT().ResumeSavedVM(savedVM.VMConfig),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().WaitForSSH(gomock.Any(), []byte("some-private-key"), 5*time.Minute),
+					mockUI.EXPECT().Say("Waiting for PCF Dev to be ready..."),
+					mockReadiness.EXPECT().Wait(savedVM.VMConfig, []byte("some-private-key"), vm.ReadinessTimeout).Return(errors.New("some-error")),
+				)
+
+				Expect(savedVM.Resume()).To(MatchError("failed to resume VM: some-error"))
+			})
+		})
+
 		Context("when reading the private key fails", func() {
 			It("should return an error", func() {
 				savedVM.Config.FreeMemory = uint64(3000)
 				savedVM.VMConfig.Memory = uint64(2000)
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Resuming VM..."),
-					mockVBox.EXPECT().ResumeSavedVM(savedVM.VMConfig),
+					mockProvider.EXPECT().ResumeSavedVM(savedVM.VMConfig),
 					mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error")),
 				)
 
@@ -258,7 +282,7 @@ var _ = Describe("Saved", func() {
 				savedVM.VMConfig.Memory = uint64(2000)
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Resuming VM..."),
-					mockVBox.EXPECT().ResumeSavedVM(savedVM.VMConfig).Return(errors.New("some-error")),
+					mockProvider.EXPECT().ResumeSavedVM(savedVM.VMConfig).Return(errors.New("some-error")),
 				)
 
 				Expect(savedVM.Resume()).To(MatchError("failed to resume VM: some-error"))
@@ -278,9 +302,11 @@ var _ = Describe("Saved", func() {
 					gomock.InOrder(
 						mockUI.EXPECT().Confirm("Less than 3000 MB of free memory detected, continue (y/N): ").Return(true),
 						mockUI.EXPECT().Say("Resuming VM..."),
-						mockVBox.EXPECT().ResumeSavedVM(savedVM.VMConfig),
+						mockProvider.EXPECT().ResumeSavedVM(savedVM.VMConfig),
 						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 						mockSSH.EXPECT().WaitForSSH(addresses, []byte("some-private-key"), 5*time.Minute),
+						mockUI.EXPECT().Say("Waiting for PCF Dev to be ready..."),
+						mockReadiness.EXPECT().Wait(savedVM.VMConfig, []byte("some-private-key"), vm.ReadinessTimeout),
 						mockUI.EXPECT().Say("PCF Dev is now running."),
 					)
 
@@ -307,6 +333,19 @@ var _ = Describe("Saved", func() {
 		})
 	})
 
+	Describe("StatusDetails", func() {
+		It("should return the saved status", func() {
+			Expect(savedVM.StatusDetails()).To(Equal(&vm.Status{
+				State:   "saved",
+				VMName:  "some-vm",
+				IP:      "some-ip",
+				Domain:  "some-domain",
+				SSHPort: "some-port",
+				APIURL:  "https://api.some-domain",
+			}))
+		})
+	})
+
 	Describe("GetDebugLogs", func() {
 		It("should say a message", func() {
 			mockUI.EXPECT().Say("Your VM is suspended. Resume to retrieve debug logs.")
@@ -334,4 +373,90 @@ var _ = Describe("Saved", func() {
 			Expect(savedVM.SSH()).To(Succeed())
 		})
 	})
+
+	Describe("Wait", func() {
+		It("should return an error", func() {
+			Expect(savedVM.Wait(time.Minute)).To(MatchError("your VM is suspended, resume to wait for PCF Dev"))
+		})
+	})
+
+	Describe("Services", func() {
+		It("should return an error", func() {
+			Expect(savedVM.Services()).To(MatchError("your VM is suspended, resume to list PCF Dev services"))
+		})
+	})
+
+	Describe("EnableService", func() {
+		It("should return an error", func() {
+			Expect(savedVM.EnableService("redis")).To(MatchError("your VM is suspended, resume to enable PCF Dev services"))
+		})
+	})
+
+	Describe("DisableService", func() {
+		It("should return an error", func() {
+			Expect(savedVM.DisableService("redis")).To(MatchError("your VM is suspended, resume to disable PCF Dev services"))
+		})
+	})
+
+	Describe("Resize", func() {
+		It("should return an error", func() {
+			Expect(savedVM.Resize(&vm.ResizeOpts{Memory: 6144})).To(MatchError("your VM is suspended, resume to resize PCF Dev"))
+		})
+	})
+
+	Describe("ResizeDisk", func() {
+		It("should return an error", func() {
+			Expect(savedVM.ResizeDisk(100)).To(MatchError("your VM is suspended, resume to resize the PCF Dev disk"))
+		})
+	})
+
+	Describe("Mount", func() {
+		It("should return an error", func() {
+			Expect(savedVM.Mount("/some-host-path", "/some-guest-path")).To(MatchError("your VM is suspended, resume to mount folders in PCF Dev"))
+		})
+	})
+
+	Describe("Mounts", func() {
+		It("should list the mounted folders", func() {
+			savedVM.VMConfig.Mounts = []*config.Mount{
+				{Name: "pcfdev-mount-1", HostPath: "/some-host-path", GuestPath: "/some-guest-path"},
+				{Name: "pcfdev-mount-2", HostPath: "/some-other-host-path", GuestPath: "/some-other-guest-path"},
+			}
+
+			gomock.InOrder(
+				mockUI.EXPECT().Say("/some-host-path => /some-guest-path"),
+				mockUI.EXPECT().Say("/some-other-host-path => /some-other-guest-path"),
+			)
+
+			Expect(savedVM.Mounts()).To(Succeed())
+		})
+
+		Context("when no folders are mounted", func() {
+			It("should say so", func() {
+				mockUI.EXPECT().Say("No folders are mounted.")
+
+				Expect(savedVM.Mounts()).To(Succeed())
+			})
+		})
+	})
+
+	Describe("RunCommand", func() {
+		It("should return an error", func() {
+			Expect(savedVM.RunCommand("some-command")).To(MatchError("your VM is suspended, resume to run commands on PCF Dev"))
+		})
+	})
+
+	Describe("Forward", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Resume to forward ports to PCF Dev.")
+			Expect(savedVM.Forward([]ssh.Forward{})).To(Succeed())
+		})
+	})
+
+	Describe("Copy", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Resume to copy files to or from PCF Dev.")
+			Expect(savedVM.Copy(&vm.CopyOpts{})).To(Succeed())
+		})
+	})
 })
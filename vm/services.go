@@ -0,0 +1,40 @@
+package vm
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pivotal-cf/pcfdev-cli/helpers"
+)
+
+// expandServices turns a comma-separated list of services, which may use the
+// all, default and scs aliases, into the sorted names of the services to run.
+func expandServices(list string) []string {
+	services := []string{}
+	for _, service := range strings.Split(list, ",") {
+		switch service {
+		case "all":
+			services = append(services, "rabbitmq", "redis", "spring-cloud-services")
+		case "default":
+			services = append(services, "rabbitmq", "redis")
+		case "rabbitmq":
+			services = append(services, "rabbitmq")
+		case "redis":
+			services = append(services, "redis")
+		case "spring-cloud-services", "scs":
+			services = append(services, "rabbitmq", "spring-cloud-services")
+		}
+	}
+	services = helpers.RemoveDuplicates(services)
+	sort.Strings(services)
+	return services
+}
+
+func containsService(services []string, name string) bool {
+	for _, service := range services {
+		if service == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pivotal-cf/pcfdev-cli/address"
+)
+
+// Verify checks the syntax of the start options, without checking them
+// against the host or the VM.
+func (opts *StartOpts) Verify() error {
+	if opts.CPUs < 0 {
+		return errors.New("cannot start with less than one core")
+	}
+
+	if len(opts.Services) != 0 {
+		var disallowedServices []string
+
+		for _, service := range strings.Split(opts.Services, ",") {
+			switch service {
+			case "all", "none", "default", "redis", "rabbitmq", "mysql", "spring-cloud-services", "scs":
+			default:
+				disallowedServices = append(disallowedServices, service)
+			}
+		}
+
+		if len(disallowedServices) > 0 {
+			return fmt.Errorf("invalid services specified: %s", strings.Join(disallowedServices, ", "))
+		}
+	}
+
+	if opts.Registries != "" && strings.Count(opts.Registries, ":") != 1 {
+		return fmt.Errorf("docker registries must be passed in 'host:port' format")
+	}
+
+	if opts.Domain != "" && !address.IsValidDomain(opts.Domain) {
+		return fmt.Errorf("%s is not a valid domain", opts.Domain)
+	}
+
+	if opts.IP != "" {
+		if err := address.VerifyIP(opts.IP); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package vm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
+)
+
+const (
+	StateNotCreated    = "not_created"
+	StateStopped       = "stopped"
+	StateRunning       = "running"
+	StateUnprovisioned = "unprovisioned"
+	StatePaused        = "paused"
+	StateSaved         = "saved"
+	StateInvalid       = "invalid"
+)
+
+type Status struct {
+	State    string   `json:"state"`
+	VMName   string   `json:"vm_name,omitempty"`
+	IP       string   `json:"ip,omitempty"`
+	Domain   string   `json:"domain,omitempty"`
+	Memory   uint64   `json:"memory,omitempty"`
+	CPUs     int      `json:"cpus,omitempty"`
+	SSHPort  string   `json:"ssh_port,omitempty"`
+	APIURL   string   `json:"api_url,omitempty"`
+	Services []string `json:"services,omitempty"`
+	DiskSize uint64   `json:"disk_size,omitempty"`
+	DiskUsed uint64   `json:"disk_used,omitempty"`
+	Message  string   `json:"message,omitempty"`
+}
+
+func newStatus(state string, vmConfig *config.VMConfig) *Status {
+	status := &Status{
+		State: state,
+	}
+	if vmConfig == nil {
+		return status
+	}
+
+	status.VMName = vmConfig.Name
+	status.IP = vmConfig.IP
+	status.Domain = vmConfig.Domain
+	status.Memory = vmConfig.Memory
+	status.CPUs = vmConfig.CPUs
+	status.SSHPort = vmConfig.SSHPort
+	if vmConfig.Domain != "" {
+		status.APIURL = fmt.Sprintf("https://api.%s", vmConfig.Domain)
+	}
+	return status
+}
+
+func provisionedServices(sshClient SSH, vmConfig *config.VMConfig, privateKey []byte) ([]string, error) {
+	provisionConfig, err := readProvisionConfig(sshClient, vmConfig, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if provisionConfig.Services == "" {
+		return []string{}, nil
+	}
+	return strings.Split(provisionConfig.Services, ","), nil
+}
+
+func readProvisionConfig(sshClient SSH, vmConfig *config.VMConfig, privateKey []byte) (*config.ProvisionConfig, error) {
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: vmConfig.SSHPort},
+		{IP: vmConfig.IP, Port: "22"},
+	}
+
+	data, err := sshClient.GetSSHOutput("cat /var/pcfdev/provision-options.json", addresses, privateKey, 30*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	provisionConfig := &config.ProvisionConfig{}
+	if err := json.Unmarshal([]byte(data), provisionConfig); err != nil {
+		return nil, err
+	}
+	return provisionConfig, nil
+}
+
+func writeProvisionConfig(sshClient SSH, vmConfig *config.VMConfig, privateKey []byte, provisionConfig *config.ProvisionConfig) error {
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: vmConfig.SSHPort},
+		{IP: vmConfig.IP, Port: "22"},
+	}
+
+	data, err := json.Marshal(provisionConfig)
+	if err != nil {
+		return err
+	}
+
+	return sshClient.RunSSHCommand("echo '"+string(data)+"' | sudo tee /var/pcfdev/provision-options.json >/dev/null", addresses, privateKey, 5*time.Minute, os.Stdout, os.Stderr)
+}
+
+// diskUsage returns the size and usage in MB of the root filesystem of the VM.
+func diskUsage(sshClient SSH, vmConfig *config.VMConfig, privateKey []byte) (size uint64, used uint64, err error) {
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: vmConfig.SSHPort},
+		{IP: vmConfig.IP, Port: "22"},
+	}
+
+	output, err := sshClient.GetSSHOutput("df -m / | tail -n 1", addresses, privateKey, 30*time.Second)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fields := strings.Fields(output)
+	if len(fields) < 3 {
+		return 0, 0, fmt.Errorf("failed to parse disk usage: %s", output)
+	}
+	if size, err = strconv.ParseUint(fields[1], 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("failed to parse disk usage: %s", output)
+	}
+	if used, err = strconv.ParseUint(fields[2], 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("failed to parse disk usage: %s", output)
+	}
+	return size, used, nil
+}
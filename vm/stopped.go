@@ -5,12 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"sort"
 	"strings"
 	"time"
 
 	"github.com/pivotal-cf/pcfdev-cli/config"
-	"github.com/pivotal-cf/pcfdev-cli/helpers"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
 )
 
@@ -19,7 +17,7 @@ type Stopped struct {
 	VMConfig *config.VMConfig
 
 	FS        FS
-	VBox      VBox
+	Provider  Provider
 	SSHClient SSH
 	UI        UI
 	Builder   Builder
@@ -32,10 +30,10 @@ func (s *Stopped) Stop() error {
 
 func (s *Stopped) VerifyStartOpts(opts *StartOpts) error {
 	if opts.Memory != uint64(0) {
-		return errors.New("memory cannot be changed once the vm has been created")
+		return errors.New("memory cannot be changed with the -m flag once the vm has been created, use `cf dev resize -m`")
 	}
 	if opts.CPUs != 0 {
-		return errors.New("cores cannot be changed once the vm has been created")
+		return errors.New("cores cannot be changed with the -c flag once the vm has been created, use `cf dev resize -c`")
 	}
 	if opts.Services != "" {
 		return errors.New("services cannot be changed once the vm has been created")
@@ -59,30 +57,13 @@ func (s *Stopped) VerifyStartOpts(opts *StartOpts) error {
 
 func (s *Stopped) Start(opts *StartOpts) error {
 	s.UI.Say("Starting VM...")
-	if err := s.VBox.StartVM(s.VMConfig); err != nil {
+	if err := s.Provider.StartVM(s.VMConfig); err != nil {
 		return &StartVMError{err}
 	}
 
-	services := []string{}
-	if len(opts.Services) == 0 {
-		services = append(services, "rabbitmq", "redis")
-	} else {
-		for _, service := range strings.Split(opts.Services, ",") {
-			switch service {
-			case "all":
-				services = append(services, "rabbitmq", "redis", "spring-cloud-services")
-			case "default":
-				services = append(services, "rabbitmq", "redis")
-			case "rabbitmq":
-				services = append(services, "rabbitmq")
-			case "redis":
-				services = append(services, "redis")
-			case "spring-cloud-services", "scs":
-				services = append(services, "rabbitmq", "spring-cloud-services")
-			}
-		}
-		services = helpers.RemoveDuplicates(services)
-		sort.Strings(services)
+	services := []string{"rabbitmq", "redis"}
+	if opts.Services != "" {
+		services = expandServices(opts.Services)
 	}
 
 	registries := []string{}
@@ -136,6 +117,17 @@ func (s *Stopped) Start(opts *StartOpts) error {
 		return &StartVMError{err}
 	}
 
+	s.VMConfig.Services = services
+	if err := s.Provider.SaveVMConfig(s.VMConfig); err != nil {
+		return &StartVMError{err}
+	}
+
+	for _, mount := range s.VMConfig.Mounts {
+		if err := mountFolder(s.Provider, s.SSHClient, s.VMConfig, privateKeyBytes, mount); err != nil {
+			s.UI.Say(fmt.Sprintf("Warning: %s.", err))
+		}
+	}
+
 	if opts.NoProvision {
 		s.UI.Say("VM will not be provisioned because '-n' (no-provision) flag was specified.")
 		return nil
@@ -152,6 +144,10 @@ func (s *Stopped) Status() string {
 	return "Stopped"
 }
 
+func (s *Stopped) StatusDetails() (*Status, error) {
+	return newStatus(StateStopped, s.VMConfig), nil
+}
+
 func (s *Stopped) Suspend() error {
 	s.UI.Say("Your VM is currently stopped and cannot be suspended.")
 	return nil
@@ -181,3 +177,58 @@ func (s *Stopped) SSH() error {
 	s.UI.Say("Your VM is currently stopped. Start VM to SSH to PCF Dev.")
 	return nil
 }
+
+func (s *Stopped) RunCommand(string) error {
+	return errors.New("your VM is currently stopped, start VM to run commands on PCF Dev")
+}
+
+func (s *Stopped) Forward([]ssh.Forward) error {
+	s.UI.Say("Your VM is currently stopped. Start VM to forward ports to PCF Dev.")
+	return nil
+}
+
+func (s *Stopped) Wait(time.Duration) error {
+	return errors.New("your VM is currently stopped, start VM to wait for PCF Dev")
+}
+
+func (s *Stopped) Services() error {
+	return errors.New("your VM is currently stopped, start VM to list PCF Dev services")
+}
+
+func (s *Stopped) EnableService(string) error {
+	return errors.New("your VM is currently stopped, start VM to enable PCF Dev services")
+}
+
+func (s *Stopped) DisableService(string) error {
+	return errors.New("your VM is currently stopped, start VM to disable PCF Dev services")
+}
+
+func (s *Stopped) Resize(opts *ResizeOpts) error {
+	resized := resizedVMConfig(s.VMConfig, opts)
+	if err := verifyResize(s.Config, s.UI, resized, s.VMConfig.Services, 0); err != nil {
+		return err
+	}
+
+	if err := s.Provider.ResizeVM(resized); err != nil {
+		return &ResizeVMError{err}
+	}
+	s.UI.Say(fmt.Sprintf("PCF Dev VM now has %d MB of memory and %d cores.", resized.Memory, resized.CPUs))
+	return nil
+}
+
+func (s *Stopped) Mount(string, string) error {
+	return errors.New("your VM is currently stopped, start VM to mount folders in PCF Dev")
+}
+
+func (s *Stopped) Mounts() error {
+	return listMounts(s.UI, s.VMConfig)
+}
+
+func (s *Stopped) ResizeDisk(uint64) error {
+	return errors.New("your VM is currently stopped, start VM to resize the PCF Dev disk")
+}
+
+func (s *Stopped) Copy(*CopyOpts) error {
+	s.UI.Say("Your VM is currently stopped. Start VM to copy files to or from PCF Dev.")
+	return nil
+}
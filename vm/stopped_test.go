@@ -20,7 +20,7 @@ var _ = Describe("Stopped", func() {
 		mockCtrl          *gomock.Controller
 		mockFS            *mocks.MockFS
 		mockUI            *mocks.MockUI
-		mockVBox          *mocks.MockVBox
+		mockProvider      *mocks.MockProvider
 		mockSSH           *mocks.MockSSH
 		mockBuilder       *mocks.MockBuilder
 		mockUnprovisioned *mocks.MockVM
@@ -31,7 +31,7 @@ var _ = Describe("Stopped", func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockFS = mocks.NewMockFS(mockCtrl)
 		mockUI = mocks.NewMockUI(mockCtrl)
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockSSH = mocks.NewMockSSH(mockCtrl)
 		mockBuilder = mocks.NewMockBuilder(mockCtrl)
 		mockUnprovisioned = mocks.NewMockVM(mockCtrl)
@@ -45,7 +45,7 @@ var _ = Describe("Stopped", func() {
 				Provider: "some-provider",
 			},
 
-			VBox:      mockVBox,
+			Provider:  mockProvider,
 			FS:        mockFS,
 			UI:        mockUI,
 			SSHClient: mockSSH,
@@ -88,7 +88,7 @@ var _ = Describe("Stopped", func() {
 			It("should return an error", func() {
 				Expect(stoppedVM.VerifyStartOpts(&vm.StartOpts{
 					Memory: 4000,
-				})).To(MatchError("memory cannot be changed once the vm has been created"))
+				})).To(MatchError("memory cannot be changed with the -m flag once the vm has been created, use `cf dev resize -m`"))
 			})
 		})
 
@@ -96,7 +96,7 @@ var _ = Describe("Stopped", func() {
 			It("should return an error", func() {
 				Expect(stoppedVM.VerifyStartOpts(&vm.StartOpts{
 					CPUs: 2,
-				})).To(MatchError("cores cannot be changed once the vm has been created"))
+				})).To(MatchError("cores cannot be changed with the -c flag once the vm has been created, use `cf dev resize -c`"))
 			})
 		})
 
@@ -171,22 +171,24 @@ var _ = Describe("Stopped", func() {
 		allowHappyPathInteractions := func() {
 			mockSSH.EXPECT().RunSSHCommand(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 			mockUI.EXPECT().Say(gomock.Any()).AnyTimes()
-			mockVBox.EXPECT().StartVM(gomock.Any()).AnyTimes()
+			mockProvider.EXPECT().StartVM(gomock.Any()).AnyTimes()
 			mockBuilder.EXPECT().VM(gomock.Any()).AnyTimes().Return(mockUnprovisioned, nil)
 			mockFS.EXPECT().Read(gomock.Any()).AnyTimes().Return([]byte("some-private-key"), nil)
 			mockUnprovisioned.EXPECT().Provision(gomock.Any()).AnyTimes()
+			mockProvider.EXPECT().SaveVMConfig(gomock.Any()).AnyTimes()
 		}
 
 		Context("when 'none' services are specified", func() {
 			It("should start vm with no extra services", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Starting VM..."),
-					mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockProvider.EXPECT().StartVM(stoppedVM.VMConfig),
 					mockBuilder.EXPECT().VM("some-vm").Return(mockUnprovisioned, nil),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand("echo "+
 						`'{"domain":"some-domain","ip":"some-ip","services":"","registries":[],"provider":"some-provider"}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`,
 						addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig),
 					mockUnprovisioned.EXPECT().Provision(&vm.StartOpts{Services: "none"}),
 				)
 
@@ -194,20 +196,47 @@ var _ = Describe("Stopped", func() {
 			})
 		})
 
+		Context("when folders are mounted", func() {
+			It("should mount them again before provisioning", func() {
+				stoppedVM.VMConfig.Mounts = []*config.Mount{
+					{Name: "pcfdev-mount-1", HostPath: "/some-host-path", GuestPath: "/some-guest-path"},
+					{Name: "pcfdev-mount-2", HostPath: "/some-other-host-path", GuestPath: "/some-other-guest-path"},
+				}
+
+				gomock.InOrder(
+					mockUI.EXPECT().Say("Starting VM..."),
+					mockProvider.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockBuilder.EXPECT().VM("some-vm").Return(mockUnprovisioned, nil),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().RunSSHCommand(gomock.Any(), addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig),
+					mockProvider.EXPECT().ShareFolder(stoppedVM.VMConfig, stoppedVM.VMConfig.Mounts[0]),
+					mockSSH.EXPECT().GetSSHOutput("sudo mkdir -p '/some-guest-path' && sudo mount -t vboxsf -o uid=$(id -u),gid=$(id -g) pcfdev-mount-1 '/some-guest-path'", addresses, []byte("some-private-key"), 30*time.Second),
+					mockProvider.EXPECT().ShareFolder(stoppedVM.VMConfig, stoppedVM.VMConfig.Mounts[1]).Return(errors.New("some-error")),
+					mockUI.EXPECT().Say("Warning: failed to mount /some-other-host-path at /some-other-guest-path: some-error."),
+					mockUnprovisioned.EXPECT().Provision(&vm.StartOpts{Services: "none"}),
+				)
+
+				Expect(stoppedVM.Start(&vm.StartOpts{Services: "none"})).To(Succeed())
+			})
+		})
+
 		Context("when 'all' services are specified", func() {
 			It("should start the vm with services", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Starting VM..."),
-					mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockProvider.EXPECT().StartVM(stoppedVM.VMConfig),
 					mockBuilder.EXPECT().VM("some-vm").Return(mockUnprovisioned, nil),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand("echo "+
 						`'{"domain":"some-domain","ip":"some-ip","services":"rabbitmq,redis,spring-cloud-services","registries":[],"provider":"some-provider"}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`,
 						addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig),
 					mockUnprovisioned.EXPECT().Provision(&vm.StartOpts{Services: "all"}),
 				)
 
 				stoppedVM.Start(&vm.StartOpts{Services: "all"})
+				Expect(stoppedVM.VMConfig.Services).To(Equal([]string{"rabbitmq", "redis", "spring-cloud-services"}))
 			})
 		})
 
@@ -215,12 +244,13 @@ var _ = Describe("Stopped", func() {
 			It("should start the vm with rabbitmq and redis", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Starting VM..."),
-					mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockProvider.EXPECT().StartVM(stoppedVM.VMConfig),
 					mockBuilder.EXPECT().VM("some-vm").Return(mockUnprovisioned, nil),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand("echo "+
 						`'{"domain":"some-domain","ip":"some-ip","services":"rabbitmq,redis","registries":[],"provider":"some-provider"}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`,
 						addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig),
 					mockUnprovisioned.EXPECT().Provision(&vm.StartOpts{Services: "default"}),
 				)
 
@@ -232,12 +262,13 @@ var _ = Describe("Stopped", func() {
 			It("should start the vm with spring-cloud-services and rabbitmq", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Starting VM..."),
-					mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockProvider.EXPECT().StartVM(stoppedVM.VMConfig),
 					mockBuilder.EXPECT().VM("some-vm").Return(mockUnprovisioned, nil),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand("echo "+
 						`'{"domain":"some-domain","ip":"some-ip","services":"rabbitmq,spring-cloud-services","registries":[],"provider":"some-provider"}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`,
 						addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig),
 					mockUnprovisioned.EXPECT().Provision(&vm.StartOpts{Services: "spring-cloud-services"}),
 				)
 
@@ -249,12 +280,13 @@ var _ = Describe("Stopped", func() {
 			It("should start the vm with spring-cloud-services and rabbitmq", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Starting VM..."),
-					mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockProvider.EXPECT().StartVM(stoppedVM.VMConfig),
 					mockBuilder.EXPECT().VM("some-vm").Return(mockUnprovisioned, nil),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand("echo "+
 						`'{"domain":"some-domain","ip":"some-ip","services":"rabbitmq,spring-cloud-services","registries":[],"provider":"some-provider"}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`,
 						addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig),
 					mockUnprovisioned.EXPECT().Provision(&vm.StartOpts{Services: "scs"}),
 				)
 
@@ -266,12 +298,13 @@ var _ = Describe("Stopped", func() {
 			It("should start the vm with rabbitmq", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Starting VM..."),
-					mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockProvider.EXPECT().StartVM(stoppedVM.VMConfig),
 					mockBuilder.EXPECT().VM("some-vm").Return(mockUnprovisioned, nil),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand("echo "+
 						`'{"domain":"some-domain","ip":"some-ip","services":"rabbitmq","registries":[],"provider":"some-provider"}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`,
 						addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig),
 					mockUnprovisioned.EXPECT().Provision(&vm.StartOpts{Services: "rabbitmq"}),
 				)
 
@@ -283,12 +316,13 @@ var _ = Describe("Stopped", func() {
 			It("should start the vm with redis", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Starting VM..."),
-					mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockProvider.EXPECT().StartVM(stoppedVM.VMConfig),
 					mockBuilder.EXPECT().VM("some-vm").Return(mockUnprovisioned, nil),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand("echo "+
 						`'{"domain":"some-domain","ip":"some-ip","services":"redis","registries":[],"provider":"some-provider"}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`,
 						addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig),
 					mockUnprovisioned.EXPECT().Provision(&vm.StartOpts{Services: "redis"}),
 				)
 
@@ -300,12 +334,13 @@ var _ = Describe("Stopped", func() {
 			It("should start the vm with no extra services", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Starting VM..."),
-					mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockProvider.EXPECT().StartVM(stoppedVM.VMConfig),
 					mockBuilder.EXPECT().VM("some-vm").Return(mockUnprovisioned, nil),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand("echo "+
 						`'{"domain":"some-domain","ip":"some-ip","services":"","registries":[],"provider":"some-provider"}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`,
 						addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig),
 					mockUnprovisioned.EXPECT().Provision(&vm.StartOpts{Services: "mysql"}),
 				)
 
@@ -317,12 +352,13 @@ var _ = Describe("Stopped", func() {
 			It("should start the vm without duplicates services", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Starting VM..."),
-					mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockProvider.EXPECT().StartVM(stoppedVM.VMConfig),
 					mockBuilder.EXPECT().VM("some-vm").Return(mockUnprovisioned, nil),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand("echo "+
 						`'{"domain":"some-domain","ip":"some-ip","services":"rabbitmq,redis,spring-cloud-services","registries":[],"provider":"some-provider"}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`,
 						addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig),
 					mockUnprovisioned.EXPECT().Provision(&vm.StartOpts{Services: "default,spring-cloud-services,scs"}),
 				)
 
@@ -334,12 +370,13 @@ var _ = Describe("Stopped", func() {
 			It("should start the vm with default services", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Starting VM..."),
-					mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockProvider.EXPECT().StartVM(stoppedVM.VMConfig),
 					mockBuilder.EXPECT().VM("some-vm").Return(mockUnprovisioned, nil),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand("echo "+
 						`'{"domain":"some-domain","ip":"some-ip","services":"rabbitmq,redis","registries":[],"provider":"some-provider"}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`,
 						addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig),
 					mockUnprovisioned.EXPECT().Provision(&vm.StartOpts{}),
 				)
 
@@ -351,12 +388,13 @@ var _ = Describe("Stopped", func() {
 			It("should start the vm with the custom ip", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Starting VM..."),
-					mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockProvider.EXPECT().StartVM(stoppedVM.VMConfig),
 					mockBuilder.EXPECT().VM("some-vm").Return(mockUnprovisioned, nil),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand("echo "+
 						`'{"domain":"some-domain","ip":"some-custom-ip","services":"rabbitmq,redis","registries":[],"provider":"some-provider"}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`,
 						addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig),
 					mockUnprovisioned.EXPECT().Provision(&vm.StartOpts{IP: "some-custom-ip"}),
 				)
 
@@ -368,12 +406,13 @@ var _ = Describe("Stopped", func() {
 			It("should start the vm with the custom domain", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Starting VM..."),
-					mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockProvider.EXPECT().StartVM(stoppedVM.VMConfig),
 					mockBuilder.EXPECT().VM("some-vm").Return(mockUnprovisioned, nil),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand("echo "+
 						`'{"domain":"some-custom-domain","ip":"some-ip","services":"rabbitmq,redis","registries":[],"provider":"some-provider"}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`,
 						addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig),
 					mockUnprovisioned.EXPECT().Provision(&vm.StartOpts{Domain: "some-custom-domain"}),
 				)
 
@@ -385,12 +424,13 @@ var _ = Describe("Stopped", func() {
 			It("should start the vm with the registries accessible", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Starting VM..."),
-					mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockProvider.EXPECT().StartVM(stoppedVM.VMConfig),
 					mockBuilder.EXPECT().VM("some-vm").Return(mockUnprovisioned, nil),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand("echo "+
 						`'{"domain":"some-domain","ip":"some-ip","services":"rabbitmq,redis","registries":["some-private-registry","some-other-private-registry"],"provider":"some-provider"}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`,
 						addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig),
 					mockUnprovisioned.EXPECT().Provision(&vm.StartOpts{Registries: "some-private-registry,some-other-private-registry"}),
 				)
 
@@ -402,12 +442,13 @@ var _ = Describe("Stopped", func() {
 			It("should start the vm with the master password", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Starting VM..."),
-					mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockProvider.EXPECT().StartVM(stoppedVM.VMConfig),
 					mockBuilder.EXPECT().VM("some-vm").Return(mockUnprovisioned, nil),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand("echo "+
 						`'{"domain":"some-domain","ip":"some-ip","services":"rabbitmq,redis","registries":[],"provider":"some-provider"}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`,
 						addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig),
 					mockUnprovisioned.EXPECT().Provision(&vm.StartOpts{MasterPassword: "some-master-password"}),
 				)
 
@@ -419,12 +460,13 @@ var _ = Describe("Stopped", func() {
 			It("should not provision the vm", func() {
 				gomock.InOrder(
 					mockUI.EXPECT().Say("Starting VM..."),
-					mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockProvider.EXPECT().StartVM(stoppedVM.VMConfig),
 					mockBuilder.EXPECT().VM("some-vm"),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand("echo "+
 						`'{"domain":"some-domain","ip":"some-ip","services":"rabbitmq,redis","registries":[],"provider":"some-provider"}' | sudo tee /var/pcfdev/provision-options.json >/dev/null`,
 						addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout, os.Stderr),
+					mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig),
 					mockUI.EXPECT().Say("VM will not be provisioned because '-n' (no-provision) flag was specified."),
 				)
 
@@ -436,7 +478,7 @@ var _ = Describe("Stopped", func() {
 
 		Context("when starting the vm fails", func() {
 			It("should return an error", func() {
-				mockVBox.EXPECT().StartVM(stoppedVM.VMConfig).Return(errors.New("some-error"))
+				mockProvider.EXPECT().StartVM(stoppedVM.VMConfig).Return(errors.New("some-error"))
 				allowHappyPathInteractions()
 
 				Expect(stoppedVM.Start(&vm.StartOpts{})).To(MatchError("failed to start VM: some-error"))
@@ -463,6 +505,15 @@ var _ = Describe("Stopped", func() {
 			})
 		})
 
+		Context("when saving the VM config fails", func() {
+			It("should return an error", func() {
+				mockProvider.EXPECT().SaveVMConfig(stoppedVM.VMConfig).Return(errors.New("some-error"))
+				allowHappyPathInteractions()
+
+				Expect(stoppedVM.Start(&vm.StartOpts{})).To(MatchError("failed to start VM: some-error"))
+			})
+		})
+
 		Context("when retrieving the unprovisioned vm fails", func() {
 			It("should return an error", func() {
 				mockBuilder.EXPECT().VM("some-vm").Return(nil, errors.New("some-error"))
@@ -487,6 +538,19 @@ var _ = Describe("Stopped", func() {
 		})
 	})
 
+	Describe("StatusDetails", func() {
+		It("should return the stopped status", func() {
+			Expect(stoppedVM.StatusDetails()).To(Equal(&vm.Status{
+				State:   "stopped",
+				VMName:  "some-vm",
+				IP:      "some-ip",
+				Domain:  "some-domain",
+				SSHPort: "some-port",
+				APIURL:  "https://api.some-domain",
+			}))
+		})
+	})
+
 	Describe("Suspend", func() {
 		It("should say a message", func() {
 			mockUI.EXPECT().Say("Your VM is currently stopped and cannot be suspended.")
@@ -530,4 +594,164 @@ var _ = Describe("Stopped", func() {
 			Expect(stoppedVM.SSH()).To(Succeed())
 		})
 	})
+
+	Describe("Wait", func() {
+		It("should return an error", func() {
+			Expect(stoppedVM.Wait(time.Minute)).To(MatchError("your VM is currently stopped, start VM to wait for PCF Dev"))
+		})
+	})
+
+	Describe("Resize", func() {
+		BeforeEach(func() {
+			stoppedVM.VMConfig.Memory = 4096
+			stoppedVM.VMConfig.CPUs = 2
+			stoppedVM.Config.MinMemory = 3072
+			stoppedVM.Config.FreeMemory = 8192
+		})
+
+		It("should apply the new memory and cores to the VM", func() {
+			gomock.InOrder(
+				mockProvider.EXPECT().ResizeVM(&config.VMConfig{
+					Name:     "some-vm",
+					Domain:   "some-domain",
+					IP:       "some-ip",
+					SSHPort:  "some-port",
+					Provider: "some-provider",
+					Memory:   6144,
+					CPUs:     4,
+				}),
+				mockUI.EXPECT().Say("PCF Dev VM now has 6144 MB of memory and 4 cores."),
+			)
+
+			Expect(stoppedVM.Resize(&vm.ResizeOpts{Memory: 6144, CPUs: 4})).To(Succeed())
+		})
+
+		Context("when only the cores are passed", func() {
+			It("should keep the memory", func() {
+				gomock.InOrder(
+					mockProvider.EXPECT().ResizeVM(gomock.Any()).Do(func(vmConfig *config.VMConfig) {
+						Expect(vmConfig.Memory).To(Equal(uint64(4096)))
+						Expect(vmConfig.CPUs).To(Equal(4))
+					}),
+					mockUI.EXPECT().Say("PCF Dev VM now has 4096 MB of memory and 4 cores."),
+				)
+
+				Expect(stoppedVM.Resize(&vm.ResizeOpts{CPUs: 4})).To(Succeed())
+			})
+		})
+
+		Context("when the memory is below the minimum", func() {
+			It("should return an error", func() {
+				Expect(stoppedVM.Resize(&vm.ResizeOpts{Memory: 2048})).To(MatchError("PCF Dev requires at least 3072 MB of memory to run"))
+			})
+		})
+
+		Context("when Spring Cloud Services were provisioned", func() {
+			BeforeEach(func() {
+				stoppedVM.Config.SpringCloudMinMemory = 6144
+			})
+
+			It("should return an error when the memory is below the Spring Cloud Services minimum", func() {
+				stoppedVM.VMConfig.Services = []string{"rabbitmq", "spring-cloud-services"}
+
+				Expect(stoppedVM.Resize(&vm.ResizeOpts{Memory: 4096})).To(MatchError("PCF Dev requires at least 6144 MB of memory to run Spring Cloud Services"))
+			})
+
+			It("should expand service aliases", func() {
+				stoppedVM.VMConfig.Services = []string{"all"}
+
+				Expect(stoppedVM.Resize(&vm.ResizeOpts{Memory: 4096})).To(MatchError("PCF Dev requires at least 6144 MB of memory to run Spring Cloud Services"))
+			})
+		})
+
+		Context("when there is not enough free memory", func() {
+			It("should ask for confirmation", func() {
+				mockUI.EXPECT().Confirm("Less than 10240 MB of free memory detected, continue (y/N): ").Return(false)
+
+				Expect(stoppedVM.Resize(&vm.ResizeOpts{Memory: 10240})).To(MatchError("user declined to continue, exiting"))
+			})
+		})
+
+		Context("when resizing the VM fails", func() {
+			It("should return an error", func() {
+				mockProvider.EXPECT().ResizeVM(gomock.Any()).Return(errors.New("some-error"))
+
+				Expect(stoppedVM.Resize(&vm.ResizeOpts{Memory: 6144})).To(MatchError("failed to resize VM: some-error"))
+			})
+		})
+	})
+
+	Describe("ResizeDisk", func() {
+		It("should return an error", func() {
+			Expect(stoppedVM.ResizeDisk(100)).To(MatchError("your VM is currently stopped, start VM to resize the PCF Dev disk"))
+		})
+	})
+
+	Describe("Mount", func() {
+		It("should return an error", func() {
+			Expect(stoppedVM.Mount("/some-host-path", "/some-guest-path")).To(MatchError("your VM is currently stopped, start VM to mount folders in PCF Dev"))
+		})
+	})
+
+	Describe("Mounts", func() {
+		It("should list the mounted folders", func() {
+			stoppedVM.VMConfig.Mounts = []*config.Mount{
+				{Name: "pcfdev-mount-1", HostPath: "/some-host-path", GuestPath: "/some-guest-path"},
+				{Name: "pcfdev-mount-2", HostPath: "/some-other-host-path", GuestPath: "/some-other-guest-path"},
+			}
+
+			gomock.InOrder(
+				mockUI.EXPECT().Say("/some-host-path => /some-guest-path"),
+				mockUI.EXPECT().Say("/some-other-host-path => /some-other-guest-path"),
+			)
+
+			Expect(stoppedVM.Mounts()).To(Succeed())
+		})
+
+		Context("when no folders are mounted", func() {
+			It("should say so", func() {
+				mockUI.EXPECT().Say("No folders are mounted.")
+
+				Expect(stoppedVM.Mounts()).To(Succeed())
+			})
+		})
+	})
+
+	Describe("Services", func() {
+		It("should return an error", func() {
+			Expect(stoppedVM.Services()).To(MatchError("your VM is currently stopped, start VM to list PCF Dev services"))
+		})
+	})
+
+	Describe("EnableService", func() {
+		It("should return an error", func() {
+			Expect(stoppedVM.EnableService("redis")).To(MatchError("your VM is currently stopped, start VM to enable PCF Dev services"))
+		})
+	})
+
+	Describe("DisableService", func() {
+		It("should return an error", func() {
+			Expect(stoppedVM.DisableService("redis")).To(MatchError("your VM is currently stopped, start VM to disable PCF Dev services"))
+		})
+	})
+
+	Describe("RunCommand", func() {
+		It("should return an error", func() {
+			Expect(stoppedVM.RunCommand("some-command")).To(MatchError("your VM is currently stopped, start VM to run commands on PCF Dev"))
+		})
+	})
+
+	Describe("Forward", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is currently stopped. Start VM to forward ports to PCF Dev.")
+			Expect(stoppedVM.Forward([]ssh.Forward{})).To(Succeed())
+		})
+	})
+
+	Describe("Copy", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is currently stopped. Start VM to copy files to or from PCF Dev.")
+			Expect(stoppedVM.Copy(&vm.CopyOpts{})).To(Succeed())
+		})
+	})
 })
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -13,21 +14,35 @@ import (
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
 )
 
+// timedOutStatus is the exit status of timeout(1) when it stops the command
+// that it runs.
+const timedOutStatus = 124
+
+type exitStatusError interface {
+	ExitStatus() int
+}
+
 type Unprovisioned struct {
 	FS         FS
 	SSHClient  SSH
 	UI         UI
-	VBox       VBox
+	Provider   Provider
 	LogFetcher LogFetcher
 	Config     *config.Config
 	VMConfig   *config.VMConfig
 	HelpText   HelpText
 	Client     Client
+	Readiness  Readiness
+	Spinner    io.Writer
 }
 
+const provisionLogPath = "/var/pcfdev/provision.log"
+
+const provisionLogLines = 20
+
 func (u *Unprovisioned) Stop() error {
 	u.UI.Say("Stopping VM...")
-	if err := u.VBox.StopVM(u.VMConfig); err != nil {
+	if err := u.Provider.StopVM(u.VMConfig); err != nil {
 		return err
 	}
 	u.UI.Say("PCF Dev is now stopped.")
@@ -46,14 +61,31 @@ func (u *Unprovisioned) Status() string {
 	return u.err().Error()
 }
 
+func (u *Unprovisioned) StatusDetails() (*Status, error) {
+	status := newStatus(StateUnprovisioned, u.VMConfig)
+	status.Message = u.err().Error()
+	return status, nil
+}
+
 func (u *Unprovisioned) Provision(opts *StartOpts) error {
+	addresses := []ssh.SSHAddress{
+		{
+			IP:   "127.0.0.1",
+			Port: u.VMConfig.SSHPort,
+		},
+		{
+			IP:   u.VMConfig.IP,
+			Port: "22",
+		},
+	}
+
 	if opts.MasterPassword != "" {
 		privateKey, err := u.FS.Read(u.Config.PrivateKeyPath)
 		if err != nil {
 			return err
 		}
 
-		if err := u.Client.ReplaceSecrets(u.VMConfig.IP, opts.MasterPassword, privateKey); err != nil {
+		if err := u.Client.ReplaceSecrets(addresses, opts.MasterPassword, privateKey); err != nil {
 			return err
 		}
 	}
@@ -63,17 +95,6 @@ func (u *Unprovisioned) Provision(opts *StartOpts) error {
 		return err
 	}
 
-	addresses := []ssh.SSHAddress{
-		{
-			IP:   "127.0.0.1",
-			Port: u.VMConfig.SSHPort,
-		},
-		{
-			IP:   u.VMConfig.IP,
-			Port: "22",
-		},
-	}
-
 	if err := u.SSHClient.RunSSHCommand("if [ -e /var/pcfdev/provision-options.json ]; then exit 0; else exit 1; fi", addresses, privateKeyBytes, 30*time.Second, os.Stdout, os.Stderr); err != nil {
 		return &ProvisionVMError{errors.New("missing provision configuration")}
 	}
@@ -89,16 +110,42 @@ func (u *Unprovisioned) Provision(opts *StartOpts) error {
 	}
 
 	u.UI.Say("Provisioning VM...")
-	provisionCommand := fmt.Sprintf(`sudo -H /var/pcfdev/provision "%s" "%s" "%s" "%s" "%s"`, provisionConfig.Domain, provisionConfig.IP, provisionConfig.Services, strings.Join(provisionConfig.Registries, ","), provisionConfig.Provider)
-	if err := u.SSHClient.RunSSHCommand(provisionCommand, addresses, privateKeyBytes, 5*time.Minute, os.Stdout, os.Stderr); err != nil {
+	provisionCommand := fmt.Sprintf(`/var/pcfdev/provision "%s" "%s" "%s" "%s" "%s"`, provisionConfig.Domain, provisionConfig.IP, provisionConfig.Services, strings.Join(provisionConfig.Registries, ","), provisionConfig.Provider)
+	if u.Config.ProvisionTimeout != 0 {
+		provisionCommand = fmt.Sprintf("timeout %vs %s", u.Config.ProvisionTimeout.Seconds(), provisionCommand)
+	}
+	progress := &ProvisionProgress{UI: u.UI, Spinner: u.Spinner}
+	progress.Start()
+	if err := u.SSHClient.RunSSHCommand("sudo -H "+provisionCommand, addresses, privateKeyBytes, 30*time.Second, progress, os.Stderr); err != nil {
+		if exitErr, ok := err.(exitStatusError); ok && exitErr.ExitStatus() == timedOutStatus {
+			err = &ProvisionTimeoutError{u.Config.ProvisionTimeout}
+		}
+		progress.Finish(false)
+		u.printProvisionLog(addresses, privateKeyBytes)
 		return &ProvisionVMError{err}
 	}
+	progress.Finish(true)
+
+	u.UI.Say("Waiting for PCF Dev to be ready...")
+	if err := u.Readiness.Wait(u.VMConfig, privateKeyBytes, ReadinessTimeout); err != nil {
+		return err
+	}
 
 	u.HelpText.Print(u.VMConfig.Domain, opts.Target)
 
 	return nil
 }
 
+func (u *Unprovisioned) printProvisionLog(addresses []ssh.SSHAddress, privateKey []byte) {
+	output, err := u.SSHClient.GetSSHOutput(fmt.Sprintf("sudo tail -n %d %s", provisionLogLines, provisionLogPath), addresses, privateKey, 30*time.Second)
+	if err != nil || strings.TrimSpace(output) == "" {
+		return
+	}
+
+	u.UI.Say(fmt.Sprintf("Last %d lines of %s:", provisionLogLines, provisionLogPath))
+	u.UI.Say(strings.TrimRight(output, "\n"))
+}
+
 func (u *Unprovisioned) Suspend() error {
 	return u.err()
 }
@@ -130,6 +177,79 @@ func (u *Unprovisioned) SSH() error {
 	return u.SSHClient.StartSSHSession(addresses, privateKeyBytes, 5*time.Minute, stdin, stdout, stderr)
 }
 
+func (u *Unprovisioned) RunCommand(command string) error {
+	privateKeyBytes, err := u.FS.Read(u.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: u.VMConfig.SSHPort},
+		{IP: u.VMConfig.IP, Port: "22"},
+	}
+
+	_, stdout, stderr := term.StdStreams()
+	return u.SSHClient.RunSSHCommand(command, addresses, privateKeyBytes, 5*time.Minute, stdout, stderr)
+}
+
+func (u *Unprovisioned) Forward(forwards []ssh.Forward) error {
+	privateKeyBytes, err := u.FS.Read(u.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	return forwardPorts(u.SSHClient, u.UI, u.VMConfig, privateKeyBytes, forwards)
+}
+
+func (u *Unprovisioned) Wait(time.Duration) error {
+	return u.err()
+}
+
+func (u *Unprovisioned) Services() error {
+	return u.err()
+}
+
+func (u *Unprovisioned) EnableService(string) error {
+	return u.err()
+}
+
+func (u *Unprovisioned) DisableService(string) error {
+	return u.err()
+}
+
+func (u *Unprovisioned) Resize(*ResizeOpts) error {
+	return u.err()
+}
+
+func (u *Unprovisioned) ResizeDisk(uint64) error {
+	return u.err()
+}
+
+func (u *Unprovisioned) Mount(string, string) error {
+	return u.err()
+}
+
+func (u *Unprovisioned) Mounts() error {
+	return u.err()
+}
+
+func (u *Unprovisioned) Copy(opts *CopyOpts) error {
+	privateKeyBytes, err := u.FS.Read(u.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: u.VMConfig.SSHPort},
+		{IP: u.VMConfig.IP, Port: "22"},
+	}
+
+	if opts.ToGuest {
+		return u.SSHClient.CopyToGuest(opts.HostPath, opts.GuestPath, addresses, privateKeyBytes, 5*time.Minute, os.Stdout)
+	}
+	return u.SSHClient.CopyFromGuest(opts.GuestPath, opts.HostPath, addresses, privateKeyBytes, 5*time.Minute, os.Stdout)
+}
+
 func (u *Unprovisioned) err() error {
 	return errors.New("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'")
 }
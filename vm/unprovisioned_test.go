@@ -2,6 +2,8 @@ package vm_test
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -21,31 +23,34 @@ var _ = Describe("Unprovisioned", func() {
 		mockCtrl       *gomock.Controller
 		mockFS         *mocks.MockFS
 		mockUI         *mocks.MockUI
-		mockVBox       *mocks.MockVBox
+		mockProvider   *mocks.MockProvider
 		mockSSH        *mocks.MockSSH
 		mockClient     *mocks.MockClient
 		mockLogFetcher *mocks.MockLogFetcher
 		mockHelpText   *mocks.MockHelpText
+		mockReadiness  *mocks.MockReadiness
 		unprovisioned  vm.Unprovisioned
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
-		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockProvider = mocks.NewMockProvider(mockCtrl)
 		mockUI = mocks.NewMockUI(mockCtrl)
 		mockFS = mocks.NewMockFS(mockCtrl)
 		mockSSH = mocks.NewMockSSH(mockCtrl)
 		mockClient = mocks.NewMockClient(mockCtrl)
 		mockLogFetcher = mocks.NewMockLogFetcher(mockCtrl)
 		mockHelpText = mocks.NewMockHelpText(mockCtrl)
+		mockReadiness = mocks.NewMockReadiness(mockCtrl)
 
 		unprovisioned = vm.Unprovisioned{
 			UI:         mockUI,
-			VBox:       mockVBox,
+			Provider:   mockProvider,
 			FS:         mockFS,
 			SSHClient:  mockSSH,
 			LogFetcher: mockLogFetcher,
 			HelpText:   mockHelpText,
+			Readiness:  mockReadiness,
 			Client:     mockClient,
 			Config: &conf.Config{
 				PrivateKeyPath: "some-private-key-path",
@@ -67,7 +72,7 @@ var _ = Describe("Unprovisioned", func() {
 		It("should stop the VM", func() {
 			gomock.InOrder(
 				mockUI.EXPECT().Say("Stopping VM..."),
-				mockVBox.EXPECT().StopVM(unprovisioned.VMConfig),
+				mockProvider.EXPECT().StopVM(unprovisioned.VMConfig),
 				mockUI.EXPECT().Say("PCF Dev is now stopped."),
 			)
 
@@ -76,7 +81,7 @@ var _ = Describe("Unprovisioned", func() {
 
 		It("should bubble the error up if stopping fails", func() {
 			mockUI.EXPECT().Say("Stopping VM...")
-			mockVBox.EXPECT().StopVM(unprovisioned.VMConfig).Return(errors.New("some error"))
+			mockProvider.EXPECT().StopVM(unprovisioned.VMConfig).Return(errors.New("some error"))
 			Expect(unprovisioned.Stop()).To(MatchError("some error"))
 		})
 	})
@@ -118,14 +123,19 @@ var _ = Describe("Unprovisioned", func() {
 					30*time.Second,
 				).Return(`{"domain":"some-domain","ip":"some-ip","services":"some-service,some-other-service","registries":["some-registry","some-other-registry"],"provider":"some-provider"}`, nil),
 				mockUI.EXPECT().Say("Provisioning VM..."),
+				mockUI.EXPECT().Say("Configuring network..."),
 				mockSSH.EXPECT().RunSSHCommand(
 					`sudo -H /var/pcfdev/provision "some-domain" "some-ip" "some-service,some-other-service" "some-registry,some-other-registry" "some-provider"`,
 					sshAddresses,
 					[]byte("some-private-key"),
-					5*time.Minute,
-					os.Stdout,
+					30*time.Second,
+					gomock.Any(),
 					os.Stderr,
 				),
+				mockUI.EXPECT().Say("Configuring network done (0s)."),
+				mockUI.EXPECT().Say("Provisioned in 0s."),
+				mockUI.EXPECT().Say("Waiting for PCF Dev to be ready..."),
+				mockReadiness.EXPECT().Wait(unprovisioned.VMConfig, []byte("some-private-key"), vm.ReadinessTimeout),
 				mockHelpText.EXPECT().Print("some-domain", false),
 			)
 
@@ -140,7 +150,7 @@ var _ = Describe("Unprovisioned", func() {
 				}
 				gomock.InOrder(
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
-					mockClient.EXPECT().ReplaceSecrets("some-ip", "some-master-password", []byte("some-private-key")),
+					mockClient.EXPECT().ReplaceSecrets(sshAddresses, "some-master-password", []byte("some-private-key")),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand(
 						"if [ -e /var/pcfdev/provision-options.json ]; then exit 0; else exit 1; fi",
@@ -157,14 +167,19 @@ var _ = Describe("Unprovisioned", func() {
 						30*time.Second,
 					).Return(`{"domain":"some-domain","ip":"some-ip","services":"some-service,some-other-service","registries":["some-registry","some-other-registry"],"provider":"some-provider"}`, nil),
 					mockUI.EXPECT().Say("Provisioning VM..."),
+					mockUI.EXPECT().Say("Configuring network..."),
 					mockSSH.EXPECT().RunSSHCommand(
 						`sudo -H /var/pcfdev/provision "some-domain" "some-ip" "some-service,some-other-service" "some-registry,some-other-registry" "some-provider"`,
 						sshAddresses,
 						[]byte("some-private-key"),
-						5*time.Minute,
-						os.Stdout,
+						30*time.Second,
+						gomock.Any(),
 						os.Stderr,
 					),
+					mockUI.EXPECT().Say("Configuring network done (0s)."),
+					mockUI.EXPECT().Say("Provisioned in 0s."),
+					mockUI.EXPECT().Say("Waiting for PCF Dev to be ready..."),
+					mockReadiness.EXPECT().Wait(unprovisioned.VMConfig, []byte("some-private-key"), vm.ReadinessTimeout),
 					mockHelpText.EXPECT().Print("some-domain", false),
 				)
 
@@ -174,9 +189,13 @@ var _ = Describe("Unprovisioned", func() {
 
 		Context("when the user passes in a master password and there is an error", func() {
 			It("should return the error", func() {
+				sshAddresses := []ssh.SSHAddress{
+					{IP: "127.0.0.1", Port: "some-port"},
+					{IP: "some-ip", Port: "22"},
+				}
 				gomock.InOrder(
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
-					mockClient.EXPECT().ReplaceSecrets("some-ip", "some-master-password", []byte("some-private-key")).Return(errors.New("some-error")),
+					mockClient.EXPECT().ReplaceSecrets(sshAddresses, "some-master-password", []byte("some-private-key")).Return(errors.New("some-error")),
 				)
 
 				Expect(unprovisioned.Provision(&vm.StartOpts{MasterPassword: "some-master-password"})).To(MatchError("some-error"))
@@ -214,14 +233,19 @@ var _ = Describe("Unprovisioned", func() {
 						30*time.Second,
 					).Return(`{"domain":"some-domain","ip":"some-ip","services":"some-service,some-other-service","registries":["some-registry","some-other-registry"],"provider":"some-provider"}`, nil),
 					mockUI.EXPECT().Say("Provisioning VM..."),
+					mockUI.EXPECT().Say("Configuring network..."),
 					mockSSH.EXPECT().RunSSHCommand(
 						`sudo -H /var/pcfdev/provision "some-domain" "some-ip" "some-service,some-other-service" "some-registry,some-other-registry" "some-provider"`,
 						sshAddresses,
 						[]byte("some-private-key"),
-						5*time.Minute,
-						os.Stdout,
+						30*time.Second,
+						gomock.Any(),
 						os.Stderr,
 					),
+					mockUI.EXPECT().Say("Configuring network done (0s)."),
+					mockUI.EXPECT().Say("Provisioned in 0s."),
+					mockUI.EXPECT().Say("Waiting for PCF Dev to be ready..."),
+					mockReadiness.EXPECT().Wait(unprovisioned.VMConfig, []byte("some-private-key"), vm.ReadinessTimeout),
 					mockHelpText.EXPECT().Print("some-domain", true),
 				)
 
@@ -278,6 +302,72 @@ var _ = Describe("Unprovisioned", func() {
 			})
 		})
 
+		Context("when provisioning fails", func() {
+			var sshAddresses []ssh.SSHAddress
+
+			BeforeEach(func() {
+				sshAddresses = []ssh.SSHAddress{
+					{IP: "127.0.0.1", Port: "some-port"},
+					{IP: "some-ip", Port: "22"},
+				}
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil)
+				mockSSH.EXPECT().RunSSHCommand("if [ -e /var/pcfdev/provision-options.json ]; then exit 0; else exit 1; fi", sshAddresses, []byte("some-private-key"), 30*time.Second, os.Stdout, os.Stderr)
+				mockSSH.EXPECT().GetSSHOutput("cat /var/pcfdev/provision-options.json", sshAddresses, []byte("some-private-key"), 30*time.Second).Return(`{"domain":"some-domain","ip":"some-ip"}`, nil)
+			})
+
+			It("should report the failed phase and print the end of the provision log", func() {
+				gomock.InOrder(
+					mockUI.EXPECT().Say("Provisioning VM..."),
+					mockUI.EXPECT().Say("Configuring network..."),
+					mockSSH.EXPECT().RunSSHCommand(`sudo -H /var/pcfdev/provision "some-domain" "some-ip" "" "" ""`, sshAddresses, []byte("some-private-key"), 30*time.Second, gomock.Any(), os.Stderr).Do(
+						func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, stdout io.Writer, _ io.Writer) {
+							fmt.Fprintln(stdout, "Waiting for services to start...")
+						},
+					).Return(errors.New("some-error")),
+					mockUI.EXPECT().Say("Configuring network done (0s)."),
+					mockUI.EXPECT().Say("Starting services..."),
+					mockUI.EXPECT().Say("Starting services failed after 0s."),
+					mockSSH.EXPECT().GetSSHOutput("sudo tail -n 20 /var/pcfdev/provision.log", sshAddresses, []byte("some-private-key"), 30*time.Second).Return("some-log-line\nsome-other-log-line\n", nil),
+					mockUI.EXPECT().Say("Last 20 lines of /var/pcfdev/provision.log:"),
+					mockUI.EXPECT().Say("some-log-line\nsome-other-log-line"),
+				)
+
+				Expect(unprovisioned.Provision(&vm.StartOpts{})).To(MatchError("failed to provision VM: some-error"))
+			})
+
+			Context("when provisioning takes longer than the timeout", func() {
+				It("should stop the provision command in the VM and return an error", func() {
+					unprovisioned.Config.ProvisionTimeout = 90 * time.Minute
+
+					gomock.InOrder(
+						mockUI.EXPECT().Say("Provisioning VM..."),
+						mockUI.EXPECT().Say("Configuring network..."),
+						mockSSH.EXPECT().RunSSHCommand(`sudo -H timeout 5400s /var/pcfdev/provision "some-domain" "some-ip" "" "" ""`, sshAddresses, []byte("some-private-key"), 30*time.Second, gomock.Any(), os.Stderr).Return(&exitStatusError{status: 124}),
+						mockUI.EXPECT().Say("Configuring network failed after 0s."),
+						mockSSH.EXPECT().GetSSHOutput("sudo tail -n 20 /var/pcfdev/provision.log", sshAddresses, []byte("some-private-key"), 30*time.Second).Return("", errors.New("some-error")),
+					)
+
+					Expect(unprovisioned.Provision(&vm.StartOpts{})).To(MatchError("failed to provision VM: timed out after 1h30m0s, set PCFDEV_PROVISION_TIMEOUT to wait longer"))
+				})
+			})
+
+			Context("when the provision command exits with another status", func() {
+				It("should return the error", func() {
+					unprovisioned.Config.ProvisionTimeout = 90 * time.Minute
+
+					gomock.InOrder(
+						mockUI.EXPECT().Say("Provisioning VM..."),
+						mockUI.EXPECT().Say("Configuring network..."),
+						mockSSH.EXPECT().RunSSHCommand(`sudo -H timeout 5400s /var/pcfdev/provision "some-domain" "some-ip" "" "" ""`, sshAddresses, []byte("some-private-key"), 30*time.Second, gomock.Any(), os.Stderr).Return(&exitStatusError{status: 1}),
+						mockUI.EXPECT().Say("Configuring network failed after 0s."),
+						mockSSH.EXPECT().GetSSHOutput("sudo tail -n 20 /var/pcfdev/provision.log", sshAddresses, []byte("some-private-key"), 30*time.Second).Return("", errors.New("some-error")),
+					)
+
+					Expect(unprovisioned.Provision(&vm.StartOpts{})).To(MatchError("failed to provision VM: Process exited with status 1"))
+				})
+			})
+		})
+
 		Context("when there is an error parsing the provision config", func() {
 			It("should return an error", func() {
 				sshAddresses := []ssh.SSHAddress{
@@ -306,6 +396,20 @@ var _ = Describe("Unprovisioned", func() {
 		})
 	})
 
+	Describe("StatusDetails", func() {
+		It("should return the unprovisioned status with a message", func() {
+			Expect(unprovisioned.StatusDetails()).To(Equal(&vm.Status{
+				State:   "unprovisioned",
+				VMName:  "some-vm",
+				IP:      "some-ip",
+				Domain:  "some-domain",
+				SSHPort: "some-port",
+				APIURL:  "https://api.some-domain",
+				Message: "PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'",
+			}))
+		})
+	})
+
 	Describe("Suspend", func() {
 		It("should return an error", func() {
 			Expect(unprovisioned.Suspend()).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
@@ -372,6 +476,208 @@ var _ = Describe("Unprovisioned", func() {
 		})
 	})
 
+	Describe("Wait", func() {
+		It("should return an error", func() {
+			Expect(unprovisioned.Wait(time.Minute)).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
+		})
+	})
+
+	Describe("Services", func() {
+		It("should return an error", func() {
+			Expect(unprovisioned.Services()).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
+		})
+	})
+
+	Describe("EnableService", func() {
+		It("should return an error", func() {
+			Expect(unprovisioned.EnableService("redis")).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
+		})
+	})
+
+	Describe("DisableService", func() {
+		It("should return an error", func() {
+			Expect(unprovisioned.DisableService("redis")).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
+		})
+	})
+
+	Describe("Resize", func() {
+		It("should return an error", func() {
+			Expect(unprovisioned.Resize(&vm.ResizeOpts{Memory: 6144})).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
+		})
+	})
+
+	Describe("ResizeDisk", func() {
+		It("should return an error", func() {
+			Expect(unprovisioned.ResizeDisk(100)).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
+		})
+	})
+
+	Describe("Mount", func() {
+		It("should return an error", func() {
+			Expect(unprovisioned.Mount("/some-host-path", "/some-guest-path")).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
+		})
+	})
+
+	Describe("Mounts", func() {
+		It("should return an error", func() {
+			Expect(unprovisioned.Mounts()).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
+		})
+	})
+
+	Describe("RunCommand", func() {
+		var addresses []ssh.SSHAddress
+
+		BeforeEach(func() {
+			addresses = []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+		})
+
+		It("should run the command over ssh", func() {
+			_, stdout, stderr := term.StdStreams()
+
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockSSH.EXPECT().RunSSHCommand("some-command", addresses, []byte("some-private-key"), 5*time.Minute, stdout, stderr),
+			)
+
+			Expect(unprovisioned.RunCommand("some-command")).To(Succeed())
+		})
+
+		Context("when running the command fails", func() {
+			It("should return the error", func() {
+				_, stdout, stderr := term.StdStreams()
+
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().RunSSHCommand("some-command", addresses, []byte("some-private-key"), 5*time.Minute, stdout, stderr).Return(errors.New("some-error")),
+				)
+
+				Expect(unprovisioned.RunCommand("some-command")).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when reading the private key fails", func() {
+			It("should return the error", func() {
+				mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error"))
+
+				Expect(unprovisioned.RunCommand("some-command")).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("Forward", func() {
+		var (
+			addresses []ssh.SSHAddress
+			forwards  []ssh.Forward
+		)
+
+		BeforeEach(func() {
+			addresses = []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+			forwards = []ssh.Forward{{LocalAddress: "127.0.0.1:5432", RemoteAddress: "10.244.0.5:5432"}}
+		})
+
+		It("should forward the ports over ssh", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockSSH.EXPECT().ForwardPorts(forwards, addresses, []byte("some-private-key"), 5*time.Minute, gomock.Any(), gomock.Any()),
+			)
+
+			Expect(unprovisioned.Forward(forwards)).To(Succeed())
+		})
+
+		Context("when a forwarded connection fails", func() {
+			It("should report the error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().ForwardPorts(forwards, addresses, []byte("some-private-key"), 5*time.Minute, gomock.Any(), gomock.Any()).Do(
+						func(_ []ssh.Forward, _ []ssh.SSHAddress, _ []byte, _ time.Duration, onError func(ssh.Forward, error), _ func()) {
+							onError(forwards[0], errors.New("some-error"))
+						},
+					),
+					mockUI.EXPECT().Say("Error forwarding 127.0.0.1:5432 to 10.244.0.5:5432: some-error"),
+				)
+
+				Expect(unprovisioned.Forward(forwards)).To(Succeed())
+			})
+		})
+
+		Context("when forwarding the ports fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().ForwardPorts(forwards, addresses, []byte("some-private-key"), 5*time.Minute, gomock.Any(), gomock.Any()).Return(errors.New("some-error")),
+				)
+
+				Expect(unprovisioned.Forward(forwards)).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when reading the private key fails", func() {
+			It("should return the error", func() {
+				mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error"))
+
+				Expect(unprovisioned.Forward(forwards)).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("Copy", func() {
+		var addresses []ssh.SSHAddress
+
+		BeforeEach(func() {
+			addresses = []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+		})
+
+		Context("when copying to the guest", func() {
+			It("should copy the files over ssh", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().CopyToGuest("some-host-path", "some-guest-path", addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout),
+				)
+
+				Expect(unprovisioned.Copy(&vm.CopyOpts{HostPath: "some-host-path", GuestPath: "some-guest-path", ToGuest: true})).To(Succeed())
+			})
+		})
+
+		Context("when copying from the guest", func() {
+			It("should copy the files over ssh", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().CopyFromGuest("some-guest-path", "some-host-path", addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout),
+				)
+
+				Expect(unprovisioned.Copy(&vm.CopyOpts{HostPath: "some-host-path", GuestPath: "some-guest-path"})).To(Succeed())
+			})
+		})
+
+		Context("when reading the private key fails", func() {
+			It("should return an error", func() {
+				mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error"))
+
+				Expect(unprovisioned.Copy(&vm.CopyOpts{})).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when copying fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().CopyToGuest("some-host-path", "some-guest-path", addresses, []byte("some-private-key"), 5*time.Minute, os.Stdout).Return(errors.New("some-error")),
+				)
+
+				Expect(unprovisioned.Copy(&vm.CopyOpts{HostPath: "some-host-path", GuestPath: "some-guest-path", ToGuest: true})).To(MatchError("some-error"))
+			})
+		})
+	})
+
 	Describe("GetDebugLogs", func() {
 		It("should succeed", func() {
 			mockLogFetcher.EXPECT().FetchLogs()
@@ -389,3 +695,15 @@ var _ = Describe("Unprovisioned", func() {
 	})
 
 })
+
+type exitStatusError struct {
+	status int
+}
+
+func (e *exitStatusError) Error() string {
+	return fmt.Sprintf("Process exited with status %d", e.status)
+}
+
+func (e *exitStatusError) ExitStatus() int {
+	return e.status
+}
@@ -8,14 +8,19 @@ import (
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
 )
 
-//go:generate mockgen -package mocks -destination mocks/vbox.go github.com/pivotal-cf/pcfdev-cli/vm VBox
-type VBox interface {
+//go:generate mockgen -package mocks -destination mocks/provider.go github.com/pivotal-cf/pcfdev-cli/vm Provider
+type Provider interface {
 	StartVM(vmConfig *config.VMConfig) error
 	StopVM(vmConfig *config.VMConfig) error
 	ResumeSavedVM(vmConfig *config.VMConfig) error
 	ResumePausedVM(vmConfig *config.VMConfig) error
 	SuspendVM(vmConfig *config.VMConfig) error
 	PowerOffVM(vmConfig *config.VMConfig) error
+	ResizeVM(vmConfig *config.VMConfig) error
+	ResizeDisk(vmConfig *config.VMConfig, sizeInMB uint64) error
+	DiskSize(vmConfig *config.VMConfig) (sizeInMB uint64, err error)
+	ShareFolder(vmConfig *config.VMConfig, mount *config.Mount) error
+	SaveVMConfig(vmConfig *config.VMConfig) error
 	ImportVM(vmConfig *config.VMConfig) error
 	VMStatus(vmName string) (state string, err error)
 	VMConfig(vmName string) (vmConfig *config.VMConfig, err error)
@@ -36,6 +41,9 @@ type SSH interface {
 	WaitForSSH(addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration) error
 	RunSSHCommand(command string, addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration, stdout io.Writer, stderr io.Writer) error
 	GetSSHOutput(command string, addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration) (combinedOutput string, err error)
+	CopyToGuest(hostPath string, guestPath string, addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration, progress io.Writer) error
+	CopyFromGuest(guestPath string, hostPath string, addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration, progress io.Writer) error
+	ForwardPorts(forwards []ssh.Forward, addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration, onError func(forward ssh.Forward, err error), block func()) error
 }
 
 //go:generate mockgen -package mocks -destination mocks/vm.go github.com/pivotal-cf/pcfdev-cli/vm VM
@@ -44,12 +52,24 @@ type VM interface {
 	Provision(*StartOpts) error
 	Stop() error
 	Status() string
+	StatusDetails() (*Status, error)
 	Suspend() error
 	Resume() error
 	GetDebugLogs() error
 	Trust(*StartOpts) error
 	Target(autoTarget bool) error
 	SSH() error
+	RunCommand(command string) error
+	Copy(*CopyOpts) error
+	Forward(forwards []ssh.Forward) error
+	Wait(timeout time.Duration) error
+	Services() error
+	EnableService(name string) error
+	DisableService(name string) error
+	Resize(*ResizeOpts) error
+	ResizeDisk(sizeInGB uint64) error
+	Mount(hostPath string, guestPath string) error
+	Mounts() error
 
 	VerifyStartOpts(*StartOpts) error
 }
@@ -61,15 +81,26 @@ type Builder interface {
 
 //go:generate mockgen -package mocks -destination mocks/cert_store.go github.com/pivotal-cf/pcfdev-cli/vm CertStore
 type CertStore interface {
-	Store(cert string) error
+	Store(cert string, stores []string) error
 }
 
 //go:generate mockgen -package mocks -destination mocks/client.go github.com/pivotal-cf/pcfdev-cli/vm Client
 type Client interface {
-	Status(host string, privateKey []byte) (string, error)
-	ReplaceSecrets(host, password string, privateKey []byte) error
+	Status(addresses []ssh.SSHAddress, privateKey []byte) (string, error)
+	ReplaceSecrets(addresses []ssh.SSHAddress, password string, privateKey []byte) error
+	Services(addresses []ssh.SSHAddress, privateKey []byte) (services map[string]bool, err error)
+	EnableService(addresses []ssh.SSHAddress, privateKey []byte, service string) error
+	DisableService(addresses []ssh.SSHAddress, privateKey []byte, service string) error
 }
 
+//go:generate mockgen -package mocks -destination mocks/readiness.go github.com/pivotal-cf/pcfdev-cli/vm Readiness
+type Readiness interface {
+	Wait(vmConfig *config.VMConfig, privateKey []byte, timeout time.Duration) error
+}
+
+// ReadinessTimeout is how long start and resume wait for PCF Dev to serve requests.
+const ReadinessTimeout = 10 * time.Minute
+
 //go:generate mockgen -package mocks -destination mocks/fs.go github.com/pivotal-cf/pcfdev-cli/vm FS
 type FS interface {
 	Remove(path string) error
@@ -105,6 +136,17 @@ type Network interface {
 	HasIPCollision(ip string) (bool, error)
 }
 
+type CopyOpts struct {
+	HostPath  string
+	GuestPath string
+	ToGuest   bool
+}
+
+type ResizeOpts struct {
+	Memory uint64
+	CPUs   int
+}
+
 type StartOpts struct {
 	CPUs           int
 	Memory         uint64
@@ -114,6 +156,7 @@ type StartOpts struct {
 	Services       string
 	Trust          bool
 	PrintCA        bool
+	TrustStores    []string
 	Target         bool
 	IP             string
 	Domain         string